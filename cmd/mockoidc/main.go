@@ -0,0 +1,39 @@
+// mockoidc sirve un proveedor OpenID Connect local para probar el login
+// social sin red. Con el servidor en marcha:
+//
+//	OIDC_PROVIDERS=mock
+//	OIDC_MOCK_ISSUER=http://localhost:9096
+//	OIDC_MOCK_CLIENT_ID=voty
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"api_voty/internal/oidc/mock"
+)
+
+func main() {
+	addr := flag.String("addr", ":9096", "dirección de escucha")
+	issuer := flag.String("issuer", "http://localhost:9096", "URL pública del proveedor")
+	clientID := flag.String("client-id", "voty", "client_id admitido")
+	email := flag.String("email", "user@example.com", "email del usuario que inicia sesión")
+	name := flag.String("name", "Mock User", "nombre del usuario")
+	verified := flag.Bool("email-verified", true, "si el email se da por verificado")
+	flag.Parse()
+
+	p, err := mock.New(*issuer, mock.User{
+		Subject:       "mock|" + *email,
+		Email:         *email,
+		EmailVerified: *verified,
+		Name:          *name,
+	})
+	if err != nil {
+		log.Fatalf("failed creating mock provider: %v", err)
+	}
+	p.ClientID = *clientID
+
+	log.Printf("Mock OIDC provider %s on %s (use ?login_hint= to pick the user)", *issuer, *addr)
+	log.Fatal(http.ListenAndServe(*addr, p))
+}
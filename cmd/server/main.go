@@ -7,6 +7,8 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"entgo.io/ent/dialect"
 	entsql "entgo.io/ent/dialect/sql"
@@ -16,7 +18,15 @@ import (
 	"api_voty/ent"
 	"api_voty/ent/migrate"
 	"api_voty/internal/api"
+	"api_voty/internal/charts"
+	"api_voty/internal/mailer"
 	"api_voty/internal/models"
+	"api_voty/internal/oidc"
+	"api_voty/internal/password"
+	"api_voty/internal/pow"
+	"api_voty/internal/ratelimit"
+	"api_voty/internal/search"
+	"api_voty/internal/trending"
 )
 
 func main() {
@@ -66,21 +76,128 @@ func main() {
 		log.Fatalf("failed creating schema resources: %v", err)
 	}
 
+	// Índice de búsqueda en memoria, actualizado por hooks de ent
+	searchIndex := search.NewIndex()
+	indexer := search.NewIndexer(client, searchIndex)
+	indexer.Register()
+	if err := indexer.Rebuild(ctx); err != nil {
+		log.Fatalf("failed building search index: %v", err)
+	}
+
 	hub := api.NewHub()
+	// Los gráficos de resultados se descartan cuando el Hub ve cambios en su encuesta
+	chartCache := charts.NewCache()
+	api.InvalidateChartsOn(hub, chartCache)
 	go hub.Run() // No olvides poner a correr el hub en segundo plano
 
 	pollModel := models.NewPollModel(client)
 	userModel := models.NewUserModel(client, db)
+	tagModel := models.NewTagModel(client, db)
+	analyticsModel := models.NewAnalyticsModel(client, db)
+
+	// Rellena el total de votos de encuestas anteriores a la columna total_votes
+	if err := pollModel.SyncTotalVotes(ctx); err != nil {
+		log.Fatalf("failed syncing poll vote totals: %v", err)
+	}
+
+	// Ranking de tendencias: se reconstruye con los votos de la última semana
+	// y después lo alimenta CastVote
+	tracker := trending.NewTracker(trending.SystemClock{})
+	if err := pollModel.ReplayVotes(ctx, time.Now().Add(-trending.WindowWeek.Duration()), tracker); err != nil {
+		log.Fatalf("failed loading trending votes: %v", err)
+	}
+	pollModel.ObserveVotes(tracker)
+
+	// Abre y cierra las encuestas programadas
+	scheduler := api.NewPollScheduler(pollModel, hub)
+	go scheduler.Run(ctx)
+
+	port := ":8000"
+
+	// Correo: verificación de email y avisos de inicio de sesión
+	mail, err := mailer.FromEnv()
+	if err != nil {
+		log.Fatalf("failed configuring mailer: %v", err)
+	}
+	publicBaseURL := os.Getenv("PUBLIC_BASE_URL")
+	if publicBaseURL == "" {
+		publicBaseURL = "http://localhost" + port
+	}
+
+	// Reglas de las contraseñas nuevas (longitud, entropía, lista de filtradas)
+	// y algoritmo de sus hashes
+	policy, err := password.PolicyFromEnv()
+	if err != nil {
+		log.Fatalf("invalid password policy: %v", err)
+	}
+	userModel.UsePasswordPolicy(policy)
+	hasher, err := password.HasherFromEnv()
+	if err != nil {
+		log.Fatalf("invalid password hasher: %v", err)
+	}
+	userModel.UseHasher(hasher)
 
 	authModel := models.NewAuthModel(client, db)
+	authModel.UsePasswordPolicy(policy)
+	authModel.UseHasher(hasher)
+	// Con REQUIRE_ADMIN_2FA=true los administradores sin segundo factor no
+	// tienen permisos de administrador hasta activarlo
+	if os.Getenv("REQUIRE_ADMIN_2FA") == "true" {
+		userModel.RequireAdminTwoFactor(true)
+		authModel.RequireAdminTwoFactor(true)
+	}
+	authModel.UseMailer(mail, publicBaseURL)
+	authModel.NotifyLogins(models.MailLoginNotifier{Mailer: mail})
 	authAPI := api.NewAuthAPI(authModel,userModel)
-	userAPI := api.NewUserAPI(userModel, pollModel, hub)
+	// Login social con OpenID Connect (OIDC_PROVIDERS=google,corp...)
+	authAPI.OIDC, err = oidc.ProvidersFromEnv(publicBaseURL, nil)
+	if err != nil {
+		log.Fatalf("invalid OIDC configuration: %v", err)
+	}
+	if len(authAPI.OIDC) > 0 {
+		authAPI.OIDCKey = []byte(os.Getenv("SECRET"))
+		if len(authAPI.OIDCKey) < oidc.MinKeyLen {
+			log.Fatalf("OIDC_PROVIDERS requires a SECRET of at least %d bytes to sign the login flow", oidc.MinKeyLen)
+		}
+	}
+	userAPI := api.NewUserAPI(userModel, pollModel, tagModel, analyticsModel, hub, scheduler, searchIndex, tracker, chartCache)
+
+	userAPI.PublicBaseURL = publicBaseURL
+	// Con REQUIRE_VERIFIED_EMAIL=true solo votan los usuarios con el email verificado
+	userAPI.RequireVerifiedEmail = os.Getenv("REQUIRE_VERIFIED_EMAIL") == "true"
+
+	// Límites de peticiones: en memoria, o en MySQL si hay varias instancias.
+	// En MySQL se apuntan también los retos anti-bots usados
+	var spentChallenges pow.SpentStore
+	switch store := os.Getenv("RATE_LIMIT_STORE"); store {
+	case "", "memory":
+		userAPI.Limiter = ratelimit.NewLimiter(ratelimit.NewMemoryStore())
+	case "mysql":
+		sqlStore := ratelimit.NewSQLStore(db)
+		go sqlStore.RunPurge(ctx, time.Hour, 24*time.Hour)
+		userAPI.Limiter = ratelimit.NewLimiter(sqlStore)
+		spentChallenges = ratelimit.SpentChallenges{Store: sqlStore}
+	case "off":
+	default:
+		log.Fatalf("invalid RATE_LIMIT_STORE %q: use memory, mysql or off", store)
+	}
+
+	// Retos anti-bots en registro y votos. POW_DIFFICULTY=0 los desactiva.
+	// Se firman con SECRET para que cualquier instancia acepte los de otra
+	powOptions := pow.DefaultOptions
+	powOptions.Key = []byte(os.Getenv("SECRET"))
+	powOptions.Spent = spentChallenges
+	if v := os.Getenv("POW_DIFFICULTY"); v != "" {
+		if powOptions.Difficulty, err = strconv.Atoi(v); err != nil {
+			log.Fatalf("invalid POW_DIFFICULTY: %v", err)
+		}
+	}
+	userAPI.Challenges = pow.NewIssuer(powOptions)
 
 	mux := http.NewServeMux()
 
 	api.SetupRoutes(mux, userAPI, authAPI)
 
-	port := ":8000"
 	log.Printf("Servidor iniciado en http://localhost%s", port)
 	log.Printf("Documentación disponible en http://localhost%s/docs", port)
 
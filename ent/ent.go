@@ -3,8 +3,15 @@
 package ent
 
 import (
+	"api_voty/ent/linkedidentity"
+	"api_voty/ent/loginattempt"
+	"api_voty/ent/passwordreset"
 	"api_voty/ent/poll"
 	"api_voty/ent/polloption"
+	"api_voty/ent/pollrevision"
+	"api_voty/ent/ratelimitbucket"
+	"api_voty/ent/sharelink"
+	"api_voty/ent/tag"
 	"api_voty/ent/user"
 	"api_voty/ent/vote"
 	"context"
@@ -76,10 +83,17 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			poll.Table:       poll.ValidColumn,
-			polloption.Table: polloption.ValidColumn,
-			user.Table:       user.ValidColumn,
-			vote.Table:       vote.ValidColumn,
+			linkedidentity.Table:  linkedidentity.ValidColumn,
+			loginattempt.Table:    loginattempt.ValidColumn,
+			passwordreset.Table:   passwordreset.ValidColumn,
+			poll.Table:            poll.ValidColumn,
+			polloption.Table:      polloption.ValidColumn,
+			pollrevision.Table:    pollrevision.ValidColumn,
+			ratelimitbucket.Table: ratelimitbucket.ValidColumn,
+			sharelink.Table:       sharelink.ValidColumn,
+			tag.Table:             tag.ValidColumn,
+			user.Table:            user.ValidColumn,
+			vote.Table:            vote.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
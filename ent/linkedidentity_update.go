@@ -0,0 +1,481 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"api_voty/ent/linkedidentity"
+	"api_voty/ent/predicate"
+	"api_voty/ent/user"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// LinkedIdentityUpdate is the builder for updating LinkedIdentity entities.
+type LinkedIdentityUpdate struct {
+	config
+	hooks    []Hook
+	mutation *LinkedIdentityMutation
+}
+
+// Where appends a list predicates to the LinkedIdentityUpdate builder.
+func (_u *LinkedIdentityUpdate) Where(ps ...predicate.LinkedIdentity) *LinkedIdentityUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *LinkedIdentityUpdate) SetUserID(v string) *LinkedIdentityUpdate {
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *LinkedIdentityUpdate) SetNillableUserID(v *string) *LinkedIdentityUpdate {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// SetProvider sets the "provider" field.
+func (_u *LinkedIdentityUpdate) SetProvider(v string) *LinkedIdentityUpdate {
+	_u.mutation.SetProvider(v)
+	return _u
+}
+
+// SetNillableProvider sets the "provider" field if the given value is not nil.
+func (_u *LinkedIdentityUpdate) SetNillableProvider(v *string) *LinkedIdentityUpdate {
+	if v != nil {
+		_u.SetProvider(*v)
+	}
+	return _u
+}
+
+// SetSubject sets the "subject" field.
+func (_u *LinkedIdentityUpdate) SetSubject(v string) *LinkedIdentityUpdate {
+	_u.mutation.SetSubject(v)
+	return _u
+}
+
+// SetNillableSubject sets the "subject" field if the given value is not nil.
+func (_u *LinkedIdentityUpdate) SetNillableSubject(v *string) *LinkedIdentityUpdate {
+	if v != nil {
+		_u.SetSubject(*v)
+	}
+	return _u
+}
+
+// SetEmail sets the "email" field.
+func (_u *LinkedIdentityUpdate) SetEmail(v string) *LinkedIdentityUpdate {
+	_u.mutation.SetEmail(v)
+	return _u
+}
+
+// SetNillableEmail sets the "email" field if the given value is not nil.
+func (_u *LinkedIdentityUpdate) SetNillableEmail(v *string) *LinkedIdentityUpdate {
+	if v != nil {
+		_u.SetEmail(*v)
+	}
+	return _u
+}
+
+// SetLastLoginAt sets the "last_login_at" field.
+func (_u *LinkedIdentityUpdate) SetLastLoginAt(v time.Time) *LinkedIdentityUpdate {
+	_u.mutation.SetLastLoginAt(v)
+	return _u
+}
+
+// SetNillableLastLoginAt sets the "last_login_at" field if the given value is not nil.
+func (_u *LinkedIdentityUpdate) SetNillableLastLoginAt(v *time.Time) *LinkedIdentityUpdate {
+	if v != nil {
+		_u.SetLastLoginAt(*v)
+	}
+	return _u
+}
+
+// ClearLastLoginAt clears the value of the "last_login_at" field.
+func (_u *LinkedIdentityUpdate) ClearLastLoginAt() *LinkedIdentityUpdate {
+	_u.mutation.ClearLastLoginAt()
+	return _u
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *LinkedIdentityUpdate) SetUser(v *User) *LinkedIdentityUpdate {
+	return _u.SetUserID(v.ID)
+}
+
+// Mutation returns the LinkedIdentityMutation object of the builder.
+func (_u *LinkedIdentityUpdate) Mutation() *LinkedIdentityMutation {
+	return _u.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *LinkedIdentityUpdate) ClearUser() *LinkedIdentityUpdate {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *LinkedIdentityUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *LinkedIdentityUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *LinkedIdentityUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *LinkedIdentityUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *LinkedIdentityUpdate) check() error {
+	if v, ok := _u.mutation.Provider(); ok {
+		if err := linkedidentity.ProviderValidator(v); err != nil {
+			return &ValidationError{Name: "provider", err: fmt.Errorf(`ent: validator failed for field "LinkedIdentity.provider": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Subject(); ok {
+		if err := linkedidentity.SubjectValidator(v); err != nil {
+			return &ValidationError{Name: "subject", err: fmt.Errorf(`ent: validator failed for field "LinkedIdentity.subject": %w`, err)}
+		}
+	}
+	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "LinkedIdentity.user"`)
+	}
+	return nil
+}
+
+func (_u *LinkedIdentityUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(linkedidentity.Table, linkedidentity.Columns, sqlgraph.NewFieldSpec(linkedidentity.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Provider(); ok {
+		_spec.SetField(linkedidentity.FieldProvider, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Subject(); ok {
+		_spec.SetField(linkedidentity.FieldSubject, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Email(); ok {
+		_spec.SetField(linkedidentity.FieldEmail, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.LastLoginAt(); ok {
+		_spec.SetField(linkedidentity.FieldLastLoginAt, field.TypeTime, value)
+	}
+	if _u.mutation.LastLoginAtCleared() {
+		_spec.ClearField(linkedidentity.FieldLastLoginAt, field.TypeTime)
+	}
+	if _u.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   linkedidentity.UserTable,
+			Columns: []string{linkedidentity.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   linkedidentity.UserTable,
+			Columns: []string{linkedidentity.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{linkedidentity.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// LinkedIdentityUpdateOne is the builder for updating a single LinkedIdentity entity.
+type LinkedIdentityUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *LinkedIdentityMutation
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *LinkedIdentityUpdateOne) SetUserID(v string) *LinkedIdentityUpdateOne {
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *LinkedIdentityUpdateOne) SetNillableUserID(v *string) *LinkedIdentityUpdateOne {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// SetProvider sets the "provider" field.
+func (_u *LinkedIdentityUpdateOne) SetProvider(v string) *LinkedIdentityUpdateOne {
+	_u.mutation.SetProvider(v)
+	return _u
+}
+
+// SetNillableProvider sets the "provider" field if the given value is not nil.
+func (_u *LinkedIdentityUpdateOne) SetNillableProvider(v *string) *LinkedIdentityUpdateOne {
+	if v != nil {
+		_u.SetProvider(*v)
+	}
+	return _u
+}
+
+// SetSubject sets the "subject" field.
+func (_u *LinkedIdentityUpdateOne) SetSubject(v string) *LinkedIdentityUpdateOne {
+	_u.mutation.SetSubject(v)
+	return _u
+}
+
+// SetNillableSubject sets the "subject" field if the given value is not nil.
+func (_u *LinkedIdentityUpdateOne) SetNillableSubject(v *string) *LinkedIdentityUpdateOne {
+	if v != nil {
+		_u.SetSubject(*v)
+	}
+	return _u
+}
+
+// SetEmail sets the "email" field.
+func (_u *LinkedIdentityUpdateOne) SetEmail(v string) *LinkedIdentityUpdateOne {
+	_u.mutation.SetEmail(v)
+	return _u
+}
+
+// SetNillableEmail sets the "email" field if the given value is not nil.
+func (_u *LinkedIdentityUpdateOne) SetNillableEmail(v *string) *LinkedIdentityUpdateOne {
+	if v != nil {
+		_u.SetEmail(*v)
+	}
+	return _u
+}
+
+// SetLastLoginAt sets the "last_login_at" field.
+func (_u *LinkedIdentityUpdateOne) SetLastLoginAt(v time.Time) *LinkedIdentityUpdateOne {
+	_u.mutation.SetLastLoginAt(v)
+	return _u
+}
+
+// SetNillableLastLoginAt sets the "last_login_at" field if the given value is not nil.
+func (_u *LinkedIdentityUpdateOne) SetNillableLastLoginAt(v *time.Time) *LinkedIdentityUpdateOne {
+	if v != nil {
+		_u.SetLastLoginAt(*v)
+	}
+	return _u
+}
+
+// ClearLastLoginAt clears the value of the "last_login_at" field.
+func (_u *LinkedIdentityUpdateOne) ClearLastLoginAt() *LinkedIdentityUpdateOne {
+	_u.mutation.ClearLastLoginAt()
+	return _u
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (_u *LinkedIdentityUpdateOne) SetUser(v *User) *LinkedIdentityUpdateOne {
+	return _u.SetUserID(v.ID)
+}
+
+// Mutation returns the LinkedIdentityMutation object of the builder.
+func (_u *LinkedIdentityUpdateOne) Mutation() *LinkedIdentityMutation {
+	return _u.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (_u *LinkedIdentityUpdateOne) ClearUser() *LinkedIdentityUpdateOne {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// Where appends a list predicates to the LinkedIdentityUpdate builder.
+func (_u *LinkedIdentityUpdateOne) Where(ps ...predicate.LinkedIdentity) *LinkedIdentityUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *LinkedIdentityUpdateOne) Select(field string, fields ...string) *LinkedIdentityUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated LinkedIdentity entity.
+func (_u *LinkedIdentityUpdateOne) Save(ctx context.Context) (*LinkedIdentity, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *LinkedIdentityUpdateOne) SaveX(ctx context.Context) *LinkedIdentity {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *LinkedIdentityUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *LinkedIdentityUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *LinkedIdentityUpdateOne) check() error {
+	if v, ok := _u.mutation.Provider(); ok {
+		if err := linkedidentity.ProviderValidator(v); err != nil {
+			return &ValidationError{Name: "provider", err: fmt.Errorf(`ent: validator failed for field "LinkedIdentity.provider": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Subject(); ok {
+		if err := linkedidentity.SubjectValidator(v); err != nil {
+			return &ValidationError{Name: "subject", err: fmt.Errorf(`ent: validator failed for field "LinkedIdentity.subject": %w`, err)}
+		}
+	}
+	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "LinkedIdentity.user"`)
+	}
+	return nil
+}
+
+func (_u *LinkedIdentityUpdateOne) sqlSave(ctx context.Context) (_node *LinkedIdentity, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(linkedidentity.Table, linkedidentity.Columns, sqlgraph.NewFieldSpec(linkedidentity.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "LinkedIdentity.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, linkedidentity.FieldID)
+		for _, f := range fields {
+			if !linkedidentity.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != linkedidentity.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Provider(); ok {
+		_spec.SetField(linkedidentity.FieldProvider, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Subject(); ok {
+		_spec.SetField(linkedidentity.FieldSubject, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Email(); ok {
+		_spec.SetField(linkedidentity.FieldEmail, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.LastLoginAt(); ok {
+		_spec.SetField(linkedidentity.FieldLastLoginAt, field.TypeTime, value)
+	}
+	if _u.mutation.LastLoginAtCleared() {
+		_spec.ClearField(linkedidentity.FieldLastLoginAt, field.TypeTime)
+	}
+	if _u.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   linkedidentity.UserTable,
+			Columns: []string{linkedidentity.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   linkedidentity.UserTable,
+			Columns: []string{linkedidentity.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &LinkedIdentity{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{linkedidentity.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -8,24 +8,149 @@ import (
 )
 
 var (
+	// LinkedIdentitiesColumns holds the columns for the "linked_identities" table.
+	LinkedIdentitiesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "provider", Type: field.TypeString, Size: 64},
+		{Name: "subject", Type: field.TypeString, Size: 255},
+		{Name: "email", Type: field.TypeString, Default: ""},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "last_login_at", Type: field.TypeTime, Nullable: true},
+		{Name: "user_id", Type: field.TypeString},
+	}
+	// LinkedIdentitiesTable holds the schema information for the "linked_identities" table.
+	LinkedIdentitiesTable = &schema.Table{
+		Name:       "linked_identities",
+		Columns:    LinkedIdentitiesColumns,
+		PrimaryKey: []*schema.Column{LinkedIdentitiesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "linked_identities_users_identities",
+				Columns:    []*schema.Column{LinkedIdentitiesColumns[6]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "linkedidentity_provider_subject",
+				Unique:  true,
+				Columns: []*schema.Column{LinkedIdentitiesColumns[1], LinkedIdentitiesColumns[2]},
+			},
+		},
+	}
+	// LoginAttemptsColumns holds the columns for the "login_attempts" table.
+	LoginAttemptsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "email", Type: field.TypeString},
+		{Name: "ip", Type: field.TypeString},
+		{Name: "user_agent", Type: field.TypeString, Size: 512, Default: ""},
+		{Name: "success", Type: field.TypeBool},
+		{Name: "failure_reason", Type: field.TypeEnum, Nullable: true, Enums: []string{"bad_password", "unknown_user", "inactive", "locked", "throttled", "ip_blocked", "bad_second_factor"}},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "user_id", Type: field.TypeString, Nullable: true},
+	}
+	// LoginAttemptsTable holds the schema information for the "login_attempts" table.
+	LoginAttemptsTable = &schema.Table{
+		Name:       "login_attempts",
+		Columns:    LoginAttemptsColumns,
+		PrimaryKey: []*schema.Column{LoginAttemptsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "login_attempts_users_login_attempts",
+				Columns:    []*schema.Column{LoginAttemptsColumns[7]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "loginattempt_user_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{LoginAttemptsColumns[7], LoginAttemptsColumns[6]},
+			},
+			{
+				Name:    "loginattempt_ip_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{LoginAttemptsColumns[2], LoginAttemptsColumns[6]},
+			},
+		},
+	}
+	// PasswordResetsColumns holds the columns for the "password_resets" table.
+	PasswordResetsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "token_hash", Type: field.TypeString, Unique: true},
+		{Name: "ip", Type: field.TypeString, Default: ""},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "expires_at", Type: field.TypeTime},
+		{Name: "used_at", Type: field.TypeTime, Nullable: true},
+		{Name: "user_id", Type: field.TypeString},
+	}
+	// PasswordResetsTable holds the schema information for the "password_resets" table.
+	PasswordResetsTable = &schema.Table{
+		Name:       "password_resets",
+		Columns:    PasswordResetsColumns,
+		PrimaryKey: []*schema.Column{PasswordResetsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "password_resets_users_password_resets",
+				Columns:    []*schema.Column{PasswordResetsColumns[6]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "passwordreset_user_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{PasswordResetsColumns[6], PasswordResetsColumns[3]},
+			},
+		},
+	}
 	// PollsColumns holds the columns for the "polls" table.
 	PollsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
 		{Name: "title", Type: field.TypeString},
+		{Name: "description", Type: field.TypeString, Nullable: true, Size: 2147483647},
 		{Name: "is_open", Type: field.TypeBool, Default: true},
 		{Name: "created_at", Type: field.TypeTime},
+		{Name: "total_votes", Type: field.TypeInt, Default: 0},
+		{Name: "opens_at", Type: field.TypeTime, Nullable: true},
+		{Name: "closes_at", Type: field.TypeTime, Nullable: true},
+		{Name: "max_votes", Type: field.TypeInt, Nullable: true},
+		{Name: "quorum_percent", Type: field.TypeInt, Nullable: true},
+		{Name: "close_on_decisive_lead", Type: field.TypeBool, Default: false},
+		{Name: "closed_reason", Type: field.TypeEnum, Nullable: true, Enums: []string{"manual", "schedule", "vote_cap", "quorum", "decisive_lead"}},
+		{Name: "results_visibility", Type: field.TypeEnum, Enums: []string{"always", "after_vote", "after_close", "embargo"}, Default: "always"},
+		{Name: "results_visible_at", Type: field.TypeTime, Nullable: true},
+		{Name: "status", Type: field.TypeEnum, Enums: []string{"draft", "published"}, Default: "published"},
+		{Name: "published_at", Type: field.TypeTime, Nullable: true},
+		{Name: "anonymous", Type: field.TypeBool, Default: false},
+		{Name: "embeddable", Type: field.TypeBool, Default: false},
+		{Name: "allow_guests", Type: field.TypeBool, Default: false},
+		{Name: "owner_id", Type: field.TypeString, Nullable: true},
 	}
 	// PollsTable holds the schema information for the "polls" table.
 	PollsTable = &schema.Table{
 		Name:       "polls",
 		Columns:    PollsColumns,
 		PrimaryKey: []*schema.Column{PollsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "polls_users_polls",
+				Columns:    []*schema.Column{PollsColumns[19]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+		},
 	}
 	// PollOptionsColumns holds the columns for the "poll_options" table.
 	PollOptionsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
 		{Name: "text", Type: field.TypeString},
 		{Name: "votes_count", Type: field.TypeInt, Default: 0},
+		{Name: "position", Type: field.TypeInt, Default: 0},
+		{Name: "archived", Type: field.TypeBool, Default: false},
 		{Name: "poll_options", Type: field.TypeInt, Nullable: true},
 	}
 	// PollOptionsTable holds the schema information for the "poll_options" table.
@@ -36,20 +161,122 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "poll_options_polls_options",
-				Columns:    []*schema.Column{PollOptionsColumns[3]},
+				Columns:    []*schema.Column{PollOptionsColumns[5]},
 				RefColumns: []*schema.Column{PollsColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
 		},
 	}
+	// PollRevisionsColumns holds the columns for the "poll_revisions" table.
+	PollRevisionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "revision", Type: field.TypeInt},
+		{Name: "title", Type: field.TypeString},
+		{Name: "description", Type: field.TypeString, Nullable: true, Size: 2147483647},
+		{Name: "options", Type: field.TypeJSON},
+		{Name: "settings", Type: field.TypeJSON},
+		{Name: "restored_from", Type: field.TypeInt, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "poll_id", Type: field.TypeInt},
+		{Name: "author_id", Type: field.TypeString, Nullable: true},
+	}
+	// PollRevisionsTable holds the schema information for the "poll_revisions" table.
+	PollRevisionsTable = &schema.Table{
+		Name:       "poll_revisions",
+		Columns:    PollRevisionsColumns,
+		PrimaryKey: []*schema.Column{PollRevisionsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "poll_revisions_polls_revisions",
+				Columns:    []*schema.Column{PollRevisionsColumns[8]},
+				RefColumns: []*schema.Column{PollsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "poll_revisions_users_poll_revisions",
+				Columns:    []*schema.Column{PollRevisionsColumns[9]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "pollrevision_poll_id_revision",
+				Unique:  true,
+				Columns: []*schema.Column{PollRevisionsColumns[8], PollRevisionsColumns[1]},
+			},
+		},
+	}
+	// RateLimitBucketsColumns holds the columns for the "rate_limit_buckets" table.
+	RateLimitBucketsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeString, Size: 191},
+		{Name: "tokens", Type: field.TypeFloat64},
+		{Name: "updated_at", Type: field.TypeTime, SchemaType: map[string]string{"mysql": "datetime(6)"}},
+	}
+	// RateLimitBucketsTable holds the schema information for the "rate_limit_buckets" table.
+	RateLimitBucketsTable = &schema.Table{
+		Name:       "rate_limit_buckets",
+		Columns:    RateLimitBucketsColumns,
+		PrimaryKey: []*schema.Column{RateLimitBucketsColumns[0]},
+	}
+	// ShareLinksColumns holds the columns for the "share_links" table.
+	ShareLinksColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "token_hash", Type: field.TypeString, Unique: true},
+		{Name: "token_prefix", Type: field.TypeString},
+		{Name: "created_by", Type: field.TypeString, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "expires_at", Type: field.TypeTime, Nullable: true},
+		{Name: "revoked_at", Type: field.TypeTime, Nullable: true},
+		{Name: "poll_id", Type: field.TypeInt},
+	}
+	// ShareLinksTable holds the schema information for the "share_links" table.
+	ShareLinksTable = &schema.Table{
+		Name:       "share_links",
+		Columns:    ShareLinksColumns,
+		PrimaryKey: []*schema.Column{ShareLinksColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "share_links_polls_share_links",
+				Columns:    []*schema.Column{ShareLinksColumns[7]},
+				RefColumns: []*schema.Column{PollsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// TagsColumns holds the columns for the "tags" table.
+	TagsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "name", Type: field.TypeString},
+		{Name: "slug", Type: field.TypeString, Unique: true},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// TagsTable holds the schema information for the "tags" table.
+	TagsTable = &schema.Table{
+		Name:       "tags",
+		Columns:    TagsColumns,
+		PrimaryKey: []*schema.Column{TagsColumns[0]},
+	}
 	// UsersColumns holds the columns for the "users" table.
 	UsersColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeString, Unique: true},
 		{Name: "avatar_image", Type: field.TypeString, Nullable: true, Size: 2147483647},
 		{Name: "email", Type: field.TypeString, Unique: true},
+		{Name: "email_verified_at", Type: field.TypeTime, Nullable: true},
+		{Name: "verification_sent_at", Type: field.TypeTime, Nullable: true},
 		{Name: "name", Type: field.TypeString},
 		{Name: "password", Type: field.TypeString},
 		{Name: "active", Type: field.TypeBool, Default: true},
+		{Name: "role", Type: field.TypeEnum, Enums: []string{"user", "admin"}, Default: "user"},
+		{Name: "embed_origins", Type: field.TypeJSON, Nullable: true},
+		{Name: "failed_logins", Type: field.TypeInt, Default: 0},
+		{Name: "last_failed_login_at", Type: field.TypeTime, Nullable: true},
+		{Name: "locked_until", Type: field.TypeTime, Nullable: true},
+		{Name: "token_version", Type: field.TypeInt, Default: 0},
+		{Name: "totp_secret", Type: field.TypeString, Nullable: true},
+		{Name: "totp_enabled_at", Type: field.TypeTime, Nullable: true},
+		{Name: "totp_last_step", Type: field.TypeInt64, Default: 0},
+		{Name: "recovery_codes", Type: field.TypeJSON, Nullable: true},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
 	}
@@ -63,9 +290,10 @@ var (
 	VotesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
 		{Name: "created_at", Type: field.TypeTime},
+		{Name: "guest_id", Type: field.TypeString, Nullable: true},
 		{Name: "poll_votes", Type: field.TypeInt},
 		{Name: "poll_option_votes", Type: field.TypeInt},
-		{Name: "user_votes", Type: field.TypeString},
+		{Name: "user_votes", Type: field.TypeString, Nullable: true},
 	}
 	// VotesTable holds the schema information for the "votes" table.
 	VotesTable = &schema.Table{
@@ -75,43 +303,123 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "votes_polls_votes",
-				Columns:    []*schema.Column{VotesColumns[2]},
+				Columns:    []*schema.Column{VotesColumns[3]},
 				RefColumns: []*schema.Column{PollsColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 			{
 				Symbol:     "votes_poll_options_votes",
-				Columns:    []*schema.Column{VotesColumns[3]},
+				Columns:    []*schema.Column{VotesColumns[4]},
 				RefColumns: []*schema.Column{PollOptionsColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 			{
 				Symbol:     "votes_users_votes",
-				Columns:    []*schema.Column{VotesColumns[4]},
+				Columns:    []*schema.Column{VotesColumns[5]},
 				RefColumns: []*schema.Column{UsersColumns[0]},
-				OnDelete:   schema.NoAction,
+				OnDelete:   schema.Restrict,
 			},
 		},
 		Indexes: []*schema.Index{
 			{
 				Name:    "vote_user_votes_poll_votes",
 				Unique:  true,
-				Columns: []*schema.Column{VotesColumns[4], VotesColumns[2]},
+				Columns: []*schema.Column{VotesColumns[5], VotesColumns[3]},
+			},
+			{
+				Name:    "vote_guest_id_poll_votes",
+				Unique:  true,
+				Columns: []*schema.Column{VotesColumns[2], VotesColumns[3]},
+			},
+			{
+				Name:    "vote_created_at_poll_votes",
+				Unique:  false,
+				Columns: []*schema.Column{VotesColumns[1], VotesColumns[3]},
+			},
+		},
+	}
+	// PollTagsColumns holds the columns for the "poll_tags" table.
+	PollTagsColumns = []*schema.Column{
+		{Name: "poll_id", Type: field.TypeInt},
+		{Name: "tag_id", Type: field.TypeInt},
+	}
+	// PollTagsTable holds the schema information for the "poll_tags" table.
+	PollTagsTable = &schema.Table{
+		Name:       "poll_tags",
+		Columns:    PollTagsColumns,
+		PrimaryKey: []*schema.Column{PollTagsColumns[0], PollTagsColumns[1]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "poll_tags_poll_id",
+				Columns:    []*schema.Column{PollTagsColumns[0]},
+				RefColumns: []*schema.Column{PollsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "poll_tags_tag_id",
+				Columns:    []*schema.Column{PollTagsColumns[1]},
+				RefColumns: []*schema.Column{TagsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// UserFollowedTagsColumns holds the columns for the "user_followed_tags" table.
+	UserFollowedTagsColumns = []*schema.Column{
+		{Name: "user_id", Type: field.TypeString},
+		{Name: "tag_id", Type: field.TypeInt},
+	}
+	// UserFollowedTagsTable holds the schema information for the "user_followed_tags" table.
+	UserFollowedTagsTable = &schema.Table{
+		Name:       "user_followed_tags",
+		Columns:    UserFollowedTagsColumns,
+		PrimaryKey: []*schema.Column{UserFollowedTagsColumns[0], UserFollowedTagsColumns[1]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "user_followed_tags_user_id",
+				Columns:    []*schema.Column{UserFollowedTagsColumns[0]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "user_followed_tags_tag_id",
+				Columns:    []*schema.Column{UserFollowedTagsColumns[1]},
+				RefColumns: []*schema.Column{TagsColumns[0]},
+				OnDelete:   schema.Cascade,
 			},
 		},
 	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		LinkedIdentitiesTable,
+		LoginAttemptsTable,
+		PasswordResetsTable,
 		PollsTable,
 		PollOptionsTable,
+		PollRevisionsTable,
+		RateLimitBucketsTable,
+		ShareLinksTable,
+		TagsTable,
 		UsersTable,
 		VotesTable,
+		PollTagsTable,
+		UserFollowedTagsTable,
 	}
 )
 
 func init() {
+	LinkedIdentitiesTable.ForeignKeys[0].RefTable = UsersTable
+	LoginAttemptsTable.ForeignKeys[0].RefTable = UsersTable
+	PasswordResetsTable.ForeignKeys[0].RefTable = UsersTable
+	PollsTable.ForeignKeys[0].RefTable = UsersTable
 	PollOptionsTable.ForeignKeys[0].RefTable = PollsTable
+	PollRevisionsTable.ForeignKeys[0].RefTable = PollsTable
+	PollRevisionsTable.ForeignKeys[1].RefTable = UsersTable
+	ShareLinksTable.ForeignKeys[0].RefTable = PollsTable
 	VotesTable.ForeignKeys[0].RefTable = PollsTable
 	VotesTable.ForeignKeys[1].RefTable = PollOptionsTable
 	VotesTable.ForeignKeys[2].RefTable = UsersTable
+	PollTagsTable.ForeignKeys[0].RefTable = PollsTable
+	PollTagsTable.ForeignKeys[1].RefTable = TagsTable
+	UserFollowedTagsTable.ForeignKeys[0].RefTable = UsersTable
+	UserFollowedTagsTable.ForeignKeys[1].RefTable = TagsTable
 }
@@ -3,9 +3,17 @@
 package ent
 
 import (
+	"api_voty/ent/linkedidentity"
+	"api_voty/ent/loginattempt"
+	"api_voty/ent/passwordreset"
 	"api_voty/ent/poll"
 	"api_voty/ent/polloption"
+	"api_voty/ent/pollrevision"
 	"api_voty/ent/predicate"
+	"api_voty/ent/ratelimitbucket"
+	"api_voty/ent/schema"
+	"api_voty/ent/sharelink"
+	"api_voty/ent/tag"
 	"api_voty/ent/user"
 	"api_voty/ent/vote"
 	"context"
@@ -27,44 +35,49 @@ const (
 	OpUpdateOne = ent.OpUpdateOne
 
 	// Node types.
-	TypePoll       = "Poll"
-	TypePollOption = "PollOption"
-	TypeUser       = "User"
-	TypeVote       = "Vote"
+	TypeLinkedIdentity  = "LinkedIdentity"
+	TypeLoginAttempt    = "LoginAttempt"
+	TypePasswordReset   = "PasswordReset"
+	TypePoll            = "Poll"
+	TypePollOption      = "PollOption"
+	TypePollRevision    = "PollRevision"
+	TypeRateLimitBucket = "RateLimitBucket"
+	TypeShareLink       = "ShareLink"
+	TypeTag             = "Tag"
+	TypeUser            = "User"
+	TypeVote            = "Vote"
 )
 
-// PollMutation represents an operation that mutates the Poll nodes in the graph.
-type PollMutation struct {
+// LinkedIdentityMutation represents an operation that mutates the LinkedIdentity nodes in the graph.
+type LinkedIdentityMutation struct {
 	config
-	op             Op
-	typ            string
-	id             *int
-	title          *string
-	is_open        *bool
-	created_at     *time.Time
-	clearedFields  map[string]struct{}
-	options        map[int]struct{}
-	removedoptions map[int]struct{}
-	clearedoptions bool
-	votes          map[int]struct{}
-	removedvotes   map[int]struct{}
-	clearedvotes   bool
-	done           bool
-	oldValue       func(context.Context) (*Poll, error)
-	predicates     []predicate.Poll
+	op            Op
+	typ           string
+	id            *int
+	provider      *string
+	subject       *string
+	email         *string
+	created_at    *time.Time
+	last_login_at *time.Time
+	clearedFields map[string]struct{}
+	user          *string
+	cleareduser   bool
+	done          bool
+	oldValue      func(context.Context) (*LinkedIdentity, error)
+	predicates    []predicate.LinkedIdentity
 }
 
-var _ ent.Mutation = (*PollMutation)(nil)
+var _ ent.Mutation = (*LinkedIdentityMutation)(nil)
 
-// pollOption allows management of the mutation configuration using functional options.
-type pollOption func(*PollMutation)
+// linkedidentityOption allows management of the mutation configuration using functional options.
+type linkedidentityOption func(*LinkedIdentityMutation)
 
-// newPollMutation creates new mutation for the Poll entity.
-func newPollMutation(c config, op Op, opts ...pollOption) *PollMutation {
-	m := &PollMutation{
+// newLinkedIdentityMutation creates new mutation for the LinkedIdentity entity.
+func newLinkedIdentityMutation(c config, op Op, opts ...linkedidentityOption) *LinkedIdentityMutation {
+	m := &LinkedIdentityMutation{
 		config:        c,
 		op:            op,
-		typ:           TypePoll,
+		typ:           TypeLinkedIdentity,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -73,20 +86,20 @@ func newPollMutation(c config, op Op, opts ...pollOption) *PollMutation {
 	return m
 }
 
-// withPollID sets the ID field of the mutation.
-func withPollID(id int) pollOption {
-	return func(m *PollMutation) {
+// withLinkedIdentityID sets the ID field of the mutation.
+func withLinkedIdentityID(id int) linkedidentityOption {
+	return func(m *LinkedIdentityMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Poll
+			value *LinkedIdentity
 		)
-		m.oldValue = func(ctx context.Context) (*Poll, error) {
+		m.oldValue = func(ctx context.Context) (*LinkedIdentity, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Poll.Get(ctx, id)
+					value, err = m.Client().LinkedIdentity.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -95,10 +108,10 @@ func withPollID(id int) pollOption {
 	}
 }
 
-// withPoll sets the old Poll of the mutation.
-func withPoll(node *Poll) pollOption {
-	return func(m *PollMutation) {
-		m.oldValue = func(context.Context) (*Poll, error) {
+// withLinkedIdentity sets the old LinkedIdentity of the mutation.
+func withLinkedIdentity(node *LinkedIdentity) linkedidentityOption {
+	return func(m *LinkedIdentityMutation) {
+		m.oldValue = func(context.Context) (*LinkedIdentity, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -107,7 +120,7 @@ func withPoll(node *Poll) pollOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m PollMutation) Client() *Client {
+func (m LinkedIdentityMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -115,7 +128,7 @@ func (m PollMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m PollMutation) Tx() (*Tx, error) {
+func (m LinkedIdentityMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -126,7 +139,7 @@ func (m PollMutation) Tx() (*Tx, error) {
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *PollMutation) ID() (id int, exists bool) {
+func (m *LinkedIdentityMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -137,7 +150,7 @@ func (m *PollMutation) ID() (id int, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *PollMutation) IDs(ctx context.Context) ([]int, error) {
+func (m *LinkedIdentityMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -146,237 +159,277 @@ func (m *PollMutation) IDs(ctx context.Context) ([]int, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Poll.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().LinkedIdentity.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetTitle sets the "title" field.
-func (m *PollMutation) SetTitle(s string) {
-	m.title = &s
+// SetUserID sets the "user_id" field.
+func (m *LinkedIdentityMutation) SetUserID(s string) {
+	m.user = &s
 }
 
-// Title returns the value of the "title" field in the mutation.
-func (m *PollMutation) Title() (r string, exists bool) {
-	v := m.title
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *LinkedIdentityMutation) UserID() (r string, exists bool) {
+	v := m.user
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldTitle returns the old "title" field's value of the Poll entity.
-// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// OldUserID returns the old "user_id" field's value of the LinkedIdentity entity.
+// If the LinkedIdentity object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PollMutation) OldTitle(ctx context.Context) (v string, err error) {
+func (m *LinkedIdentityMutation) OldUserID(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldTitle is only allowed on UpdateOne operations")
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldTitle requires an ID field in the mutation")
+		return v, errors.New("OldUserID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldTitle: %w", err)
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
 	}
-	return oldValue.Title, nil
+	return oldValue.UserID, nil
 }
 
-// ResetTitle resets all changes to the "title" field.
-func (m *PollMutation) ResetTitle() {
-	m.title = nil
+// ResetUserID resets all changes to the "user_id" field.
+func (m *LinkedIdentityMutation) ResetUserID() {
+	m.user = nil
 }
 
-// SetIsOpen sets the "is_open" field.
-func (m *PollMutation) SetIsOpen(b bool) {
-	m.is_open = &b
+// SetProvider sets the "provider" field.
+func (m *LinkedIdentityMutation) SetProvider(s string) {
+	m.provider = &s
 }
 
-// IsOpen returns the value of the "is_open" field in the mutation.
-func (m *PollMutation) IsOpen() (r bool, exists bool) {
-	v := m.is_open
+// Provider returns the value of the "provider" field in the mutation.
+func (m *LinkedIdentityMutation) Provider() (r string, exists bool) {
+	v := m.provider
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldIsOpen returns the old "is_open" field's value of the Poll entity.
-// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// OldProvider returns the old "provider" field's value of the LinkedIdentity entity.
+// If the LinkedIdentity object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PollMutation) OldIsOpen(ctx context.Context) (v bool, err error) {
+func (m *LinkedIdentityMutation) OldProvider(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldIsOpen is only allowed on UpdateOne operations")
+		return v, errors.New("OldProvider is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldIsOpen requires an ID field in the mutation")
+		return v, errors.New("OldProvider requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldIsOpen: %w", err)
+		return v, fmt.Errorf("querying old value for OldProvider: %w", err)
 	}
-	return oldValue.IsOpen, nil
+	return oldValue.Provider, nil
 }
 
-// ResetIsOpen resets all changes to the "is_open" field.
-func (m *PollMutation) ResetIsOpen() {
-	m.is_open = nil
+// ResetProvider resets all changes to the "provider" field.
+func (m *LinkedIdentityMutation) ResetProvider() {
+	m.provider = nil
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *PollMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
+// SetSubject sets the "subject" field.
+func (m *LinkedIdentityMutation) SetSubject(s string) {
+	m.subject = &s
 }
 
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *PollMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
+// Subject returns the value of the "subject" field in the mutation.
+func (m *LinkedIdentityMutation) Subject() (r string, exists bool) {
+	v := m.subject
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the Poll entity.
-// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// OldSubject returns the old "subject" field's value of the LinkedIdentity entity.
+// If the LinkedIdentity object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PollMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *LinkedIdentityMutation) OldSubject(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldSubject is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+		return v, errors.New("OldSubject requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldSubject: %w", err)
 	}
-	return oldValue.CreatedAt, nil
+	return oldValue.Subject, nil
 }
 
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *PollMutation) ResetCreatedAt() {
-	m.created_at = nil
+// ResetSubject resets all changes to the "subject" field.
+func (m *LinkedIdentityMutation) ResetSubject() {
+	m.subject = nil
 }
 
-// AddOptionIDs adds the "options" edge to the PollOption entity by ids.
-func (m *PollMutation) AddOptionIDs(ids ...int) {
-	if m.options == nil {
-		m.options = make(map[int]struct{})
+// SetEmail sets the "email" field.
+func (m *LinkedIdentityMutation) SetEmail(s string) {
+	m.email = &s
+}
+
+// Email returns the value of the "email" field in the mutation.
+func (m *LinkedIdentityMutation) Email() (r string, exists bool) {
+	v := m.email
+	if v == nil {
+		return
 	}
-	for i := range ids {
-		m.options[ids[i]] = struct{}{}
+	return *v, true
+}
+
+// OldEmail returns the old "email" field's value of the LinkedIdentity entity.
+// If the LinkedIdentity object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *LinkedIdentityMutation) OldEmail(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEmail is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEmail requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEmail: %w", err)
 	}
+	return oldValue.Email, nil
 }
 
-// ClearOptions clears the "options" edge to the PollOption entity.
-func (m *PollMutation) ClearOptions() {
-	m.clearedoptions = true
+// ResetEmail resets all changes to the "email" field.
+func (m *LinkedIdentityMutation) ResetEmail() {
+	m.email = nil
 }
 
-// OptionsCleared reports if the "options" edge to the PollOption entity was cleared.
-func (m *PollMutation) OptionsCleared() bool {
-	return m.clearedoptions
+// SetCreatedAt sets the "created_at" field.
+func (m *LinkedIdentityMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
 }
 
-// RemoveOptionIDs removes the "options" edge to the PollOption entity by IDs.
-func (m *PollMutation) RemoveOptionIDs(ids ...int) {
-	if m.removedoptions == nil {
-		m.removedoptions = make(map[int]struct{})
-	}
-	for i := range ids {
-		delete(m.options, ids[i])
-		m.removedoptions[ids[i]] = struct{}{}
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *LinkedIdentityMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// RemovedOptions returns the removed IDs of the "options" edge to the PollOption entity.
-func (m *PollMutation) RemovedOptionsIDs() (ids []int) {
-	for id := range m.removedoptions {
-		ids = append(ids, id)
+// OldCreatedAt returns the old "created_at" field's value of the LinkedIdentity entity.
+// If the LinkedIdentity object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *LinkedIdentityMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
 }
 
-// OptionsIDs returns the "options" edge IDs in the mutation.
-func (m *PollMutation) OptionsIDs() (ids []int) {
-	for id := range m.options {
-		ids = append(ids, id)
-	}
-	return
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *LinkedIdentityMutation) ResetCreatedAt() {
+	m.created_at = nil
 }
 
-// ResetOptions resets all changes to the "options" edge.
-func (m *PollMutation) ResetOptions() {
-	m.options = nil
-	m.clearedoptions = false
-	m.removedoptions = nil
+// SetLastLoginAt sets the "last_login_at" field.
+func (m *LinkedIdentityMutation) SetLastLoginAt(t time.Time) {
+	m.last_login_at = &t
 }
 
-// AddVoteIDs adds the "votes" edge to the Vote entity by ids.
-func (m *PollMutation) AddVoteIDs(ids ...int) {
-	if m.votes == nil {
-		m.votes = make(map[int]struct{})
+// LastLoginAt returns the value of the "last_login_at" field in the mutation.
+func (m *LinkedIdentityMutation) LastLoginAt() (r time.Time, exists bool) {
+	v := m.last_login_at
+	if v == nil {
+		return
 	}
-	for i := range ids {
-		m.votes[ids[i]] = struct{}{}
+	return *v, true
+}
+
+// OldLastLoginAt returns the old "last_login_at" field's value of the LinkedIdentity entity.
+// If the LinkedIdentity object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *LinkedIdentityMutation) OldLastLoginAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLastLoginAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLastLoginAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLastLoginAt: %w", err)
 	}
+	return oldValue.LastLoginAt, nil
 }
 
-// ClearVotes clears the "votes" edge to the Vote entity.
-func (m *PollMutation) ClearVotes() {
-	m.clearedvotes = true
+// ClearLastLoginAt clears the value of the "last_login_at" field.
+func (m *LinkedIdentityMutation) ClearLastLoginAt() {
+	m.last_login_at = nil
+	m.clearedFields[linkedidentity.FieldLastLoginAt] = struct{}{}
 }
 
-// VotesCleared reports if the "votes" edge to the Vote entity was cleared.
-func (m *PollMutation) VotesCleared() bool {
-	return m.clearedvotes
+// LastLoginAtCleared returns if the "last_login_at" field was cleared in this mutation.
+func (m *LinkedIdentityMutation) LastLoginAtCleared() bool {
+	_, ok := m.clearedFields[linkedidentity.FieldLastLoginAt]
+	return ok
 }
 
-// RemoveVoteIDs removes the "votes" edge to the Vote entity by IDs.
-func (m *PollMutation) RemoveVoteIDs(ids ...int) {
-	if m.removedvotes == nil {
-		m.removedvotes = make(map[int]struct{})
-	}
-	for i := range ids {
-		delete(m.votes, ids[i])
-		m.removedvotes[ids[i]] = struct{}{}
-	}
+// ResetLastLoginAt resets all changes to the "last_login_at" field.
+func (m *LinkedIdentityMutation) ResetLastLoginAt() {
+	m.last_login_at = nil
+	delete(m.clearedFields, linkedidentity.FieldLastLoginAt)
 }
 
-// RemovedVotes returns the removed IDs of the "votes" edge to the Vote entity.
-func (m *PollMutation) RemovedVotesIDs() (ids []int) {
-	for id := range m.removedvotes {
-		ids = append(ids, id)
-	}
-	return
+// ClearUser clears the "user" edge to the User entity.
+func (m *LinkedIdentityMutation) ClearUser() {
+	m.cleareduser = true
+	m.clearedFields[linkedidentity.FieldUserID] = struct{}{}
 }
 
-// VotesIDs returns the "votes" edge IDs in the mutation.
-func (m *PollMutation) VotesIDs() (ids []int) {
-	for id := range m.votes {
-		ids = append(ids, id)
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *LinkedIdentityMutation) UserCleared() bool {
+	return m.cleareduser
+}
+
+// UserIDs returns the "user" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// UserID instead. It exists only for internal usage by the builders.
+func (m *LinkedIdentityMutation) UserIDs() (ids []string) {
+	if id := m.user; id != nil {
+		ids = append(ids, *id)
 	}
 	return
 }
 
-// ResetVotes resets all changes to the "votes" edge.
-func (m *PollMutation) ResetVotes() {
-	m.votes = nil
-	m.clearedvotes = false
-	m.removedvotes = nil
+// ResetUser resets all changes to the "user" edge.
+func (m *LinkedIdentityMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
 }
 
-// Where appends a list predicates to the PollMutation builder.
-func (m *PollMutation) Where(ps ...predicate.Poll) {
+// Where appends a list predicates to the LinkedIdentityMutation builder.
+func (m *LinkedIdentityMutation) Where(ps ...predicate.LinkedIdentity) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the PollMutation builder. Using this method,
+// WhereP appends storage-level predicates to the LinkedIdentityMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *PollMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Poll, len(ps))
+func (m *LinkedIdentityMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.LinkedIdentity, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -384,33 +437,42 @@ func (m *PollMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *PollMutation) Op() Op {
+func (m *LinkedIdentityMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *PollMutation) SetOp(op Op) {
+func (m *LinkedIdentityMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (Poll).
-func (m *PollMutation) Type() string {
+// Type returns the node type of this mutation (LinkedIdentity).
+func (m *LinkedIdentityMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *PollMutation) Fields() []string {
-	fields := make([]string, 0, 3)
-	if m.title != nil {
-		fields = append(fields, poll.FieldTitle)
+func (m *LinkedIdentityMutation) Fields() []string {
+	fields := make([]string, 0, 6)
+	if m.user != nil {
+		fields = append(fields, linkedidentity.FieldUserID)
 	}
-	if m.is_open != nil {
-		fields = append(fields, poll.FieldIsOpen)
+	if m.provider != nil {
+		fields = append(fields, linkedidentity.FieldProvider)
+	}
+	if m.subject != nil {
+		fields = append(fields, linkedidentity.FieldSubject)
+	}
+	if m.email != nil {
+		fields = append(fields, linkedidentity.FieldEmail)
 	}
 	if m.created_at != nil {
-		fields = append(fields, poll.FieldCreatedAt)
+		fields = append(fields, linkedidentity.FieldCreatedAt)
+	}
+	if m.last_login_at != nil {
+		fields = append(fields, linkedidentity.FieldLastLoginAt)
 	}
 	return fields
 }
@@ -418,14 +480,20 @@ func (m *PollMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *PollMutation) Field(name string) (ent.Value, bool) {
+func (m *LinkedIdentityMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case poll.FieldTitle:
-		return m.Title()
-	case poll.FieldIsOpen:
-		return m.IsOpen()
-	case poll.FieldCreatedAt:
+	case linkedidentity.FieldUserID:
+		return m.UserID()
+	case linkedidentity.FieldProvider:
+		return m.Provider()
+	case linkedidentity.FieldSubject:
+		return m.Subject()
+	case linkedidentity.FieldEmail:
+		return m.Email()
+	case linkedidentity.FieldCreatedAt:
 		return m.CreatedAt()
+	case linkedidentity.FieldLastLoginAt:
+		return m.LastLoginAt()
 	}
 	return nil, false
 }
@@ -433,247 +501,256 @@ func (m *PollMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *PollMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *LinkedIdentityMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case poll.FieldTitle:
-		return m.OldTitle(ctx)
-	case poll.FieldIsOpen:
-		return m.OldIsOpen(ctx)
-	case poll.FieldCreatedAt:
+	case linkedidentity.FieldUserID:
+		return m.OldUserID(ctx)
+	case linkedidentity.FieldProvider:
+		return m.OldProvider(ctx)
+	case linkedidentity.FieldSubject:
+		return m.OldSubject(ctx)
+	case linkedidentity.FieldEmail:
+		return m.OldEmail(ctx)
+	case linkedidentity.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
+	case linkedidentity.FieldLastLoginAt:
+		return m.OldLastLoginAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown Poll field %s", name)
+	return nil, fmt.Errorf("unknown LinkedIdentity field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PollMutation) SetField(name string, value ent.Value) error {
+func (m *LinkedIdentityMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case poll.FieldTitle:
+	case linkedidentity.FieldUserID:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetTitle(v)
+		m.SetUserID(v)
 		return nil
-	case poll.FieldIsOpen:
-		v, ok := value.(bool)
+	case linkedidentity.FieldProvider:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetIsOpen(v)
+		m.SetProvider(v)
 		return nil
-	case poll.FieldCreatedAt:
+	case linkedidentity.FieldSubject:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSubject(v)
+		return nil
+	case linkedidentity.FieldEmail:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEmail(v)
+		return nil
+	case linkedidentity.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetCreatedAt(v)
 		return nil
+	case linkedidentity.FieldLastLoginAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLastLoginAt(v)
+		return nil
 	}
-	return fmt.Errorf("unknown Poll field %s", name)
+	return fmt.Errorf("unknown LinkedIdentity field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *PollMutation) AddedFields() []string {
+func (m *LinkedIdentityMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *PollMutation) AddedField(name string) (ent.Value, bool) {
+func (m *LinkedIdentityMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PollMutation) AddField(name string, value ent.Value) error {
+func (m *LinkedIdentityMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown Poll numeric field %s", name)
+	return fmt.Errorf("unknown LinkedIdentity numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *PollMutation) ClearedFields() []string {
-	return nil
+func (m *LinkedIdentityMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(linkedidentity.FieldLastLoginAt) {
+		fields = append(fields, linkedidentity.FieldLastLoginAt)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *PollMutation) FieldCleared(name string) bool {
+func (m *LinkedIdentityMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *PollMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown Poll nullable field %s", name)
+func (m *LinkedIdentityMutation) ClearField(name string) error {
+	switch name {
+	case linkedidentity.FieldLastLoginAt:
+		m.ClearLastLoginAt()
+		return nil
+	}
+	return fmt.Errorf("unknown LinkedIdentity nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *PollMutation) ResetField(name string) error {
+func (m *LinkedIdentityMutation) ResetField(name string) error {
 	switch name {
-	case poll.FieldTitle:
-		m.ResetTitle()
+	case linkedidentity.FieldUserID:
+		m.ResetUserID()
 		return nil
-	case poll.FieldIsOpen:
-		m.ResetIsOpen()
+	case linkedidentity.FieldProvider:
+		m.ResetProvider()
 		return nil
-	case poll.FieldCreatedAt:
+	case linkedidentity.FieldSubject:
+		m.ResetSubject()
+		return nil
+	case linkedidentity.FieldEmail:
+		m.ResetEmail()
+		return nil
+	case linkedidentity.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
+	case linkedidentity.FieldLastLoginAt:
+		m.ResetLastLoginAt()
+		return nil
 	}
-	return fmt.Errorf("unknown Poll field %s", name)
+	return fmt.Errorf("unknown LinkedIdentity field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *PollMutation) AddedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.options != nil {
-		edges = append(edges, poll.EdgeOptions)
-	}
-	if m.votes != nil {
-		edges = append(edges, poll.EdgeVotes)
+func (m *LinkedIdentityMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.user != nil {
+		edges = append(edges, linkedidentity.EdgeUser)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *PollMutation) AddedIDs(name string) []ent.Value {
+func (m *LinkedIdentityMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case poll.EdgeOptions:
-		ids := make([]ent.Value, 0, len(m.options))
-		for id := range m.options {
-			ids = append(ids, id)
-		}
-		return ids
-	case poll.EdgeVotes:
-		ids := make([]ent.Value, 0, len(m.votes))
-		for id := range m.votes {
-			ids = append(ids, id)
+	case linkedidentity.EdgeUser:
+		if id := m.user; id != nil {
+			return []ent.Value{*id}
 		}
-		return ids
 	}
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *PollMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.removedoptions != nil {
-		edges = append(edges, poll.EdgeOptions)
-	}
-	if m.removedvotes != nil {
-		edges = append(edges, poll.EdgeVotes)
-	}
+func (m *LinkedIdentityMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *PollMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case poll.EdgeOptions:
-		ids := make([]ent.Value, 0, len(m.removedoptions))
-		for id := range m.removedoptions {
-			ids = append(ids, id)
-		}
-		return ids
-	case poll.EdgeVotes:
-		ids := make([]ent.Value, 0, len(m.removedvotes))
-		for id := range m.removedvotes {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *LinkedIdentityMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *PollMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.clearedoptions {
-		edges = append(edges, poll.EdgeOptions)
-	}
-	if m.clearedvotes {
-		edges = append(edges, poll.EdgeVotes)
+func (m *LinkedIdentityMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.cleareduser {
+		edges = append(edges, linkedidentity.EdgeUser)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *PollMutation) EdgeCleared(name string) bool {
+func (m *LinkedIdentityMutation) EdgeCleared(name string) bool {
 	switch name {
-	case poll.EdgeOptions:
-		return m.clearedoptions
-	case poll.EdgeVotes:
-		return m.clearedvotes
+	case linkedidentity.EdgeUser:
+		return m.cleareduser
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *PollMutation) ClearEdge(name string) error {
+func (m *LinkedIdentityMutation) ClearEdge(name string) error {
 	switch name {
+	case linkedidentity.EdgeUser:
+		m.ClearUser()
+		return nil
 	}
-	return fmt.Errorf("unknown Poll unique edge %s", name)
+	return fmt.Errorf("unknown LinkedIdentity unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *PollMutation) ResetEdge(name string) error {
+func (m *LinkedIdentityMutation) ResetEdge(name string) error {
 	switch name {
-	case poll.EdgeOptions:
-		m.ResetOptions()
-		return nil
-	case poll.EdgeVotes:
-		m.ResetVotes()
+	case linkedidentity.EdgeUser:
+		m.ResetUser()
 		return nil
 	}
-	return fmt.Errorf("unknown Poll edge %s", name)
+	return fmt.Errorf("unknown LinkedIdentity edge %s", name)
 }
 
-// PollOptionMutation represents an operation that mutates the PollOption nodes in the graph.
-type PollOptionMutation struct {
+// LoginAttemptMutation represents an operation that mutates the LoginAttempt nodes in the graph.
+type LoginAttemptMutation struct {
 	config
 	op             Op
 	typ            string
 	id             *int
-	text           *string
-	votes_count    *int
-	addvotes_count *int
+	email          *string
+	ip             *string
+	user_agent     *string
+	success        *bool
+	failure_reason *loginattempt.FailureReason
+	created_at     *time.Time
 	clearedFields  map[string]struct{}
-	poll           *int
-	clearedpoll    bool
-	votes          map[int]struct{}
-	removedvotes   map[int]struct{}
-	clearedvotes   bool
+	user           *string
+	cleareduser    bool
 	done           bool
-	oldValue       func(context.Context) (*PollOption, error)
-	predicates     []predicate.PollOption
+	oldValue       func(context.Context) (*LoginAttempt, error)
+	predicates     []predicate.LoginAttempt
 }
 
-var _ ent.Mutation = (*PollOptionMutation)(nil)
+var _ ent.Mutation = (*LoginAttemptMutation)(nil)
 
-// polloptionOption allows management of the mutation configuration using functional options.
-type polloptionOption func(*PollOptionMutation)
+// loginattemptOption allows management of the mutation configuration using functional options.
+type loginattemptOption func(*LoginAttemptMutation)
 
-// newPollOptionMutation creates new mutation for the PollOption entity.
-func newPollOptionMutation(c config, op Op, opts ...polloptionOption) *PollOptionMutation {
-	m := &PollOptionMutation{
+// newLoginAttemptMutation creates new mutation for the LoginAttempt entity.
+func newLoginAttemptMutation(c config, op Op, opts ...loginattemptOption) *LoginAttemptMutation {
+	m := &LoginAttemptMutation{
 		config:        c,
 		op:            op,
-		typ:           TypePollOption,
+		typ:           TypeLoginAttempt,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -682,20 +759,20 @@ func newPollOptionMutation(c config, op Op, opts ...polloptionOption) *PollOptio
 	return m
 }
 
-// withPollOptionID sets the ID field of the mutation.
-func withPollOptionID(id int) polloptionOption {
-	return func(m *PollOptionMutation) {
+// withLoginAttemptID sets the ID field of the mutation.
+func withLoginAttemptID(id int) loginattemptOption {
+	return func(m *LoginAttemptMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *PollOption
+			value *LoginAttempt
 		)
-		m.oldValue = func(ctx context.Context) (*PollOption, error) {
+		m.oldValue = func(ctx context.Context) (*LoginAttempt, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().PollOption.Get(ctx, id)
+					value, err = m.Client().LoginAttempt.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -704,10 +781,10 @@ func withPollOptionID(id int) polloptionOption {
 	}
 }
 
-// withPollOption sets the old PollOption of the mutation.
-func withPollOption(node *PollOption) polloptionOption {
-	return func(m *PollOptionMutation) {
-		m.oldValue = func(context.Context) (*PollOption, error) {
+// withLoginAttempt sets the old LoginAttempt of the mutation.
+func withLoginAttempt(node *LoginAttempt) loginattemptOption {
+	return func(m *LoginAttemptMutation) {
+		m.oldValue = func(context.Context) (*LoginAttempt, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -716,7 +793,7 @@ func withPollOption(node *PollOption) polloptionOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m PollOptionMutation) Client() *Client {
+func (m LoginAttemptMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -724,7 +801,7 @@ func (m PollOptionMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m PollOptionMutation) Tx() (*Tx, error) {
+func (m LoginAttemptMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -735,7 +812,7 @@ func (m PollOptionMutation) Tx() (*Tx, error) {
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *PollOptionMutation) ID() (id int, exists bool) {
+func (m *LoginAttemptMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -746,7 +823,7 @@ func (m *PollOptionMutation) ID() (id int, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *PollOptionMutation) IDs(ctx context.Context) ([]int, error) {
+func (m *LoginAttemptMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -755,206 +832,326 @@ func (m *PollOptionMutation) IDs(ctx context.Context) ([]int, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().PollOption.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().LoginAttempt.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetText sets the "text" field.
-func (m *PollOptionMutation) SetText(s string) {
-	m.text = &s
+// SetUserID sets the "user_id" field.
+func (m *LoginAttemptMutation) SetUserID(s string) {
+	m.user = &s
 }
 
-// Text returns the value of the "text" field in the mutation.
-func (m *PollOptionMutation) Text() (r string, exists bool) {
-	v := m.text
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *LoginAttemptMutation) UserID() (r string, exists bool) {
+	v := m.user
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldText returns the old "text" field's value of the PollOption entity.
-// If the PollOption object wasn't provided to the builder, the object is fetched from the database.
+// OldUserID returns the old "user_id" field's value of the LoginAttempt entity.
+// If the LoginAttempt object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PollOptionMutation) OldText(ctx context.Context) (v string, err error) {
+func (m *LoginAttemptMutation) OldUserID(ctx context.Context) (v *string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldText is only allowed on UpdateOne operations")
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldText requires an ID field in the mutation")
+		return v, errors.New("OldUserID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldText: %w", err)
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
 	}
-	return oldValue.Text, nil
+	return oldValue.UserID, nil
 }
 
-// ResetText resets all changes to the "text" field.
-func (m *PollOptionMutation) ResetText() {
-	m.text = nil
+// ClearUserID clears the value of the "user_id" field.
+func (m *LoginAttemptMutation) ClearUserID() {
+	m.user = nil
+	m.clearedFields[loginattempt.FieldUserID] = struct{}{}
 }
 
-// SetVotesCount sets the "votes_count" field.
-func (m *PollOptionMutation) SetVotesCount(i int) {
-	m.votes_count = &i
-	m.addvotes_count = nil
+// UserIDCleared returns if the "user_id" field was cleared in this mutation.
+func (m *LoginAttemptMutation) UserIDCleared() bool {
+	_, ok := m.clearedFields[loginattempt.FieldUserID]
+	return ok
 }
 
-// VotesCount returns the value of the "votes_count" field in the mutation.
-func (m *PollOptionMutation) VotesCount() (r int, exists bool) {
-	v := m.votes_count
+// ResetUserID resets all changes to the "user_id" field.
+func (m *LoginAttemptMutation) ResetUserID() {
+	m.user = nil
+	delete(m.clearedFields, loginattempt.FieldUserID)
+}
+
+// SetEmail sets the "email" field.
+func (m *LoginAttemptMutation) SetEmail(s string) {
+	m.email = &s
+}
+
+// Email returns the value of the "email" field in the mutation.
+func (m *LoginAttemptMutation) Email() (r string, exists bool) {
+	v := m.email
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldVotesCount returns the old "votes_count" field's value of the PollOption entity.
-// If the PollOption object wasn't provided to the builder, the object is fetched from the database.
+// OldEmail returns the old "email" field's value of the LoginAttempt entity.
+// If the LoginAttempt object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PollOptionMutation) OldVotesCount(ctx context.Context) (v int, err error) {
+func (m *LoginAttemptMutation) OldEmail(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldVotesCount is only allowed on UpdateOne operations")
+		return v, errors.New("OldEmail is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldVotesCount requires an ID field in the mutation")
+		return v, errors.New("OldEmail requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldVotesCount: %w", err)
+		return v, fmt.Errorf("querying old value for OldEmail: %w", err)
 	}
-	return oldValue.VotesCount, nil
+	return oldValue.Email, nil
 }
 
-// AddVotesCount adds i to the "votes_count" field.
-func (m *PollOptionMutation) AddVotesCount(i int) {
-	if m.addvotes_count != nil {
-		*m.addvotes_count += i
-	} else {
-		m.addvotes_count = &i
-	}
+// ResetEmail resets all changes to the "email" field.
+func (m *LoginAttemptMutation) ResetEmail() {
+	m.email = nil
 }
 
-// AddedVotesCount returns the value that was added to the "votes_count" field in this mutation.
-func (m *PollOptionMutation) AddedVotesCount() (r int, exists bool) {
-	v := m.addvotes_count
+// SetIP sets the "ip" field.
+func (m *LoginAttemptMutation) SetIP(s string) {
+	m.ip = &s
+}
+
+// IP returns the value of the "ip" field in the mutation.
+func (m *LoginAttemptMutation) IP() (r string, exists bool) {
+	v := m.ip
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetVotesCount resets all changes to the "votes_count" field.
-func (m *PollOptionMutation) ResetVotesCount() {
-	m.votes_count = nil
-	m.addvotes_count = nil
+// OldIP returns the old "ip" field's value of the LoginAttempt entity.
+// If the LoginAttempt object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *LoginAttemptMutation) OldIP(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldIP is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldIP requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldIP: %w", err)
+	}
+	return oldValue.IP, nil
 }
 
-// SetPollID sets the "poll" edge to the Poll entity by id.
-func (m *PollOptionMutation) SetPollID(id int) {
-	m.poll = &id
+// ResetIP resets all changes to the "ip" field.
+func (m *LoginAttemptMutation) ResetIP() {
+	m.ip = nil
 }
 
-// ClearPoll clears the "poll" edge to the Poll entity.
-func (m *PollOptionMutation) ClearPoll() {
-	m.clearedpoll = true
+// SetUserAgent sets the "user_agent" field.
+func (m *LoginAttemptMutation) SetUserAgent(s string) {
+	m.user_agent = &s
 }
 
-// PollCleared reports if the "poll" edge to the Poll entity was cleared.
-func (m *PollOptionMutation) PollCleared() bool {
-	return m.clearedpoll
+// UserAgent returns the value of the "user_agent" field in the mutation.
+func (m *LoginAttemptMutation) UserAgent() (r string, exists bool) {
+	v := m.user_agent
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// PollID returns the "poll" edge ID in the mutation.
-func (m *PollOptionMutation) PollID() (id int, exists bool) {
-	if m.poll != nil {
-		return *m.poll, true
+// OldUserAgent returns the old "user_agent" field's value of the LoginAttempt entity.
+// If the LoginAttempt object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *LoginAttemptMutation) OldUserAgent(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUserAgent is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUserAgent requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUserAgent: %w", err)
+	}
+	return oldValue.UserAgent, nil
 }
 
-// PollIDs returns the "poll" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// PollID instead. It exists only for internal usage by the builders.
-func (m *PollOptionMutation) PollIDs() (ids []int) {
-	if id := m.poll; id != nil {
-		ids = append(ids, *id)
-	}
-	return
+// ResetUserAgent resets all changes to the "user_agent" field.
+func (m *LoginAttemptMutation) ResetUserAgent() {
+	m.user_agent = nil
 }
 
-// ResetPoll resets all changes to the "poll" edge.
-func (m *PollOptionMutation) ResetPoll() {
-	m.poll = nil
-	m.clearedpoll = false
+// SetSuccess sets the "success" field.
+func (m *LoginAttemptMutation) SetSuccess(b bool) {
+	m.success = &b
 }
 
-// AddVoteIDs adds the "votes" edge to the Vote entity by ids.
-func (m *PollOptionMutation) AddVoteIDs(ids ...int) {
-	if m.votes == nil {
-		m.votes = make(map[int]struct{})
+// Success returns the value of the "success" field in the mutation.
+func (m *LoginAttemptMutation) Success() (r bool, exists bool) {
+	v := m.success
+	if v == nil {
+		return
 	}
-	for i := range ids {
-		m.votes[ids[i]] = struct{}{}
+	return *v, true
+}
+
+// OldSuccess returns the old "success" field's value of the LoginAttempt entity.
+// If the LoginAttempt object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *LoginAttemptMutation) OldSuccess(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSuccess is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSuccess requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSuccess: %w", err)
 	}
+	return oldValue.Success, nil
 }
 
-// ClearVotes clears the "votes" edge to the Vote entity.
-func (m *PollOptionMutation) ClearVotes() {
-	m.clearedvotes = true
+// ResetSuccess resets all changes to the "success" field.
+func (m *LoginAttemptMutation) ResetSuccess() {
+	m.success = nil
 }
 
-// VotesCleared reports if the "votes" edge to the Vote entity was cleared.
-func (m *PollOptionMutation) VotesCleared() bool {
-	return m.clearedvotes
+// SetFailureReason sets the "failure_reason" field.
+func (m *LoginAttemptMutation) SetFailureReason(lr loginattempt.FailureReason) {
+	m.failure_reason = &lr
 }
 
-// RemoveVoteIDs removes the "votes" edge to the Vote entity by IDs.
-func (m *PollOptionMutation) RemoveVoteIDs(ids ...int) {
-	if m.removedvotes == nil {
-		m.removedvotes = make(map[int]struct{})
+// FailureReason returns the value of the "failure_reason" field in the mutation.
+func (m *LoginAttemptMutation) FailureReason() (r loginattempt.FailureReason, exists bool) {
+	v := m.failure_reason
+	if v == nil {
+		return
 	}
-	for i := range ids {
-		delete(m.votes, ids[i])
-		m.removedvotes[ids[i]] = struct{}{}
+	return *v, true
+}
+
+// OldFailureReason returns the old "failure_reason" field's value of the LoginAttempt entity.
+// If the LoginAttempt object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *LoginAttemptMutation) OldFailureReason(ctx context.Context) (v *loginattempt.FailureReason, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldFailureReason is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldFailureReason requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldFailureReason: %w", err)
 	}
+	return oldValue.FailureReason, nil
 }
 
-// RemovedVotes returns the removed IDs of the "votes" edge to the Vote entity.
-func (m *PollOptionMutation) RemovedVotesIDs() (ids []int) {
-	for id := range m.removedvotes {
-		ids = append(ids, id)
+// ClearFailureReason clears the value of the "failure_reason" field.
+func (m *LoginAttemptMutation) ClearFailureReason() {
+	m.failure_reason = nil
+	m.clearedFields[loginattempt.FieldFailureReason] = struct{}{}
+}
+
+// FailureReasonCleared returns if the "failure_reason" field was cleared in this mutation.
+func (m *LoginAttemptMutation) FailureReasonCleared() bool {
+	_, ok := m.clearedFields[loginattempt.FieldFailureReason]
+	return ok
+}
+
+// ResetFailureReason resets all changes to the "failure_reason" field.
+func (m *LoginAttemptMutation) ResetFailureReason() {
+	m.failure_reason = nil
+	delete(m.clearedFields, loginattempt.FieldFailureReason)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *LoginAttemptMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *LoginAttemptMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
 	}
-	return
+	return *v, true
 }
 
-// VotesIDs returns the "votes" edge IDs in the mutation.
-func (m *PollOptionMutation) VotesIDs() (ids []int) {
-	for id := range m.votes {
-		ids = append(ids, id)
+// OldCreatedAt returns the old "created_at" field's value of the LoginAttempt entity.
+// If the LoginAttempt object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *LoginAttemptMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *LoginAttemptMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (m *LoginAttemptMutation) ClearUser() {
+	m.cleareduser = true
+	m.clearedFields[loginattempt.FieldUserID] = struct{}{}
+}
+
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *LoginAttemptMutation) UserCleared() bool {
+	return m.UserIDCleared() || m.cleareduser
+}
+
+// UserIDs returns the "user" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// UserID instead. It exists only for internal usage by the builders.
+func (m *LoginAttemptMutation) UserIDs() (ids []string) {
+	if id := m.user; id != nil {
+		ids = append(ids, *id)
 	}
 	return
 }
 
-// ResetVotes resets all changes to the "votes" edge.
-func (m *PollOptionMutation) ResetVotes() {
-	m.votes = nil
-	m.clearedvotes = false
-	m.removedvotes = nil
+// ResetUser resets all changes to the "user" edge.
+func (m *LoginAttemptMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
 }
 
-// Where appends a list predicates to the PollOptionMutation builder.
-func (m *PollOptionMutation) Where(ps ...predicate.PollOption) {
+// Where appends a list predicates to the LoginAttemptMutation builder.
+func (m *LoginAttemptMutation) Where(ps ...predicate.LoginAttempt) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the PollOptionMutation builder. Using this method,
+// WhereP appends storage-level predicates to the LoginAttemptMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *PollOptionMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.PollOption, len(ps))
+func (m *LoginAttemptMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.LoginAttempt, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -962,30 +1159,45 @@ func (m *PollOptionMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *PollOptionMutation) Op() Op {
+func (m *LoginAttemptMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *PollOptionMutation) SetOp(op Op) {
+func (m *LoginAttemptMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (PollOption).
-func (m *PollOptionMutation) Type() string {
+// Type returns the node type of this mutation (LoginAttempt).
+func (m *LoginAttemptMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *PollOptionMutation) Fields() []string {
-	fields := make([]string, 0, 2)
-	if m.text != nil {
-		fields = append(fields, polloption.FieldText)
+func (m *LoginAttemptMutation) Fields() []string {
+	fields := make([]string, 0, 7)
+	if m.user != nil {
+		fields = append(fields, loginattempt.FieldUserID)
 	}
-	if m.votes_count != nil {
-		fields = append(fields, polloption.FieldVotesCount)
+	if m.email != nil {
+		fields = append(fields, loginattempt.FieldEmail)
+	}
+	if m.ip != nil {
+		fields = append(fields, loginattempt.FieldIP)
+	}
+	if m.user_agent != nil {
+		fields = append(fields, loginattempt.FieldUserAgent)
+	}
+	if m.success != nil {
+		fields = append(fields, loginattempt.FieldSuccess)
+	}
+	if m.failure_reason != nil {
+		fields = append(fields, loginattempt.FieldFailureReason)
+	}
+	if m.created_at != nil {
+		fields = append(fields, loginattempt.FieldCreatedAt)
 	}
 	return fields
 }
@@ -993,12 +1205,22 @@ func (m *PollOptionMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *PollOptionMutation) Field(name string) (ent.Value, bool) {
+func (m *LoginAttemptMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case polloption.FieldText:
-		return m.Text()
-	case polloption.FieldVotesCount:
-		return m.VotesCount()
+	case loginattempt.FieldUserID:
+		return m.UserID()
+	case loginattempt.FieldEmail:
+		return m.Email()
+	case loginattempt.FieldIP:
+		return m.IP()
+	case loginattempt.FieldUserAgent:
+		return m.UserAgent()
+	case loginattempt.FieldSuccess:
+		return m.Success()
+	case loginattempt.FieldFailureReason:
+		return m.FailureReason()
+	case loginattempt.FieldCreatedAt:
+		return m.CreatedAt()
 	}
 	return nil, false
 }
@@ -1006,244 +1228,273 @@ func (m *PollOptionMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *PollOptionMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *LoginAttemptMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case polloption.FieldText:
-		return m.OldText(ctx)
-	case polloption.FieldVotesCount:
-		return m.OldVotesCount(ctx)
+	case loginattempt.FieldUserID:
+		return m.OldUserID(ctx)
+	case loginattempt.FieldEmail:
+		return m.OldEmail(ctx)
+	case loginattempt.FieldIP:
+		return m.OldIP(ctx)
+	case loginattempt.FieldUserAgent:
+		return m.OldUserAgent(ctx)
+	case loginattempt.FieldSuccess:
+		return m.OldSuccess(ctx)
+	case loginattempt.FieldFailureReason:
+		return m.OldFailureReason(ctx)
+	case loginattempt.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown PollOption field %s", name)
+	return nil, fmt.Errorf("unknown LoginAttempt field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PollOptionMutation) SetField(name string, value ent.Value) error {
+func (m *LoginAttemptMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case polloption.FieldText:
+	case loginattempt.FieldUserID:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetText(v)
+		m.SetUserID(v)
 		return nil
-	case polloption.FieldVotesCount:
-		v, ok := value.(int)
+	case loginattempt.FieldEmail:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetVotesCount(v)
+		m.SetEmail(v)
+		return nil
+	case loginattempt.FieldIP:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetIP(v)
+		return nil
+	case loginattempt.FieldUserAgent:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUserAgent(v)
+		return nil
+	case loginattempt.FieldSuccess:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSuccess(v)
+		return nil
+	case loginattempt.FieldFailureReason:
+		v, ok := value.(loginattempt.FailureReason)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetFailureReason(v)
+		return nil
+	case loginattempt.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown PollOption field %s", name)
+	return fmt.Errorf("unknown LoginAttempt field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *PollOptionMutation) AddedFields() []string {
-	var fields []string
-	if m.addvotes_count != nil {
-		fields = append(fields, polloption.FieldVotesCount)
-	}
-	return fields
+func (m *LoginAttemptMutation) AddedFields() []string {
+	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *PollOptionMutation) AddedField(name string) (ent.Value, bool) {
-	switch name {
-	case polloption.FieldVotesCount:
-		return m.AddedVotesCount()
-	}
+func (m *LoginAttemptMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PollOptionMutation) AddField(name string, value ent.Value) error {
+func (m *LoginAttemptMutation) AddField(name string, value ent.Value) error {
 	switch name {
-	case polloption.FieldVotesCount:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddVotesCount(v)
-		return nil
 	}
-	return fmt.Errorf("unknown PollOption numeric field %s", name)
+	return fmt.Errorf("unknown LoginAttempt numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *PollOptionMutation) ClearedFields() []string {
-	return nil
+func (m *LoginAttemptMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(loginattempt.FieldUserID) {
+		fields = append(fields, loginattempt.FieldUserID)
+	}
+	if m.FieldCleared(loginattempt.FieldFailureReason) {
+		fields = append(fields, loginattempt.FieldFailureReason)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *PollOptionMutation) FieldCleared(name string) bool {
+func (m *LoginAttemptMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *PollOptionMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown PollOption nullable field %s", name)
+func (m *LoginAttemptMutation) ClearField(name string) error {
+	switch name {
+	case loginattempt.FieldUserID:
+		m.ClearUserID()
+		return nil
+	case loginattempt.FieldFailureReason:
+		m.ClearFailureReason()
+		return nil
+	}
+	return fmt.Errorf("unknown LoginAttempt nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *PollOptionMutation) ResetField(name string) error {
+func (m *LoginAttemptMutation) ResetField(name string) error {
 	switch name {
-	case polloption.FieldText:
-		m.ResetText()
+	case loginattempt.FieldUserID:
+		m.ResetUserID()
 		return nil
-	case polloption.FieldVotesCount:
-		m.ResetVotesCount()
+	case loginattempt.FieldEmail:
+		m.ResetEmail()
+		return nil
+	case loginattempt.FieldIP:
+		m.ResetIP()
+		return nil
+	case loginattempt.FieldUserAgent:
+		m.ResetUserAgent()
+		return nil
+	case loginattempt.FieldSuccess:
+		m.ResetSuccess()
+		return nil
+	case loginattempt.FieldFailureReason:
+		m.ResetFailureReason()
+		return nil
+	case loginattempt.FieldCreatedAt:
+		m.ResetCreatedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown PollOption field %s", name)
+	return fmt.Errorf("unknown LoginAttempt field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *PollOptionMutation) AddedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.poll != nil {
-		edges = append(edges, polloption.EdgePoll)
-	}
-	if m.votes != nil {
-		edges = append(edges, polloption.EdgeVotes)
+func (m *LoginAttemptMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.user != nil {
+		edges = append(edges, loginattempt.EdgeUser)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *PollOptionMutation) AddedIDs(name string) []ent.Value {
+func (m *LoginAttemptMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case polloption.EdgePoll:
-		if id := m.poll; id != nil {
+	case loginattempt.EdgeUser:
+		if id := m.user; id != nil {
 			return []ent.Value{*id}
 		}
-	case polloption.EdgeVotes:
-		ids := make([]ent.Value, 0, len(m.votes))
-		for id := range m.votes {
-			ids = append(ids, id)
-		}
-		return ids
 	}
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *PollOptionMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.removedvotes != nil {
-		edges = append(edges, polloption.EdgeVotes)
-	}
+func (m *LoginAttemptMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *PollOptionMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case polloption.EdgeVotes:
-		ids := make([]ent.Value, 0, len(m.removedvotes))
-		for id := range m.removedvotes {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *LoginAttemptMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *PollOptionMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.clearedpoll {
-		edges = append(edges, polloption.EdgePoll)
-	}
-	if m.clearedvotes {
-		edges = append(edges, polloption.EdgeVotes)
+func (m *LoginAttemptMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.cleareduser {
+		edges = append(edges, loginattempt.EdgeUser)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *PollOptionMutation) EdgeCleared(name string) bool {
+func (m *LoginAttemptMutation) EdgeCleared(name string) bool {
 	switch name {
-	case polloption.EdgePoll:
-		return m.clearedpoll
-	case polloption.EdgeVotes:
-		return m.clearedvotes
+	case loginattempt.EdgeUser:
+		return m.cleareduser
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *PollOptionMutation) ClearEdge(name string) error {
+func (m *LoginAttemptMutation) ClearEdge(name string) error {
 	switch name {
-	case polloption.EdgePoll:
-		m.ClearPoll()
+	case loginattempt.EdgeUser:
+		m.ClearUser()
 		return nil
 	}
-	return fmt.Errorf("unknown PollOption unique edge %s", name)
+	return fmt.Errorf("unknown LoginAttempt unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *PollOptionMutation) ResetEdge(name string) error {
+func (m *LoginAttemptMutation) ResetEdge(name string) error {
 	switch name {
-	case polloption.EdgePoll:
-		m.ResetPoll()
-		return nil
-	case polloption.EdgeVotes:
-		m.ResetVotes()
+	case loginattempt.EdgeUser:
+		m.ResetUser()
 		return nil
 	}
-	return fmt.Errorf("unknown PollOption edge %s", name)
+	return fmt.Errorf("unknown LoginAttempt edge %s", name)
 }
 
-// UserMutation represents an operation that mutates the User nodes in the graph.
-type UserMutation struct {
+// PasswordResetMutation represents an operation that mutates the PasswordReset nodes in the graph.
+type PasswordResetMutation struct {
 	config
 	op            Op
 	typ           string
-	id            *string
-	avatar_image  *string
-	email         *string
-	name          *string
-	password      *string
-	active        *bool
+	id            *int
+	token_hash    *string
+	ip            *string
 	created_at    *time.Time
-	updated_at    *time.Time
+	expires_at    *time.Time
+	used_at       *time.Time
 	clearedFields map[string]struct{}
-	votes         map[int]struct{}
-	removedvotes  map[int]struct{}
-	clearedvotes  bool
+	user          *string
+	cleareduser   bool
 	done          bool
-	oldValue      func(context.Context) (*User, error)
-	predicates    []predicate.User
+	oldValue      func(context.Context) (*PasswordReset, error)
+	predicates    []predicate.PasswordReset
 }
 
-var _ ent.Mutation = (*UserMutation)(nil)
+var _ ent.Mutation = (*PasswordResetMutation)(nil)
 
-// userOption allows management of the mutation configuration using functional options.
-type userOption func(*UserMutation)
+// passwordresetOption allows management of the mutation configuration using functional options.
+type passwordresetOption func(*PasswordResetMutation)
 
-// newUserMutation creates new mutation for the User entity.
-func newUserMutation(c config, op Op, opts ...userOption) *UserMutation {
-	m := &UserMutation{
+// newPasswordResetMutation creates new mutation for the PasswordReset entity.
+func newPasswordResetMutation(c config, op Op, opts ...passwordresetOption) *PasswordResetMutation {
+	m := &PasswordResetMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeUser,
+		typ:           TypePasswordReset,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -1252,20 +1503,20 @@ func newUserMutation(c config, op Op, opts ...userOption) *UserMutation {
 	return m
 }
 
-// withUserID sets the ID field of the mutation.
-func withUserID(id string) userOption {
-	return func(m *UserMutation) {
+// withPasswordResetID sets the ID field of the mutation.
+func withPasswordResetID(id int) passwordresetOption {
+	return func(m *PasswordResetMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *User
+			value *PasswordReset
 		)
-		m.oldValue = func(ctx context.Context) (*User, error) {
+		m.oldValue = func(ctx context.Context) (*PasswordReset, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().User.Get(ctx, id)
+					value, err = m.Client().PasswordReset.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -1274,10 +1525,10 @@ func withUserID(id string) userOption {
 	}
 }
 
-// withUser sets the old User of the mutation.
-func withUser(node *User) userOption {
-	return func(m *UserMutation) {
-		m.oldValue = func(context.Context) (*User, error) {
+// withPasswordReset sets the old PasswordReset of the mutation.
+func withPasswordReset(node *PasswordReset) passwordresetOption {
+	return func(m *PasswordResetMutation) {
+		m.oldValue = func(context.Context) (*PasswordReset, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -1286,7 +1537,7 @@ func withUser(node *User) userOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m UserMutation) Client() *Client {
+func (m PasswordResetMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -1294,7 +1545,7 @@ func (m UserMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m UserMutation) Tx() (*Tx, error) {
+func (m PasswordResetMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -1303,15 +1554,9 @@ func (m UserMutation) Tx() (*Tx, error) {
 	return tx, nil
 }
 
-// SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of User entities.
-func (m *UserMutation) SetID(id string) {
-	m.id = &id
-}
-
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *UserMutation) ID() (id string, exists bool) {
+func (m *PasswordResetMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -1322,338 +1567,7565 @@ func (m *UserMutation) ID() (id string, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *UserMutation) IDs(ctx context.Context) ([]string, error) {
+func (m *PasswordResetMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
 		if exists {
-			return []string{id}, nil
+			return []int{id}, nil
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().User.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().PasswordReset.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetAvatarImage sets the "avatar_image" field.
-func (m *UserMutation) SetAvatarImage(s string) {
-	m.avatar_image = &s
+// SetUserID sets the "user_id" field.
+func (m *PasswordResetMutation) SetUserID(s string) {
+	m.user = &s
 }
 
-// AvatarImage returns the value of the "avatar_image" field in the mutation.
-func (m *UserMutation) AvatarImage() (r string, exists bool) {
-	v := m.avatar_image
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *PasswordResetMutation) UserID() (r string, exists bool) {
+	v := m.user
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldAvatarImage returns the old "avatar_image" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldUserID returns the old "user_id" field's value of the PasswordReset entity.
+// If the PasswordReset object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldAvatarImage(ctx context.Context) (v *string, err error) {
+func (m *PasswordResetMutation) OldUserID(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldAvatarImage is only allowed on UpdateOne operations")
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldAvatarImage requires an ID field in the mutation")
+		return v, errors.New("OldUserID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldAvatarImage: %w", err)
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
 	}
-	return oldValue.AvatarImage, nil
-}
-
-// ClearAvatarImage clears the value of the "avatar_image" field.
-func (m *UserMutation) ClearAvatarImage() {
-	m.avatar_image = nil
-	m.clearedFields[user.FieldAvatarImage] = struct{}{}
-}
-
-// AvatarImageCleared returns if the "avatar_image" field was cleared in this mutation.
-func (m *UserMutation) AvatarImageCleared() bool {
-	_, ok := m.clearedFields[user.FieldAvatarImage]
-	return ok
+	return oldValue.UserID, nil
 }
 
-// ResetAvatarImage resets all changes to the "avatar_image" field.
-func (m *UserMutation) ResetAvatarImage() {
-	m.avatar_image = nil
-	delete(m.clearedFields, user.FieldAvatarImage)
+// ResetUserID resets all changes to the "user_id" field.
+func (m *PasswordResetMutation) ResetUserID() {
+	m.user = nil
 }
 
-// SetEmail sets the "email" field.
-func (m *UserMutation) SetEmail(s string) {
-	m.email = &s
+// SetTokenHash sets the "token_hash" field.
+func (m *PasswordResetMutation) SetTokenHash(s string) {
+	m.token_hash = &s
 }
 
-// Email returns the value of the "email" field in the mutation.
-func (m *UserMutation) Email() (r string, exists bool) {
-	v := m.email
+// TokenHash returns the value of the "token_hash" field in the mutation.
+func (m *PasswordResetMutation) TokenHash() (r string, exists bool) {
+	v := m.token_hash
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldEmail returns the old "email" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldTokenHash returns the old "token_hash" field's value of the PasswordReset entity.
+// If the PasswordReset object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldEmail(ctx context.Context) (v string, err error) {
+func (m *PasswordResetMutation) OldTokenHash(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldEmail is only allowed on UpdateOne operations")
+		return v, errors.New("OldTokenHash is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldEmail requires an ID field in the mutation")
+		return v, errors.New("OldTokenHash requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldEmail: %w", err)
+		return v, fmt.Errorf("querying old value for OldTokenHash: %w", err)
 	}
-	return oldValue.Email, nil
+	return oldValue.TokenHash, nil
 }
 
-// ResetEmail resets all changes to the "email" field.
-func (m *UserMutation) ResetEmail() {
-	m.email = nil
+// ResetTokenHash resets all changes to the "token_hash" field.
+func (m *PasswordResetMutation) ResetTokenHash() {
+	m.token_hash = nil
 }
 
-// SetName sets the "name" field.
-func (m *UserMutation) SetName(s string) {
-	m.name = &s
+// SetIP sets the "ip" field.
+func (m *PasswordResetMutation) SetIP(s string) {
+	m.ip = &s
 }
 
-// Name returns the value of the "name" field in the mutation.
-func (m *UserMutation) Name() (r string, exists bool) {
-	v := m.name
+// IP returns the value of the "ip" field in the mutation.
+func (m *PasswordResetMutation) IP() (r string, exists bool) {
+	v := m.ip
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldName returns the old "name" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldIP returns the old "ip" field's value of the PasswordReset entity.
+// If the PasswordReset object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldName(ctx context.Context) (v string, err error) {
+func (m *PasswordResetMutation) OldIP(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldName is only allowed on UpdateOne operations")
+		return v, errors.New("OldIP is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldName requires an ID field in the mutation")
+		return v, errors.New("OldIP requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldName: %w", err)
+		return v, fmt.Errorf("querying old value for OldIP: %w", err)
 	}
-	return oldValue.Name, nil
+	return oldValue.IP, nil
 }
 
-// ResetName resets all changes to the "name" field.
-func (m *UserMutation) ResetName() {
-	m.name = nil
+// ResetIP resets all changes to the "ip" field.
+func (m *PasswordResetMutation) ResetIP() {
+	m.ip = nil
 }
 
-// SetPassword sets the "password" field.
-func (m *UserMutation) SetPassword(s string) {
-	m.password = &s
+// SetCreatedAt sets the "created_at" field.
+func (m *PasswordResetMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
 }
 
-// Password returns the value of the "password" field in the mutation.
-func (m *UserMutation) Password() (r string, exists bool) {
-	v := m.password
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *PasswordResetMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPassword returns the old "password" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the PasswordReset entity.
+// If the PasswordReset object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldPassword(ctx context.Context) (v string, err error) {
+func (m *PasswordResetMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPassword is only allowed on UpdateOne operations")
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPassword requires an ID field in the mutation")
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPassword: %w", err)
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
 	}
-	return oldValue.Password, nil
+	return oldValue.CreatedAt, nil
 }
 
-// ResetPassword resets all changes to the "password" field.
-func (m *UserMutation) ResetPassword() {
-	m.password = nil
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *PasswordResetMutation) ResetCreatedAt() {
+	m.created_at = nil
 }
 
-// SetActive sets the "active" field.
-func (m *UserMutation) SetActive(b bool) {
-	m.active = &b
+// SetExpiresAt sets the "expires_at" field.
+func (m *PasswordResetMutation) SetExpiresAt(t time.Time) {
+	m.expires_at = &t
 }
 
-// Active returns the value of the "active" field in the mutation.
-func (m *UserMutation) Active() (r bool, exists bool) {
-	v := m.active
+// ExpiresAt returns the value of the "expires_at" field in the mutation.
+func (m *PasswordResetMutation) ExpiresAt() (r time.Time, exists bool) {
+	v := m.expires_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldActive returns the old "active" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldExpiresAt returns the old "expires_at" field's value of the PasswordReset entity.
+// If the PasswordReset object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldActive(ctx context.Context) (v bool, err error) {
+func (m *PasswordResetMutation) OldExpiresAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldActive is only allowed on UpdateOne operations")
+		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldActive requires an ID field in the mutation")
+		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldActive: %w", err)
+		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
 	}
-	return oldValue.Active, nil
+	return oldValue.ExpiresAt, nil
 }
 
-// ResetActive resets all changes to the "active" field.
-func (m *UserMutation) ResetActive() {
-	m.active = nil
+// ResetExpiresAt resets all changes to the "expires_at" field.
+func (m *PasswordResetMutation) ResetExpiresAt() {
+	m.expires_at = nil
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *UserMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
+// SetUsedAt sets the "used_at" field.
+func (m *PasswordResetMutation) SetUsedAt(t time.Time) {
+	m.used_at = &t
 }
 
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *UserMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
+// UsedAt returns the value of the "used_at" field in the mutation.
+func (m *PasswordResetMutation) UsedAt() (r time.Time, exists bool) {
+	v := m.used_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldUsedAt returns the old "used_at" field's value of the PasswordReset entity.
+// If the PasswordReset object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *PasswordResetMutation) OldUsedAt(ctx context.Context) (v *time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldUsedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+		return v, errors.New("OldUsedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldUsedAt: %w", err)
 	}
-	return oldValue.CreatedAt, nil
+	return oldValue.UsedAt, nil
 }
 
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *UserMutation) ResetCreatedAt() {
-	m.created_at = nil
+// ClearUsedAt clears the value of the "used_at" field.
+func (m *PasswordResetMutation) ClearUsedAt() {
+	m.used_at = nil
+	m.clearedFields[passwordreset.FieldUsedAt] = struct{}{}
 }
 
-// SetUpdatedAt sets the "updated_at" field.
-func (m *UserMutation) SetUpdatedAt(t time.Time) {
-	m.updated_at = &t
+// UsedAtCleared returns if the "used_at" field was cleared in this mutation.
+func (m *PasswordResetMutation) UsedAtCleared() bool {
+	_, ok := m.clearedFields[passwordreset.FieldUsedAt]
+	return ok
 }
 
-// UpdatedAt returns the value of the "updated_at" field in the mutation.
-func (m *UserMutation) UpdatedAt() (r time.Time, exists bool) {
-	v := m.updated_at
-	if v == nil {
-		return
-	}
-	return *v, true
+// ResetUsedAt resets all changes to the "used_at" field.
+func (m *PasswordResetMutation) ResetUsedAt() {
+	m.used_at = nil
+	delete(m.clearedFields, passwordreset.FieldUsedAt)
 }
 
-// OldUpdatedAt returns the old "updated_at" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+// ClearUser clears the "user" edge to the User entity.
+func (m *PasswordResetMutation) ClearUser() {
+	m.cleareduser = true
+	m.clearedFields[passwordreset.FieldUserID] = struct{}{}
+}
+
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *PasswordResetMutation) UserCleared() bool {
+	return m.cleareduser
+}
+
+// UserIDs returns the "user" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// UserID instead. It exists only for internal usage by the builders.
+func (m *PasswordResetMutation) UserIDs() (ids []string) {
+	if id := m.user; id != nil {
+		ids = append(ids, *id)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	return
+}
+
+// ResetUser resets all changes to the "user" edge.
+func (m *PasswordResetMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
+}
+
+// Where appends a list predicates to the PasswordResetMutation builder.
+func (m *PasswordResetMutation) Where(ps ...predicate.PasswordReset) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the PasswordResetMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *PasswordResetMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.PasswordReset, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *PasswordResetMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *PasswordResetMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (PasswordReset).
+func (m *PasswordResetMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *PasswordResetMutation) Fields() []string {
+	fields := make([]string, 0, 6)
+	if m.user != nil {
+		fields = append(fields, passwordreset.FieldUserID)
+	}
+	if m.token_hash != nil {
+		fields = append(fields, passwordreset.FieldTokenHash)
+	}
+	if m.ip != nil {
+		fields = append(fields, passwordreset.FieldIP)
+	}
+	if m.created_at != nil {
+		fields = append(fields, passwordreset.FieldCreatedAt)
+	}
+	if m.expires_at != nil {
+		fields = append(fields, passwordreset.FieldExpiresAt)
+	}
+	if m.used_at != nil {
+		fields = append(fields, passwordreset.FieldUsedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *PasswordResetMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case passwordreset.FieldUserID:
+		return m.UserID()
+	case passwordreset.FieldTokenHash:
+		return m.TokenHash()
+	case passwordreset.FieldIP:
+		return m.IP()
+	case passwordreset.FieldCreatedAt:
+		return m.CreatedAt()
+	case passwordreset.FieldExpiresAt:
+		return m.ExpiresAt()
+	case passwordreset.FieldUsedAt:
+		return m.UsedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *PasswordResetMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case passwordreset.FieldUserID:
+		return m.OldUserID(ctx)
+	case passwordreset.FieldTokenHash:
+		return m.OldTokenHash(ctx)
+	case passwordreset.FieldIP:
+		return m.OldIP(ctx)
+	case passwordreset.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case passwordreset.FieldExpiresAt:
+		return m.OldExpiresAt(ctx)
+	case passwordreset.FieldUsedAt:
+		return m.OldUsedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown PasswordReset field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PasswordResetMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case passwordreset.FieldUserID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUserID(v)
+		return nil
+	case passwordreset.FieldTokenHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTokenHash(v)
+		return nil
+	case passwordreset.FieldIP:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetIP(v)
+		return nil
+	case passwordreset.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case passwordreset.FieldExpiresAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExpiresAt(v)
+		return nil
+	case passwordreset.FieldUsedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUsedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown PasswordReset field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *PasswordResetMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *PasswordResetMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PasswordResetMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown PasswordReset numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *PasswordResetMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(passwordreset.FieldUsedAt) {
+		fields = append(fields, passwordreset.FieldUsedAt)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *PasswordResetMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *PasswordResetMutation) ClearField(name string) error {
+	switch name {
+	case passwordreset.FieldUsedAt:
+		m.ClearUsedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown PasswordReset nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *PasswordResetMutation) ResetField(name string) error {
+	switch name {
+	case passwordreset.FieldUserID:
+		m.ResetUserID()
+		return nil
+	case passwordreset.FieldTokenHash:
+		m.ResetTokenHash()
+		return nil
+	case passwordreset.FieldIP:
+		m.ResetIP()
+		return nil
+	case passwordreset.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case passwordreset.FieldExpiresAt:
+		m.ResetExpiresAt()
+		return nil
+	case passwordreset.FieldUsedAt:
+		m.ResetUsedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown PasswordReset field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *PasswordResetMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.user != nil {
+		edges = append(edges, passwordreset.EdgeUser)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *PasswordResetMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case passwordreset.EdgeUser:
+		if id := m.user; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *PasswordResetMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *PasswordResetMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *PasswordResetMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.cleareduser {
+		edges = append(edges, passwordreset.EdgeUser)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *PasswordResetMutation) EdgeCleared(name string) bool {
+	switch name {
+	case passwordreset.EdgeUser:
+		return m.cleareduser
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *PasswordResetMutation) ClearEdge(name string) error {
+	switch name {
+	case passwordreset.EdgeUser:
+		m.ClearUser()
+		return nil
+	}
+	return fmt.Errorf("unknown PasswordReset unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *PasswordResetMutation) ResetEdge(name string) error {
+	switch name {
+	case passwordreset.EdgeUser:
+		m.ResetUser()
+		return nil
+	}
+	return fmt.Errorf("unknown PasswordReset edge %s", name)
+}
+
+// PollMutation represents an operation that mutates the Poll nodes in the graph.
+type PollMutation struct {
+	config
+	op                     Op
+	typ                    string
+	id                     *int
+	title                  *string
+	description            *string
+	is_open                *bool
+	created_at             *time.Time
+	total_votes            *int
+	addtotal_votes         *int
+	opens_at               *time.Time
+	closes_at              *time.Time
+	max_votes              *int
+	addmax_votes           *int
+	quorum_percent         *int
+	addquorum_percent      *int
+	close_on_decisive_lead *bool
+	closed_reason          *poll.ClosedReason
+	results_visibility     *poll.ResultsVisibility
+	results_visible_at     *time.Time
+	status                 *poll.Status
+	published_at           *time.Time
+	anonymous              *bool
+	embeddable             *bool
+	allow_guests           *bool
+	clearedFields          map[string]struct{}
+	options                map[int]struct{}
+	removedoptions         map[int]struct{}
+	clearedoptions         bool
+	votes                  map[int]struct{}
+	removedvotes           map[int]struct{}
+	clearedvotes           bool
+	revisions              map[int]struct{}
+	removedrevisions       map[int]struct{}
+	clearedrevisions       bool
+	tags                   map[int]struct{}
+	removedtags            map[int]struct{}
+	clearedtags            bool
+	share_links            map[int]struct{}
+	removedshare_links     map[int]struct{}
+	clearedshare_links     bool
+	owner                  *string
+	clearedowner           bool
+	done                   bool
+	oldValue               func(context.Context) (*Poll, error)
+	predicates             []predicate.Poll
+}
+
+var _ ent.Mutation = (*PollMutation)(nil)
+
+// pollOption allows management of the mutation configuration using functional options.
+type pollOption func(*PollMutation)
+
+// newPollMutation creates new mutation for the Poll entity.
+func newPollMutation(c config, op Op, opts ...pollOption) *PollMutation {
+	m := &PollMutation{
+		config:        c,
+		op:            op,
+		typ:           TypePoll,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withPollID sets the ID field of the mutation.
+func withPollID(id int) pollOption {
+	return func(m *PollMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Poll
+		)
+		m.oldValue = func(ctx context.Context) (*Poll, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Poll.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withPoll sets the old Poll of the mutation.
+func withPoll(node *Poll) pollOption {
+	return func(m *PollMutation) {
+		m.oldValue = func(context.Context) (*Poll, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m PollMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m PollMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *PollMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *PollMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Poll.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetTitle sets the "title" field.
+func (m *PollMutation) SetTitle(s string) {
+	m.title = &s
+}
+
+// Title returns the value of the "title" field in the mutation.
+func (m *PollMutation) Title() (r string, exists bool) {
+	v := m.title
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTitle returns the old "title" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldTitle(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTitle is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTitle requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTitle: %w", err)
+	}
+	return oldValue.Title, nil
+}
+
+// ResetTitle resets all changes to the "title" field.
+func (m *PollMutation) ResetTitle() {
+	m.title = nil
+}
+
+// SetDescription sets the "description" field.
+func (m *PollMutation) SetDescription(s string) {
+	m.description = &s
+}
+
+// Description returns the value of the "description" field in the mutation.
+func (m *PollMutation) Description() (r string, exists bool) {
+	v := m.description
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDescription returns the old "description" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldDescription(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDescription is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDescription requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDescription: %w", err)
+	}
+	return oldValue.Description, nil
+}
+
+// ClearDescription clears the value of the "description" field.
+func (m *PollMutation) ClearDescription() {
+	m.description = nil
+	m.clearedFields[poll.FieldDescription] = struct{}{}
+}
+
+// DescriptionCleared returns if the "description" field was cleared in this mutation.
+func (m *PollMutation) DescriptionCleared() bool {
+	_, ok := m.clearedFields[poll.FieldDescription]
+	return ok
+}
+
+// ResetDescription resets all changes to the "description" field.
+func (m *PollMutation) ResetDescription() {
+	m.description = nil
+	delete(m.clearedFields, poll.FieldDescription)
+}
+
+// SetIsOpen sets the "is_open" field.
+func (m *PollMutation) SetIsOpen(b bool) {
+	m.is_open = &b
+}
+
+// IsOpen returns the value of the "is_open" field in the mutation.
+func (m *PollMutation) IsOpen() (r bool, exists bool) {
+	v := m.is_open
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldIsOpen returns the old "is_open" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldIsOpen(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldIsOpen is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldIsOpen requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldIsOpen: %w", err)
+	}
+	return oldValue.IsOpen, nil
+}
+
+// ResetIsOpen resets all changes to the "is_open" field.
+func (m *PollMutation) ResetIsOpen() {
+	m.is_open = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *PollMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *PollMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *PollMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetTotalVotes sets the "total_votes" field.
+func (m *PollMutation) SetTotalVotes(i int) {
+	m.total_votes = &i
+	m.addtotal_votes = nil
+}
+
+// TotalVotes returns the value of the "total_votes" field in the mutation.
+func (m *PollMutation) TotalVotes() (r int, exists bool) {
+	v := m.total_votes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTotalVotes returns the old "total_votes" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldTotalVotes(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTotalVotes is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTotalVotes requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTotalVotes: %w", err)
+	}
+	return oldValue.TotalVotes, nil
+}
+
+// AddTotalVotes adds i to the "total_votes" field.
+func (m *PollMutation) AddTotalVotes(i int) {
+	if m.addtotal_votes != nil {
+		*m.addtotal_votes += i
+	} else {
+		m.addtotal_votes = &i
+	}
+}
+
+// AddedTotalVotes returns the value that was added to the "total_votes" field in this mutation.
+func (m *PollMutation) AddedTotalVotes() (r int, exists bool) {
+	v := m.addtotal_votes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetTotalVotes resets all changes to the "total_votes" field.
+func (m *PollMutation) ResetTotalVotes() {
+	m.total_votes = nil
+	m.addtotal_votes = nil
+}
+
+// SetOpensAt sets the "opens_at" field.
+func (m *PollMutation) SetOpensAt(t time.Time) {
+	m.opens_at = &t
+}
+
+// OpensAt returns the value of the "opens_at" field in the mutation.
+func (m *PollMutation) OpensAt() (r time.Time, exists bool) {
+	v := m.opens_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOpensAt returns the old "opens_at" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldOpensAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOpensAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOpensAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOpensAt: %w", err)
+	}
+	return oldValue.OpensAt, nil
+}
+
+// ClearOpensAt clears the value of the "opens_at" field.
+func (m *PollMutation) ClearOpensAt() {
+	m.opens_at = nil
+	m.clearedFields[poll.FieldOpensAt] = struct{}{}
+}
+
+// OpensAtCleared returns if the "opens_at" field was cleared in this mutation.
+func (m *PollMutation) OpensAtCleared() bool {
+	_, ok := m.clearedFields[poll.FieldOpensAt]
+	return ok
+}
+
+// ResetOpensAt resets all changes to the "opens_at" field.
+func (m *PollMutation) ResetOpensAt() {
+	m.opens_at = nil
+	delete(m.clearedFields, poll.FieldOpensAt)
+}
+
+// SetClosesAt sets the "closes_at" field.
+func (m *PollMutation) SetClosesAt(t time.Time) {
+	m.closes_at = &t
+}
+
+// ClosesAt returns the value of the "closes_at" field in the mutation.
+func (m *PollMutation) ClosesAt() (r time.Time, exists bool) {
+	v := m.closes_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClosesAt returns the old "closes_at" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldClosesAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClosesAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClosesAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClosesAt: %w", err)
+	}
+	return oldValue.ClosesAt, nil
+}
+
+// ClearClosesAt clears the value of the "closes_at" field.
+func (m *PollMutation) ClearClosesAt() {
+	m.closes_at = nil
+	m.clearedFields[poll.FieldClosesAt] = struct{}{}
+}
+
+// ClosesAtCleared returns if the "closes_at" field was cleared in this mutation.
+func (m *PollMutation) ClosesAtCleared() bool {
+	_, ok := m.clearedFields[poll.FieldClosesAt]
+	return ok
+}
+
+// ResetClosesAt resets all changes to the "closes_at" field.
+func (m *PollMutation) ResetClosesAt() {
+	m.closes_at = nil
+	delete(m.clearedFields, poll.FieldClosesAt)
+}
+
+// SetMaxVotes sets the "max_votes" field.
+func (m *PollMutation) SetMaxVotes(i int) {
+	m.max_votes = &i
+	m.addmax_votes = nil
+}
+
+// MaxVotes returns the value of the "max_votes" field in the mutation.
+func (m *PollMutation) MaxVotes() (r int, exists bool) {
+	v := m.max_votes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMaxVotes returns the old "max_votes" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldMaxVotes(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMaxVotes is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMaxVotes requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMaxVotes: %w", err)
+	}
+	return oldValue.MaxVotes, nil
+}
+
+// AddMaxVotes adds i to the "max_votes" field.
+func (m *PollMutation) AddMaxVotes(i int) {
+	if m.addmax_votes != nil {
+		*m.addmax_votes += i
+	} else {
+		m.addmax_votes = &i
+	}
+}
+
+// AddedMaxVotes returns the value that was added to the "max_votes" field in this mutation.
+func (m *PollMutation) AddedMaxVotes() (r int, exists bool) {
+	v := m.addmax_votes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearMaxVotes clears the value of the "max_votes" field.
+func (m *PollMutation) ClearMaxVotes() {
+	m.max_votes = nil
+	m.addmax_votes = nil
+	m.clearedFields[poll.FieldMaxVotes] = struct{}{}
+}
+
+// MaxVotesCleared returns if the "max_votes" field was cleared in this mutation.
+func (m *PollMutation) MaxVotesCleared() bool {
+	_, ok := m.clearedFields[poll.FieldMaxVotes]
+	return ok
+}
+
+// ResetMaxVotes resets all changes to the "max_votes" field.
+func (m *PollMutation) ResetMaxVotes() {
+	m.max_votes = nil
+	m.addmax_votes = nil
+	delete(m.clearedFields, poll.FieldMaxVotes)
+}
+
+// SetQuorumPercent sets the "quorum_percent" field.
+func (m *PollMutation) SetQuorumPercent(i int) {
+	m.quorum_percent = &i
+	m.addquorum_percent = nil
+}
+
+// QuorumPercent returns the value of the "quorum_percent" field in the mutation.
+func (m *PollMutation) QuorumPercent() (r int, exists bool) {
+	v := m.quorum_percent
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldQuorumPercent returns the old "quorum_percent" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldQuorumPercent(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldQuorumPercent is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldQuorumPercent requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldQuorumPercent: %w", err)
+	}
+	return oldValue.QuorumPercent, nil
+}
+
+// AddQuorumPercent adds i to the "quorum_percent" field.
+func (m *PollMutation) AddQuorumPercent(i int) {
+	if m.addquorum_percent != nil {
+		*m.addquorum_percent += i
+	} else {
+		m.addquorum_percent = &i
+	}
+}
+
+// AddedQuorumPercent returns the value that was added to the "quorum_percent" field in this mutation.
+func (m *PollMutation) AddedQuorumPercent() (r int, exists bool) {
+	v := m.addquorum_percent
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearQuorumPercent clears the value of the "quorum_percent" field.
+func (m *PollMutation) ClearQuorumPercent() {
+	m.quorum_percent = nil
+	m.addquorum_percent = nil
+	m.clearedFields[poll.FieldQuorumPercent] = struct{}{}
+}
+
+// QuorumPercentCleared returns if the "quorum_percent" field was cleared in this mutation.
+func (m *PollMutation) QuorumPercentCleared() bool {
+	_, ok := m.clearedFields[poll.FieldQuorumPercent]
+	return ok
+}
+
+// ResetQuorumPercent resets all changes to the "quorum_percent" field.
+func (m *PollMutation) ResetQuorumPercent() {
+	m.quorum_percent = nil
+	m.addquorum_percent = nil
+	delete(m.clearedFields, poll.FieldQuorumPercent)
+}
+
+// SetCloseOnDecisiveLead sets the "close_on_decisive_lead" field.
+func (m *PollMutation) SetCloseOnDecisiveLead(b bool) {
+	m.close_on_decisive_lead = &b
+}
+
+// CloseOnDecisiveLead returns the value of the "close_on_decisive_lead" field in the mutation.
+func (m *PollMutation) CloseOnDecisiveLead() (r bool, exists bool) {
+	v := m.close_on_decisive_lead
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCloseOnDecisiveLead returns the old "close_on_decisive_lead" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldCloseOnDecisiveLead(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCloseOnDecisiveLead is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCloseOnDecisiveLead requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCloseOnDecisiveLead: %w", err)
+	}
+	return oldValue.CloseOnDecisiveLead, nil
+}
+
+// ResetCloseOnDecisiveLead resets all changes to the "close_on_decisive_lead" field.
+func (m *PollMutation) ResetCloseOnDecisiveLead() {
+	m.close_on_decisive_lead = nil
+}
+
+// SetClosedReason sets the "closed_reason" field.
+func (m *PollMutation) SetClosedReason(pr poll.ClosedReason) {
+	m.closed_reason = &pr
+}
+
+// ClosedReason returns the value of the "closed_reason" field in the mutation.
+func (m *PollMutation) ClosedReason() (r poll.ClosedReason, exists bool) {
+	v := m.closed_reason
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClosedReason returns the old "closed_reason" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldClosedReason(ctx context.Context) (v *poll.ClosedReason, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClosedReason is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClosedReason requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClosedReason: %w", err)
+	}
+	return oldValue.ClosedReason, nil
+}
+
+// ClearClosedReason clears the value of the "closed_reason" field.
+func (m *PollMutation) ClearClosedReason() {
+	m.closed_reason = nil
+	m.clearedFields[poll.FieldClosedReason] = struct{}{}
+}
+
+// ClosedReasonCleared returns if the "closed_reason" field was cleared in this mutation.
+func (m *PollMutation) ClosedReasonCleared() bool {
+	_, ok := m.clearedFields[poll.FieldClosedReason]
+	return ok
+}
+
+// ResetClosedReason resets all changes to the "closed_reason" field.
+func (m *PollMutation) ResetClosedReason() {
+	m.closed_reason = nil
+	delete(m.clearedFields, poll.FieldClosedReason)
+}
+
+// SetResultsVisibility sets the "results_visibility" field.
+func (m *PollMutation) SetResultsVisibility(pv poll.ResultsVisibility) {
+	m.results_visibility = &pv
+}
+
+// ResultsVisibility returns the value of the "results_visibility" field in the mutation.
+func (m *PollMutation) ResultsVisibility() (r poll.ResultsVisibility, exists bool) {
+	v := m.results_visibility
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldResultsVisibility returns the old "results_visibility" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldResultsVisibility(ctx context.Context) (v poll.ResultsVisibility, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldResultsVisibility is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldResultsVisibility requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldResultsVisibility: %w", err)
+	}
+	return oldValue.ResultsVisibility, nil
+}
+
+// ResetResultsVisibility resets all changes to the "results_visibility" field.
+func (m *PollMutation) ResetResultsVisibility() {
+	m.results_visibility = nil
+}
+
+// SetResultsVisibleAt sets the "results_visible_at" field.
+func (m *PollMutation) SetResultsVisibleAt(t time.Time) {
+	m.results_visible_at = &t
+}
+
+// ResultsVisibleAt returns the value of the "results_visible_at" field in the mutation.
+func (m *PollMutation) ResultsVisibleAt() (r time.Time, exists bool) {
+	v := m.results_visible_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldResultsVisibleAt returns the old "results_visible_at" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldResultsVisibleAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldResultsVisibleAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldResultsVisibleAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldResultsVisibleAt: %w", err)
+	}
+	return oldValue.ResultsVisibleAt, nil
+}
+
+// ClearResultsVisibleAt clears the value of the "results_visible_at" field.
+func (m *PollMutation) ClearResultsVisibleAt() {
+	m.results_visible_at = nil
+	m.clearedFields[poll.FieldResultsVisibleAt] = struct{}{}
+}
+
+// ResultsVisibleAtCleared returns if the "results_visible_at" field was cleared in this mutation.
+func (m *PollMutation) ResultsVisibleAtCleared() bool {
+	_, ok := m.clearedFields[poll.FieldResultsVisibleAt]
+	return ok
+}
+
+// ResetResultsVisibleAt resets all changes to the "results_visible_at" field.
+func (m *PollMutation) ResetResultsVisibleAt() {
+	m.results_visible_at = nil
+	delete(m.clearedFields, poll.FieldResultsVisibleAt)
+}
+
+// SetStatus sets the "status" field.
+func (m *PollMutation) SetStatus(po poll.Status) {
+	m.status = &po
+}
+
+// Status returns the value of the "status" field in the mutation.
+func (m *PollMutation) Status() (r poll.Status, exists bool) {
+	v := m.status
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStatus returns the old "status" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldStatus(ctx context.Context) (v poll.Status, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStatus requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
+	}
+	return oldValue.Status, nil
+}
+
+// ResetStatus resets all changes to the "status" field.
+func (m *PollMutation) ResetStatus() {
+	m.status = nil
+}
+
+// SetPublishedAt sets the "published_at" field.
+func (m *PollMutation) SetPublishedAt(t time.Time) {
+	m.published_at = &t
+}
+
+// PublishedAt returns the value of the "published_at" field in the mutation.
+func (m *PollMutation) PublishedAt() (r time.Time, exists bool) {
+	v := m.published_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPublishedAt returns the old "published_at" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldPublishedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPublishedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPublishedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPublishedAt: %w", err)
+	}
+	return oldValue.PublishedAt, nil
+}
+
+// ClearPublishedAt clears the value of the "published_at" field.
+func (m *PollMutation) ClearPublishedAt() {
+	m.published_at = nil
+	m.clearedFields[poll.FieldPublishedAt] = struct{}{}
+}
+
+// PublishedAtCleared returns if the "published_at" field was cleared in this mutation.
+func (m *PollMutation) PublishedAtCleared() bool {
+	_, ok := m.clearedFields[poll.FieldPublishedAt]
+	return ok
+}
+
+// ResetPublishedAt resets all changes to the "published_at" field.
+func (m *PollMutation) ResetPublishedAt() {
+	m.published_at = nil
+	delete(m.clearedFields, poll.FieldPublishedAt)
+}
+
+// SetAnonymous sets the "anonymous" field.
+func (m *PollMutation) SetAnonymous(b bool) {
+	m.anonymous = &b
+}
+
+// Anonymous returns the value of the "anonymous" field in the mutation.
+func (m *PollMutation) Anonymous() (r bool, exists bool) {
+	v := m.anonymous
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAnonymous returns the old "anonymous" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldAnonymous(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAnonymous is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAnonymous requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAnonymous: %w", err)
+	}
+	return oldValue.Anonymous, nil
+}
+
+// ResetAnonymous resets all changes to the "anonymous" field.
+func (m *PollMutation) ResetAnonymous() {
+	m.anonymous = nil
+}
+
+// SetEmbeddable sets the "embeddable" field.
+func (m *PollMutation) SetEmbeddable(b bool) {
+	m.embeddable = &b
+}
+
+// Embeddable returns the value of the "embeddable" field in the mutation.
+func (m *PollMutation) Embeddable() (r bool, exists bool) {
+	v := m.embeddable
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEmbeddable returns the old "embeddable" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldEmbeddable(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEmbeddable is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEmbeddable requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEmbeddable: %w", err)
+	}
+	return oldValue.Embeddable, nil
+}
+
+// ResetEmbeddable resets all changes to the "embeddable" field.
+func (m *PollMutation) ResetEmbeddable() {
+	m.embeddable = nil
+}
+
+// SetAllowGuests sets the "allow_guests" field.
+func (m *PollMutation) SetAllowGuests(b bool) {
+	m.allow_guests = &b
+}
+
+// AllowGuests returns the value of the "allow_guests" field in the mutation.
+func (m *PollMutation) AllowGuests() (r bool, exists bool) {
+	v := m.allow_guests
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAllowGuests returns the old "allow_guests" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldAllowGuests(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAllowGuests is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAllowGuests requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAllowGuests: %w", err)
+	}
+	return oldValue.AllowGuests, nil
+}
+
+// ResetAllowGuests resets all changes to the "allow_guests" field.
+func (m *PollMutation) ResetAllowGuests() {
+	m.allow_guests = nil
+}
+
+// SetOwnerID sets the "owner_id" field.
+func (m *PollMutation) SetOwnerID(s string) {
+	m.owner = &s
+}
+
+// OwnerID returns the value of the "owner_id" field in the mutation.
+func (m *PollMutation) OwnerID() (r string, exists bool) {
+	v := m.owner
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOwnerID returns the old "owner_id" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldOwnerID(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOwnerID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOwnerID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOwnerID: %w", err)
+	}
+	return oldValue.OwnerID, nil
+}
+
+// ClearOwnerID clears the value of the "owner_id" field.
+func (m *PollMutation) ClearOwnerID() {
+	m.owner = nil
+	m.clearedFields[poll.FieldOwnerID] = struct{}{}
+}
+
+// OwnerIDCleared returns if the "owner_id" field was cleared in this mutation.
+func (m *PollMutation) OwnerIDCleared() bool {
+	_, ok := m.clearedFields[poll.FieldOwnerID]
+	return ok
+}
+
+// ResetOwnerID resets all changes to the "owner_id" field.
+func (m *PollMutation) ResetOwnerID() {
+	m.owner = nil
+	delete(m.clearedFields, poll.FieldOwnerID)
+}
+
+// AddOptionIDs adds the "options" edge to the PollOption entity by ids.
+func (m *PollMutation) AddOptionIDs(ids ...int) {
+	if m.options == nil {
+		m.options = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.options[ids[i]] = struct{}{}
+	}
+}
+
+// ClearOptions clears the "options" edge to the PollOption entity.
+func (m *PollMutation) ClearOptions() {
+	m.clearedoptions = true
+}
+
+// OptionsCleared reports if the "options" edge to the PollOption entity was cleared.
+func (m *PollMutation) OptionsCleared() bool {
+	return m.clearedoptions
+}
+
+// RemoveOptionIDs removes the "options" edge to the PollOption entity by IDs.
+func (m *PollMutation) RemoveOptionIDs(ids ...int) {
+	if m.removedoptions == nil {
+		m.removedoptions = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.options, ids[i])
+		m.removedoptions[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedOptions returns the removed IDs of the "options" edge to the PollOption entity.
+func (m *PollMutation) RemovedOptionsIDs() (ids []int) {
+	for id := range m.removedoptions {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// OptionsIDs returns the "options" edge IDs in the mutation.
+func (m *PollMutation) OptionsIDs() (ids []int) {
+	for id := range m.options {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetOptions resets all changes to the "options" edge.
+func (m *PollMutation) ResetOptions() {
+	m.options = nil
+	m.clearedoptions = false
+	m.removedoptions = nil
+}
+
+// AddVoteIDs adds the "votes" edge to the Vote entity by ids.
+func (m *PollMutation) AddVoteIDs(ids ...int) {
+	if m.votes == nil {
+		m.votes = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.votes[ids[i]] = struct{}{}
+	}
+}
+
+// ClearVotes clears the "votes" edge to the Vote entity.
+func (m *PollMutation) ClearVotes() {
+	m.clearedvotes = true
+}
+
+// VotesCleared reports if the "votes" edge to the Vote entity was cleared.
+func (m *PollMutation) VotesCleared() bool {
+	return m.clearedvotes
+}
+
+// RemoveVoteIDs removes the "votes" edge to the Vote entity by IDs.
+func (m *PollMutation) RemoveVoteIDs(ids ...int) {
+	if m.removedvotes == nil {
+		m.removedvotes = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.votes, ids[i])
+		m.removedvotes[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedVotes returns the removed IDs of the "votes" edge to the Vote entity.
+func (m *PollMutation) RemovedVotesIDs() (ids []int) {
+	for id := range m.removedvotes {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// VotesIDs returns the "votes" edge IDs in the mutation.
+func (m *PollMutation) VotesIDs() (ids []int) {
+	for id := range m.votes {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetVotes resets all changes to the "votes" edge.
+func (m *PollMutation) ResetVotes() {
+	m.votes = nil
+	m.clearedvotes = false
+	m.removedvotes = nil
+}
+
+// AddRevisionIDs adds the "revisions" edge to the PollRevision entity by ids.
+func (m *PollMutation) AddRevisionIDs(ids ...int) {
+	if m.revisions == nil {
+		m.revisions = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.revisions[ids[i]] = struct{}{}
+	}
+}
+
+// ClearRevisions clears the "revisions" edge to the PollRevision entity.
+func (m *PollMutation) ClearRevisions() {
+	m.clearedrevisions = true
+}
+
+// RevisionsCleared reports if the "revisions" edge to the PollRevision entity was cleared.
+func (m *PollMutation) RevisionsCleared() bool {
+	return m.clearedrevisions
+}
+
+// RemoveRevisionIDs removes the "revisions" edge to the PollRevision entity by IDs.
+func (m *PollMutation) RemoveRevisionIDs(ids ...int) {
+	if m.removedrevisions == nil {
+		m.removedrevisions = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.revisions, ids[i])
+		m.removedrevisions[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedRevisions returns the removed IDs of the "revisions" edge to the PollRevision entity.
+func (m *PollMutation) RemovedRevisionsIDs() (ids []int) {
+	for id := range m.removedrevisions {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// RevisionsIDs returns the "revisions" edge IDs in the mutation.
+func (m *PollMutation) RevisionsIDs() (ids []int) {
+	for id := range m.revisions {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetRevisions resets all changes to the "revisions" edge.
+func (m *PollMutation) ResetRevisions() {
+	m.revisions = nil
+	m.clearedrevisions = false
+	m.removedrevisions = nil
+}
+
+// AddTagIDs adds the "tags" edge to the Tag entity by ids.
+func (m *PollMutation) AddTagIDs(ids ...int) {
+	if m.tags == nil {
+		m.tags = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.tags[ids[i]] = struct{}{}
+	}
+}
+
+// ClearTags clears the "tags" edge to the Tag entity.
+func (m *PollMutation) ClearTags() {
+	m.clearedtags = true
+}
+
+// TagsCleared reports if the "tags" edge to the Tag entity was cleared.
+func (m *PollMutation) TagsCleared() bool {
+	return m.clearedtags
+}
+
+// RemoveTagIDs removes the "tags" edge to the Tag entity by IDs.
+func (m *PollMutation) RemoveTagIDs(ids ...int) {
+	if m.removedtags == nil {
+		m.removedtags = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.tags, ids[i])
+		m.removedtags[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedTags returns the removed IDs of the "tags" edge to the Tag entity.
+func (m *PollMutation) RemovedTagsIDs() (ids []int) {
+	for id := range m.removedtags {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// TagsIDs returns the "tags" edge IDs in the mutation.
+func (m *PollMutation) TagsIDs() (ids []int) {
+	for id := range m.tags {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetTags resets all changes to the "tags" edge.
+func (m *PollMutation) ResetTags() {
+	m.tags = nil
+	m.clearedtags = false
+	m.removedtags = nil
+}
+
+// AddShareLinkIDs adds the "share_links" edge to the ShareLink entity by ids.
+func (m *PollMutation) AddShareLinkIDs(ids ...int) {
+	if m.share_links == nil {
+		m.share_links = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.share_links[ids[i]] = struct{}{}
+	}
+}
+
+// ClearShareLinks clears the "share_links" edge to the ShareLink entity.
+func (m *PollMutation) ClearShareLinks() {
+	m.clearedshare_links = true
+}
+
+// ShareLinksCleared reports if the "share_links" edge to the ShareLink entity was cleared.
+func (m *PollMutation) ShareLinksCleared() bool {
+	return m.clearedshare_links
+}
+
+// RemoveShareLinkIDs removes the "share_links" edge to the ShareLink entity by IDs.
+func (m *PollMutation) RemoveShareLinkIDs(ids ...int) {
+	if m.removedshare_links == nil {
+		m.removedshare_links = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.share_links, ids[i])
+		m.removedshare_links[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedShareLinks returns the removed IDs of the "share_links" edge to the ShareLink entity.
+func (m *PollMutation) RemovedShareLinksIDs() (ids []int) {
+	for id := range m.removedshare_links {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ShareLinksIDs returns the "share_links" edge IDs in the mutation.
+func (m *PollMutation) ShareLinksIDs() (ids []int) {
+	for id := range m.share_links {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetShareLinks resets all changes to the "share_links" edge.
+func (m *PollMutation) ResetShareLinks() {
+	m.share_links = nil
+	m.clearedshare_links = false
+	m.removedshare_links = nil
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (m *PollMutation) ClearOwner() {
+	m.clearedowner = true
+	m.clearedFields[poll.FieldOwnerID] = struct{}{}
+}
+
+// OwnerCleared reports if the "owner" edge to the User entity was cleared.
+func (m *PollMutation) OwnerCleared() bool {
+	return m.OwnerIDCleared() || m.clearedowner
+}
+
+// OwnerIDs returns the "owner" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// OwnerID instead. It exists only for internal usage by the builders.
+func (m *PollMutation) OwnerIDs() (ids []string) {
+	if id := m.owner; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetOwner resets all changes to the "owner" edge.
+func (m *PollMutation) ResetOwner() {
+	m.owner = nil
+	m.clearedowner = false
+}
+
+// Where appends a list predicates to the PollMutation builder.
+func (m *PollMutation) Where(ps ...predicate.Poll) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the PollMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *PollMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Poll, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *PollMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *PollMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (Poll).
+func (m *PollMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *PollMutation) Fields() []string {
+	fields := make([]string, 0, 19)
+	if m.title != nil {
+		fields = append(fields, poll.FieldTitle)
+	}
+	if m.description != nil {
+		fields = append(fields, poll.FieldDescription)
+	}
+	if m.is_open != nil {
+		fields = append(fields, poll.FieldIsOpen)
+	}
+	if m.created_at != nil {
+		fields = append(fields, poll.FieldCreatedAt)
+	}
+	if m.total_votes != nil {
+		fields = append(fields, poll.FieldTotalVotes)
+	}
+	if m.opens_at != nil {
+		fields = append(fields, poll.FieldOpensAt)
+	}
+	if m.closes_at != nil {
+		fields = append(fields, poll.FieldClosesAt)
+	}
+	if m.max_votes != nil {
+		fields = append(fields, poll.FieldMaxVotes)
+	}
+	if m.quorum_percent != nil {
+		fields = append(fields, poll.FieldQuorumPercent)
+	}
+	if m.close_on_decisive_lead != nil {
+		fields = append(fields, poll.FieldCloseOnDecisiveLead)
+	}
+	if m.closed_reason != nil {
+		fields = append(fields, poll.FieldClosedReason)
+	}
+	if m.results_visibility != nil {
+		fields = append(fields, poll.FieldResultsVisibility)
+	}
+	if m.results_visible_at != nil {
+		fields = append(fields, poll.FieldResultsVisibleAt)
+	}
+	if m.status != nil {
+		fields = append(fields, poll.FieldStatus)
+	}
+	if m.published_at != nil {
+		fields = append(fields, poll.FieldPublishedAt)
+	}
+	if m.anonymous != nil {
+		fields = append(fields, poll.FieldAnonymous)
+	}
+	if m.embeddable != nil {
+		fields = append(fields, poll.FieldEmbeddable)
+	}
+	if m.allow_guests != nil {
+		fields = append(fields, poll.FieldAllowGuests)
+	}
+	if m.owner != nil {
+		fields = append(fields, poll.FieldOwnerID)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *PollMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case poll.FieldTitle:
+		return m.Title()
+	case poll.FieldDescription:
+		return m.Description()
+	case poll.FieldIsOpen:
+		return m.IsOpen()
+	case poll.FieldCreatedAt:
+		return m.CreatedAt()
+	case poll.FieldTotalVotes:
+		return m.TotalVotes()
+	case poll.FieldOpensAt:
+		return m.OpensAt()
+	case poll.FieldClosesAt:
+		return m.ClosesAt()
+	case poll.FieldMaxVotes:
+		return m.MaxVotes()
+	case poll.FieldQuorumPercent:
+		return m.QuorumPercent()
+	case poll.FieldCloseOnDecisiveLead:
+		return m.CloseOnDecisiveLead()
+	case poll.FieldClosedReason:
+		return m.ClosedReason()
+	case poll.FieldResultsVisibility:
+		return m.ResultsVisibility()
+	case poll.FieldResultsVisibleAt:
+		return m.ResultsVisibleAt()
+	case poll.FieldStatus:
+		return m.Status()
+	case poll.FieldPublishedAt:
+		return m.PublishedAt()
+	case poll.FieldAnonymous:
+		return m.Anonymous()
+	case poll.FieldEmbeddable:
+		return m.Embeddable()
+	case poll.FieldAllowGuests:
+		return m.AllowGuests()
+	case poll.FieldOwnerID:
+		return m.OwnerID()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *PollMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case poll.FieldTitle:
+		return m.OldTitle(ctx)
+	case poll.FieldDescription:
+		return m.OldDescription(ctx)
+	case poll.FieldIsOpen:
+		return m.OldIsOpen(ctx)
+	case poll.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case poll.FieldTotalVotes:
+		return m.OldTotalVotes(ctx)
+	case poll.FieldOpensAt:
+		return m.OldOpensAt(ctx)
+	case poll.FieldClosesAt:
+		return m.OldClosesAt(ctx)
+	case poll.FieldMaxVotes:
+		return m.OldMaxVotes(ctx)
+	case poll.FieldQuorumPercent:
+		return m.OldQuorumPercent(ctx)
+	case poll.FieldCloseOnDecisiveLead:
+		return m.OldCloseOnDecisiveLead(ctx)
+	case poll.FieldClosedReason:
+		return m.OldClosedReason(ctx)
+	case poll.FieldResultsVisibility:
+		return m.OldResultsVisibility(ctx)
+	case poll.FieldResultsVisibleAt:
+		return m.OldResultsVisibleAt(ctx)
+	case poll.FieldStatus:
+		return m.OldStatus(ctx)
+	case poll.FieldPublishedAt:
+		return m.OldPublishedAt(ctx)
+	case poll.FieldAnonymous:
+		return m.OldAnonymous(ctx)
+	case poll.FieldEmbeddable:
+		return m.OldEmbeddable(ctx)
+	case poll.FieldAllowGuests:
+		return m.OldAllowGuests(ctx)
+	case poll.FieldOwnerID:
+		return m.OldOwnerID(ctx)
+	}
+	return nil, fmt.Errorf("unknown Poll field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PollMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case poll.FieldTitle:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTitle(v)
+		return nil
+	case poll.FieldDescription:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDescription(v)
+		return nil
+	case poll.FieldIsOpen:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetIsOpen(v)
+		return nil
+	case poll.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case poll.FieldTotalVotes:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTotalVotes(v)
+		return nil
+	case poll.FieldOpensAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOpensAt(v)
+		return nil
+	case poll.FieldClosesAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClosesAt(v)
+		return nil
+	case poll.FieldMaxVotes:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMaxVotes(v)
+		return nil
+	case poll.FieldQuorumPercent:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetQuorumPercent(v)
+		return nil
+	case poll.FieldCloseOnDecisiveLead:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCloseOnDecisiveLead(v)
+		return nil
+	case poll.FieldClosedReason:
+		v, ok := value.(poll.ClosedReason)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClosedReason(v)
+		return nil
+	case poll.FieldResultsVisibility:
+		v, ok := value.(poll.ResultsVisibility)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResultsVisibility(v)
+		return nil
+	case poll.FieldResultsVisibleAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResultsVisibleAt(v)
+		return nil
+	case poll.FieldStatus:
+		v, ok := value.(poll.Status)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStatus(v)
+		return nil
+	case poll.FieldPublishedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPublishedAt(v)
+		return nil
+	case poll.FieldAnonymous:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAnonymous(v)
+		return nil
+	case poll.FieldEmbeddable:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEmbeddable(v)
+		return nil
+	case poll.FieldAllowGuests:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAllowGuests(v)
+		return nil
+	case poll.FieldOwnerID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOwnerID(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Poll field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *PollMutation) AddedFields() []string {
+	var fields []string
+	if m.addtotal_votes != nil {
+		fields = append(fields, poll.FieldTotalVotes)
+	}
+	if m.addmax_votes != nil {
+		fields = append(fields, poll.FieldMaxVotes)
+	}
+	if m.addquorum_percent != nil {
+		fields = append(fields, poll.FieldQuorumPercent)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *PollMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case poll.FieldTotalVotes:
+		return m.AddedTotalVotes()
+	case poll.FieldMaxVotes:
+		return m.AddedMaxVotes()
+	case poll.FieldQuorumPercent:
+		return m.AddedQuorumPercent()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PollMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case poll.FieldTotalVotes:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddTotalVotes(v)
+		return nil
+	case poll.FieldMaxVotes:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddMaxVotes(v)
+		return nil
+	case poll.FieldQuorumPercent:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddQuorumPercent(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Poll numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *PollMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(poll.FieldDescription) {
+		fields = append(fields, poll.FieldDescription)
+	}
+	if m.FieldCleared(poll.FieldOpensAt) {
+		fields = append(fields, poll.FieldOpensAt)
+	}
+	if m.FieldCleared(poll.FieldClosesAt) {
+		fields = append(fields, poll.FieldClosesAt)
+	}
+	if m.FieldCleared(poll.FieldMaxVotes) {
+		fields = append(fields, poll.FieldMaxVotes)
+	}
+	if m.FieldCleared(poll.FieldQuorumPercent) {
+		fields = append(fields, poll.FieldQuorumPercent)
+	}
+	if m.FieldCleared(poll.FieldClosedReason) {
+		fields = append(fields, poll.FieldClosedReason)
+	}
+	if m.FieldCleared(poll.FieldResultsVisibleAt) {
+		fields = append(fields, poll.FieldResultsVisibleAt)
+	}
+	if m.FieldCleared(poll.FieldPublishedAt) {
+		fields = append(fields, poll.FieldPublishedAt)
+	}
+	if m.FieldCleared(poll.FieldOwnerID) {
+		fields = append(fields, poll.FieldOwnerID)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *PollMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *PollMutation) ClearField(name string) error {
+	switch name {
+	case poll.FieldDescription:
+		m.ClearDescription()
+		return nil
+	case poll.FieldOpensAt:
+		m.ClearOpensAt()
+		return nil
+	case poll.FieldClosesAt:
+		m.ClearClosesAt()
+		return nil
+	case poll.FieldMaxVotes:
+		m.ClearMaxVotes()
+		return nil
+	case poll.FieldQuorumPercent:
+		m.ClearQuorumPercent()
+		return nil
+	case poll.FieldClosedReason:
+		m.ClearClosedReason()
+		return nil
+	case poll.FieldResultsVisibleAt:
+		m.ClearResultsVisibleAt()
+		return nil
+	case poll.FieldPublishedAt:
+		m.ClearPublishedAt()
+		return nil
+	case poll.FieldOwnerID:
+		m.ClearOwnerID()
+		return nil
+	}
+	return fmt.Errorf("unknown Poll nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *PollMutation) ResetField(name string) error {
+	switch name {
+	case poll.FieldTitle:
+		m.ResetTitle()
+		return nil
+	case poll.FieldDescription:
+		m.ResetDescription()
+		return nil
+	case poll.FieldIsOpen:
+		m.ResetIsOpen()
+		return nil
+	case poll.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case poll.FieldTotalVotes:
+		m.ResetTotalVotes()
+		return nil
+	case poll.FieldOpensAt:
+		m.ResetOpensAt()
+		return nil
+	case poll.FieldClosesAt:
+		m.ResetClosesAt()
+		return nil
+	case poll.FieldMaxVotes:
+		m.ResetMaxVotes()
+		return nil
+	case poll.FieldQuorumPercent:
+		m.ResetQuorumPercent()
+		return nil
+	case poll.FieldCloseOnDecisiveLead:
+		m.ResetCloseOnDecisiveLead()
+		return nil
+	case poll.FieldClosedReason:
+		m.ResetClosedReason()
+		return nil
+	case poll.FieldResultsVisibility:
+		m.ResetResultsVisibility()
+		return nil
+	case poll.FieldResultsVisibleAt:
+		m.ResetResultsVisibleAt()
+		return nil
+	case poll.FieldStatus:
+		m.ResetStatus()
+		return nil
+	case poll.FieldPublishedAt:
+		m.ResetPublishedAt()
+		return nil
+	case poll.FieldAnonymous:
+		m.ResetAnonymous()
+		return nil
+	case poll.FieldEmbeddable:
+		m.ResetEmbeddable()
+		return nil
+	case poll.FieldAllowGuests:
+		m.ResetAllowGuests()
+		return nil
+	case poll.FieldOwnerID:
+		m.ResetOwnerID()
+		return nil
+	}
+	return fmt.Errorf("unknown Poll field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *PollMutation) AddedEdges() []string {
+	edges := make([]string, 0, 6)
+	if m.options != nil {
+		edges = append(edges, poll.EdgeOptions)
+	}
+	if m.votes != nil {
+		edges = append(edges, poll.EdgeVotes)
+	}
+	if m.revisions != nil {
+		edges = append(edges, poll.EdgeRevisions)
+	}
+	if m.tags != nil {
+		edges = append(edges, poll.EdgeTags)
+	}
+	if m.share_links != nil {
+		edges = append(edges, poll.EdgeShareLinks)
+	}
+	if m.owner != nil {
+		edges = append(edges, poll.EdgeOwner)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *PollMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case poll.EdgeOptions:
+		ids := make([]ent.Value, 0, len(m.options))
+		for id := range m.options {
+			ids = append(ids, id)
+		}
+		return ids
+	case poll.EdgeVotes:
+		ids := make([]ent.Value, 0, len(m.votes))
+		for id := range m.votes {
+			ids = append(ids, id)
+		}
+		return ids
+	case poll.EdgeRevisions:
+		ids := make([]ent.Value, 0, len(m.revisions))
+		for id := range m.revisions {
+			ids = append(ids, id)
+		}
+		return ids
+	case poll.EdgeTags:
+		ids := make([]ent.Value, 0, len(m.tags))
+		for id := range m.tags {
+			ids = append(ids, id)
+		}
+		return ids
+	case poll.EdgeShareLinks:
+		ids := make([]ent.Value, 0, len(m.share_links))
+		for id := range m.share_links {
+			ids = append(ids, id)
+		}
+		return ids
+	case poll.EdgeOwner:
+		if id := m.owner; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *PollMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 6)
+	if m.removedoptions != nil {
+		edges = append(edges, poll.EdgeOptions)
+	}
+	if m.removedvotes != nil {
+		edges = append(edges, poll.EdgeVotes)
+	}
+	if m.removedrevisions != nil {
+		edges = append(edges, poll.EdgeRevisions)
+	}
+	if m.removedtags != nil {
+		edges = append(edges, poll.EdgeTags)
+	}
+	if m.removedshare_links != nil {
+		edges = append(edges, poll.EdgeShareLinks)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *PollMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case poll.EdgeOptions:
+		ids := make([]ent.Value, 0, len(m.removedoptions))
+		for id := range m.removedoptions {
+			ids = append(ids, id)
+		}
+		return ids
+	case poll.EdgeVotes:
+		ids := make([]ent.Value, 0, len(m.removedvotes))
+		for id := range m.removedvotes {
+			ids = append(ids, id)
+		}
+		return ids
+	case poll.EdgeRevisions:
+		ids := make([]ent.Value, 0, len(m.removedrevisions))
+		for id := range m.removedrevisions {
+			ids = append(ids, id)
+		}
+		return ids
+	case poll.EdgeTags:
+		ids := make([]ent.Value, 0, len(m.removedtags))
+		for id := range m.removedtags {
+			ids = append(ids, id)
+		}
+		return ids
+	case poll.EdgeShareLinks:
+		ids := make([]ent.Value, 0, len(m.removedshare_links))
+		for id := range m.removedshare_links {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *PollMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 6)
+	if m.clearedoptions {
+		edges = append(edges, poll.EdgeOptions)
+	}
+	if m.clearedvotes {
+		edges = append(edges, poll.EdgeVotes)
+	}
+	if m.clearedrevisions {
+		edges = append(edges, poll.EdgeRevisions)
+	}
+	if m.clearedtags {
+		edges = append(edges, poll.EdgeTags)
+	}
+	if m.clearedshare_links {
+		edges = append(edges, poll.EdgeShareLinks)
+	}
+	if m.clearedowner {
+		edges = append(edges, poll.EdgeOwner)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *PollMutation) EdgeCleared(name string) bool {
+	switch name {
+	case poll.EdgeOptions:
+		return m.clearedoptions
+	case poll.EdgeVotes:
+		return m.clearedvotes
+	case poll.EdgeRevisions:
+		return m.clearedrevisions
+	case poll.EdgeTags:
+		return m.clearedtags
+	case poll.EdgeShareLinks:
+		return m.clearedshare_links
+	case poll.EdgeOwner:
+		return m.clearedowner
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *PollMutation) ClearEdge(name string) error {
+	switch name {
+	case poll.EdgeOwner:
+		m.ClearOwner()
+		return nil
+	}
+	return fmt.Errorf("unknown Poll unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *PollMutation) ResetEdge(name string) error {
+	switch name {
+	case poll.EdgeOptions:
+		m.ResetOptions()
+		return nil
+	case poll.EdgeVotes:
+		m.ResetVotes()
+		return nil
+	case poll.EdgeRevisions:
+		m.ResetRevisions()
+		return nil
+	case poll.EdgeTags:
+		m.ResetTags()
+		return nil
+	case poll.EdgeShareLinks:
+		m.ResetShareLinks()
+		return nil
+	case poll.EdgeOwner:
+		m.ResetOwner()
+		return nil
+	}
+	return fmt.Errorf("unknown Poll edge %s", name)
+}
+
+// PollOptionMutation represents an operation that mutates the PollOption nodes in the graph.
+type PollOptionMutation struct {
+	config
+	op             Op
+	typ            string
+	id             *int
+	text           *string
+	votes_count    *int
+	addvotes_count *int
+	position       *int
+	addposition    *int
+	archived       *bool
+	clearedFields  map[string]struct{}
+	poll           *int
+	clearedpoll    bool
+	votes          map[int]struct{}
+	removedvotes   map[int]struct{}
+	clearedvotes   bool
+	done           bool
+	oldValue       func(context.Context) (*PollOption, error)
+	predicates     []predicate.PollOption
+}
+
+var _ ent.Mutation = (*PollOptionMutation)(nil)
+
+// polloptionOption allows management of the mutation configuration using functional options.
+type polloptionOption func(*PollOptionMutation)
+
+// newPollOptionMutation creates new mutation for the PollOption entity.
+func newPollOptionMutation(c config, op Op, opts ...polloptionOption) *PollOptionMutation {
+	m := &PollOptionMutation{
+		config:        c,
+		op:            op,
+		typ:           TypePollOption,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withPollOptionID sets the ID field of the mutation.
+func withPollOptionID(id int) polloptionOption {
+	return func(m *PollOptionMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *PollOption
+		)
+		m.oldValue = func(ctx context.Context) (*PollOption, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().PollOption.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withPollOption sets the old PollOption of the mutation.
+func withPollOption(node *PollOption) polloptionOption {
+	return func(m *PollOptionMutation) {
+		m.oldValue = func(context.Context) (*PollOption, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m PollOptionMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m PollOptionMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *PollOptionMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *PollOptionMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().PollOption.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetText sets the "text" field.
+func (m *PollOptionMutation) SetText(s string) {
+	m.text = &s
+}
+
+// Text returns the value of the "text" field in the mutation.
+func (m *PollOptionMutation) Text() (r string, exists bool) {
+	v := m.text
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldText returns the old "text" field's value of the PollOption entity.
+// If the PollOption object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollOptionMutation) OldText(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldText is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldText requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldText: %w", err)
+	}
+	return oldValue.Text, nil
+}
+
+// ResetText resets all changes to the "text" field.
+func (m *PollOptionMutation) ResetText() {
+	m.text = nil
+}
+
+// SetVotesCount sets the "votes_count" field.
+func (m *PollOptionMutation) SetVotesCount(i int) {
+	m.votes_count = &i
+	m.addvotes_count = nil
+}
+
+// VotesCount returns the value of the "votes_count" field in the mutation.
+func (m *PollOptionMutation) VotesCount() (r int, exists bool) {
+	v := m.votes_count
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldVotesCount returns the old "votes_count" field's value of the PollOption entity.
+// If the PollOption object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollOptionMutation) OldVotesCount(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldVotesCount is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldVotesCount requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldVotesCount: %w", err)
+	}
+	return oldValue.VotesCount, nil
+}
+
+// AddVotesCount adds i to the "votes_count" field.
+func (m *PollOptionMutation) AddVotesCount(i int) {
+	if m.addvotes_count != nil {
+		*m.addvotes_count += i
+	} else {
+		m.addvotes_count = &i
+	}
+}
+
+// AddedVotesCount returns the value that was added to the "votes_count" field in this mutation.
+func (m *PollOptionMutation) AddedVotesCount() (r int, exists bool) {
+	v := m.addvotes_count
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetVotesCount resets all changes to the "votes_count" field.
+func (m *PollOptionMutation) ResetVotesCount() {
+	m.votes_count = nil
+	m.addvotes_count = nil
+}
+
+// SetPosition sets the "position" field.
+func (m *PollOptionMutation) SetPosition(i int) {
+	m.position = &i
+	m.addposition = nil
+}
+
+// Position returns the value of the "position" field in the mutation.
+func (m *PollOptionMutation) Position() (r int, exists bool) {
+	v := m.position
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPosition returns the old "position" field's value of the PollOption entity.
+// If the PollOption object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollOptionMutation) OldPosition(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPosition is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPosition requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPosition: %w", err)
+	}
+	return oldValue.Position, nil
+}
+
+// AddPosition adds i to the "position" field.
+func (m *PollOptionMutation) AddPosition(i int) {
+	if m.addposition != nil {
+		*m.addposition += i
+	} else {
+		m.addposition = &i
+	}
+}
+
+// AddedPosition returns the value that was added to the "position" field in this mutation.
+func (m *PollOptionMutation) AddedPosition() (r int, exists bool) {
+	v := m.addposition
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetPosition resets all changes to the "position" field.
+func (m *PollOptionMutation) ResetPosition() {
+	m.position = nil
+	m.addposition = nil
+}
+
+// SetArchived sets the "archived" field.
+func (m *PollOptionMutation) SetArchived(b bool) {
+	m.archived = &b
+}
+
+// Archived returns the value of the "archived" field in the mutation.
+func (m *PollOptionMutation) Archived() (r bool, exists bool) {
+	v := m.archived
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldArchived returns the old "archived" field's value of the PollOption entity.
+// If the PollOption object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollOptionMutation) OldArchived(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldArchived is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldArchived requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldArchived: %w", err)
+	}
+	return oldValue.Archived, nil
+}
+
+// ResetArchived resets all changes to the "archived" field.
+func (m *PollOptionMutation) ResetArchived() {
+	m.archived = nil
+}
+
+// SetPollID sets the "poll" edge to the Poll entity by id.
+func (m *PollOptionMutation) SetPollID(id int) {
+	m.poll = &id
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (m *PollOptionMutation) ClearPoll() {
+	m.clearedpoll = true
+}
+
+// PollCleared reports if the "poll" edge to the Poll entity was cleared.
+func (m *PollOptionMutation) PollCleared() bool {
+	return m.clearedpoll
+}
+
+// PollID returns the "poll" edge ID in the mutation.
+func (m *PollOptionMutation) PollID() (id int, exists bool) {
+	if m.poll != nil {
+		return *m.poll, true
+	}
+	return
+}
+
+// PollIDs returns the "poll" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// PollID instead. It exists only for internal usage by the builders.
+func (m *PollOptionMutation) PollIDs() (ids []int) {
+	if id := m.poll; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetPoll resets all changes to the "poll" edge.
+func (m *PollOptionMutation) ResetPoll() {
+	m.poll = nil
+	m.clearedpoll = false
+}
+
+// AddVoteIDs adds the "votes" edge to the Vote entity by ids.
+func (m *PollOptionMutation) AddVoteIDs(ids ...int) {
+	if m.votes == nil {
+		m.votes = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.votes[ids[i]] = struct{}{}
+	}
+}
+
+// ClearVotes clears the "votes" edge to the Vote entity.
+func (m *PollOptionMutation) ClearVotes() {
+	m.clearedvotes = true
+}
+
+// VotesCleared reports if the "votes" edge to the Vote entity was cleared.
+func (m *PollOptionMutation) VotesCleared() bool {
+	return m.clearedvotes
+}
+
+// RemoveVoteIDs removes the "votes" edge to the Vote entity by IDs.
+func (m *PollOptionMutation) RemoveVoteIDs(ids ...int) {
+	if m.removedvotes == nil {
+		m.removedvotes = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.votes, ids[i])
+		m.removedvotes[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedVotes returns the removed IDs of the "votes" edge to the Vote entity.
+func (m *PollOptionMutation) RemovedVotesIDs() (ids []int) {
+	for id := range m.removedvotes {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// VotesIDs returns the "votes" edge IDs in the mutation.
+func (m *PollOptionMutation) VotesIDs() (ids []int) {
+	for id := range m.votes {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetVotes resets all changes to the "votes" edge.
+func (m *PollOptionMutation) ResetVotes() {
+	m.votes = nil
+	m.clearedvotes = false
+	m.removedvotes = nil
+}
+
+// Where appends a list predicates to the PollOptionMutation builder.
+func (m *PollOptionMutation) Where(ps ...predicate.PollOption) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the PollOptionMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *PollOptionMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.PollOption, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *PollOptionMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *PollOptionMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (PollOption).
+func (m *PollOptionMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *PollOptionMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.text != nil {
+		fields = append(fields, polloption.FieldText)
+	}
+	if m.votes_count != nil {
+		fields = append(fields, polloption.FieldVotesCount)
+	}
+	if m.position != nil {
+		fields = append(fields, polloption.FieldPosition)
+	}
+	if m.archived != nil {
+		fields = append(fields, polloption.FieldArchived)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *PollOptionMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case polloption.FieldText:
+		return m.Text()
+	case polloption.FieldVotesCount:
+		return m.VotesCount()
+	case polloption.FieldPosition:
+		return m.Position()
+	case polloption.FieldArchived:
+		return m.Archived()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *PollOptionMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case polloption.FieldText:
+		return m.OldText(ctx)
+	case polloption.FieldVotesCount:
+		return m.OldVotesCount(ctx)
+	case polloption.FieldPosition:
+		return m.OldPosition(ctx)
+	case polloption.FieldArchived:
+		return m.OldArchived(ctx)
+	}
+	return nil, fmt.Errorf("unknown PollOption field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PollOptionMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case polloption.FieldText:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetText(v)
+		return nil
+	case polloption.FieldVotesCount:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetVotesCount(v)
+		return nil
+	case polloption.FieldPosition:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPosition(v)
+		return nil
+	case polloption.FieldArchived:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetArchived(v)
+		return nil
+	}
+	return fmt.Errorf("unknown PollOption field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *PollOptionMutation) AddedFields() []string {
+	var fields []string
+	if m.addvotes_count != nil {
+		fields = append(fields, polloption.FieldVotesCount)
+	}
+	if m.addposition != nil {
+		fields = append(fields, polloption.FieldPosition)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *PollOptionMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case polloption.FieldVotesCount:
+		return m.AddedVotesCount()
+	case polloption.FieldPosition:
+		return m.AddedPosition()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PollOptionMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case polloption.FieldVotesCount:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddVotesCount(v)
+		return nil
+	case polloption.FieldPosition:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddPosition(v)
+		return nil
+	}
+	return fmt.Errorf("unknown PollOption numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *PollOptionMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *PollOptionMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *PollOptionMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown PollOption nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *PollOptionMutation) ResetField(name string) error {
+	switch name {
+	case polloption.FieldText:
+		m.ResetText()
+		return nil
+	case polloption.FieldVotesCount:
+		m.ResetVotesCount()
+		return nil
+	case polloption.FieldPosition:
+		m.ResetPosition()
+		return nil
+	case polloption.FieldArchived:
+		m.ResetArchived()
+		return nil
+	}
+	return fmt.Errorf("unknown PollOption field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *PollOptionMutation) AddedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.poll != nil {
+		edges = append(edges, polloption.EdgePoll)
+	}
+	if m.votes != nil {
+		edges = append(edges, polloption.EdgeVotes)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *PollOptionMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case polloption.EdgePoll:
+		if id := m.poll; id != nil {
+			return []ent.Value{*id}
+		}
+	case polloption.EdgeVotes:
+		ids := make([]ent.Value, 0, len(m.votes))
+		for id := range m.votes {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *PollOptionMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.removedvotes != nil {
+		edges = append(edges, polloption.EdgeVotes)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *PollOptionMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case polloption.EdgeVotes:
+		ids := make([]ent.Value, 0, len(m.removedvotes))
+		for id := range m.removedvotes {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *PollOptionMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.clearedpoll {
+		edges = append(edges, polloption.EdgePoll)
+	}
+	if m.clearedvotes {
+		edges = append(edges, polloption.EdgeVotes)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *PollOptionMutation) EdgeCleared(name string) bool {
+	switch name {
+	case polloption.EdgePoll:
+		return m.clearedpoll
+	case polloption.EdgeVotes:
+		return m.clearedvotes
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *PollOptionMutation) ClearEdge(name string) error {
+	switch name {
+	case polloption.EdgePoll:
+		m.ClearPoll()
+		return nil
+	}
+	return fmt.Errorf("unknown PollOption unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *PollOptionMutation) ResetEdge(name string) error {
+	switch name {
+	case polloption.EdgePoll:
+		m.ResetPoll()
+		return nil
+	case polloption.EdgeVotes:
+		m.ResetVotes()
+		return nil
+	}
+	return fmt.Errorf("unknown PollOption edge %s", name)
+}
+
+// PollRevisionMutation represents an operation that mutates the PollRevision nodes in the graph.
+type PollRevisionMutation struct {
+	config
+	op               Op
+	typ              string
+	id               *int
+	revision         *int
+	addrevision      *int
+	title            *string
+	description      *string
+	options          *[]schema.OptionSnapshot
+	appendoptions    []schema.OptionSnapshot
+	settings         *schema.PollSettings
+	restored_from    *int
+	addrestored_from *int
+	created_at       *time.Time
+	clearedFields    map[string]struct{}
+	poll             *int
+	clearedpoll      bool
+	author           *string
+	clearedauthor    bool
+	done             bool
+	oldValue         func(context.Context) (*PollRevision, error)
+	predicates       []predicate.PollRevision
+}
+
+var _ ent.Mutation = (*PollRevisionMutation)(nil)
+
+// pollrevisionOption allows management of the mutation configuration using functional options.
+type pollrevisionOption func(*PollRevisionMutation)
+
+// newPollRevisionMutation creates new mutation for the PollRevision entity.
+func newPollRevisionMutation(c config, op Op, opts ...pollrevisionOption) *PollRevisionMutation {
+	m := &PollRevisionMutation{
+		config:        c,
+		op:            op,
+		typ:           TypePollRevision,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withPollRevisionID sets the ID field of the mutation.
+func withPollRevisionID(id int) pollrevisionOption {
+	return func(m *PollRevisionMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *PollRevision
+		)
+		m.oldValue = func(ctx context.Context) (*PollRevision, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().PollRevision.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withPollRevision sets the old PollRevision of the mutation.
+func withPollRevision(node *PollRevision) pollrevisionOption {
+	return func(m *PollRevisionMutation) {
+		m.oldValue = func(context.Context) (*PollRevision, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m PollRevisionMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m PollRevisionMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *PollRevisionMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *PollRevisionMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().PollRevision.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetPollID sets the "poll_id" field.
+func (m *PollRevisionMutation) SetPollID(i int) {
+	m.poll = &i
+}
+
+// PollID returns the value of the "poll_id" field in the mutation.
+func (m *PollRevisionMutation) PollID() (r int, exists bool) {
+	v := m.poll
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPollID returns the old "poll_id" field's value of the PollRevision entity.
+// If the PollRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollRevisionMutation) OldPollID(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPollID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPollID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPollID: %w", err)
+	}
+	return oldValue.PollID, nil
+}
+
+// ResetPollID resets all changes to the "poll_id" field.
+func (m *PollRevisionMutation) ResetPollID() {
+	m.poll = nil
+}
+
+// SetRevision sets the "revision" field.
+func (m *PollRevisionMutation) SetRevision(i int) {
+	m.revision = &i
+	m.addrevision = nil
+}
+
+// Revision returns the value of the "revision" field in the mutation.
+func (m *PollRevisionMutation) Revision() (r int, exists bool) {
+	v := m.revision
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRevision returns the old "revision" field's value of the PollRevision entity.
+// If the PollRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollRevisionMutation) OldRevision(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRevision is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRevision requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRevision: %w", err)
+	}
+	return oldValue.Revision, nil
+}
+
+// AddRevision adds i to the "revision" field.
+func (m *PollRevisionMutation) AddRevision(i int) {
+	if m.addrevision != nil {
+		*m.addrevision += i
+	} else {
+		m.addrevision = &i
+	}
+}
+
+// AddedRevision returns the value that was added to the "revision" field in this mutation.
+func (m *PollRevisionMutation) AddedRevision() (r int, exists bool) {
+	v := m.addrevision
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetRevision resets all changes to the "revision" field.
+func (m *PollRevisionMutation) ResetRevision() {
+	m.revision = nil
+	m.addrevision = nil
+}
+
+// SetTitle sets the "title" field.
+func (m *PollRevisionMutation) SetTitle(s string) {
+	m.title = &s
+}
+
+// Title returns the value of the "title" field in the mutation.
+func (m *PollRevisionMutation) Title() (r string, exists bool) {
+	v := m.title
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTitle returns the old "title" field's value of the PollRevision entity.
+// If the PollRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollRevisionMutation) OldTitle(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTitle is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTitle requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTitle: %w", err)
+	}
+	return oldValue.Title, nil
+}
+
+// ResetTitle resets all changes to the "title" field.
+func (m *PollRevisionMutation) ResetTitle() {
+	m.title = nil
+}
+
+// SetDescription sets the "description" field.
+func (m *PollRevisionMutation) SetDescription(s string) {
+	m.description = &s
+}
+
+// Description returns the value of the "description" field in the mutation.
+func (m *PollRevisionMutation) Description() (r string, exists bool) {
+	v := m.description
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDescription returns the old "description" field's value of the PollRevision entity.
+// If the PollRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollRevisionMutation) OldDescription(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDescription is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDescription requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDescription: %w", err)
+	}
+	return oldValue.Description, nil
+}
+
+// ClearDescription clears the value of the "description" field.
+func (m *PollRevisionMutation) ClearDescription() {
+	m.description = nil
+	m.clearedFields[pollrevision.FieldDescription] = struct{}{}
+}
+
+// DescriptionCleared returns if the "description" field was cleared in this mutation.
+func (m *PollRevisionMutation) DescriptionCleared() bool {
+	_, ok := m.clearedFields[pollrevision.FieldDescription]
+	return ok
+}
+
+// ResetDescription resets all changes to the "description" field.
+func (m *PollRevisionMutation) ResetDescription() {
+	m.description = nil
+	delete(m.clearedFields, pollrevision.FieldDescription)
+}
+
+// SetOptions sets the "options" field.
+func (m *PollRevisionMutation) SetOptions(ss []schema.OptionSnapshot) {
+	m.options = &ss
+	m.appendoptions = nil
+}
+
+// Options returns the value of the "options" field in the mutation.
+func (m *PollRevisionMutation) Options() (r []schema.OptionSnapshot, exists bool) {
+	v := m.options
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOptions returns the old "options" field's value of the PollRevision entity.
+// If the PollRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollRevisionMutation) OldOptions(ctx context.Context) (v []schema.OptionSnapshot, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOptions is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOptions requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOptions: %w", err)
+	}
+	return oldValue.Options, nil
+}
+
+// AppendOptions adds ss to the "options" field.
+func (m *PollRevisionMutation) AppendOptions(ss []schema.OptionSnapshot) {
+	m.appendoptions = append(m.appendoptions, ss...)
+}
+
+// AppendedOptions returns the list of values that were appended to the "options" field in this mutation.
+func (m *PollRevisionMutation) AppendedOptions() ([]schema.OptionSnapshot, bool) {
+	if len(m.appendoptions) == 0 {
+		return nil, false
+	}
+	return m.appendoptions, true
+}
+
+// ResetOptions resets all changes to the "options" field.
+func (m *PollRevisionMutation) ResetOptions() {
+	m.options = nil
+	m.appendoptions = nil
+}
+
+// SetSettings sets the "settings" field.
+func (m *PollRevisionMutation) SetSettings(ss schema.PollSettings) {
+	m.settings = &ss
+}
+
+// Settings returns the value of the "settings" field in the mutation.
+func (m *PollRevisionMutation) Settings() (r schema.PollSettings, exists bool) {
+	v := m.settings
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSettings returns the old "settings" field's value of the PollRevision entity.
+// If the PollRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollRevisionMutation) OldSettings(ctx context.Context) (v schema.PollSettings, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSettings is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSettings requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSettings: %w", err)
+	}
+	return oldValue.Settings, nil
+}
+
+// ResetSettings resets all changes to the "settings" field.
+func (m *PollRevisionMutation) ResetSettings() {
+	m.settings = nil
+}
+
+// SetAuthorID sets the "author_id" field.
+func (m *PollRevisionMutation) SetAuthorID(s string) {
+	m.author = &s
+}
+
+// AuthorID returns the value of the "author_id" field in the mutation.
+func (m *PollRevisionMutation) AuthorID() (r string, exists bool) {
+	v := m.author
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAuthorID returns the old "author_id" field's value of the PollRevision entity.
+// If the PollRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollRevisionMutation) OldAuthorID(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAuthorID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAuthorID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAuthorID: %w", err)
+	}
+	return oldValue.AuthorID, nil
+}
+
+// ClearAuthorID clears the value of the "author_id" field.
+func (m *PollRevisionMutation) ClearAuthorID() {
+	m.author = nil
+	m.clearedFields[pollrevision.FieldAuthorID] = struct{}{}
+}
+
+// AuthorIDCleared returns if the "author_id" field was cleared in this mutation.
+func (m *PollRevisionMutation) AuthorIDCleared() bool {
+	_, ok := m.clearedFields[pollrevision.FieldAuthorID]
+	return ok
+}
+
+// ResetAuthorID resets all changes to the "author_id" field.
+func (m *PollRevisionMutation) ResetAuthorID() {
+	m.author = nil
+	delete(m.clearedFields, pollrevision.FieldAuthorID)
+}
+
+// SetRestoredFrom sets the "restored_from" field.
+func (m *PollRevisionMutation) SetRestoredFrom(i int) {
+	m.restored_from = &i
+	m.addrestored_from = nil
+}
+
+// RestoredFrom returns the value of the "restored_from" field in the mutation.
+func (m *PollRevisionMutation) RestoredFrom() (r int, exists bool) {
+	v := m.restored_from
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRestoredFrom returns the old "restored_from" field's value of the PollRevision entity.
+// If the PollRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollRevisionMutation) OldRestoredFrom(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRestoredFrom is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRestoredFrom requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRestoredFrom: %w", err)
+	}
+	return oldValue.RestoredFrom, nil
+}
+
+// AddRestoredFrom adds i to the "restored_from" field.
+func (m *PollRevisionMutation) AddRestoredFrom(i int) {
+	if m.addrestored_from != nil {
+		*m.addrestored_from += i
+	} else {
+		m.addrestored_from = &i
+	}
+}
+
+// AddedRestoredFrom returns the value that was added to the "restored_from" field in this mutation.
+func (m *PollRevisionMutation) AddedRestoredFrom() (r int, exists bool) {
+	v := m.addrestored_from
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearRestoredFrom clears the value of the "restored_from" field.
+func (m *PollRevisionMutation) ClearRestoredFrom() {
+	m.restored_from = nil
+	m.addrestored_from = nil
+	m.clearedFields[pollrevision.FieldRestoredFrom] = struct{}{}
+}
+
+// RestoredFromCleared returns if the "restored_from" field was cleared in this mutation.
+func (m *PollRevisionMutation) RestoredFromCleared() bool {
+	_, ok := m.clearedFields[pollrevision.FieldRestoredFrom]
+	return ok
+}
+
+// ResetRestoredFrom resets all changes to the "restored_from" field.
+func (m *PollRevisionMutation) ResetRestoredFrom() {
+	m.restored_from = nil
+	m.addrestored_from = nil
+	delete(m.clearedFields, pollrevision.FieldRestoredFrom)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *PollRevisionMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *PollRevisionMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the PollRevision entity.
+// If the PollRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollRevisionMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *PollRevisionMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (m *PollRevisionMutation) ClearPoll() {
+	m.clearedpoll = true
+	m.clearedFields[pollrevision.FieldPollID] = struct{}{}
+}
+
+// PollCleared reports if the "poll" edge to the Poll entity was cleared.
+func (m *PollRevisionMutation) PollCleared() bool {
+	return m.clearedpoll
+}
+
+// PollIDs returns the "poll" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// PollID instead. It exists only for internal usage by the builders.
+func (m *PollRevisionMutation) PollIDs() (ids []int) {
+	if id := m.poll; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetPoll resets all changes to the "poll" edge.
+func (m *PollRevisionMutation) ResetPoll() {
+	m.poll = nil
+	m.clearedpoll = false
+}
+
+// ClearAuthor clears the "author" edge to the User entity.
+func (m *PollRevisionMutation) ClearAuthor() {
+	m.clearedauthor = true
+	m.clearedFields[pollrevision.FieldAuthorID] = struct{}{}
+}
+
+// AuthorCleared reports if the "author" edge to the User entity was cleared.
+func (m *PollRevisionMutation) AuthorCleared() bool {
+	return m.AuthorIDCleared() || m.clearedauthor
+}
+
+// AuthorIDs returns the "author" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// AuthorID instead. It exists only for internal usage by the builders.
+func (m *PollRevisionMutation) AuthorIDs() (ids []string) {
+	if id := m.author; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetAuthor resets all changes to the "author" edge.
+func (m *PollRevisionMutation) ResetAuthor() {
+	m.author = nil
+	m.clearedauthor = false
+}
+
+// Where appends a list predicates to the PollRevisionMutation builder.
+func (m *PollRevisionMutation) Where(ps ...predicate.PollRevision) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the PollRevisionMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *PollRevisionMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.PollRevision, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *PollRevisionMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *PollRevisionMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (PollRevision).
+func (m *PollRevisionMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *PollRevisionMutation) Fields() []string {
+	fields := make([]string, 0, 9)
+	if m.poll != nil {
+		fields = append(fields, pollrevision.FieldPollID)
+	}
+	if m.revision != nil {
+		fields = append(fields, pollrevision.FieldRevision)
+	}
+	if m.title != nil {
+		fields = append(fields, pollrevision.FieldTitle)
+	}
+	if m.description != nil {
+		fields = append(fields, pollrevision.FieldDescription)
+	}
+	if m.options != nil {
+		fields = append(fields, pollrevision.FieldOptions)
+	}
+	if m.settings != nil {
+		fields = append(fields, pollrevision.FieldSettings)
+	}
+	if m.author != nil {
+		fields = append(fields, pollrevision.FieldAuthorID)
+	}
+	if m.restored_from != nil {
+		fields = append(fields, pollrevision.FieldRestoredFrom)
+	}
+	if m.created_at != nil {
+		fields = append(fields, pollrevision.FieldCreatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *PollRevisionMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case pollrevision.FieldPollID:
+		return m.PollID()
+	case pollrevision.FieldRevision:
+		return m.Revision()
+	case pollrevision.FieldTitle:
+		return m.Title()
+	case pollrevision.FieldDescription:
+		return m.Description()
+	case pollrevision.FieldOptions:
+		return m.Options()
+	case pollrevision.FieldSettings:
+		return m.Settings()
+	case pollrevision.FieldAuthorID:
+		return m.AuthorID()
+	case pollrevision.FieldRestoredFrom:
+		return m.RestoredFrom()
+	case pollrevision.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *PollRevisionMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case pollrevision.FieldPollID:
+		return m.OldPollID(ctx)
+	case pollrevision.FieldRevision:
+		return m.OldRevision(ctx)
+	case pollrevision.FieldTitle:
+		return m.OldTitle(ctx)
+	case pollrevision.FieldDescription:
+		return m.OldDescription(ctx)
+	case pollrevision.FieldOptions:
+		return m.OldOptions(ctx)
+	case pollrevision.FieldSettings:
+		return m.OldSettings(ctx)
+	case pollrevision.FieldAuthorID:
+		return m.OldAuthorID(ctx)
+	case pollrevision.FieldRestoredFrom:
+		return m.OldRestoredFrom(ctx)
+	case pollrevision.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown PollRevision field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PollRevisionMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case pollrevision.FieldPollID:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPollID(v)
+		return nil
+	case pollrevision.FieldRevision:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRevision(v)
+		return nil
+	case pollrevision.FieldTitle:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTitle(v)
+		return nil
+	case pollrevision.FieldDescription:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDescription(v)
+		return nil
+	case pollrevision.FieldOptions:
+		v, ok := value.([]schema.OptionSnapshot)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOptions(v)
+		return nil
+	case pollrevision.FieldSettings:
+		v, ok := value.(schema.PollSettings)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSettings(v)
+		return nil
+	case pollrevision.FieldAuthorID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAuthorID(v)
+		return nil
+	case pollrevision.FieldRestoredFrom:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRestoredFrom(v)
+		return nil
+	case pollrevision.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown PollRevision field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *PollRevisionMutation) AddedFields() []string {
+	var fields []string
+	if m.addrevision != nil {
+		fields = append(fields, pollrevision.FieldRevision)
+	}
+	if m.addrestored_from != nil {
+		fields = append(fields, pollrevision.FieldRestoredFrom)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *PollRevisionMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case pollrevision.FieldRevision:
+		return m.AddedRevision()
+	case pollrevision.FieldRestoredFrom:
+		return m.AddedRestoredFrom()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PollRevisionMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case pollrevision.FieldRevision:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddRevision(v)
+		return nil
+	case pollrevision.FieldRestoredFrom:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddRestoredFrom(v)
+		return nil
+	}
+	return fmt.Errorf("unknown PollRevision numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *PollRevisionMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(pollrevision.FieldDescription) {
+		fields = append(fields, pollrevision.FieldDescription)
+	}
+	if m.FieldCleared(pollrevision.FieldAuthorID) {
+		fields = append(fields, pollrevision.FieldAuthorID)
+	}
+	if m.FieldCleared(pollrevision.FieldRestoredFrom) {
+		fields = append(fields, pollrevision.FieldRestoredFrom)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *PollRevisionMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *PollRevisionMutation) ClearField(name string) error {
+	switch name {
+	case pollrevision.FieldDescription:
+		m.ClearDescription()
+		return nil
+	case pollrevision.FieldAuthorID:
+		m.ClearAuthorID()
+		return nil
+	case pollrevision.FieldRestoredFrom:
+		m.ClearRestoredFrom()
+		return nil
+	}
+	return fmt.Errorf("unknown PollRevision nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *PollRevisionMutation) ResetField(name string) error {
+	switch name {
+	case pollrevision.FieldPollID:
+		m.ResetPollID()
+		return nil
+	case pollrevision.FieldRevision:
+		m.ResetRevision()
+		return nil
+	case pollrevision.FieldTitle:
+		m.ResetTitle()
+		return nil
+	case pollrevision.FieldDescription:
+		m.ResetDescription()
+		return nil
+	case pollrevision.FieldOptions:
+		m.ResetOptions()
+		return nil
+	case pollrevision.FieldSettings:
+		m.ResetSettings()
+		return nil
+	case pollrevision.FieldAuthorID:
+		m.ResetAuthorID()
+		return nil
+	case pollrevision.FieldRestoredFrom:
+		m.ResetRestoredFrom()
+		return nil
+	case pollrevision.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown PollRevision field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *PollRevisionMutation) AddedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.poll != nil {
+		edges = append(edges, pollrevision.EdgePoll)
+	}
+	if m.author != nil {
+		edges = append(edges, pollrevision.EdgeAuthor)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *PollRevisionMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case pollrevision.EdgePoll:
+		if id := m.poll; id != nil {
+			return []ent.Value{*id}
+		}
+	case pollrevision.EdgeAuthor:
+		if id := m.author; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *PollRevisionMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 2)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *PollRevisionMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *PollRevisionMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.clearedpoll {
+		edges = append(edges, pollrevision.EdgePoll)
+	}
+	if m.clearedauthor {
+		edges = append(edges, pollrevision.EdgeAuthor)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *PollRevisionMutation) EdgeCleared(name string) bool {
+	switch name {
+	case pollrevision.EdgePoll:
+		return m.clearedpoll
+	case pollrevision.EdgeAuthor:
+		return m.clearedauthor
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *PollRevisionMutation) ClearEdge(name string) error {
+	switch name {
+	case pollrevision.EdgePoll:
+		m.ClearPoll()
+		return nil
+	case pollrevision.EdgeAuthor:
+		m.ClearAuthor()
+		return nil
+	}
+	return fmt.Errorf("unknown PollRevision unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *PollRevisionMutation) ResetEdge(name string) error {
+	switch name {
+	case pollrevision.EdgePoll:
+		m.ResetPoll()
+		return nil
+	case pollrevision.EdgeAuthor:
+		m.ResetAuthor()
+		return nil
+	}
+	return fmt.Errorf("unknown PollRevision edge %s", name)
+}
+
+// RateLimitBucketMutation represents an operation that mutates the RateLimitBucket nodes in the graph.
+type RateLimitBucketMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *string
+	tokens        *float64
+	addtokens     *float64
+	updated_at    *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*RateLimitBucket, error)
+	predicates    []predicate.RateLimitBucket
+}
+
+var _ ent.Mutation = (*RateLimitBucketMutation)(nil)
+
+// ratelimitbucketOption allows management of the mutation configuration using functional options.
+type ratelimitbucketOption func(*RateLimitBucketMutation)
+
+// newRateLimitBucketMutation creates new mutation for the RateLimitBucket entity.
+func newRateLimitBucketMutation(c config, op Op, opts ...ratelimitbucketOption) *RateLimitBucketMutation {
+	m := &RateLimitBucketMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeRateLimitBucket,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withRateLimitBucketID sets the ID field of the mutation.
+func withRateLimitBucketID(id string) ratelimitbucketOption {
+	return func(m *RateLimitBucketMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *RateLimitBucket
+		)
+		m.oldValue = func(ctx context.Context) (*RateLimitBucket, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().RateLimitBucket.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withRateLimitBucket sets the old RateLimitBucket of the mutation.
+func withRateLimitBucket(node *RateLimitBucket) ratelimitbucketOption {
+	return func(m *RateLimitBucketMutation) {
+		m.oldValue = func(context.Context) (*RateLimitBucket, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m RateLimitBucketMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m RateLimitBucketMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of RateLimitBucket entities.
+func (m *RateLimitBucketMutation) SetID(id string) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *RateLimitBucketMutation) ID() (id string, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *RateLimitBucketMutation) IDs(ctx context.Context) ([]string, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []string{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().RateLimitBucket.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetTokens sets the "tokens" field.
+func (m *RateLimitBucketMutation) SetTokens(f float64) {
+	m.tokens = &f
+	m.addtokens = nil
+}
+
+// Tokens returns the value of the "tokens" field in the mutation.
+func (m *RateLimitBucketMutation) Tokens() (r float64, exists bool) {
+	v := m.tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTokens returns the old "tokens" field's value of the RateLimitBucket entity.
+// If the RateLimitBucket object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RateLimitBucketMutation) OldTokens(ctx context.Context) (v float64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTokens is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTokens requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTokens: %w", err)
+	}
+	return oldValue.Tokens, nil
+}
+
+// AddTokens adds f to the "tokens" field.
+func (m *RateLimitBucketMutation) AddTokens(f float64) {
+	if m.addtokens != nil {
+		*m.addtokens += f
+	} else {
+		m.addtokens = &f
+	}
+}
+
+// AddedTokens returns the value that was added to the "tokens" field in this mutation.
+func (m *RateLimitBucketMutation) AddedTokens() (r float64, exists bool) {
+	v := m.addtokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetTokens resets all changes to the "tokens" field.
+func (m *RateLimitBucketMutation) ResetTokens() {
+	m.tokens = nil
+	m.addtokens = nil
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (m *RateLimitBucketMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *RateLimitBucketMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "updated_at" field's value of the RateLimitBucket entity.
+// If the RateLimitBucket object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RateLimitBucketMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
+}
+
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *RateLimitBucketMutation) ResetUpdatedAt() {
+	m.updated_at = nil
+}
+
+// Where appends a list predicates to the RateLimitBucketMutation builder.
+func (m *RateLimitBucketMutation) Where(ps ...predicate.RateLimitBucket) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the RateLimitBucketMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *RateLimitBucketMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.RateLimitBucket, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *RateLimitBucketMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *RateLimitBucketMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (RateLimitBucket).
+func (m *RateLimitBucketMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *RateLimitBucketMutation) Fields() []string {
+	fields := make([]string, 0, 2)
+	if m.tokens != nil {
+		fields = append(fields, ratelimitbucket.FieldTokens)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, ratelimitbucket.FieldUpdatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *RateLimitBucketMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case ratelimitbucket.FieldTokens:
+		return m.Tokens()
+	case ratelimitbucket.FieldUpdatedAt:
+		return m.UpdatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *RateLimitBucketMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case ratelimitbucket.FieldTokens:
+		return m.OldTokens(ctx)
+	case ratelimitbucket.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown RateLimitBucket field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *RateLimitBucketMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case ratelimitbucket.FieldTokens:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTokens(v)
+		return nil
+	case ratelimitbucket.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown RateLimitBucket field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *RateLimitBucketMutation) AddedFields() []string {
+	var fields []string
+	if m.addtokens != nil {
+		fields = append(fields, ratelimitbucket.FieldTokens)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *RateLimitBucketMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case ratelimitbucket.FieldTokens:
+		return m.AddedTokens()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *RateLimitBucketMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case ratelimitbucket.FieldTokens:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddTokens(v)
+		return nil
+	}
+	return fmt.Errorf("unknown RateLimitBucket numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *RateLimitBucketMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *RateLimitBucketMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *RateLimitBucketMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown RateLimitBucket nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *RateLimitBucketMutation) ResetField(name string) error {
+	switch name {
+	case ratelimitbucket.FieldTokens:
+		m.ResetTokens()
+		return nil
+	case ratelimitbucket.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown RateLimitBucket field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *RateLimitBucketMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *RateLimitBucketMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *RateLimitBucketMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *RateLimitBucketMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *RateLimitBucketMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *RateLimitBucketMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *RateLimitBucketMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown RateLimitBucket unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *RateLimitBucketMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown RateLimitBucket edge %s", name)
+}
+
+// ShareLinkMutation represents an operation that mutates the ShareLink nodes in the graph.
+type ShareLinkMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *int
+	token_hash    *string
+	token_prefix  *string
+	created_by    *string
+	created_at    *time.Time
+	expires_at    *time.Time
+	revoked_at    *time.Time
+	clearedFields map[string]struct{}
+	poll          *int
+	clearedpoll   bool
+	done          bool
+	oldValue      func(context.Context) (*ShareLink, error)
+	predicates    []predicate.ShareLink
+}
+
+var _ ent.Mutation = (*ShareLinkMutation)(nil)
+
+// sharelinkOption allows management of the mutation configuration using functional options.
+type sharelinkOption func(*ShareLinkMutation)
+
+// newShareLinkMutation creates new mutation for the ShareLink entity.
+func newShareLinkMutation(c config, op Op, opts ...sharelinkOption) *ShareLinkMutation {
+	m := &ShareLinkMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeShareLink,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withShareLinkID sets the ID field of the mutation.
+func withShareLinkID(id int) sharelinkOption {
+	return func(m *ShareLinkMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *ShareLink
+		)
+		m.oldValue = func(ctx context.Context) (*ShareLink, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().ShareLink.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withShareLink sets the old ShareLink of the mutation.
+func withShareLink(node *ShareLink) sharelinkOption {
+	return func(m *ShareLinkMutation) {
+		m.oldValue = func(context.Context) (*ShareLink, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m ShareLinkMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m ShareLinkMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *ShareLinkMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *ShareLinkMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().ShareLink.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetPollID sets the "poll_id" field.
+func (m *ShareLinkMutation) SetPollID(i int) {
+	m.poll = &i
+}
+
+// PollID returns the value of the "poll_id" field in the mutation.
+func (m *ShareLinkMutation) PollID() (r int, exists bool) {
+	v := m.poll
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPollID returns the old "poll_id" field's value of the ShareLink entity.
+// If the ShareLink object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ShareLinkMutation) OldPollID(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPollID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPollID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPollID: %w", err)
+	}
+	return oldValue.PollID, nil
+}
+
+// ResetPollID resets all changes to the "poll_id" field.
+func (m *ShareLinkMutation) ResetPollID() {
+	m.poll = nil
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (m *ShareLinkMutation) SetTokenHash(s string) {
+	m.token_hash = &s
+}
+
+// TokenHash returns the value of the "token_hash" field in the mutation.
+func (m *ShareLinkMutation) TokenHash() (r string, exists bool) {
+	v := m.token_hash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTokenHash returns the old "token_hash" field's value of the ShareLink entity.
+// If the ShareLink object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ShareLinkMutation) OldTokenHash(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTokenHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTokenHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTokenHash: %w", err)
+	}
+	return oldValue.TokenHash, nil
+}
+
+// ResetTokenHash resets all changes to the "token_hash" field.
+func (m *ShareLinkMutation) ResetTokenHash() {
+	m.token_hash = nil
+}
+
+// SetTokenPrefix sets the "token_prefix" field.
+func (m *ShareLinkMutation) SetTokenPrefix(s string) {
+	m.token_prefix = &s
+}
+
+// TokenPrefix returns the value of the "token_prefix" field in the mutation.
+func (m *ShareLinkMutation) TokenPrefix() (r string, exists bool) {
+	v := m.token_prefix
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTokenPrefix returns the old "token_prefix" field's value of the ShareLink entity.
+// If the ShareLink object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ShareLinkMutation) OldTokenPrefix(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTokenPrefix is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTokenPrefix requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTokenPrefix: %w", err)
+	}
+	return oldValue.TokenPrefix, nil
+}
+
+// ResetTokenPrefix resets all changes to the "token_prefix" field.
+func (m *ShareLinkMutation) ResetTokenPrefix() {
+	m.token_prefix = nil
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (m *ShareLinkMutation) SetCreatedBy(s string) {
+	m.created_by = &s
+}
+
+// CreatedBy returns the value of the "created_by" field in the mutation.
+func (m *ShareLinkMutation) CreatedBy() (r string, exists bool) {
+	v := m.created_by
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedBy returns the old "created_by" field's value of the ShareLink entity.
+// If the ShareLink object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ShareLinkMutation) OldCreatedBy(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedBy is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedBy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedBy: %w", err)
+	}
+	return oldValue.CreatedBy, nil
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (m *ShareLinkMutation) ClearCreatedBy() {
+	m.created_by = nil
+	m.clearedFields[sharelink.FieldCreatedBy] = struct{}{}
+}
+
+// CreatedByCleared returns if the "created_by" field was cleared in this mutation.
+func (m *ShareLinkMutation) CreatedByCleared() bool {
+	_, ok := m.clearedFields[sharelink.FieldCreatedBy]
+	return ok
+}
+
+// ResetCreatedBy resets all changes to the "created_by" field.
+func (m *ShareLinkMutation) ResetCreatedBy() {
+	m.created_by = nil
+	delete(m.clearedFields, sharelink.FieldCreatedBy)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *ShareLinkMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *ShareLinkMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the ShareLink entity.
+// If the ShareLink object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ShareLinkMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *ShareLinkMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (m *ShareLinkMutation) SetExpiresAt(t time.Time) {
+	m.expires_at = &t
+}
+
+// ExpiresAt returns the value of the "expires_at" field in the mutation.
+func (m *ShareLinkMutation) ExpiresAt() (r time.Time, exists bool) {
+	v := m.expires_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldExpiresAt returns the old "expires_at" field's value of the ShareLink entity.
+// If the ShareLink object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ShareLinkMutation) OldExpiresAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
+	}
+	return oldValue.ExpiresAt, nil
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (m *ShareLinkMutation) ClearExpiresAt() {
+	m.expires_at = nil
+	m.clearedFields[sharelink.FieldExpiresAt] = struct{}{}
+}
+
+// ExpiresAtCleared returns if the "expires_at" field was cleared in this mutation.
+func (m *ShareLinkMutation) ExpiresAtCleared() bool {
+	_, ok := m.clearedFields[sharelink.FieldExpiresAt]
+	return ok
+}
+
+// ResetExpiresAt resets all changes to the "expires_at" field.
+func (m *ShareLinkMutation) ResetExpiresAt() {
+	m.expires_at = nil
+	delete(m.clearedFields, sharelink.FieldExpiresAt)
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (m *ShareLinkMutation) SetRevokedAt(t time.Time) {
+	m.revoked_at = &t
+}
+
+// RevokedAt returns the value of the "revoked_at" field in the mutation.
+func (m *ShareLinkMutation) RevokedAt() (r time.Time, exists bool) {
+	v := m.revoked_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRevokedAt returns the old "revoked_at" field's value of the ShareLink entity.
+// If the ShareLink object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ShareLinkMutation) OldRevokedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRevokedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRevokedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRevokedAt: %w", err)
+	}
+	return oldValue.RevokedAt, nil
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (m *ShareLinkMutation) ClearRevokedAt() {
+	m.revoked_at = nil
+	m.clearedFields[sharelink.FieldRevokedAt] = struct{}{}
+}
+
+// RevokedAtCleared returns if the "revoked_at" field was cleared in this mutation.
+func (m *ShareLinkMutation) RevokedAtCleared() bool {
+	_, ok := m.clearedFields[sharelink.FieldRevokedAt]
+	return ok
+}
+
+// ResetRevokedAt resets all changes to the "revoked_at" field.
+func (m *ShareLinkMutation) ResetRevokedAt() {
+	m.revoked_at = nil
+	delete(m.clearedFields, sharelink.FieldRevokedAt)
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (m *ShareLinkMutation) ClearPoll() {
+	m.clearedpoll = true
+	m.clearedFields[sharelink.FieldPollID] = struct{}{}
+}
+
+// PollCleared reports if the "poll" edge to the Poll entity was cleared.
+func (m *ShareLinkMutation) PollCleared() bool {
+	return m.clearedpoll
+}
+
+// PollIDs returns the "poll" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// PollID instead. It exists only for internal usage by the builders.
+func (m *ShareLinkMutation) PollIDs() (ids []int) {
+	if id := m.poll; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetPoll resets all changes to the "poll" edge.
+func (m *ShareLinkMutation) ResetPoll() {
+	m.poll = nil
+	m.clearedpoll = false
+}
+
+// Where appends a list predicates to the ShareLinkMutation builder.
+func (m *ShareLinkMutation) Where(ps ...predicate.ShareLink) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the ShareLinkMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *ShareLinkMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.ShareLink, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *ShareLinkMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *ShareLinkMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (ShareLink).
+func (m *ShareLinkMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *ShareLinkMutation) Fields() []string {
+	fields := make([]string, 0, 7)
+	if m.poll != nil {
+		fields = append(fields, sharelink.FieldPollID)
+	}
+	if m.token_hash != nil {
+		fields = append(fields, sharelink.FieldTokenHash)
+	}
+	if m.token_prefix != nil {
+		fields = append(fields, sharelink.FieldTokenPrefix)
+	}
+	if m.created_by != nil {
+		fields = append(fields, sharelink.FieldCreatedBy)
+	}
+	if m.created_at != nil {
+		fields = append(fields, sharelink.FieldCreatedAt)
+	}
+	if m.expires_at != nil {
+		fields = append(fields, sharelink.FieldExpiresAt)
+	}
+	if m.revoked_at != nil {
+		fields = append(fields, sharelink.FieldRevokedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *ShareLinkMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case sharelink.FieldPollID:
+		return m.PollID()
+	case sharelink.FieldTokenHash:
+		return m.TokenHash()
+	case sharelink.FieldTokenPrefix:
+		return m.TokenPrefix()
+	case sharelink.FieldCreatedBy:
+		return m.CreatedBy()
+	case sharelink.FieldCreatedAt:
+		return m.CreatedAt()
+	case sharelink.FieldExpiresAt:
+		return m.ExpiresAt()
+	case sharelink.FieldRevokedAt:
+		return m.RevokedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *ShareLinkMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case sharelink.FieldPollID:
+		return m.OldPollID(ctx)
+	case sharelink.FieldTokenHash:
+		return m.OldTokenHash(ctx)
+	case sharelink.FieldTokenPrefix:
+		return m.OldTokenPrefix(ctx)
+	case sharelink.FieldCreatedBy:
+		return m.OldCreatedBy(ctx)
+	case sharelink.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case sharelink.FieldExpiresAt:
+		return m.OldExpiresAt(ctx)
+	case sharelink.FieldRevokedAt:
+		return m.OldRevokedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown ShareLink field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ShareLinkMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case sharelink.FieldPollID:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPollID(v)
+		return nil
+	case sharelink.FieldTokenHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTokenHash(v)
+		return nil
+	case sharelink.FieldTokenPrefix:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTokenPrefix(v)
+		return nil
+	case sharelink.FieldCreatedBy:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedBy(v)
+		return nil
+	case sharelink.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case sharelink.FieldExpiresAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExpiresAt(v)
+		return nil
+	case sharelink.FieldRevokedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRevokedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown ShareLink field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *ShareLinkMutation) AddedFields() []string {
+	var fields []string
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *ShareLinkMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ShareLinkMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown ShareLink numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *ShareLinkMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(sharelink.FieldCreatedBy) {
+		fields = append(fields, sharelink.FieldCreatedBy)
+	}
+	if m.FieldCleared(sharelink.FieldExpiresAt) {
+		fields = append(fields, sharelink.FieldExpiresAt)
+	}
+	if m.FieldCleared(sharelink.FieldRevokedAt) {
+		fields = append(fields, sharelink.FieldRevokedAt)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *ShareLinkMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *ShareLinkMutation) ClearField(name string) error {
+	switch name {
+	case sharelink.FieldCreatedBy:
+		m.ClearCreatedBy()
+		return nil
+	case sharelink.FieldExpiresAt:
+		m.ClearExpiresAt()
+		return nil
+	case sharelink.FieldRevokedAt:
+		m.ClearRevokedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown ShareLink nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *ShareLinkMutation) ResetField(name string) error {
+	switch name {
+	case sharelink.FieldPollID:
+		m.ResetPollID()
+		return nil
+	case sharelink.FieldTokenHash:
+		m.ResetTokenHash()
+		return nil
+	case sharelink.FieldTokenPrefix:
+		m.ResetTokenPrefix()
+		return nil
+	case sharelink.FieldCreatedBy:
+		m.ResetCreatedBy()
+		return nil
+	case sharelink.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case sharelink.FieldExpiresAt:
+		m.ResetExpiresAt()
+		return nil
+	case sharelink.FieldRevokedAt:
+		m.ResetRevokedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown ShareLink field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *ShareLinkMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.poll != nil {
+		edges = append(edges, sharelink.EdgePoll)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *ShareLinkMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case sharelink.EdgePoll:
+		if id := m.poll; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *ShareLinkMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *ShareLinkMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *ShareLinkMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.clearedpoll {
+		edges = append(edges, sharelink.EdgePoll)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *ShareLinkMutation) EdgeCleared(name string) bool {
+	switch name {
+	case sharelink.EdgePoll:
+		return m.clearedpoll
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *ShareLinkMutation) ClearEdge(name string) error {
+	switch name {
+	case sharelink.EdgePoll:
+		m.ClearPoll()
+		return nil
+	}
+	return fmt.Errorf("unknown ShareLink unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *ShareLinkMutation) ResetEdge(name string) error {
+	switch name {
+	case sharelink.EdgePoll:
+		m.ResetPoll()
+		return nil
+	}
+	return fmt.Errorf("unknown ShareLink edge %s", name)
+}
+
+// TagMutation represents an operation that mutates the Tag nodes in the graph.
+type TagMutation struct {
+	config
+	op               Op
+	typ              string
+	id               *int
+	name             *string
+	slug             *string
+	created_at       *time.Time
+	clearedFields    map[string]struct{}
+	polls            map[int]struct{}
+	removedpolls     map[int]struct{}
+	clearedpolls     bool
+	followers        map[string]struct{}
+	removedfollowers map[string]struct{}
+	clearedfollowers bool
+	done             bool
+	oldValue         func(context.Context) (*Tag, error)
+	predicates       []predicate.Tag
+}
+
+var _ ent.Mutation = (*TagMutation)(nil)
+
+// tagOption allows management of the mutation configuration using functional options.
+type tagOption func(*TagMutation)
+
+// newTagMutation creates new mutation for the Tag entity.
+func newTagMutation(c config, op Op, opts ...tagOption) *TagMutation {
+	m := &TagMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeTag,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withTagID sets the ID field of the mutation.
+func withTagID(id int) tagOption {
+	return func(m *TagMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Tag
+		)
+		m.oldValue = func(ctx context.Context) (*Tag, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Tag.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withTag sets the old Tag of the mutation.
+func withTag(node *Tag) tagOption {
+	return func(m *TagMutation) {
+		m.oldValue = func(context.Context) (*Tag, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m TagMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m TagMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *TagMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *TagMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Tag.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetName sets the "name" field.
+func (m *TagMutation) SetName(s string) {
+	m.name = &s
+}
+
+// Name returns the value of the "name" field in the mutation.
+func (m *TagMutation) Name() (r string, exists bool) {
+	v := m.name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldName returns the old "name" field's value of the Tag entity.
+// If the Tag object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TagMutation) OldName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
+	}
+	return oldValue.Name, nil
+}
+
+// ResetName resets all changes to the "name" field.
+func (m *TagMutation) ResetName() {
+	m.name = nil
+}
+
+// SetSlug sets the "slug" field.
+func (m *TagMutation) SetSlug(s string) {
+	m.slug = &s
+}
+
+// Slug returns the value of the "slug" field in the mutation.
+func (m *TagMutation) Slug() (r string, exists bool) {
+	v := m.slug
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSlug returns the old "slug" field's value of the Tag entity.
+// If the Tag object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TagMutation) OldSlug(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSlug is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSlug requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSlug: %w", err)
+	}
+	return oldValue.Slug, nil
+}
+
+// ResetSlug resets all changes to the "slug" field.
+func (m *TagMutation) ResetSlug() {
+	m.slug = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *TagMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *TagMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the Tag entity.
+// If the Tag object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TagMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *TagMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// AddPollIDs adds the "polls" edge to the Poll entity by ids.
+func (m *TagMutation) AddPollIDs(ids ...int) {
+	if m.polls == nil {
+		m.polls = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.polls[ids[i]] = struct{}{}
+	}
+}
+
+// ClearPolls clears the "polls" edge to the Poll entity.
+func (m *TagMutation) ClearPolls() {
+	m.clearedpolls = true
+}
+
+// PollsCleared reports if the "polls" edge to the Poll entity was cleared.
+func (m *TagMutation) PollsCleared() bool {
+	return m.clearedpolls
+}
+
+// RemovePollIDs removes the "polls" edge to the Poll entity by IDs.
+func (m *TagMutation) RemovePollIDs(ids ...int) {
+	if m.removedpolls == nil {
+		m.removedpolls = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.polls, ids[i])
+		m.removedpolls[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedPolls returns the removed IDs of the "polls" edge to the Poll entity.
+func (m *TagMutation) RemovedPollsIDs() (ids []int) {
+	for id := range m.removedpolls {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// PollsIDs returns the "polls" edge IDs in the mutation.
+func (m *TagMutation) PollsIDs() (ids []int) {
+	for id := range m.polls {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetPolls resets all changes to the "polls" edge.
+func (m *TagMutation) ResetPolls() {
+	m.polls = nil
+	m.clearedpolls = false
+	m.removedpolls = nil
+}
+
+// AddFollowerIDs adds the "followers" edge to the User entity by ids.
+func (m *TagMutation) AddFollowerIDs(ids ...string) {
+	if m.followers == nil {
+		m.followers = make(map[string]struct{})
+	}
+	for i := range ids {
+		m.followers[ids[i]] = struct{}{}
+	}
+}
+
+// ClearFollowers clears the "followers" edge to the User entity.
+func (m *TagMutation) ClearFollowers() {
+	m.clearedfollowers = true
+}
+
+// FollowersCleared reports if the "followers" edge to the User entity was cleared.
+func (m *TagMutation) FollowersCleared() bool {
+	return m.clearedfollowers
+}
+
+// RemoveFollowerIDs removes the "followers" edge to the User entity by IDs.
+func (m *TagMutation) RemoveFollowerIDs(ids ...string) {
+	if m.removedfollowers == nil {
+		m.removedfollowers = make(map[string]struct{})
+	}
+	for i := range ids {
+		delete(m.followers, ids[i])
+		m.removedfollowers[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedFollowers returns the removed IDs of the "followers" edge to the User entity.
+func (m *TagMutation) RemovedFollowersIDs() (ids []string) {
+	for id := range m.removedfollowers {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// FollowersIDs returns the "followers" edge IDs in the mutation.
+func (m *TagMutation) FollowersIDs() (ids []string) {
+	for id := range m.followers {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetFollowers resets all changes to the "followers" edge.
+func (m *TagMutation) ResetFollowers() {
+	m.followers = nil
+	m.clearedfollowers = false
+	m.removedfollowers = nil
+}
+
+// Where appends a list predicates to the TagMutation builder.
+func (m *TagMutation) Where(ps ...predicate.Tag) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the TagMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *TagMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Tag, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *TagMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *TagMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (Tag).
+func (m *TagMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *TagMutation) Fields() []string {
+	fields := make([]string, 0, 3)
+	if m.name != nil {
+		fields = append(fields, tag.FieldName)
+	}
+	if m.slug != nil {
+		fields = append(fields, tag.FieldSlug)
+	}
+	if m.created_at != nil {
+		fields = append(fields, tag.FieldCreatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *TagMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case tag.FieldName:
+		return m.Name()
+	case tag.FieldSlug:
+		return m.Slug()
+	case tag.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *TagMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case tag.FieldName:
+		return m.OldName(ctx)
+	case tag.FieldSlug:
+		return m.OldSlug(ctx)
+	case tag.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown Tag field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *TagMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case tag.FieldName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetName(v)
+		return nil
+	case tag.FieldSlug:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSlug(v)
+		return nil
+	case tag.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Tag field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *TagMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *TagMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *TagMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Tag numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *TagMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *TagMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *TagMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown Tag nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *TagMutation) ResetField(name string) error {
+	switch name {
+	case tag.FieldName:
+		m.ResetName()
+		return nil
+	case tag.FieldSlug:
+		m.ResetSlug()
+		return nil
+	case tag.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown Tag field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *TagMutation) AddedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.polls != nil {
+		edges = append(edges, tag.EdgePolls)
+	}
+	if m.followers != nil {
+		edges = append(edges, tag.EdgeFollowers)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *TagMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case tag.EdgePolls:
+		ids := make([]ent.Value, 0, len(m.polls))
+		for id := range m.polls {
+			ids = append(ids, id)
+		}
+		return ids
+	case tag.EdgeFollowers:
+		ids := make([]ent.Value, 0, len(m.followers))
+		for id := range m.followers {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *TagMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.removedpolls != nil {
+		edges = append(edges, tag.EdgePolls)
+	}
+	if m.removedfollowers != nil {
+		edges = append(edges, tag.EdgeFollowers)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *TagMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case tag.EdgePolls:
+		ids := make([]ent.Value, 0, len(m.removedpolls))
+		for id := range m.removedpolls {
+			ids = append(ids, id)
+		}
+		return ids
+	case tag.EdgeFollowers:
+		ids := make([]ent.Value, 0, len(m.removedfollowers))
+		for id := range m.removedfollowers {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *TagMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.clearedpolls {
+		edges = append(edges, tag.EdgePolls)
+	}
+	if m.clearedfollowers {
+		edges = append(edges, tag.EdgeFollowers)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *TagMutation) EdgeCleared(name string) bool {
+	switch name {
+	case tag.EdgePolls:
+		return m.clearedpolls
+	case tag.EdgeFollowers:
+		return m.clearedfollowers
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *TagMutation) ClearEdge(name string) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Tag unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *TagMutation) ResetEdge(name string) error {
+	switch name {
+	case tag.EdgePolls:
+		m.ResetPolls()
+		return nil
+	case tag.EdgeFollowers:
+		m.ResetFollowers()
+		return nil
+	}
+	return fmt.Errorf("unknown Tag edge %s", name)
+}
+
+// UserMutation represents an operation that mutates the User nodes in the graph.
+type UserMutation struct {
+	config
+	op                     Op
+	typ                    string
+	id                     *string
+	avatar_image           *string
+	email                  *string
+	email_verified_at      *time.Time
+	verification_sent_at   *time.Time
+	name                   *string
+	password               *string
+	active                 *bool
+	role                   *user.Role
+	embed_origins          *[]string
+	appendembed_origins    []string
+	failed_logins          *int
+	addfailed_logins       *int
+	last_failed_login_at   *time.Time
+	locked_until           *time.Time
+	token_version          *int
+	addtoken_version       *int
+	totp_secret            *string
+	totp_enabled_at        *time.Time
+	totp_last_step         *int64
+	addtotp_last_step      *int64
+	recovery_codes         *[]string
+	appendrecovery_codes   []string
+	created_at             *time.Time
+	updated_at             *time.Time
+	clearedFields          map[string]struct{}
+	votes                  map[int]struct{}
+	removedvotes           map[int]struct{}
+	clearedvotes           bool
+	polls                  map[int]struct{}
+	removedpolls           map[int]struct{}
+	clearedpolls           bool
+	poll_revisions         map[int]struct{}
+	removedpoll_revisions  map[int]struct{}
+	clearedpoll_revisions  bool
+	followed_tags          map[int]struct{}
+	removedfollowed_tags   map[int]struct{}
+	clearedfollowed_tags   bool
+	login_attempts         map[int]struct{}
+	removedlogin_attempts  map[int]struct{}
+	clearedlogin_attempts  bool
+	password_resets        map[int]struct{}
+	removedpassword_resets map[int]struct{}
+	clearedpassword_resets bool
+	identities             map[int]struct{}
+	removedidentities      map[int]struct{}
+	clearedidentities      bool
+	done                   bool
+	oldValue               func(context.Context) (*User, error)
+	predicates             []predicate.User
+}
+
+var _ ent.Mutation = (*UserMutation)(nil)
+
+// userOption allows management of the mutation configuration using functional options.
+type userOption func(*UserMutation)
+
+// newUserMutation creates new mutation for the User entity.
+func newUserMutation(c config, op Op, opts ...userOption) *UserMutation {
+	m := &UserMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeUser,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withUserID sets the ID field of the mutation.
+func withUserID(id string) userOption {
+	return func(m *UserMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *User
+		)
+		m.oldValue = func(ctx context.Context) (*User, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().User.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withUser sets the old User of the mutation.
+func withUser(node *User) userOption {
+	return func(m *UserMutation) {
+		m.oldValue = func(context.Context) (*User, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m UserMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m UserMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of User entities.
+func (m *UserMutation) SetID(id string) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *UserMutation) ID() (id string, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *UserMutation) IDs(ctx context.Context) ([]string, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []string{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().User.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetAvatarImage sets the "avatar_image" field.
+func (m *UserMutation) SetAvatarImage(s string) {
+	m.avatar_image = &s
+}
+
+// AvatarImage returns the value of the "avatar_image" field in the mutation.
+func (m *UserMutation) AvatarImage() (r string, exists bool) {
+	v := m.avatar_image
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAvatarImage returns the old "avatar_image" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldAvatarImage(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAvatarImage is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAvatarImage requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAvatarImage: %w", err)
+	}
+	return oldValue.AvatarImage, nil
+}
+
+// ClearAvatarImage clears the value of the "avatar_image" field.
+func (m *UserMutation) ClearAvatarImage() {
+	m.avatar_image = nil
+	m.clearedFields[user.FieldAvatarImage] = struct{}{}
+}
+
+// AvatarImageCleared returns if the "avatar_image" field was cleared in this mutation.
+func (m *UserMutation) AvatarImageCleared() bool {
+	_, ok := m.clearedFields[user.FieldAvatarImage]
+	return ok
+}
+
+// ResetAvatarImage resets all changes to the "avatar_image" field.
+func (m *UserMutation) ResetAvatarImage() {
+	m.avatar_image = nil
+	delete(m.clearedFields, user.FieldAvatarImage)
+}
+
+// SetEmail sets the "email" field.
+func (m *UserMutation) SetEmail(s string) {
+	m.email = &s
+}
+
+// Email returns the value of the "email" field in the mutation.
+func (m *UserMutation) Email() (r string, exists bool) {
+	v := m.email
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEmail returns the old "email" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldEmail(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEmail is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEmail requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEmail: %w", err)
+	}
+	return oldValue.Email, nil
+}
+
+// ResetEmail resets all changes to the "email" field.
+func (m *UserMutation) ResetEmail() {
+	m.email = nil
+}
+
+// SetEmailVerifiedAt sets the "email_verified_at" field.
+func (m *UserMutation) SetEmailVerifiedAt(t time.Time) {
+	m.email_verified_at = &t
+}
+
+// EmailVerifiedAt returns the value of the "email_verified_at" field in the mutation.
+func (m *UserMutation) EmailVerifiedAt() (r time.Time, exists bool) {
+	v := m.email_verified_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEmailVerifiedAt returns the old "email_verified_at" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldEmailVerifiedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEmailVerifiedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEmailVerifiedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEmailVerifiedAt: %w", err)
+	}
+	return oldValue.EmailVerifiedAt, nil
+}
+
+// ClearEmailVerifiedAt clears the value of the "email_verified_at" field.
+func (m *UserMutation) ClearEmailVerifiedAt() {
+	m.email_verified_at = nil
+	m.clearedFields[user.FieldEmailVerifiedAt] = struct{}{}
+}
+
+// EmailVerifiedAtCleared returns if the "email_verified_at" field was cleared in this mutation.
+func (m *UserMutation) EmailVerifiedAtCleared() bool {
+	_, ok := m.clearedFields[user.FieldEmailVerifiedAt]
+	return ok
+}
+
+// ResetEmailVerifiedAt resets all changes to the "email_verified_at" field.
+func (m *UserMutation) ResetEmailVerifiedAt() {
+	m.email_verified_at = nil
+	delete(m.clearedFields, user.FieldEmailVerifiedAt)
+}
+
+// SetVerificationSentAt sets the "verification_sent_at" field.
+func (m *UserMutation) SetVerificationSentAt(t time.Time) {
+	m.verification_sent_at = &t
+}
+
+// VerificationSentAt returns the value of the "verification_sent_at" field in the mutation.
+func (m *UserMutation) VerificationSentAt() (r time.Time, exists bool) {
+	v := m.verification_sent_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldVerificationSentAt returns the old "verification_sent_at" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldVerificationSentAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldVerificationSentAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldVerificationSentAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldVerificationSentAt: %w", err)
+	}
+	return oldValue.VerificationSentAt, nil
+}
+
+// ClearVerificationSentAt clears the value of the "verification_sent_at" field.
+func (m *UserMutation) ClearVerificationSentAt() {
+	m.verification_sent_at = nil
+	m.clearedFields[user.FieldVerificationSentAt] = struct{}{}
+}
+
+// VerificationSentAtCleared returns if the "verification_sent_at" field was cleared in this mutation.
+func (m *UserMutation) VerificationSentAtCleared() bool {
+	_, ok := m.clearedFields[user.FieldVerificationSentAt]
+	return ok
+}
+
+// ResetVerificationSentAt resets all changes to the "verification_sent_at" field.
+func (m *UserMutation) ResetVerificationSentAt() {
+	m.verification_sent_at = nil
+	delete(m.clearedFields, user.FieldVerificationSentAt)
+}
+
+// SetName sets the "name" field.
+func (m *UserMutation) SetName(s string) {
+	m.name = &s
+}
+
+// Name returns the value of the "name" field in the mutation.
+func (m *UserMutation) Name() (r string, exists bool) {
+	v := m.name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldName returns the old "name" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
+	}
+	return oldValue.Name, nil
+}
+
+// ResetName resets all changes to the "name" field.
+func (m *UserMutation) ResetName() {
+	m.name = nil
+}
+
+// SetPassword sets the "password" field.
+func (m *UserMutation) SetPassword(s string) {
+	m.password = &s
+}
+
+// Password returns the value of the "password" field in the mutation.
+func (m *UserMutation) Password() (r string, exists bool) {
+	v := m.password
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPassword returns the old "password" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldPassword(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPassword is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPassword requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPassword: %w", err)
+	}
+	return oldValue.Password, nil
+}
+
+// ResetPassword resets all changes to the "password" field.
+func (m *UserMutation) ResetPassword() {
+	m.password = nil
+}
+
+// SetActive sets the "active" field.
+func (m *UserMutation) SetActive(b bool) {
+	m.active = &b
+}
+
+// Active returns the value of the "active" field in the mutation.
+func (m *UserMutation) Active() (r bool, exists bool) {
+	v := m.active
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldActive returns the old "active" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldActive(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldActive is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldActive requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldActive: %w", err)
+	}
+	return oldValue.Active, nil
+}
+
+// ResetActive resets all changes to the "active" field.
+func (m *UserMutation) ResetActive() {
+	m.active = nil
+}
+
+// SetRole sets the "role" field.
+func (m *UserMutation) SetRole(u user.Role) {
+	m.role = &u
+}
+
+// Role returns the value of the "role" field in the mutation.
+func (m *UserMutation) Role() (r user.Role, exists bool) {
+	v := m.role
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRole returns the old "role" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldRole(ctx context.Context) (v user.Role, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRole is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRole requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRole: %w", err)
+	}
+	return oldValue.Role, nil
+}
+
+// ResetRole resets all changes to the "role" field.
+func (m *UserMutation) ResetRole() {
+	m.role = nil
+}
+
+// SetEmbedOrigins sets the "embed_origins" field.
+func (m *UserMutation) SetEmbedOrigins(s []string) {
+	m.embed_origins = &s
+	m.appendembed_origins = nil
+}
+
+// EmbedOrigins returns the value of the "embed_origins" field in the mutation.
+func (m *UserMutation) EmbedOrigins() (r []string, exists bool) {
+	v := m.embed_origins
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEmbedOrigins returns the old "embed_origins" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldEmbedOrigins(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEmbedOrigins is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEmbedOrigins requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEmbedOrigins: %w", err)
+	}
+	return oldValue.EmbedOrigins, nil
+}
+
+// AppendEmbedOrigins adds s to the "embed_origins" field.
+func (m *UserMutation) AppendEmbedOrigins(s []string) {
+	m.appendembed_origins = append(m.appendembed_origins, s...)
+}
+
+// AppendedEmbedOrigins returns the list of values that were appended to the "embed_origins" field in this mutation.
+func (m *UserMutation) AppendedEmbedOrigins() ([]string, bool) {
+	if len(m.appendembed_origins) == 0 {
+		return nil, false
+	}
+	return m.appendembed_origins, true
+}
+
+// ClearEmbedOrigins clears the value of the "embed_origins" field.
+func (m *UserMutation) ClearEmbedOrigins() {
+	m.embed_origins = nil
+	m.appendembed_origins = nil
+	m.clearedFields[user.FieldEmbedOrigins] = struct{}{}
+}
+
+// EmbedOriginsCleared returns if the "embed_origins" field was cleared in this mutation.
+func (m *UserMutation) EmbedOriginsCleared() bool {
+	_, ok := m.clearedFields[user.FieldEmbedOrigins]
+	return ok
+}
+
+// ResetEmbedOrigins resets all changes to the "embed_origins" field.
+func (m *UserMutation) ResetEmbedOrigins() {
+	m.embed_origins = nil
+	m.appendembed_origins = nil
+	delete(m.clearedFields, user.FieldEmbedOrigins)
+}
+
+// SetFailedLogins sets the "failed_logins" field.
+func (m *UserMutation) SetFailedLogins(i int) {
+	m.failed_logins = &i
+	m.addfailed_logins = nil
+}
+
+// FailedLogins returns the value of the "failed_logins" field in the mutation.
+func (m *UserMutation) FailedLogins() (r int, exists bool) {
+	v := m.failed_logins
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldFailedLogins returns the old "failed_logins" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldFailedLogins(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldFailedLogins is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldFailedLogins requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldFailedLogins: %w", err)
+	}
+	return oldValue.FailedLogins, nil
+}
+
+// AddFailedLogins adds i to the "failed_logins" field.
+func (m *UserMutation) AddFailedLogins(i int) {
+	if m.addfailed_logins != nil {
+		*m.addfailed_logins += i
+	} else {
+		m.addfailed_logins = &i
+	}
+}
+
+// AddedFailedLogins returns the value that was added to the "failed_logins" field in this mutation.
+func (m *UserMutation) AddedFailedLogins() (r int, exists bool) {
+	v := m.addfailed_logins
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetFailedLogins resets all changes to the "failed_logins" field.
+func (m *UserMutation) ResetFailedLogins() {
+	m.failed_logins = nil
+	m.addfailed_logins = nil
+}
+
+// SetLastFailedLoginAt sets the "last_failed_login_at" field.
+func (m *UserMutation) SetLastFailedLoginAt(t time.Time) {
+	m.last_failed_login_at = &t
+}
+
+// LastFailedLoginAt returns the value of the "last_failed_login_at" field in the mutation.
+func (m *UserMutation) LastFailedLoginAt() (r time.Time, exists bool) {
+	v := m.last_failed_login_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLastFailedLoginAt returns the old "last_failed_login_at" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldLastFailedLoginAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLastFailedLoginAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLastFailedLoginAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLastFailedLoginAt: %w", err)
+	}
+	return oldValue.LastFailedLoginAt, nil
+}
+
+// ClearLastFailedLoginAt clears the value of the "last_failed_login_at" field.
+func (m *UserMutation) ClearLastFailedLoginAt() {
+	m.last_failed_login_at = nil
+	m.clearedFields[user.FieldLastFailedLoginAt] = struct{}{}
+}
+
+// LastFailedLoginAtCleared returns if the "last_failed_login_at" field was cleared in this mutation.
+func (m *UserMutation) LastFailedLoginAtCleared() bool {
+	_, ok := m.clearedFields[user.FieldLastFailedLoginAt]
+	return ok
+}
+
+// ResetLastFailedLoginAt resets all changes to the "last_failed_login_at" field.
+func (m *UserMutation) ResetLastFailedLoginAt() {
+	m.last_failed_login_at = nil
+	delete(m.clearedFields, user.FieldLastFailedLoginAt)
+}
+
+// SetLockedUntil sets the "locked_until" field.
+func (m *UserMutation) SetLockedUntil(t time.Time) {
+	m.locked_until = &t
+}
+
+// LockedUntil returns the value of the "locked_until" field in the mutation.
+func (m *UserMutation) LockedUntil() (r time.Time, exists bool) {
+	v := m.locked_until
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLockedUntil returns the old "locked_until" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldLockedUntil(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLockedUntil is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLockedUntil requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLockedUntil: %w", err)
+	}
+	return oldValue.LockedUntil, nil
+}
+
+// ClearLockedUntil clears the value of the "locked_until" field.
+func (m *UserMutation) ClearLockedUntil() {
+	m.locked_until = nil
+	m.clearedFields[user.FieldLockedUntil] = struct{}{}
+}
+
+// LockedUntilCleared returns if the "locked_until" field was cleared in this mutation.
+func (m *UserMutation) LockedUntilCleared() bool {
+	_, ok := m.clearedFields[user.FieldLockedUntil]
+	return ok
+}
+
+// ResetLockedUntil resets all changes to the "locked_until" field.
+func (m *UserMutation) ResetLockedUntil() {
+	m.locked_until = nil
+	delete(m.clearedFields, user.FieldLockedUntil)
+}
+
+// SetTokenVersion sets the "token_version" field.
+func (m *UserMutation) SetTokenVersion(i int) {
+	m.token_version = &i
+	m.addtoken_version = nil
+}
+
+// TokenVersion returns the value of the "token_version" field in the mutation.
+func (m *UserMutation) TokenVersion() (r int, exists bool) {
+	v := m.token_version
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTokenVersion returns the old "token_version" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldTokenVersion(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTokenVersion is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTokenVersion requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTokenVersion: %w", err)
+	}
+	return oldValue.TokenVersion, nil
+}
+
+// AddTokenVersion adds i to the "token_version" field.
+func (m *UserMutation) AddTokenVersion(i int) {
+	if m.addtoken_version != nil {
+		*m.addtoken_version += i
+	} else {
+		m.addtoken_version = &i
+	}
+}
+
+// AddedTokenVersion returns the value that was added to the "token_version" field in this mutation.
+func (m *UserMutation) AddedTokenVersion() (r int, exists bool) {
+	v := m.addtoken_version
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetTokenVersion resets all changes to the "token_version" field.
+func (m *UserMutation) ResetTokenVersion() {
+	m.token_version = nil
+	m.addtoken_version = nil
+}
+
+// SetTotpSecret sets the "totp_secret" field.
+func (m *UserMutation) SetTotpSecret(s string) {
+	m.totp_secret = &s
+}
+
+// TotpSecret returns the value of the "totp_secret" field in the mutation.
+func (m *UserMutation) TotpSecret() (r string, exists bool) {
+	v := m.totp_secret
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTotpSecret returns the old "totp_secret" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldTotpSecret(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTotpSecret is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTotpSecret requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTotpSecret: %w", err)
+	}
+	return oldValue.TotpSecret, nil
+}
+
+// ClearTotpSecret clears the value of the "totp_secret" field.
+func (m *UserMutation) ClearTotpSecret() {
+	m.totp_secret = nil
+	m.clearedFields[user.FieldTotpSecret] = struct{}{}
+}
+
+// TotpSecretCleared returns if the "totp_secret" field was cleared in this mutation.
+func (m *UserMutation) TotpSecretCleared() bool {
+	_, ok := m.clearedFields[user.FieldTotpSecret]
+	return ok
+}
+
+// ResetTotpSecret resets all changes to the "totp_secret" field.
+func (m *UserMutation) ResetTotpSecret() {
+	m.totp_secret = nil
+	delete(m.clearedFields, user.FieldTotpSecret)
+}
+
+// SetTotpEnabledAt sets the "totp_enabled_at" field.
+func (m *UserMutation) SetTotpEnabledAt(t time.Time) {
+	m.totp_enabled_at = &t
+}
+
+// TotpEnabledAt returns the value of the "totp_enabled_at" field in the mutation.
+func (m *UserMutation) TotpEnabledAt() (r time.Time, exists bool) {
+	v := m.totp_enabled_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTotpEnabledAt returns the old "totp_enabled_at" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldTotpEnabledAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTotpEnabledAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTotpEnabledAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTotpEnabledAt: %w", err)
+	}
+	return oldValue.TotpEnabledAt, nil
+}
+
+// ClearTotpEnabledAt clears the value of the "totp_enabled_at" field.
+func (m *UserMutation) ClearTotpEnabledAt() {
+	m.totp_enabled_at = nil
+	m.clearedFields[user.FieldTotpEnabledAt] = struct{}{}
+}
+
+// TotpEnabledAtCleared returns if the "totp_enabled_at" field was cleared in this mutation.
+func (m *UserMutation) TotpEnabledAtCleared() bool {
+	_, ok := m.clearedFields[user.FieldTotpEnabledAt]
+	return ok
+}
+
+// ResetTotpEnabledAt resets all changes to the "totp_enabled_at" field.
+func (m *UserMutation) ResetTotpEnabledAt() {
+	m.totp_enabled_at = nil
+	delete(m.clearedFields, user.FieldTotpEnabledAt)
+}
+
+// SetTotpLastStep sets the "totp_last_step" field.
+func (m *UserMutation) SetTotpLastStep(i int64) {
+	m.totp_last_step = &i
+	m.addtotp_last_step = nil
+}
+
+// TotpLastStep returns the value of the "totp_last_step" field in the mutation.
+func (m *UserMutation) TotpLastStep() (r int64, exists bool) {
+	v := m.totp_last_step
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTotpLastStep returns the old "totp_last_step" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldTotpLastStep(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTotpLastStep is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTotpLastStep requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTotpLastStep: %w", err)
+	}
+	return oldValue.TotpLastStep, nil
+}
+
+// AddTotpLastStep adds i to the "totp_last_step" field.
+func (m *UserMutation) AddTotpLastStep(i int64) {
+	if m.addtotp_last_step != nil {
+		*m.addtotp_last_step += i
+	} else {
+		m.addtotp_last_step = &i
+	}
+}
+
+// AddedTotpLastStep returns the value that was added to the "totp_last_step" field in this mutation.
+func (m *UserMutation) AddedTotpLastStep() (r int64, exists bool) {
+	v := m.addtotp_last_step
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetTotpLastStep resets all changes to the "totp_last_step" field.
+func (m *UserMutation) ResetTotpLastStep() {
+	m.totp_last_step = nil
+	m.addtotp_last_step = nil
+}
+
+// SetRecoveryCodes sets the "recovery_codes" field.
+func (m *UserMutation) SetRecoveryCodes(s []string) {
+	m.recovery_codes = &s
+	m.appendrecovery_codes = nil
+}
+
+// RecoveryCodes returns the value of the "recovery_codes" field in the mutation.
+func (m *UserMutation) RecoveryCodes() (r []string, exists bool) {
+	v := m.recovery_codes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRecoveryCodes returns the old "recovery_codes" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldRecoveryCodes(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRecoveryCodes is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRecoveryCodes requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRecoveryCodes: %w", err)
+	}
+	return oldValue.RecoveryCodes, nil
+}
+
+// AppendRecoveryCodes adds s to the "recovery_codes" field.
+func (m *UserMutation) AppendRecoveryCodes(s []string) {
+	m.appendrecovery_codes = append(m.appendrecovery_codes, s...)
+}
+
+// AppendedRecoveryCodes returns the list of values that were appended to the "recovery_codes" field in this mutation.
+func (m *UserMutation) AppendedRecoveryCodes() ([]string, bool) {
+	if len(m.appendrecovery_codes) == 0 {
+		return nil, false
+	}
+	return m.appendrecovery_codes, true
+}
+
+// ClearRecoveryCodes clears the value of the "recovery_codes" field.
+func (m *UserMutation) ClearRecoveryCodes() {
+	m.recovery_codes = nil
+	m.appendrecovery_codes = nil
+	m.clearedFields[user.FieldRecoveryCodes] = struct{}{}
+}
+
+// RecoveryCodesCleared returns if the "recovery_codes" field was cleared in this mutation.
+func (m *UserMutation) RecoveryCodesCleared() bool {
+	_, ok := m.clearedFields[user.FieldRecoveryCodes]
+	return ok
+}
+
+// ResetRecoveryCodes resets all changes to the "recovery_codes" field.
+func (m *UserMutation) ResetRecoveryCodes() {
+	m.recovery_codes = nil
+	m.appendrecovery_codes = nil
+	delete(m.clearedFields, user.FieldRecoveryCodes)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *UserMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *UserMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *UserMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (m *UserMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *UserMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "updated_at" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
 		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
 	}
-	return oldValue.UpdatedAt, nil
+	return oldValue.UpdatedAt, nil
+}
+
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *UserMutation) ResetUpdatedAt() {
+	m.updated_at = nil
+}
+
+// AddVoteIDs adds the "votes" edge to the Vote entity by ids.
+func (m *UserMutation) AddVoteIDs(ids ...int) {
+	if m.votes == nil {
+		m.votes = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.votes[ids[i]] = struct{}{}
+	}
+}
+
+// ClearVotes clears the "votes" edge to the Vote entity.
+func (m *UserMutation) ClearVotes() {
+	m.clearedvotes = true
+}
+
+// VotesCleared reports if the "votes" edge to the Vote entity was cleared.
+func (m *UserMutation) VotesCleared() bool {
+	return m.clearedvotes
+}
+
+// RemoveVoteIDs removes the "votes" edge to the Vote entity by IDs.
+func (m *UserMutation) RemoveVoteIDs(ids ...int) {
+	if m.removedvotes == nil {
+		m.removedvotes = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.votes, ids[i])
+		m.removedvotes[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedVotes returns the removed IDs of the "votes" edge to the Vote entity.
+func (m *UserMutation) RemovedVotesIDs() (ids []int) {
+	for id := range m.removedvotes {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// VotesIDs returns the "votes" edge IDs in the mutation.
+func (m *UserMutation) VotesIDs() (ids []int) {
+	for id := range m.votes {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetVotes resets all changes to the "votes" edge.
+func (m *UserMutation) ResetVotes() {
+	m.votes = nil
+	m.clearedvotes = false
+	m.removedvotes = nil
+}
+
+// AddPollIDs adds the "polls" edge to the Poll entity by ids.
+func (m *UserMutation) AddPollIDs(ids ...int) {
+	if m.polls == nil {
+		m.polls = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.polls[ids[i]] = struct{}{}
+	}
+}
+
+// ClearPolls clears the "polls" edge to the Poll entity.
+func (m *UserMutation) ClearPolls() {
+	m.clearedpolls = true
+}
+
+// PollsCleared reports if the "polls" edge to the Poll entity was cleared.
+func (m *UserMutation) PollsCleared() bool {
+	return m.clearedpolls
+}
+
+// RemovePollIDs removes the "polls" edge to the Poll entity by IDs.
+func (m *UserMutation) RemovePollIDs(ids ...int) {
+	if m.removedpolls == nil {
+		m.removedpolls = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.polls, ids[i])
+		m.removedpolls[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedPolls returns the removed IDs of the "polls" edge to the Poll entity.
+func (m *UserMutation) RemovedPollsIDs() (ids []int) {
+	for id := range m.removedpolls {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// PollsIDs returns the "polls" edge IDs in the mutation.
+func (m *UserMutation) PollsIDs() (ids []int) {
+	for id := range m.polls {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetPolls resets all changes to the "polls" edge.
+func (m *UserMutation) ResetPolls() {
+	m.polls = nil
+	m.clearedpolls = false
+	m.removedpolls = nil
+}
+
+// AddPollRevisionIDs adds the "poll_revisions" edge to the PollRevision entity by ids.
+func (m *UserMutation) AddPollRevisionIDs(ids ...int) {
+	if m.poll_revisions == nil {
+		m.poll_revisions = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.poll_revisions[ids[i]] = struct{}{}
+	}
+}
+
+// ClearPollRevisions clears the "poll_revisions" edge to the PollRevision entity.
+func (m *UserMutation) ClearPollRevisions() {
+	m.clearedpoll_revisions = true
+}
+
+// PollRevisionsCleared reports if the "poll_revisions" edge to the PollRevision entity was cleared.
+func (m *UserMutation) PollRevisionsCleared() bool {
+	return m.clearedpoll_revisions
+}
+
+// RemovePollRevisionIDs removes the "poll_revisions" edge to the PollRevision entity by IDs.
+func (m *UserMutation) RemovePollRevisionIDs(ids ...int) {
+	if m.removedpoll_revisions == nil {
+		m.removedpoll_revisions = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.poll_revisions, ids[i])
+		m.removedpoll_revisions[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedPollRevisions returns the removed IDs of the "poll_revisions" edge to the PollRevision entity.
+func (m *UserMutation) RemovedPollRevisionsIDs() (ids []int) {
+	for id := range m.removedpoll_revisions {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// PollRevisionsIDs returns the "poll_revisions" edge IDs in the mutation.
+func (m *UserMutation) PollRevisionsIDs() (ids []int) {
+	for id := range m.poll_revisions {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetPollRevisions resets all changes to the "poll_revisions" edge.
+func (m *UserMutation) ResetPollRevisions() {
+	m.poll_revisions = nil
+	m.clearedpoll_revisions = false
+	m.removedpoll_revisions = nil
+}
+
+// AddFollowedTagIDs adds the "followed_tags" edge to the Tag entity by ids.
+func (m *UserMutation) AddFollowedTagIDs(ids ...int) {
+	if m.followed_tags == nil {
+		m.followed_tags = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.followed_tags[ids[i]] = struct{}{}
+	}
+}
+
+// ClearFollowedTags clears the "followed_tags" edge to the Tag entity.
+func (m *UserMutation) ClearFollowedTags() {
+	m.clearedfollowed_tags = true
+}
+
+// FollowedTagsCleared reports if the "followed_tags" edge to the Tag entity was cleared.
+func (m *UserMutation) FollowedTagsCleared() bool {
+	return m.clearedfollowed_tags
+}
+
+// RemoveFollowedTagIDs removes the "followed_tags" edge to the Tag entity by IDs.
+func (m *UserMutation) RemoveFollowedTagIDs(ids ...int) {
+	if m.removedfollowed_tags == nil {
+		m.removedfollowed_tags = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.followed_tags, ids[i])
+		m.removedfollowed_tags[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedFollowedTags returns the removed IDs of the "followed_tags" edge to the Tag entity.
+func (m *UserMutation) RemovedFollowedTagsIDs() (ids []int) {
+	for id := range m.removedfollowed_tags {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// FollowedTagsIDs returns the "followed_tags" edge IDs in the mutation.
+func (m *UserMutation) FollowedTagsIDs() (ids []int) {
+	for id := range m.followed_tags {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetFollowedTags resets all changes to the "followed_tags" edge.
+func (m *UserMutation) ResetFollowedTags() {
+	m.followed_tags = nil
+	m.clearedfollowed_tags = false
+	m.removedfollowed_tags = nil
+}
+
+// AddLoginAttemptIDs adds the "login_attempts" edge to the LoginAttempt entity by ids.
+func (m *UserMutation) AddLoginAttemptIDs(ids ...int) {
+	if m.login_attempts == nil {
+		m.login_attempts = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.login_attempts[ids[i]] = struct{}{}
+	}
+}
+
+// ClearLoginAttempts clears the "login_attempts" edge to the LoginAttempt entity.
+func (m *UserMutation) ClearLoginAttempts() {
+	m.clearedlogin_attempts = true
+}
+
+// LoginAttemptsCleared reports if the "login_attempts" edge to the LoginAttempt entity was cleared.
+func (m *UserMutation) LoginAttemptsCleared() bool {
+	return m.clearedlogin_attempts
+}
+
+// RemoveLoginAttemptIDs removes the "login_attempts" edge to the LoginAttempt entity by IDs.
+func (m *UserMutation) RemoveLoginAttemptIDs(ids ...int) {
+	if m.removedlogin_attempts == nil {
+		m.removedlogin_attempts = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.login_attempts, ids[i])
+		m.removedlogin_attempts[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedLoginAttempts returns the removed IDs of the "login_attempts" edge to the LoginAttempt entity.
+func (m *UserMutation) RemovedLoginAttemptsIDs() (ids []int) {
+	for id := range m.removedlogin_attempts {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// LoginAttemptsIDs returns the "login_attempts" edge IDs in the mutation.
+func (m *UserMutation) LoginAttemptsIDs() (ids []int) {
+	for id := range m.login_attempts {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetLoginAttempts resets all changes to the "login_attempts" edge.
+func (m *UserMutation) ResetLoginAttempts() {
+	m.login_attempts = nil
+	m.clearedlogin_attempts = false
+	m.removedlogin_attempts = nil
+}
+
+// AddPasswordResetIDs adds the "password_resets" edge to the PasswordReset entity by ids.
+func (m *UserMutation) AddPasswordResetIDs(ids ...int) {
+	if m.password_resets == nil {
+		m.password_resets = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.password_resets[ids[i]] = struct{}{}
+	}
+}
+
+// ClearPasswordResets clears the "password_resets" edge to the PasswordReset entity.
+func (m *UserMutation) ClearPasswordResets() {
+	m.clearedpassword_resets = true
+}
+
+// PasswordResetsCleared reports if the "password_resets" edge to the PasswordReset entity was cleared.
+func (m *UserMutation) PasswordResetsCleared() bool {
+	return m.clearedpassword_resets
+}
+
+// RemovePasswordResetIDs removes the "password_resets" edge to the PasswordReset entity by IDs.
+func (m *UserMutation) RemovePasswordResetIDs(ids ...int) {
+	if m.removedpassword_resets == nil {
+		m.removedpassword_resets = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.password_resets, ids[i])
+		m.removedpassword_resets[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedPasswordResets returns the removed IDs of the "password_resets" edge to the PasswordReset entity.
+func (m *UserMutation) RemovedPasswordResetsIDs() (ids []int) {
+	for id := range m.removedpassword_resets {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// PasswordResetsIDs returns the "password_resets" edge IDs in the mutation.
+func (m *UserMutation) PasswordResetsIDs() (ids []int) {
+	for id := range m.password_resets {
+		ids = append(ids, id)
+	}
+	return
 }
 
-// ResetUpdatedAt resets all changes to the "updated_at" field.
-func (m *UserMutation) ResetUpdatedAt() {
-	m.updated_at = nil
+// ResetPasswordResets resets all changes to the "password_resets" edge.
+func (m *UserMutation) ResetPasswordResets() {
+	m.password_resets = nil
+	m.clearedpassword_resets = false
+	m.removedpassword_resets = nil
 }
 
-// AddVoteIDs adds the "votes" edge to the Vote entity by ids.
-func (m *UserMutation) AddVoteIDs(ids ...int) {
-	if m.votes == nil {
-		m.votes = make(map[int]struct{})
+// AddIdentityIDs adds the "identities" edge to the LinkedIdentity entity by ids.
+func (m *UserMutation) AddIdentityIDs(ids ...int) {
+	if m.identities == nil {
+		m.identities = make(map[int]struct{})
 	}
 	for i := range ids {
-		m.votes[ids[i]] = struct{}{}
+		m.identities[ids[i]] = struct{}{}
 	}
 }
 
-// ClearVotes clears the "votes" edge to the Vote entity.
-func (m *UserMutation) ClearVotes() {
-	m.clearedvotes = true
+// ClearIdentities clears the "identities" edge to the LinkedIdentity entity.
+func (m *UserMutation) ClearIdentities() {
+	m.clearedidentities = true
 }
 
-// VotesCleared reports if the "votes" edge to the Vote entity was cleared.
-func (m *UserMutation) VotesCleared() bool {
-	return m.clearedvotes
+// IdentitiesCleared reports if the "identities" edge to the LinkedIdentity entity was cleared.
+func (m *UserMutation) IdentitiesCleared() bool {
+	return m.clearedidentities
 }
 
-// RemoveVoteIDs removes the "votes" edge to the Vote entity by IDs.
-func (m *UserMutation) RemoveVoteIDs(ids ...int) {
-	if m.removedvotes == nil {
-		m.removedvotes = make(map[int]struct{})
+// RemoveIdentityIDs removes the "identities" edge to the LinkedIdentity entity by IDs.
+func (m *UserMutation) RemoveIdentityIDs(ids ...int) {
+	if m.removedidentities == nil {
+		m.removedidentities = make(map[int]struct{})
 	}
 	for i := range ids {
-		delete(m.votes, ids[i])
-		m.removedvotes[ids[i]] = struct{}{}
+		delete(m.identities, ids[i])
+		m.removedidentities[ids[i]] = struct{}{}
 	}
 }
 
-// RemovedVotes returns the removed IDs of the "votes" edge to the Vote entity.
-func (m *UserMutation) RemovedVotesIDs() (ids []int) {
-	for id := range m.removedvotes {
+// RemovedIdentities returns the removed IDs of the "identities" edge to the LinkedIdentity entity.
+func (m *UserMutation) RemovedIdentitiesIDs() (ids []int) {
+	for id := range m.removedidentities {
 		ids = append(ids, id)
 	}
 	return
 }
 
-// VotesIDs returns the "votes" edge IDs in the mutation.
-func (m *UserMutation) VotesIDs() (ids []int) {
-	for id := range m.votes {
+// IdentitiesIDs returns the "identities" edge IDs in the mutation.
+func (m *UserMutation) IdentitiesIDs() (ids []int) {
+	for id := range m.identities {
 		ids = append(ids, id)
 	}
 	return
 }
 
-// ResetVotes resets all changes to the "votes" edge.
-func (m *UserMutation) ResetVotes() {
-	m.votes = nil
-	m.clearedvotes = false
-	m.removedvotes = nil
+// ResetIdentities resets all changes to the "identities" edge.
+func (m *UserMutation) ResetIdentities() {
+	m.identities = nil
+	m.clearedidentities = false
+	m.removedidentities = nil
 }
 
 // Where appends a list predicates to the UserMutation builder.
@@ -1690,13 +9162,19 @@ func (m *UserMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *UserMutation) Fields() []string {
-	fields := make([]string, 0, 7)
+	fields := make([]string, 0, 19)
 	if m.avatar_image != nil {
 		fields = append(fields, user.FieldAvatarImage)
 	}
 	if m.email != nil {
 		fields = append(fields, user.FieldEmail)
 	}
+	if m.email_verified_at != nil {
+		fields = append(fields, user.FieldEmailVerifiedAt)
+	}
+	if m.verification_sent_at != nil {
+		fields = append(fields, user.FieldVerificationSentAt)
+	}
 	if m.name != nil {
 		fields = append(fields, user.FieldName)
 	}
@@ -1706,6 +9184,36 @@ func (m *UserMutation) Fields() []string {
 	if m.active != nil {
 		fields = append(fields, user.FieldActive)
 	}
+	if m.role != nil {
+		fields = append(fields, user.FieldRole)
+	}
+	if m.embed_origins != nil {
+		fields = append(fields, user.FieldEmbedOrigins)
+	}
+	if m.failed_logins != nil {
+		fields = append(fields, user.FieldFailedLogins)
+	}
+	if m.last_failed_login_at != nil {
+		fields = append(fields, user.FieldLastFailedLoginAt)
+	}
+	if m.locked_until != nil {
+		fields = append(fields, user.FieldLockedUntil)
+	}
+	if m.token_version != nil {
+		fields = append(fields, user.FieldTokenVersion)
+	}
+	if m.totp_secret != nil {
+		fields = append(fields, user.FieldTotpSecret)
+	}
+	if m.totp_enabled_at != nil {
+		fields = append(fields, user.FieldTotpEnabledAt)
+	}
+	if m.totp_last_step != nil {
+		fields = append(fields, user.FieldTotpLastStep)
+	}
+	if m.recovery_codes != nil {
+		fields = append(fields, user.FieldRecoveryCodes)
+	}
 	if m.created_at != nil {
 		fields = append(fields, user.FieldCreatedAt)
 	}
@@ -1724,12 +9232,36 @@ func (m *UserMutation) Field(name string) (ent.Value, bool) {
 		return m.AvatarImage()
 	case user.FieldEmail:
 		return m.Email()
+	case user.FieldEmailVerifiedAt:
+		return m.EmailVerifiedAt()
+	case user.FieldVerificationSentAt:
+		return m.VerificationSentAt()
 	case user.FieldName:
 		return m.Name()
 	case user.FieldPassword:
 		return m.Password()
 	case user.FieldActive:
 		return m.Active()
+	case user.FieldRole:
+		return m.Role()
+	case user.FieldEmbedOrigins:
+		return m.EmbedOrigins()
+	case user.FieldFailedLogins:
+		return m.FailedLogins()
+	case user.FieldLastFailedLoginAt:
+		return m.LastFailedLoginAt()
+	case user.FieldLockedUntil:
+		return m.LockedUntil()
+	case user.FieldTokenVersion:
+		return m.TokenVersion()
+	case user.FieldTotpSecret:
+		return m.TotpSecret()
+	case user.FieldTotpEnabledAt:
+		return m.TotpEnabledAt()
+	case user.FieldTotpLastStep:
+		return m.TotpLastStep()
+	case user.FieldRecoveryCodes:
+		return m.RecoveryCodes()
 	case user.FieldCreatedAt:
 		return m.CreatedAt()
 	case user.FieldUpdatedAt:
@@ -1747,12 +9279,36 @@ func (m *UserMutation) OldField(ctx context.Context, name string) (ent.Value, er
 		return m.OldAvatarImage(ctx)
 	case user.FieldEmail:
 		return m.OldEmail(ctx)
+	case user.FieldEmailVerifiedAt:
+		return m.OldEmailVerifiedAt(ctx)
+	case user.FieldVerificationSentAt:
+		return m.OldVerificationSentAt(ctx)
 	case user.FieldName:
 		return m.OldName(ctx)
 	case user.FieldPassword:
 		return m.OldPassword(ctx)
 	case user.FieldActive:
 		return m.OldActive(ctx)
+	case user.FieldRole:
+		return m.OldRole(ctx)
+	case user.FieldEmbedOrigins:
+		return m.OldEmbedOrigins(ctx)
+	case user.FieldFailedLogins:
+		return m.OldFailedLogins(ctx)
+	case user.FieldLastFailedLoginAt:
+		return m.OldLastFailedLoginAt(ctx)
+	case user.FieldLockedUntil:
+		return m.OldLockedUntil(ctx)
+	case user.FieldTokenVersion:
+		return m.OldTokenVersion(ctx)
+	case user.FieldTotpSecret:
+		return m.OldTotpSecret(ctx)
+	case user.FieldTotpEnabledAt:
+		return m.OldTotpEnabledAt(ctx)
+	case user.FieldTotpLastStep:
+		return m.OldTotpLastStep(ctx)
+	case user.FieldRecoveryCodes:
+		return m.OldRecoveryCodes(ctx)
 	case user.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
 	case user.FieldUpdatedAt:
@@ -1780,6 +9336,20 @@ func (m *UserMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetEmail(v)
 		return nil
+	case user.FieldEmailVerifiedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEmailVerifiedAt(v)
+		return nil
+	case user.FieldVerificationSentAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetVerificationSentAt(v)
+		return nil
 	case user.FieldName:
 		v, ok := value.(string)
 		if !ok {
@@ -1801,6 +9371,76 @@ func (m *UserMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetActive(v)
 		return nil
+	case user.FieldRole:
+		v, ok := value.(user.Role)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRole(v)
+		return nil
+	case user.FieldEmbedOrigins:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEmbedOrigins(v)
+		return nil
+	case user.FieldFailedLogins:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetFailedLogins(v)
+		return nil
+	case user.FieldLastFailedLoginAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLastFailedLoginAt(v)
+		return nil
+	case user.FieldLockedUntil:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLockedUntil(v)
+		return nil
+	case user.FieldTokenVersion:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTokenVersion(v)
+		return nil
+	case user.FieldTotpSecret:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTotpSecret(v)
+		return nil
+	case user.FieldTotpEnabledAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTotpEnabledAt(v)
+		return nil
+	case user.FieldTotpLastStep:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTotpLastStep(v)
+		return nil
+	case user.FieldRecoveryCodes:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRecoveryCodes(v)
+		return nil
 	case user.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
@@ -1822,13 +9462,31 @@ func (m *UserMutation) SetField(name string, value ent.Value) error {
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
 func (m *UserMutation) AddedFields() []string {
-	return nil
+	var fields []string
+	if m.addfailed_logins != nil {
+		fields = append(fields, user.FieldFailedLogins)
+	}
+	if m.addtoken_version != nil {
+		fields = append(fields, user.FieldTokenVersion)
+	}
+	if m.addtotp_last_step != nil {
+		fields = append(fields, user.FieldTotpLastStep)
+	}
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
 func (m *UserMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case user.FieldFailedLogins:
+		return m.AddedFailedLogins()
+	case user.FieldTokenVersion:
+		return m.AddedTokenVersion()
+	case user.FieldTotpLastStep:
+		return m.AddedTotpLastStep()
+	}
 	return nil, false
 }
 
@@ -1837,6 +9495,27 @@ func (m *UserMutation) AddedField(name string) (ent.Value, bool) {
 // type.
 func (m *UserMutation) AddField(name string, value ent.Value) error {
 	switch name {
+	case user.FieldFailedLogins:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddFailedLogins(v)
+		return nil
+	case user.FieldTokenVersion:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddTokenVersion(v)
+		return nil
+	case user.FieldTotpLastStep:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddTotpLastStep(v)
+		return nil
 	}
 	return fmt.Errorf("unknown User numeric field %s", name)
 }
@@ -1848,6 +9527,30 @@ func (m *UserMutation) ClearedFields() []string {
 	if m.FieldCleared(user.FieldAvatarImage) {
 		fields = append(fields, user.FieldAvatarImage)
 	}
+	if m.FieldCleared(user.FieldEmailVerifiedAt) {
+		fields = append(fields, user.FieldEmailVerifiedAt)
+	}
+	if m.FieldCleared(user.FieldVerificationSentAt) {
+		fields = append(fields, user.FieldVerificationSentAt)
+	}
+	if m.FieldCleared(user.FieldEmbedOrigins) {
+		fields = append(fields, user.FieldEmbedOrigins)
+	}
+	if m.FieldCleared(user.FieldLastFailedLoginAt) {
+		fields = append(fields, user.FieldLastFailedLoginAt)
+	}
+	if m.FieldCleared(user.FieldLockedUntil) {
+		fields = append(fields, user.FieldLockedUntil)
+	}
+	if m.FieldCleared(user.FieldTotpSecret) {
+		fields = append(fields, user.FieldTotpSecret)
+	}
+	if m.FieldCleared(user.FieldTotpEnabledAt) {
+		fields = append(fields, user.FieldTotpEnabledAt)
+	}
+	if m.FieldCleared(user.FieldRecoveryCodes) {
+		fields = append(fields, user.FieldRecoveryCodes)
+	}
 	return fields
 }
 
@@ -1865,6 +9568,30 @@ func (m *UserMutation) ClearField(name string) error {
 	case user.FieldAvatarImage:
 		m.ClearAvatarImage()
 		return nil
+	case user.FieldEmailVerifiedAt:
+		m.ClearEmailVerifiedAt()
+		return nil
+	case user.FieldVerificationSentAt:
+		m.ClearVerificationSentAt()
+		return nil
+	case user.FieldEmbedOrigins:
+		m.ClearEmbedOrigins()
+		return nil
+	case user.FieldLastFailedLoginAt:
+		m.ClearLastFailedLoginAt()
+		return nil
+	case user.FieldLockedUntil:
+		m.ClearLockedUntil()
+		return nil
+	case user.FieldTotpSecret:
+		m.ClearTotpSecret()
+		return nil
+	case user.FieldTotpEnabledAt:
+		m.ClearTotpEnabledAt()
+		return nil
+	case user.FieldRecoveryCodes:
+		m.ClearRecoveryCodes()
+		return nil
 	}
 	return fmt.Errorf("unknown User nullable field %s", name)
 }
@@ -1879,6 +9606,12 @@ func (m *UserMutation) ResetField(name string) error {
 	case user.FieldEmail:
 		m.ResetEmail()
 		return nil
+	case user.FieldEmailVerifiedAt:
+		m.ResetEmailVerifiedAt()
+		return nil
+	case user.FieldVerificationSentAt:
+		m.ResetVerificationSentAt()
+		return nil
 	case user.FieldName:
 		m.ResetName()
 		return nil
@@ -1888,6 +9621,36 @@ func (m *UserMutation) ResetField(name string) error {
 	case user.FieldActive:
 		m.ResetActive()
 		return nil
+	case user.FieldRole:
+		m.ResetRole()
+		return nil
+	case user.FieldEmbedOrigins:
+		m.ResetEmbedOrigins()
+		return nil
+	case user.FieldFailedLogins:
+		m.ResetFailedLogins()
+		return nil
+	case user.FieldLastFailedLoginAt:
+		m.ResetLastFailedLoginAt()
+		return nil
+	case user.FieldLockedUntil:
+		m.ResetLockedUntil()
+		return nil
+	case user.FieldTokenVersion:
+		m.ResetTokenVersion()
+		return nil
+	case user.FieldTotpSecret:
+		m.ResetTotpSecret()
+		return nil
+	case user.FieldTotpEnabledAt:
+		m.ResetTotpEnabledAt()
+		return nil
+	case user.FieldTotpLastStep:
+		m.ResetTotpLastStep()
+		return nil
+	case user.FieldRecoveryCodes:
+		m.ResetRecoveryCodes()
+		return nil
 	case user.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
@@ -1900,10 +9663,28 @@ func (m *UserMutation) ResetField(name string) error {
 
 // AddedEdges returns all edge names that were set/added in this mutation.
 func (m *UserMutation) AddedEdges() []string {
-	edges := make([]string, 0, 1)
+	edges := make([]string, 0, 7)
 	if m.votes != nil {
 		edges = append(edges, user.EdgeVotes)
 	}
+	if m.polls != nil {
+		edges = append(edges, user.EdgePolls)
+	}
+	if m.poll_revisions != nil {
+		edges = append(edges, user.EdgePollRevisions)
+	}
+	if m.followed_tags != nil {
+		edges = append(edges, user.EdgeFollowedTags)
+	}
+	if m.login_attempts != nil {
+		edges = append(edges, user.EdgeLoginAttempts)
+	}
+	if m.password_resets != nil {
+		edges = append(edges, user.EdgePasswordResets)
+	}
+	if m.identities != nil {
+		edges = append(edges, user.EdgeIdentities)
+	}
 	return edges
 }
 
@@ -1917,16 +9698,70 @@ func (m *UserMutation) AddedIDs(name string) []ent.Value {
 			ids = append(ids, id)
 		}
 		return ids
+	case user.EdgePolls:
+		ids := make([]ent.Value, 0, len(m.polls))
+		for id := range m.polls {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgePollRevisions:
+		ids := make([]ent.Value, 0, len(m.poll_revisions))
+		for id := range m.poll_revisions {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeFollowedTags:
+		ids := make([]ent.Value, 0, len(m.followed_tags))
+		for id := range m.followed_tags {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeLoginAttempts:
+		ids := make([]ent.Value, 0, len(m.login_attempts))
+		for id := range m.login_attempts {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgePasswordResets:
+		ids := make([]ent.Value, 0, len(m.password_resets))
+		for id := range m.password_resets {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeIdentities:
+		ids := make([]ent.Value, 0, len(m.identities))
+		for id := range m.identities {
+			ids = append(ids, id)
+		}
+		return ids
 	}
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
 func (m *UserMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 1)
+	edges := make([]string, 0, 7)
 	if m.removedvotes != nil {
 		edges = append(edges, user.EdgeVotes)
 	}
+	if m.removedpolls != nil {
+		edges = append(edges, user.EdgePolls)
+	}
+	if m.removedpoll_revisions != nil {
+		edges = append(edges, user.EdgePollRevisions)
+	}
+	if m.removedfollowed_tags != nil {
+		edges = append(edges, user.EdgeFollowedTags)
+	}
+	if m.removedlogin_attempts != nil {
+		edges = append(edges, user.EdgeLoginAttempts)
+	}
+	if m.removedpassword_resets != nil {
+		edges = append(edges, user.EdgePasswordResets)
+	}
+	if m.removedidentities != nil {
+		edges = append(edges, user.EdgeIdentities)
+	}
 	return edges
 }
 
@@ -1940,16 +9775,70 @@ func (m *UserMutation) RemovedIDs(name string) []ent.Value {
 			ids = append(ids, id)
 		}
 		return ids
+	case user.EdgePolls:
+		ids := make([]ent.Value, 0, len(m.removedpolls))
+		for id := range m.removedpolls {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgePollRevisions:
+		ids := make([]ent.Value, 0, len(m.removedpoll_revisions))
+		for id := range m.removedpoll_revisions {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeFollowedTags:
+		ids := make([]ent.Value, 0, len(m.removedfollowed_tags))
+		for id := range m.removedfollowed_tags {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeLoginAttempts:
+		ids := make([]ent.Value, 0, len(m.removedlogin_attempts))
+		for id := range m.removedlogin_attempts {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgePasswordResets:
+		ids := make([]ent.Value, 0, len(m.removedpassword_resets))
+		for id := range m.removedpassword_resets {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeIdentities:
+		ids := make([]ent.Value, 0, len(m.removedidentities))
+		for id := range m.removedidentities {
+			ids = append(ids, id)
+		}
+		return ids
 	}
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
 func (m *UserMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 1)
+	edges := make([]string, 0, 7)
 	if m.clearedvotes {
 		edges = append(edges, user.EdgeVotes)
 	}
+	if m.clearedpolls {
+		edges = append(edges, user.EdgePolls)
+	}
+	if m.clearedpoll_revisions {
+		edges = append(edges, user.EdgePollRevisions)
+	}
+	if m.clearedfollowed_tags {
+		edges = append(edges, user.EdgeFollowedTags)
+	}
+	if m.clearedlogin_attempts {
+		edges = append(edges, user.EdgeLoginAttempts)
+	}
+	if m.clearedpassword_resets {
+		edges = append(edges, user.EdgePasswordResets)
+	}
+	if m.clearedidentities {
+		edges = append(edges, user.EdgeIdentities)
+	}
 	return edges
 }
 
@@ -1959,6 +9848,18 @@ func (m *UserMutation) EdgeCleared(name string) bool {
 	switch name {
 	case user.EdgeVotes:
 		return m.clearedvotes
+	case user.EdgePolls:
+		return m.clearedpolls
+	case user.EdgePollRevisions:
+		return m.clearedpoll_revisions
+	case user.EdgeFollowedTags:
+		return m.clearedfollowed_tags
+	case user.EdgeLoginAttempts:
+		return m.clearedlogin_attempts
+	case user.EdgePasswordResets:
+		return m.clearedpassword_resets
+	case user.EdgeIdentities:
+		return m.clearedidentities
 	}
 	return false
 }
@@ -1978,6 +9879,24 @@ func (m *UserMutation) ResetEdge(name string) error {
 	case user.EdgeVotes:
 		m.ResetVotes()
 		return nil
+	case user.EdgePolls:
+		m.ResetPolls()
+		return nil
+	case user.EdgePollRevisions:
+		m.ResetPollRevisions()
+		return nil
+	case user.EdgeFollowedTags:
+		m.ResetFollowedTags()
+		return nil
+	case user.EdgeLoginAttempts:
+		m.ResetLoginAttempts()
+		return nil
+	case user.EdgePasswordResets:
+		m.ResetPasswordResets()
+		return nil
+	case user.EdgeIdentities:
+		m.ResetIdentities()
+		return nil
 	}
 	return fmt.Errorf("unknown User edge %s", name)
 }
@@ -1989,6 +9908,7 @@ type VoteMutation struct {
 	typ                string
 	id                 *int
 	created_at         *time.Time
+	guest_id           *string
 	clearedFields      map[string]struct{}
 	user               *string
 	cleareduser        bool
@@ -2135,6 +10055,55 @@ func (m *VoteMutation) ResetCreatedAt() {
 	m.created_at = nil
 }
 
+// SetGuestID sets the "guest_id" field.
+func (m *VoteMutation) SetGuestID(s string) {
+	m.guest_id = &s
+}
+
+// GuestID returns the value of the "guest_id" field in the mutation.
+func (m *VoteMutation) GuestID() (r string, exists bool) {
+	v := m.guest_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldGuestID returns the old "guest_id" field's value of the Vote entity.
+// If the Vote object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *VoteMutation) OldGuestID(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldGuestID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldGuestID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldGuestID: %w", err)
+	}
+	return oldValue.GuestID, nil
+}
+
+// ClearGuestID clears the value of the "guest_id" field.
+func (m *VoteMutation) ClearGuestID() {
+	m.guest_id = nil
+	m.clearedFields[vote.FieldGuestID] = struct{}{}
+}
+
+// GuestIDCleared returns if the "guest_id" field was cleared in this mutation.
+func (m *VoteMutation) GuestIDCleared() bool {
+	_, ok := m.clearedFields[vote.FieldGuestID]
+	return ok
+}
+
+// ResetGuestID resets all changes to the "guest_id" field.
+func (m *VoteMutation) ResetGuestID() {
+	m.guest_id = nil
+	delete(m.clearedFields, vote.FieldGuestID)
+}
+
 // SetUserID sets the "user" edge to the User entity by id.
 func (m *VoteMutation) SetUserID(id string) {
 	m.user = &id
@@ -2286,10 +10255,13 @@ func (m *VoteMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *VoteMutation) Fields() []string {
-	fields := make([]string, 0, 1)
+	fields := make([]string, 0, 2)
 	if m.created_at != nil {
 		fields = append(fields, vote.FieldCreatedAt)
 	}
+	if m.guest_id != nil {
+		fields = append(fields, vote.FieldGuestID)
+	}
 	return fields
 }
 
@@ -2300,6 +10272,8 @@ func (m *VoteMutation) Field(name string) (ent.Value, bool) {
 	switch name {
 	case vote.FieldCreatedAt:
 		return m.CreatedAt()
+	case vote.FieldGuestID:
+		return m.GuestID()
 	}
 	return nil, false
 }
@@ -2311,6 +10285,8 @@ func (m *VoteMutation) OldField(ctx context.Context, name string) (ent.Value, er
 	switch name {
 	case vote.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
+	case vote.FieldGuestID:
+		return m.OldGuestID(ctx)
 	}
 	return nil, fmt.Errorf("unknown Vote field %s", name)
 }
@@ -2327,6 +10303,13 @@ func (m *VoteMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetCreatedAt(v)
 		return nil
+	case vote.FieldGuestID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetGuestID(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Vote field %s", name)
 }
@@ -2356,7 +10339,11 @@ func (m *VoteMutation) AddField(name string, value ent.Value) error {
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
 func (m *VoteMutation) ClearedFields() []string {
-	return nil
+	var fields []string
+	if m.FieldCleared(vote.FieldGuestID) {
+		fields = append(fields, vote.FieldGuestID)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
@@ -2369,6 +10356,11 @@ func (m *VoteMutation) FieldCleared(name string) bool {
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
 func (m *VoteMutation) ClearField(name string) error {
+	switch name {
+	case vote.FieldGuestID:
+		m.ClearGuestID()
+		return nil
+	}
 	return fmt.Errorf("unknown Vote nullable field %s", name)
 }
 
@@ -2379,6 +10371,9 @@ func (m *VoteMutation) ResetField(name string) error {
 	case vote.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
+	case vote.FieldGuestID:
+		m.ResetGuestID()
+		return nil
 	}
 	return fmt.Errorf("unknown Vote field %s", name)
 }
@@ -4,6 +4,7 @@ package ent
 
 import (
 	"api_voty/ent/poll"
+	"api_voty/ent/user"
 	"fmt"
 	"strings"
 	"time"
@@ -19,10 +20,42 @@ type Poll struct {
 	ID int `json:"id,omitempty"`
 	// Title holds the value of the "title" field.
 	Title string `json:"title,omitempty"`
+	// Description holds the value of the "description" field.
+	Description string `json:"description,omitempty"`
 	// IsOpen holds the value of the "is_open" field.
 	IsOpen bool `json:"is_open,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
+	// TotalVotes holds the value of the "total_votes" field.
+	TotalVotes int `json:"total_votes,omitempty"`
+	// OpensAt holds the value of the "opens_at" field.
+	OpensAt *time.Time `json:"opens_at,omitempty"`
+	// ClosesAt holds the value of the "closes_at" field.
+	ClosesAt *time.Time `json:"closes_at,omitempty"`
+	// MaxVotes holds the value of the "max_votes" field.
+	MaxVotes *int `json:"max_votes,omitempty"`
+	// QuorumPercent holds the value of the "quorum_percent" field.
+	QuorumPercent *int `json:"quorum_percent,omitempty"`
+	// CloseOnDecisiveLead holds the value of the "close_on_decisive_lead" field.
+	CloseOnDecisiveLead bool `json:"close_on_decisive_lead,omitempty"`
+	// ClosedReason holds the value of the "closed_reason" field.
+	ClosedReason *poll.ClosedReason `json:"closed_reason,omitempty"`
+	// ResultsVisibility holds the value of the "results_visibility" field.
+	ResultsVisibility poll.ResultsVisibility `json:"results_visibility,omitempty"`
+	// ResultsVisibleAt holds the value of the "results_visible_at" field.
+	ResultsVisibleAt *time.Time `json:"results_visible_at,omitempty"`
+	// Status holds the value of the "status" field.
+	Status poll.Status `json:"status,omitempty"`
+	// PublishedAt holds the value of the "published_at" field.
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	// Anonymous holds the value of the "anonymous" field.
+	Anonymous bool `json:"anonymous,omitempty"`
+	// Embeddable holds the value of the "embeddable" field.
+	Embeddable bool `json:"embeddable,omitempty"`
+	// AllowGuests holds the value of the "allow_guests" field.
+	AllowGuests bool `json:"allow_guests,omitempty"`
+	// OwnerID holds the value of the "owner_id" field.
+	OwnerID *string `json:"owner_id,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the PollQuery when eager-loading is set.
 	Edges        PollEdges `json:"edges"`
@@ -35,9 +68,17 @@ type PollEdges struct {
 	Options []*PollOption `json:"options,omitempty"`
 	// Votes holds the value of the votes edge.
 	Votes []*Vote `json:"votes,omitempty"`
+	// Revisions holds the value of the revisions edge.
+	Revisions []*PollRevision `json:"revisions,omitempty"`
+	// Tags holds the value of the tags edge.
+	Tags []*Tag `json:"tags,omitempty"`
+	// ShareLinks holds the value of the share_links edge.
+	ShareLinks []*ShareLink `json:"share_links,omitempty"`
+	// Owner holds the value of the owner edge.
+	Owner *User `json:"owner,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [2]bool
+	loadedTypes [6]bool
 }
 
 // OptionsOrErr returns the Options value or an error if the edge
@@ -58,18 +99,56 @@ func (e PollEdges) VotesOrErr() ([]*Vote, error) {
 	return nil, &NotLoadedError{edge: "votes"}
 }
 
+// RevisionsOrErr returns the Revisions value or an error if the edge
+// was not loaded in eager-loading.
+func (e PollEdges) RevisionsOrErr() ([]*PollRevision, error) {
+	if e.loadedTypes[2] {
+		return e.Revisions, nil
+	}
+	return nil, &NotLoadedError{edge: "revisions"}
+}
+
+// TagsOrErr returns the Tags value or an error if the edge
+// was not loaded in eager-loading.
+func (e PollEdges) TagsOrErr() ([]*Tag, error) {
+	if e.loadedTypes[3] {
+		return e.Tags, nil
+	}
+	return nil, &NotLoadedError{edge: "tags"}
+}
+
+// ShareLinksOrErr returns the ShareLinks value or an error if the edge
+// was not loaded in eager-loading.
+func (e PollEdges) ShareLinksOrErr() ([]*ShareLink, error) {
+	if e.loadedTypes[4] {
+		return e.ShareLinks, nil
+	}
+	return nil, &NotLoadedError{edge: "share_links"}
+}
+
+// OwnerOrErr returns the Owner value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e PollEdges) OwnerOrErr() (*User, error) {
+	if e.Owner != nil {
+		return e.Owner, nil
+	} else if e.loadedTypes[5] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "owner"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Poll) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case poll.FieldIsOpen:
+		case poll.FieldIsOpen, poll.FieldCloseOnDecisiveLead, poll.FieldAnonymous, poll.FieldEmbeddable, poll.FieldAllowGuests:
 			values[i] = new(sql.NullBool)
-		case poll.FieldID:
+		case poll.FieldID, poll.FieldTotalVotes, poll.FieldMaxVotes, poll.FieldQuorumPercent:
 			values[i] = new(sql.NullInt64)
-		case poll.FieldTitle:
+		case poll.FieldTitle, poll.FieldDescription, poll.FieldClosedReason, poll.FieldResultsVisibility, poll.FieldStatus, poll.FieldOwnerID:
 			values[i] = new(sql.NullString)
-		case poll.FieldCreatedAt:
+		case poll.FieldCreatedAt, poll.FieldOpensAt, poll.FieldClosesAt, poll.FieldResultsVisibleAt, poll.FieldPublishedAt:
 			values[i] = new(sql.NullTime)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -98,6 +177,12 @@ func (_m *Poll) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Title = value.String
 			}
+		case poll.FieldDescription:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field description", values[i])
+			} else if value.Valid {
+				_m.Description = value.String
+			}
 		case poll.FieldIsOpen:
 			if value, ok := values[i].(*sql.NullBool); !ok {
 				return fmt.Errorf("unexpected type %T for field is_open", values[i])
@@ -110,6 +195,104 @@ func (_m *Poll) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.CreatedAt = value.Time
 			}
+		case poll.FieldTotalVotes:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field total_votes", values[i])
+			} else if value.Valid {
+				_m.TotalVotes = int(value.Int64)
+			}
+		case poll.FieldOpensAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field opens_at", values[i])
+			} else if value.Valid {
+				_m.OpensAt = new(time.Time)
+				*_m.OpensAt = value.Time
+			}
+		case poll.FieldClosesAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field closes_at", values[i])
+			} else if value.Valid {
+				_m.ClosesAt = new(time.Time)
+				*_m.ClosesAt = value.Time
+			}
+		case poll.FieldMaxVotes:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field max_votes", values[i])
+			} else if value.Valid {
+				_m.MaxVotes = new(int)
+				*_m.MaxVotes = int(value.Int64)
+			}
+		case poll.FieldQuorumPercent:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field quorum_percent", values[i])
+			} else if value.Valid {
+				_m.QuorumPercent = new(int)
+				*_m.QuorumPercent = int(value.Int64)
+			}
+		case poll.FieldCloseOnDecisiveLead:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field close_on_decisive_lead", values[i])
+			} else if value.Valid {
+				_m.CloseOnDecisiveLead = value.Bool
+			}
+		case poll.FieldClosedReason:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field closed_reason", values[i])
+			} else if value.Valid {
+				_m.ClosedReason = new(poll.ClosedReason)
+				*_m.ClosedReason = poll.ClosedReason(value.String)
+			}
+		case poll.FieldResultsVisibility:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field results_visibility", values[i])
+			} else if value.Valid {
+				_m.ResultsVisibility = poll.ResultsVisibility(value.String)
+			}
+		case poll.FieldResultsVisibleAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field results_visible_at", values[i])
+			} else if value.Valid {
+				_m.ResultsVisibleAt = new(time.Time)
+				*_m.ResultsVisibleAt = value.Time
+			}
+		case poll.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				_m.Status = poll.Status(value.String)
+			}
+		case poll.FieldPublishedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field published_at", values[i])
+			} else if value.Valid {
+				_m.PublishedAt = new(time.Time)
+				*_m.PublishedAt = value.Time
+			}
+		case poll.FieldAnonymous:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field anonymous", values[i])
+			} else if value.Valid {
+				_m.Anonymous = value.Bool
+			}
+		case poll.FieldEmbeddable:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field embeddable", values[i])
+			} else if value.Valid {
+				_m.Embeddable = value.Bool
+			}
+		case poll.FieldAllowGuests:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field allow_guests", values[i])
+			} else if value.Valid {
+				_m.AllowGuests = value.Bool
+			}
+		case poll.FieldOwnerID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field owner_id", values[i])
+			} else if value.Valid {
+				_m.OwnerID = new(string)
+				*_m.OwnerID = value.String
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -133,6 +316,26 @@ func (_m *Poll) QueryVotes() *VoteQuery {
 	return NewPollClient(_m.config).QueryVotes(_m)
 }
 
+// QueryRevisions queries the "revisions" edge of the Poll entity.
+func (_m *Poll) QueryRevisions() *PollRevisionQuery {
+	return NewPollClient(_m.config).QueryRevisions(_m)
+}
+
+// QueryTags queries the "tags" edge of the Poll entity.
+func (_m *Poll) QueryTags() *TagQuery {
+	return NewPollClient(_m.config).QueryTags(_m)
+}
+
+// QueryShareLinks queries the "share_links" edge of the Poll entity.
+func (_m *Poll) QueryShareLinks() *ShareLinkQuery {
+	return NewPollClient(_m.config).QueryShareLinks(_m)
+}
+
+// QueryOwner queries the "owner" edge of the Poll entity.
+func (_m *Poll) QueryOwner() *UserQuery {
+	return NewPollClient(_m.config).QueryOwner(_m)
+}
+
 // Update returns a builder for updating this Poll.
 // Note that you need to call Poll.Unwrap() before calling this method if this Poll
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -159,11 +362,75 @@ func (_m *Poll) String() string {
 	builder.WriteString("title=")
 	builder.WriteString(_m.Title)
 	builder.WriteString(", ")
+	builder.WriteString("description=")
+	builder.WriteString(_m.Description)
+	builder.WriteString(", ")
 	builder.WriteString("is_open=")
 	builder.WriteString(fmt.Sprintf("%v", _m.IsOpen))
 	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("total_votes=")
+	builder.WriteString(fmt.Sprintf("%v", _m.TotalVotes))
+	builder.WriteString(", ")
+	if v := _m.OpensAt; v != nil {
+		builder.WriteString("opens_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := _m.ClosesAt; v != nil {
+		builder.WriteString("closes_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := _m.MaxVotes; v != nil {
+		builder.WriteString("max_votes=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := _m.QuorumPercent; v != nil {
+		builder.WriteString("quorum_percent=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("close_on_decisive_lead=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CloseOnDecisiveLead))
+	builder.WriteString(", ")
+	if v := _m.ClosedReason; v != nil {
+		builder.WriteString("closed_reason=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("results_visibility=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ResultsVisibility))
+	builder.WriteString(", ")
+	if v := _m.ResultsVisibleAt; v != nil {
+		builder.WriteString("results_visible_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Status))
+	builder.WriteString(", ")
+	if v := _m.PublishedAt; v != nil {
+		builder.WriteString("published_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("anonymous=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Anonymous))
+	builder.WriteString(", ")
+	builder.WriteString("embeddable=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Embeddable))
+	builder.WriteString(", ")
+	builder.WriteString("allow_guests=")
+	builder.WriteString(fmt.Sprintf("%v", _m.AllowGuests))
+	builder.WriteString(", ")
+	if v := _m.OwnerID; v != nil {
+		builder.WriteString("owner_id=")
+		builder.WriteString(*v)
+	}
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -3,6 +3,7 @@
 package poll
 
 import (
+	"fmt"
 	"time"
 
 	"entgo.io/ent/dialect/sql"
@@ -16,14 +17,54 @@ const (
 	FieldID = "id"
 	// FieldTitle holds the string denoting the title field in the database.
 	FieldTitle = "title"
+	// FieldDescription holds the string denoting the description field in the database.
+	FieldDescription = "description"
 	// FieldIsOpen holds the string denoting the is_open field in the database.
 	FieldIsOpen = "is_open"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
+	// FieldTotalVotes holds the string denoting the total_votes field in the database.
+	FieldTotalVotes = "total_votes"
+	// FieldOpensAt holds the string denoting the opens_at field in the database.
+	FieldOpensAt = "opens_at"
+	// FieldClosesAt holds the string denoting the closes_at field in the database.
+	FieldClosesAt = "closes_at"
+	// FieldMaxVotes holds the string denoting the max_votes field in the database.
+	FieldMaxVotes = "max_votes"
+	// FieldQuorumPercent holds the string denoting the quorum_percent field in the database.
+	FieldQuorumPercent = "quorum_percent"
+	// FieldCloseOnDecisiveLead holds the string denoting the close_on_decisive_lead field in the database.
+	FieldCloseOnDecisiveLead = "close_on_decisive_lead"
+	// FieldClosedReason holds the string denoting the closed_reason field in the database.
+	FieldClosedReason = "closed_reason"
+	// FieldResultsVisibility holds the string denoting the results_visibility field in the database.
+	FieldResultsVisibility = "results_visibility"
+	// FieldResultsVisibleAt holds the string denoting the results_visible_at field in the database.
+	FieldResultsVisibleAt = "results_visible_at"
+	// FieldStatus holds the string denoting the status field in the database.
+	FieldStatus = "status"
+	// FieldPublishedAt holds the string denoting the published_at field in the database.
+	FieldPublishedAt = "published_at"
+	// FieldAnonymous holds the string denoting the anonymous field in the database.
+	FieldAnonymous = "anonymous"
+	// FieldEmbeddable holds the string denoting the embeddable field in the database.
+	FieldEmbeddable = "embeddable"
+	// FieldAllowGuests holds the string denoting the allow_guests field in the database.
+	FieldAllowGuests = "allow_guests"
+	// FieldOwnerID holds the string denoting the owner_id field in the database.
+	FieldOwnerID = "owner_id"
 	// EdgeOptions holds the string denoting the options edge name in mutations.
 	EdgeOptions = "options"
 	// EdgeVotes holds the string denoting the votes edge name in mutations.
 	EdgeVotes = "votes"
+	// EdgeRevisions holds the string denoting the revisions edge name in mutations.
+	EdgeRevisions = "revisions"
+	// EdgeTags holds the string denoting the tags edge name in mutations.
+	EdgeTags = "tags"
+	// EdgeShareLinks holds the string denoting the share_links edge name in mutations.
+	EdgeShareLinks = "share_links"
+	// EdgeOwner holds the string denoting the owner edge name in mutations.
+	EdgeOwner = "owner"
 	// Table holds the table name of the poll in the database.
 	Table = "polls"
 	// OptionsTable is the table that holds the options relation/edge.
@@ -40,16 +81,64 @@ const (
 	VotesInverseTable = "votes"
 	// VotesColumn is the table column denoting the votes relation/edge.
 	VotesColumn = "poll_votes"
+	// RevisionsTable is the table that holds the revisions relation/edge.
+	RevisionsTable = "poll_revisions"
+	// RevisionsInverseTable is the table name for the PollRevision entity.
+	// It exists in this package in order to avoid circular dependency with the "pollrevision" package.
+	RevisionsInverseTable = "poll_revisions"
+	// RevisionsColumn is the table column denoting the revisions relation/edge.
+	RevisionsColumn = "poll_id"
+	// TagsTable is the table that holds the tags relation/edge. The primary key declared below.
+	TagsTable = "poll_tags"
+	// TagsInverseTable is the table name for the Tag entity.
+	// It exists in this package in order to avoid circular dependency with the "tag" package.
+	TagsInverseTable = "tags"
+	// ShareLinksTable is the table that holds the share_links relation/edge.
+	ShareLinksTable = "share_links"
+	// ShareLinksInverseTable is the table name for the ShareLink entity.
+	// It exists in this package in order to avoid circular dependency with the "sharelink" package.
+	ShareLinksInverseTable = "share_links"
+	// ShareLinksColumn is the table column denoting the share_links relation/edge.
+	ShareLinksColumn = "poll_id"
+	// OwnerTable is the table that holds the owner relation/edge.
+	OwnerTable = "polls"
+	// OwnerInverseTable is the table name for the User entity.
+	// It exists in this package in order to avoid circular dependency with the "user" package.
+	OwnerInverseTable = "users"
+	// OwnerColumn is the table column denoting the owner relation/edge.
+	OwnerColumn = "owner_id"
 )
 
 // Columns holds all SQL columns for poll fields.
 var Columns = []string{
 	FieldID,
 	FieldTitle,
+	FieldDescription,
 	FieldIsOpen,
 	FieldCreatedAt,
+	FieldTotalVotes,
+	FieldOpensAt,
+	FieldClosesAt,
+	FieldMaxVotes,
+	FieldQuorumPercent,
+	FieldCloseOnDecisiveLead,
+	FieldClosedReason,
+	FieldResultsVisibility,
+	FieldResultsVisibleAt,
+	FieldStatus,
+	FieldPublishedAt,
+	FieldAnonymous,
+	FieldEmbeddable,
+	FieldAllowGuests,
+	FieldOwnerID,
 }
 
+var (
+	// TagsPrimaryKey and TagsColumn2 are the table columns denoting the
+	// primary key for the tags relation (M2M).
+	TagsPrimaryKey = []string{"poll_id", "tag_id"}
+)
+
 // ValidColumn reports if the column name is valid (part of the table columns).
 func ValidColumn(column string) bool {
 	for i := range Columns {
@@ -65,8 +154,102 @@ var (
 	DefaultIsOpen bool
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
 	DefaultCreatedAt func() time.Time
+	// DefaultTotalVotes holds the default value on creation for the "total_votes" field.
+	DefaultTotalVotes int
+	// MaxVotesValidator is a validator for the "max_votes" field. It is called by the builders before save.
+	MaxVotesValidator func(int) error
+	// QuorumPercentValidator is a validator for the "quorum_percent" field. It is called by the builders before save.
+	QuorumPercentValidator func(int) error
+	// DefaultCloseOnDecisiveLead holds the default value on creation for the "close_on_decisive_lead" field.
+	DefaultCloseOnDecisiveLead bool
+	// DefaultAnonymous holds the default value on creation for the "anonymous" field.
+	DefaultAnonymous bool
+	// DefaultEmbeddable holds the default value on creation for the "embeddable" field.
+	DefaultEmbeddable bool
+	// DefaultAllowGuests holds the default value on creation for the "allow_guests" field.
+	DefaultAllowGuests bool
+)
+
+// ClosedReason defines the type for the "closed_reason" enum field.
+type ClosedReason string
+
+// ClosedReason values.
+const (
+	ClosedReasonManual       ClosedReason = "manual"
+	ClosedReasonSchedule     ClosedReason = "schedule"
+	ClosedReasonVoteCap      ClosedReason = "vote_cap"
+	ClosedReasonQuorum       ClosedReason = "quorum"
+	ClosedReasonDecisiveLead ClosedReason = "decisive_lead"
+)
+
+func (cr ClosedReason) String() string {
+	return string(cr)
+}
+
+// ClosedReasonValidator is a validator for the "closed_reason" field enum values. It is called by the builders before save.
+func ClosedReasonValidator(cr ClosedReason) error {
+	switch cr {
+	case ClosedReasonManual, ClosedReasonSchedule, ClosedReasonVoteCap, ClosedReasonQuorum, ClosedReasonDecisiveLead:
+		return nil
+	default:
+		return fmt.Errorf("poll: invalid enum value for closed_reason field: %q", cr)
+	}
+}
+
+// ResultsVisibility defines the type for the "results_visibility" enum field.
+type ResultsVisibility string
+
+// ResultsVisibilityAlways is the default value of the ResultsVisibility enum.
+const DefaultResultsVisibility = ResultsVisibilityAlways
+
+// ResultsVisibility values.
+const (
+	ResultsVisibilityAlways     ResultsVisibility = "always"
+	ResultsVisibilityAfterVote  ResultsVisibility = "after_vote"
+	ResultsVisibilityAfterClose ResultsVisibility = "after_close"
+	ResultsVisibilityEmbargo    ResultsVisibility = "embargo"
+)
+
+func (rv ResultsVisibility) String() string {
+	return string(rv)
+}
+
+// ResultsVisibilityValidator is a validator for the "results_visibility" field enum values. It is called by the builders before save.
+func ResultsVisibilityValidator(rv ResultsVisibility) error {
+	switch rv {
+	case ResultsVisibilityAlways, ResultsVisibilityAfterVote, ResultsVisibilityAfterClose, ResultsVisibilityEmbargo:
+		return nil
+	default:
+		return fmt.Errorf("poll: invalid enum value for results_visibility field: %q", rv)
+	}
+}
+
+// Status defines the type for the "status" enum field.
+type Status string
+
+// StatusPublished is the default value of the Status enum.
+const DefaultStatus = StatusPublished
+
+// Status values.
+const (
+	StatusDraft     Status = "draft"
+	StatusPublished Status = "published"
 )
 
+func (s Status) String() string {
+	return string(s)
+}
+
+// StatusValidator is a validator for the "status" field enum values. It is called by the builders before save.
+func StatusValidator(s Status) error {
+	switch s {
+	case StatusDraft, StatusPublished:
+		return nil
+	default:
+		return fmt.Errorf("poll: invalid enum value for status field: %q", s)
+	}
+}
+
 // OrderOption defines the ordering options for the Poll queries.
 type OrderOption func(*sql.Selector)
 
@@ -80,6 +263,11 @@ func ByTitle(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldTitle, opts...).ToFunc()
 }
 
+// ByDescription orders the results by the description field.
+func ByDescription(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDescription, opts...).ToFunc()
+}
+
 // ByIsOpen orders the results by the is_open field.
 func ByIsOpen(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldIsOpen, opts...).ToFunc()
@@ -90,6 +278,81 @@ func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
 }
 
+// ByTotalVotes orders the results by the total_votes field.
+func ByTotalVotes(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTotalVotes, opts...).ToFunc()
+}
+
+// ByOpensAt orders the results by the opens_at field.
+func ByOpensAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOpensAt, opts...).ToFunc()
+}
+
+// ByClosesAt orders the results by the closes_at field.
+func ByClosesAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldClosesAt, opts...).ToFunc()
+}
+
+// ByMaxVotes orders the results by the max_votes field.
+func ByMaxVotes(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMaxVotes, opts...).ToFunc()
+}
+
+// ByQuorumPercent orders the results by the quorum_percent field.
+func ByQuorumPercent(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldQuorumPercent, opts...).ToFunc()
+}
+
+// ByCloseOnDecisiveLead orders the results by the close_on_decisive_lead field.
+func ByCloseOnDecisiveLead(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCloseOnDecisiveLead, opts...).ToFunc()
+}
+
+// ByClosedReason orders the results by the closed_reason field.
+func ByClosedReason(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldClosedReason, opts...).ToFunc()
+}
+
+// ByResultsVisibility orders the results by the results_visibility field.
+func ByResultsVisibility(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldResultsVisibility, opts...).ToFunc()
+}
+
+// ByResultsVisibleAt orders the results by the results_visible_at field.
+func ByResultsVisibleAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldResultsVisibleAt, opts...).ToFunc()
+}
+
+// ByStatus orders the results by the status field.
+func ByStatus(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldStatus, opts...).ToFunc()
+}
+
+// ByPublishedAt orders the results by the published_at field.
+func ByPublishedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPublishedAt, opts...).ToFunc()
+}
+
+// ByAnonymous orders the results by the anonymous field.
+func ByAnonymous(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAnonymous, opts...).ToFunc()
+}
+
+// ByEmbeddable orders the results by the embeddable field.
+func ByEmbeddable(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEmbeddable, opts...).ToFunc()
+}
+
+// ByAllowGuests orders the results by the allow_guests field.
+func ByAllowGuests(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAllowGuests, opts...).ToFunc()
+}
+
+// ByOwnerID orders the results by the owner_id field.
+func ByOwnerID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOwnerID, opts...).ToFunc()
+}
+
 // ByOptionsCount orders the results by options count.
 func ByOptionsCount(opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -117,6 +380,55 @@ func ByVotes(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newVotesStep(), append([]sql.OrderTerm{term}, terms...)...)
 	}
 }
+
+// ByRevisionsCount orders the results by revisions count.
+func ByRevisionsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newRevisionsStep(), opts...)
+	}
+}
+
+// ByRevisions orders the results by revisions terms.
+func ByRevisions(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newRevisionsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByTagsCount orders the results by tags count.
+func ByTagsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newTagsStep(), opts...)
+	}
+}
+
+// ByTags orders the results by tags terms.
+func ByTags(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newTagsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByShareLinksCount orders the results by share_links count.
+func ByShareLinksCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newShareLinksStep(), opts...)
+	}
+}
+
+// ByShareLinks orders the results by share_links terms.
+func ByShareLinks(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newShareLinksStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByOwnerField orders the results by owner field.
+func ByOwnerField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newOwnerStep(), sql.OrderByField(field, opts...))
+	}
+}
 func newOptionsStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -131,3 +443,31 @@ func newVotesStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.O2M, false, VotesTable, VotesColumn),
 	)
 }
+func newRevisionsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(RevisionsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, RevisionsTable, RevisionsColumn),
+	)
+}
+func newTagsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(TagsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2M, false, TagsTable, TagsPrimaryKey...),
+	)
+}
+func newShareLinksStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(ShareLinksInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, ShareLinksTable, ShareLinksColumn),
+	)
+}
+func newOwnerStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(OwnerInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, OwnerTable, OwnerColumn),
+	)
+}
@@ -60,6 +60,11 @@ func Title(v string) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldTitle, v))
 }
 
+// Description applies equality check predicate on the "description" field. It's identical to DescriptionEQ.
+func Description(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldDescription, v))
+}
+
 // IsOpen applies equality check predicate on the "is_open" field. It's identical to IsOpenEQ.
 func IsOpen(v bool) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldIsOpen, v))
@@ -70,6 +75,66 @@ func CreatedAt(v time.Time) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldCreatedAt, v))
 }
 
+// TotalVotes applies equality check predicate on the "total_votes" field. It's identical to TotalVotesEQ.
+func TotalVotes(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldTotalVotes, v))
+}
+
+// OpensAt applies equality check predicate on the "opens_at" field. It's identical to OpensAtEQ.
+func OpensAt(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldOpensAt, v))
+}
+
+// ClosesAt applies equality check predicate on the "closes_at" field. It's identical to ClosesAtEQ.
+func ClosesAt(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldClosesAt, v))
+}
+
+// MaxVotes applies equality check predicate on the "max_votes" field. It's identical to MaxVotesEQ.
+func MaxVotes(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldMaxVotes, v))
+}
+
+// QuorumPercent applies equality check predicate on the "quorum_percent" field. It's identical to QuorumPercentEQ.
+func QuorumPercent(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldQuorumPercent, v))
+}
+
+// CloseOnDecisiveLead applies equality check predicate on the "close_on_decisive_lead" field. It's identical to CloseOnDecisiveLeadEQ.
+func CloseOnDecisiveLead(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldCloseOnDecisiveLead, v))
+}
+
+// ResultsVisibleAt applies equality check predicate on the "results_visible_at" field. It's identical to ResultsVisibleAtEQ.
+func ResultsVisibleAt(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldResultsVisibleAt, v))
+}
+
+// PublishedAt applies equality check predicate on the "published_at" field. It's identical to PublishedAtEQ.
+func PublishedAt(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldPublishedAt, v))
+}
+
+// Anonymous applies equality check predicate on the "anonymous" field. It's identical to AnonymousEQ.
+func Anonymous(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldAnonymous, v))
+}
+
+// Embeddable applies equality check predicate on the "embeddable" field. It's identical to EmbeddableEQ.
+func Embeddable(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldEmbeddable, v))
+}
+
+// AllowGuests applies equality check predicate on the "allow_guests" field. It's identical to AllowGuestsEQ.
+func AllowGuests(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldAllowGuests, v))
+}
+
+// OwnerID applies equality check predicate on the "owner_id" field. It's identical to OwnerIDEQ.
+func OwnerID(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldOwnerID, v))
+}
+
 // TitleEQ applies the EQ predicate on the "title" field.
 func TitleEQ(v string) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldTitle, v))
@@ -135,6 +200,81 @@ func TitleContainsFold(v string) predicate.Poll {
 	return predicate.Poll(sql.FieldContainsFold(FieldTitle, v))
 }
 
+// DescriptionEQ applies the EQ predicate on the "description" field.
+func DescriptionEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldDescription, v))
+}
+
+// DescriptionNEQ applies the NEQ predicate on the "description" field.
+func DescriptionNEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldDescription, v))
+}
+
+// DescriptionIn applies the In predicate on the "description" field.
+func DescriptionIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldDescription, vs...))
+}
+
+// DescriptionNotIn applies the NotIn predicate on the "description" field.
+func DescriptionNotIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldDescription, vs...))
+}
+
+// DescriptionGT applies the GT predicate on the "description" field.
+func DescriptionGT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldDescription, v))
+}
+
+// DescriptionGTE applies the GTE predicate on the "description" field.
+func DescriptionGTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldDescription, v))
+}
+
+// DescriptionLT applies the LT predicate on the "description" field.
+func DescriptionLT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldDescription, v))
+}
+
+// DescriptionLTE applies the LTE predicate on the "description" field.
+func DescriptionLTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldDescription, v))
+}
+
+// DescriptionContains applies the Contains predicate on the "description" field.
+func DescriptionContains(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContains(FieldDescription, v))
+}
+
+// DescriptionHasPrefix applies the HasPrefix predicate on the "description" field.
+func DescriptionHasPrefix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasPrefix(FieldDescription, v))
+}
+
+// DescriptionHasSuffix applies the HasSuffix predicate on the "description" field.
+func DescriptionHasSuffix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasSuffix(FieldDescription, v))
+}
+
+// DescriptionIsNil applies the IsNil predicate on the "description" field.
+func DescriptionIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldDescription))
+}
+
+// DescriptionNotNil applies the NotNil predicate on the "description" field.
+func DescriptionNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldDescription))
+}
+
+// DescriptionEqualFold applies the EqualFold predicate on the "description" field.
+func DescriptionEqualFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEqualFold(FieldDescription, v))
+}
+
+// DescriptionContainsFold applies the ContainsFold predicate on the "description" field.
+func DescriptionContainsFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContainsFold(FieldDescription, v))
+}
+
 // IsOpenEQ applies the EQ predicate on the "is_open" field.
 func IsOpenEQ(v bool) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldIsOpen, v))
@@ -185,6 +325,531 @@ func CreatedAtLTE(v time.Time) predicate.Poll {
 	return predicate.Poll(sql.FieldLTE(FieldCreatedAt, v))
 }
 
+// TotalVotesEQ applies the EQ predicate on the "total_votes" field.
+func TotalVotesEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldTotalVotes, v))
+}
+
+// TotalVotesNEQ applies the NEQ predicate on the "total_votes" field.
+func TotalVotesNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldTotalVotes, v))
+}
+
+// TotalVotesIn applies the In predicate on the "total_votes" field.
+func TotalVotesIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldTotalVotes, vs...))
+}
+
+// TotalVotesNotIn applies the NotIn predicate on the "total_votes" field.
+func TotalVotesNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldTotalVotes, vs...))
+}
+
+// TotalVotesGT applies the GT predicate on the "total_votes" field.
+func TotalVotesGT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldTotalVotes, v))
+}
+
+// TotalVotesGTE applies the GTE predicate on the "total_votes" field.
+func TotalVotesGTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldTotalVotes, v))
+}
+
+// TotalVotesLT applies the LT predicate on the "total_votes" field.
+func TotalVotesLT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldTotalVotes, v))
+}
+
+// TotalVotesLTE applies the LTE predicate on the "total_votes" field.
+func TotalVotesLTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldTotalVotes, v))
+}
+
+// OpensAtEQ applies the EQ predicate on the "opens_at" field.
+func OpensAtEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldOpensAt, v))
+}
+
+// OpensAtNEQ applies the NEQ predicate on the "opens_at" field.
+func OpensAtNEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldOpensAt, v))
+}
+
+// OpensAtIn applies the In predicate on the "opens_at" field.
+func OpensAtIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldOpensAt, vs...))
+}
+
+// OpensAtNotIn applies the NotIn predicate on the "opens_at" field.
+func OpensAtNotIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldOpensAt, vs...))
+}
+
+// OpensAtGT applies the GT predicate on the "opens_at" field.
+func OpensAtGT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldOpensAt, v))
+}
+
+// OpensAtGTE applies the GTE predicate on the "opens_at" field.
+func OpensAtGTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldOpensAt, v))
+}
+
+// OpensAtLT applies the LT predicate on the "opens_at" field.
+func OpensAtLT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldOpensAt, v))
+}
+
+// OpensAtLTE applies the LTE predicate on the "opens_at" field.
+func OpensAtLTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldOpensAt, v))
+}
+
+// OpensAtIsNil applies the IsNil predicate on the "opens_at" field.
+func OpensAtIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldOpensAt))
+}
+
+// OpensAtNotNil applies the NotNil predicate on the "opens_at" field.
+func OpensAtNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldOpensAt))
+}
+
+// ClosesAtEQ applies the EQ predicate on the "closes_at" field.
+func ClosesAtEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldClosesAt, v))
+}
+
+// ClosesAtNEQ applies the NEQ predicate on the "closes_at" field.
+func ClosesAtNEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldClosesAt, v))
+}
+
+// ClosesAtIn applies the In predicate on the "closes_at" field.
+func ClosesAtIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldClosesAt, vs...))
+}
+
+// ClosesAtNotIn applies the NotIn predicate on the "closes_at" field.
+func ClosesAtNotIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldClosesAt, vs...))
+}
+
+// ClosesAtGT applies the GT predicate on the "closes_at" field.
+func ClosesAtGT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldClosesAt, v))
+}
+
+// ClosesAtGTE applies the GTE predicate on the "closes_at" field.
+func ClosesAtGTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldClosesAt, v))
+}
+
+// ClosesAtLT applies the LT predicate on the "closes_at" field.
+func ClosesAtLT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldClosesAt, v))
+}
+
+// ClosesAtLTE applies the LTE predicate on the "closes_at" field.
+func ClosesAtLTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldClosesAt, v))
+}
+
+// ClosesAtIsNil applies the IsNil predicate on the "closes_at" field.
+func ClosesAtIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldClosesAt))
+}
+
+// ClosesAtNotNil applies the NotNil predicate on the "closes_at" field.
+func ClosesAtNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldClosesAt))
+}
+
+// MaxVotesEQ applies the EQ predicate on the "max_votes" field.
+func MaxVotesEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldMaxVotes, v))
+}
+
+// MaxVotesNEQ applies the NEQ predicate on the "max_votes" field.
+func MaxVotesNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldMaxVotes, v))
+}
+
+// MaxVotesIn applies the In predicate on the "max_votes" field.
+func MaxVotesIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldMaxVotes, vs...))
+}
+
+// MaxVotesNotIn applies the NotIn predicate on the "max_votes" field.
+func MaxVotesNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldMaxVotes, vs...))
+}
+
+// MaxVotesGT applies the GT predicate on the "max_votes" field.
+func MaxVotesGT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldMaxVotes, v))
+}
+
+// MaxVotesGTE applies the GTE predicate on the "max_votes" field.
+func MaxVotesGTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldMaxVotes, v))
+}
+
+// MaxVotesLT applies the LT predicate on the "max_votes" field.
+func MaxVotesLT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldMaxVotes, v))
+}
+
+// MaxVotesLTE applies the LTE predicate on the "max_votes" field.
+func MaxVotesLTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldMaxVotes, v))
+}
+
+// MaxVotesIsNil applies the IsNil predicate on the "max_votes" field.
+func MaxVotesIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldMaxVotes))
+}
+
+// MaxVotesNotNil applies the NotNil predicate on the "max_votes" field.
+func MaxVotesNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldMaxVotes))
+}
+
+// QuorumPercentEQ applies the EQ predicate on the "quorum_percent" field.
+func QuorumPercentEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldQuorumPercent, v))
+}
+
+// QuorumPercentNEQ applies the NEQ predicate on the "quorum_percent" field.
+func QuorumPercentNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldQuorumPercent, v))
+}
+
+// QuorumPercentIn applies the In predicate on the "quorum_percent" field.
+func QuorumPercentIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldQuorumPercent, vs...))
+}
+
+// QuorumPercentNotIn applies the NotIn predicate on the "quorum_percent" field.
+func QuorumPercentNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldQuorumPercent, vs...))
+}
+
+// QuorumPercentGT applies the GT predicate on the "quorum_percent" field.
+func QuorumPercentGT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldQuorumPercent, v))
+}
+
+// QuorumPercentGTE applies the GTE predicate on the "quorum_percent" field.
+func QuorumPercentGTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldQuorumPercent, v))
+}
+
+// QuorumPercentLT applies the LT predicate on the "quorum_percent" field.
+func QuorumPercentLT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldQuorumPercent, v))
+}
+
+// QuorumPercentLTE applies the LTE predicate on the "quorum_percent" field.
+func QuorumPercentLTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldQuorumPercent, v))
+}
+
+// QuorumPercentIsNil applies the IsNil predicate on the "quorum_percent" field.
+func QuorumPercentIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldQuorumPercent))
+}
+
+// QuorumPercentNotNil applies the NotNil predicate on the "quorum_percent" field.
+func QuorumPercentNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldQuorumPercent))
+}
+
+// CloseOnDecisiveLeadEQ applies the EQ predicate on the "close_on_decisive_lead" field.
+func CloseOnDecisiveLeadEQ(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldCloseOnDecisiveLead, v))
+}
+
+// CloseOnDecisiveLeadNEQ applies the NEQ predicate on the "close_on_decisive_lead" field.
+func CloseOnDecisiveLeadNEQ(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldCloseOnDecisiveLead, v))
+}
+
+// ClosedReasonEQ applies the EQ predicate on the "closed_reason" field.
+func ClosedReasonEQ(v ClosedReason) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldClosedReason, v))
+}
+
+// ClosedReasonNEQ applies the NEQ predicate on the "closed_reason" field.
+func ClosedReasonNEQ(v ClosedReason) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldClosedReason, v))
+}
+
+// ClosedReasonIn applies the In predicate on the "closed_reason" field.
+func ClosedReasonIn(vs ...ClosedReason) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldClosedReason, vs...))
+}
+
+// ClosedReasonNotIn applies the NotIn predicate on the "closed_reason" field.
+func ClosedReasonNotIn(vs ...ClosedReason) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldClosedReason, vs...))
+}
+
+// ClosedReasonIsNil applies the IsNil predicate on the "closed_reason" field.
+func ClosedReasonIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldClosedReason))
+}
+
+// ClosedReasonNotNil applies the NotNil predicate on the "closed_reason" field.
+func ClosedReasonNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldClosedReason))
+}
+
+// ResultsVisibilityEQ applies the EQ predicate on the "results_visibility" field.
+func ResultsVisibilityEQ(v ResultsVisibility) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldResultsVisibility, v))
+}
+
+// ResultsVisibilityNEQ applies the NEQ predicate on the "results_visibility" field.
+func ResultsVisibilityNEQ(v ResultsVisibility) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldResultsVisibility, v))
+}
+
+// ResultsVisibilityIn applies the In predicate on the "results_visibility" field.
+func ResultsVisibilityIn(vs ...ResultsVisibility) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldResultsVisibility, vs...))
+}
+
+// ResultsVisibilityNotIn applies the NotIn predicate on the "results_visibility" field.
+func ResultsVisibilityNotIn(vs ...ResultsVisibility) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldResultsVisibility, vs...))
+}
+
+// ResultsVisibleAtEQ applies the EQ predicate on the "results_visible_at" field.
+func ResultsVisibleAtEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldResultsVisibleAt, v))
+}
+
+// ResultsVisibleAtNEQ applies the NEQ predicate on the "results_visible_at" field.
+func ResultsVisibleAtNEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldResultsVisibleAt, v))
+}
+
+// ResultsVisibleAtIn applies the In predicate on the "results_visible_at" field.
+func ResultsVisibleAtIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldResultsVisibleAt, vs...))
+}
+
+// ResultsVisibleAtNotIn applies the NotIn predicate on the "results_visible_at" field.
+func ResultsVisibleAtNotIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldResultsVisibleAt, vs...))
+}
+
+// ResultsVisibleAtGT applies the GT predicate on the "results_visible_at" field.
+func ResultsVisibleAtGT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldResultsVisibleAt, v))
+}
+
+// ResultsVisibleAtGTE applies the GTE predicate on the "results_visible_at" field.
+func ResultsVisibleAtGTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldResultsVisibleAt, v))
+}
+
+// ResultsVisibleAtLT applies the LT predicate on the "results_visible_at" field.
+func ResultsVisibleAtLT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldResultsVisibleAt, v))
+}
+
+// ResultsVisibleAtLTE applies the LTE predicate on the "results_visible_at" field.
+func ResultsVisibleAtLTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldResultsVisibleAt, v))
+}
+
+// ResultsVisibleAtIsNil applies the IsNil predicate on the "results_visible_at" field.
+func ResultsVisibleAtIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldResultsVisibleAt))
+}
+
+// ResultsVisibleAtNotNil applies the NotNil predicate on the "results_visible_at" field.
+func ResultsVisibleAtNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldResultsVisibleAt))
+}
+
+// StatusEQ applies the EQ predicate on the "status" field.
+func StatusEQ(v Status) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldStatus, v))
+}
+
+// StatusNEQ applies the NEQ predicate on the "status" field.
+func StatusNEQ(v Status) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldStatus, v))
+}
+
+// StatusIn applies the In predicate on the "status" field.
+func StatusIn(vs ...Status) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldStatus, vs...))
+}
+
+// StatusNotIn applies the NotIn predicate on the "status" field.
+func StatusNotIn(vs ...Status) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldStatus, vs...))
+}
+
+// PublishedAtEQ applies the EQ predicate on the "published_at" field.
+func PublishedAtEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldPublishedAt, v))
+}
+
+// PublishedAtNEQ applies the NEQ predicate on the "published_at" field.
+func PublishedAtNEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldPublishedAt, v))
+}
+
+// PublishedAtIn applies the In predicate on the "published_at" field.
+func PublishedAtIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldPublishedAt, vs...))
+}
+
+// PublishedAtNotIn applies the NotIn predicate on the "published_at" field.
+func PublishedAtNotIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldPublishedAt, vs...))
+}
+
+// PublishedAtGT applies the GT predicate on the "published_at" field.
+func PublishedAtGT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldPublishedAt, v))
+}
+
+// PublishedAtGTE applies the GTE predicate on the "published_at" field.
+func PublishedAtGTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldPublishedAt, v))
+}
+
+// PublishedAtLT applies the LT predicate on the "published_at" field.
+func PublishedAtLT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldPublishedAt, v))
+}
+
+// PublishedAtLTE applies the LTE predicate on the "published_at" field.
+func PublishedAtLTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldPublishedAt, v))
+}
+
+// PublishedAtIsNil applies the IsNil predicate on the "published_at" field.
+func PublishedAtIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldPublishedAt))
+}
+
+// PublishedAtNotNil applies the NotNil predicate on the "published_at" field.
+func PublishedAtNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldPublishedAt))
+}
+
+// AnonymousEQ applies the EQ predicate on the "anonymous" field.
+func AnonymousEQ(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldAnonymous, v))
+}
+
+// AnonymousNEQ applies the NEQ predicate on the "anonymous" field.
+func AnonymousNEQ(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldAnonymous, v))
+}
+
+// EmbeddableEQ applies the EQ predicate on the "embeddable" field.
+func EmbeddableEQ(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldEmbeddable, v))
+}
+
+// EmbeddableNEQ applies the NEQ predicate on the "embeddable" field.
+func EmbeddableNEQ(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldEmbeddable, v))
+}
+
+// AllowGuestsEQ applies the EQ predicate on the "allow_guests" field.
+func AllowGuestsEQ(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldAllowGuests, v))
+}
+
+// AllowGuestsNEQ applies the NEQ predicate on the "allow_guests" field.
+func AllowGuestsNEQ(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldAllowGuests, v))
+}
+
+// OwnerIDEQ applies the EQ predicate on the "owner_id" field.
+func OwnerIDEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldOwnerID, v))
+}
+
+// OwnerIDNEQ applies the NEQ predicate on the "owner_id" field.
+func OwnerIDNEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldOwnerID, v))
+}
+
+// OwnerIDIn applies the In predicate on the "owner_id" field.
+func OwnerIDIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldOwnerID, vs...))
+}
+
+// OwnerIDNotIn applies the NotIn predicate on the "owner_id" field.
+func OwnerIDNotIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldOwnerID, vs...))
+}
+
+// OwnerIDGT applies the GT predicate on the "owner_id" field.
+func OwnerIDGT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldOwnerID, v))
+}
+
+// OwnerIDGTE applies the GTE predicate on the "owner_id" field.
+func OwnerIDGTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldOwnerID, v))
+}
+
+// OwnerIDLT applies the LT predicate on the "owner_id" field.
+func OwnerIDLT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldOwnerID, v))
+}
+
+// OwnerIDLTE applies the LTE predicate on the "owner_id" field.
+func OwnerIDLTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldOwnerID, v))
+}
+
+// OwnerIDContains applies the Contains predicate on the "owner_id" field.
+func OwnerIDContains(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContains(FieldOwnerID, v))
+}
+
+// OwnerIDHasPrefix applies the HasPrefix predicate on the "owner_id" field.
+func OwnerIDHasPrefix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasPrefix(FieldOwnerID, v))
+}
+
+// OwnerIDHasSuffix applies the HasSuffix predicate on the "owner_id" field.
+func OwnerIDHasSuffix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasSuffix(FieldOwnerID, v))
+}
+
+// OwnerIDIsNil applies the IsNil predicate on the "owner_id" field.
+func OwnerIDIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldOwnerID))
+}
+
+// OwnerIDNotNil applies the NotNil predicate on the "owner_id" field.
+func OwnerIDNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldOwnerID))
+}
+
+// OwnerIDEqualFold applies the EqualFold predicate on the "owner_id" field.
+func OwnerIDEqualFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEqualFold(FieldOwnerID, v))
+}
+
+// OwnerIDContainsFold applies the ContainsFold predicate on the "owner_id" field.
+func OwnerIDContainsFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContainsFold(FieldOwnerID, v))
+}
+
 // HasOptions applies the HasEdge predicate on the "options" edge.
 func HasOptions() predicate.Poll {
 	return predicate.Poll(func(s *sql.Selector) {
@@ -231,6 +896,98 @@ func HasVotesWith(preds ...predicate.Vote) predicate.Poll {
 	})
 }
 
+// HasRevisions applies the HasEdge predicate on the "revisions" edge.
+func HasRevisions() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, RevisionsTable, RevisionsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasRevisionsWith applies the HasEdge predicate on the "revisions" edge with a given conditions (other predicates).
+func HasRevisionsWith(preds ...predicate.PollRevision) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newRevisionsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasTags applies the HasEdge predicate on the "tags" edge.
+func HasTags() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2M, false, TagsTable, TagsPrimaryKey...),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasTagsWith applies the HasEdge predicate on the "tags" edge with a given conditions (other predicates).
+func HasTagsWith(preds ...predicate.Tag) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newTagsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasShareLinks applies the HasEdge predicate on the "share_links" edge.
+func HasShareLinks() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, ShareLinksTable, ShareLinksColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasShareLinksWith applies the HasEdge predicate on the "share_links" edge with a given conditions (other predicates).
+func HasShareLinksWith(preds ...predicate.ShareLink) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newShareLinksStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasOwner applies the HasEdge predicate on the "owner" edge.
+func HasOwner() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, OwnerTable, OwnerColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasOwnerWith applies the HasEdge predicate on the "owner" edge with a given conditions (other predicates).
+func HasOwnerWith(preds ...predicate.User) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newOwnerStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Poll) predicate.Poll {
 	return predicate.Poll(sql.AndPredicates(predicates...))
@@ -5,6 +5,10 @@ package ent
 import (
 	"api_voty/ent/poll"
 	"api_voty/ent/polloption"
+	"api_voty/ent/pollrevision"
+	"api_voty/ent/sharelink"
+	"api_voty/ent/tag"
+	"api_voty/ent/user"
 	"api_voty/ent/vote"
 	"context"
 	"errors"
@@ -28,6 +32,20 @@ func (_c *PollCreate) SetTitle(v string) *PollCreate {
 	return _c
 }
 
+// SetDescription sets the "description" field.
+func (_c *PollCreate) SetDescription(v string) *PollCreate {
+	_c.mutation.SetDescription(v)
+	return _c
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_c *PollCreate) SetNillableDescription(v *string) *PollCreate {
+	if v != nil {
+		_c.SetDescription(*v)
+	}
+	return _c
+}
+
 // SetIsOpen sets the "is_open" field.
 func (_c *PollCreate) SetIsOpen(v bool) *PollCreate {
 	_c.mutation.SetIsOpen(v)
@@ -56,6 +74,216 @@ func (_c *PollCreate) SetNillableCreatedAt(v *time.Time) *PollCreate {
 	return _c
 }
 
+// SetTotalVotes sets the "total_votes" field.
+func (_c *PollCreate) SetTotalVotes(v int) *PollCreate {
+	_c.mutation.SetTotalVotes(v)
+	return _c
+}
+
+// SetNillableTotalVotes sets the "total_votes" field if the given value is not nil.
+func (_c *PollCreate) SetNillableTotalVotes(v *int) *PollCreate {
+	if v != nil {
+		_c.SetTotalVotes(*v)
+	}
+	return _c
+}
+
+// SetOpensAt sets the "opens_at" field.
+func (_c *PollCreate) SetOpensAt(v time.Time) *PollCreate {
+	_c.mutation.SetOpensAt(v)
+	return _c
+}
+
+// SetNillableOpensAt sets the "opens_at" field if the given value is not nil.
+func (_c *PollCreate) SetNillableOpensAt(v *time.Time) *PollCreate {
+	if v != nil {
+		_c.SetOpensAt(*v)
+	}
+	return _c
+}
+
+// SetClosesAt sets the "closes_at" field.
+func (_c *PollCreate) SetClosesAt(v time.Time) *PollCreate {
+	_c.mutation.SetClosesAt(v)
+	return _c
+}
+
+// SetNillableClosesAt sets the "closes_at" field if the given value is not nil.
+func (_c *PollCreate) SetNillableClosesAt(v *time.Time) *PollCreate {
+	if v != nil {
+		_c.SetClosesAt(*v)
+	}
+	return _c
+}
+
+// SetMaxVotes sets the "max_votes" field.
+func (_c *PollCreate) SetMaxVotes(v int) *PollCreate {
+	_c.mutation.SetMaxVotes(v)
+	return _c
+}
+
+// SetNillableMaxVotes sets the "max_votes" field if the given value is not nil.
+func (_c *PollCreate) SetNillableMaxVotes(v *int) *PollCreate {
+	if v != nil {
+		_c.SetMaxVotes(*v)
+	}
+	return _c
+}
+
+// SetQuorumPercent sets the "quorum_percent" field.
+func (_c *PollCreate) SetQuorumPercent(v int) *PollCreate {
+	_c.mutation.SetQuorumPercent(v)
+	return _c
+}
+
+// SetNillableQuorumPercent sets the "quorum_percent" field if the given value is not nil.
+func (_c *PollCreate) SetNillableQuorumPercent(v *int) *PollCreate {
+	if v != nil {
+		_c.SetQuorumPercent(*v)
+	}
+	return _c
+}
+
+// SetCloseOnDecisiveLead sets the "close_on_decisive_lead" field.
+func (_c *PollCreate) SetCloseOnDecisiveLead(v bool) *PollCreate {
+	_c.mutation.SetCloseOnDecisiveLead(v)
+	return _c
+}
+
+// SetNillableCloseOnDecisiveLead sets the "close_on_decisive_lead" field if the given value is not nil.
+func (_c *PollCreate) SetNillableCloseOnDecisiveLead(v *bool) *PollCreate {
+	if v != nil {
+		_c.SetCloseOnDecisiveLead(*v)
+	}
+	return _c
+}
+
+// SetClosedReason sets the "closed_reason" field.
+func (_c *PollCreate) SetClosedReason(v poll.ClosedReason) *PollCreate {
+	_c.mutation.SetClosedReason(v)
+	return _c
+}
+
+// SetNillableClosedReason sets the "closed_reason" field if the given value is not nil.
+func (_c *PollCreate) SetNillableClosedReason(v *poll.ClosedReason) *PollCreate {
+	if v != nil {
+		_c.SetClosedReason(*v)
+	}
+	return _c
+}
+
+// SetResultsVisibility sets the "results_visibility" field.
+func (_c *PollCreate) SetResultsVisibility(v poll.ResultsVisibility) *PollCreate {
+	_c.mutation.SetResultsVisibility(v)
+	return _c
+}
+
+// SetNillableResultsVisibility sets the "results_visibility" field if the given value is not nil.
+func (_c *PollCreate) SetNillableResultsVisibility(v *poll.ResultsVisibility) *PollCreate {
+	if v != nil {
+		_c.SetResultsVisibility(*v)
+	}
+	return _c
+}
+
+// SetResultsVisibleAt sets the "results_visible_at" field.
+func (_c *PollCreate) SetResultsVisibleAt(v time.Time) *PollCreate {
+	_c.mutation.SetResultsVisibleAt(v)
+	return _c
+}
+
+// SetNillableResultsVisibleAt sets the "results_visible_at" field if the given value is not nil.
+func (_c *PollCreate) SetNillableResultsVisibleAt(v *time.Time) *PollCreate {
+	if v != nil {
+		_c.SetResultsVisibleAt(*v)
+	}
+	return _c
+}
+
+// SetStatus sets the "status" field.
+func (_c *PollCreate) SetStatus(v poll.Status) *PollCreate {
+	_c.mutation.SetStatus(v)
+	return _c
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_c *PollCreate) SetNillableStatus(v *poll.Status) *PollCreate {
+	if v != nil {
+		_c.SetStatus(*v)
+	}
+	return _c
+}
+
+// SetPublishedAt sets the "published_at" field.
+func (_c *PollCreate) SetPublishedAt(v time.Time) *PollCreate {
+	_c.mutation.SetPublishedAt(v)
+	return _c
+}
+
+// SetNillablePublishedAt sets the "published_at" field if the given value is not nil.
+func (_c *PollCreate) SetNillablePublishedAt(v *time.Time) *PollCreate {
+	if v != nil {
+		_c.SetPublishedAt(*v)
+	}
+	return _c
+}
+
+// SetAnonymous sets the "anonymous" field.
+func (_c *PollCreate) SetAnonymous(v bool) *PollCreate {
+	_c.mutation.SetAnonymous(v)
+	return _c
+}
+
+// SetNillableAnonymous sets the "anonymous" field if the given value is not nil.
+func (_c *PollCreate) SetNillableAnonymous(v *bool) *PollCreate {
+	if v != nil {
+		_c.SetAnonymous(*v)
+	}
+	return _c
+}
+
+// SetEmbeddable sets the "embeddable" field.
+func (_c *PollCreate) SetEmbeddable(v bool) *PollCreate {
+	_c.mutation.SetEmbeddable(v)
+	return _c
+}
+
+// SetNillableEmbeddable sets the "embeddable" field if the given value is not nil.
+func (_c *PollCreate) SetNillableEmbeddable(v *bool) *PollCreate {
+	if v != nil {
+		_c.SetEmbeddable(*v)
+	}
+	return _c
+}
+
+// SetAllowGuests sets the "allow_guests" field.
+func (_c *PollCreate) SetAllowGuests(v bool) *PollCreate {
+	_c.mutation.SetAllowGuests(v)
+	return _c
+}
+
+// SetNillableAllowGuests sets the "allow_guests" field if the given value is not nil.
+func (_c *PollCreate) SetNillableAllowGuests(v *bool) *PollCreate {
+	if v != nil {
+		_c.SetAllowGuests(*v)
+	}
+	return _c
+}
+
+// SetOwnerID sets the "owner_id" field.
+func (_c *PollCreate) SetOwnerID(v string) *PollCreate {
+	_c.mutation.SetOwnerID(v)
+	return _c
+}
+
+// SetNillableOwnerID sets the "owner_id" field if the given value is not nil.
+func (_c *PollCreate) SetNillableOwnerID(v *string) *PollCreate {
+	if v != nil {
+		_c.SetOwnerID(*v)
+	}
+	return _c
+}
+
 // AddOptionIDs adds the "options" edge to the PollOption entity by IDs.
 func (_c *PollCreate) AddOptionIDs(ids ...int) *PollCreate {
 	_c.mutation.AddOptionIDs(ids...)
@@ -86,6 +314,56 @@ func (_c *PollCreate) AddVotes(v ...*Vote) *PollCreate {
 	return _c.AddVoteIDs(ids...)
 }
 
+// AddRevisionIDs adds the "revisions" edge to the PollRevision entity by IDs.
+func (_c *PollCreate) AddRevisionIDs(ids ...int) *PollCreate {
+	_c.mutation.AddRevisionIDs(ids...)
+	return _c
+}
+
+// AddRevisions adds the "revisions" edges to the PollRevision entity.
+func (_c *PollCreate) AddRevisions(v ...*PollRevision) *PollCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddRevisionIDs(ids...)
+}
+
+// AddTagIDs adds the "tags" edge to the Tag entity by IDs.
+func (_c *PollCreate) AddTagIDs(ids ...int) *PollCreate {
+	_c.mutation.AddTagIDs(ids...)
+	return _c
+}
+
+// AddTags adds the "tags" edges to the Tag entity.
+func (_c *PollCreate) AddTags(v ...*Tag) *PollCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddTagIDs(ids...)
+}
+
+// AddShareLinkIDs adds the "share_links" edge to the ShareLink entity by IDs.
+func (_c *PollCreate) AddShareLinkIDs(ids ...int) *PollCreate {
+	_c.mutation.AddShareLinkIDs(ids...)
+	return _c
+}
+
+// AddShareLinks adds the "share_links" edges to the ShareLink entity.
+func (_c *PollCreate) AddShareLinks(v ...*ShareLink) *PollCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddShareLinkIDs(ids...)
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_c *PollCreate) SetOwner(v *User) *PollCreate {
+	return _c.SetOwnerID(v.ID)
+}
+
 // Mutation returns the PollMutation object of the builder.
 func (_c *PollCreate) Mutation() *PollMutation {
 	return _c.mutation
@@ -129,6 +407,34 @@ func (_c *PollCreate) defaults() {
 		v := poll.DefaultCreatedAt()
 		_c.mutation.SetCreatedAt(v)
 	}
+	if _, ok := _c.mutation.TotalVotes(); !ok {
+		v := poll.DefaultTotalVotes
+		_c.mutation.SetTotalVotes(v)
+	}
+	if _, ok := _c.mutation.CloseOnDecisiveLead(); !ok {
+		v := poll.DefaultCloseOnDecisiveLead
+		_c.mutation.SetCloseOnDecisiveLead(v)
+	}
+	if _, ok := _c.mutation.ResultsVisibility(); !ok {
+		v := poll.DefaultResultsVisibility
+		_c.mutation.SetResultsVisibility(v)
+	}
+	if _, ok := _c.mutation.Status(); !ok {
+		v := poll.DefaultStatus
+		_c.mutation.SetStatus(v)
+	}
+	if _, ok := _c.mutation.Anonymous(); !ok {
+		v := poll.DefaultAnonymous
+		_c.mutation.SetAnonymous(v)
+	}
+	if _, ok := _c.mutation.Embeddable(); !ok {
+		v := poll.DefaultEmbeddable
+		_c.mutation.SetEmbeddable(v)
+	}
+	if _, ok := _c.mutation.AllowGuests(); !ok {
+		v := poll.DefaultAllowGuests
+		_c.mutation.SetAllowGuests(v)
+	}
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -142,6 +448,52 @@ func (_c *PollCreate) check() error {
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Poll.created_at"`)}
 	}
+	if _, ok := _c.mutation.TotalVotes(); !ok {
+		return &ValidationError{Name: "total_votes", err: errors.New(`ent: missing required field "Poll.total_votes"`)}
+	}
+	if v, ok := _c.mutation.MaxVotes(); ok {
+		if err := poll.MaxVotesValidator(v); err != nil {
+			return &ValidationError{Name: "max_votes", err: fmt.Errorf(`ent: validator failed for field "Poll.max_votes": %w`, err)}
+		}
+	}
+	if v, ok := _c.mutation.QuorumPercent(); ok {
+		if err := poll.QuorumPercentValidator(v); err != nil {
+			return &ValidationError{Name: "quorum_percent", err: fmt.Errorf(`ent: validator failed for field "Poll.quorum_percent": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CloseOnDecisiveLead(); !ok {
+		return &ValidationError{Name: "close_on_decisive_lead", err: errors.New(`ent: missing required field "Poll.close_on_decisive_lead"`)}
+	}
+	if v, ok := _c.mutation.ClosedReason(); ok {
+		if err := poll.ClosedReasonValidator(v); err != nil {
+			return &ValidationError{Name: "closed_reason", err: fmt.Errorf(`ent: validator failed for field "Poll.closed_reason": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.ResultsVisibility(); !ok {
+		return &ValidationError{Name: "results_visibility", err: errors.New(`ent: missing required field "Poll.results_visibility"`)}
+	}
+	if v, ok := _c.mutation.ResultsVisibility(); ok {
+		if err := poll.ResultsVisibilityValidator(v); err != nil {
+			return &ValidationError{Name: "results_visibility", err: fmt.Errorf(`ent: validator failed for field "Poll.results_visibility": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "Poll.status"`)}
+	}
+	if v, ok := _c.mutation.Status(); ok {
+		if err := poll.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Poll.status": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Anonymous(); !ok {
+		return &ValidationError{Name: "anonymous", err: errors.New(`ent: missing required field "Poll.anonymous"`)}
+	}
+	if _, ok := _c.mutation.Embeddable(); !ok {
+		return &ValidationError{Name: "embeddable", err: errors.New(`ent: missing required field "Poll.embeddable"`)}
+	}
+	if _, ok := _c.mutation.AllowGuests(); !ok {
+		return &ValidationError{Name: "allow_guests", err: errors.New(`ent: missing required field "Poll.allow_guests"`)}
+	}
 	return nil
 }
 
@@ -172,6 +524,10 @@ func (_c *PollCreate) createSpec() (*Poll, *sqlgraph.CreateSpec) {
 		_spec.SetField(poll.FieldTitle, field.TypeString, value)
 		_node.Title = value
 	}
+	if value, ok := _c.mutation.Description(); ok {
+		_spec.SetField(poll.FieldDescription, field.TypeString, value)
+		_node.Description = value
+	}
 	if value, ok := _c.mutation.IsOpen(); ok {
 		_spec.SetField(poll.FieldIsOpen, field.TypeBool, value)
 		_node.IsOpen = value
@@ -180,6 +536,62 @@ func (_c *PollCreate) createSpec() (*Poll, *sqlgraph.CreateSpec) {
 		_spec.SetField(poll.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
 	}
+	if value, ok := _c.mutation.TotalVotes(); ok {
+		_spec.SetField(poll.FieldTotalVotes, field.TypeInt, value)
+		_node.TotalVotes = value
+	}
+	if value, ok := _c.mutation.OpensAt(); ok {
+		_spec.SetField(poll.FieldOpensAt, field.TypeTime, value)
+		_node.OpensAt = &value
+	}
+	if value, ok := _c.mutation.ClosesAt(); ok {
+		_spec.SetField(poll.FieldClosesAt, field.TypeTime, value)
+		_node.ClosesAt = &value
+	}
+	if value, ok := _c.mutation.MaxVotes(); ok {
+		_spec.SetField(poll.FieldMaxVotes, field.TypeInt, value)
+		_node.MaxVotes = &value
+	}
+	if value, ok := _c.mutation.QuorumPercent(); ok {
+		_spec.SetField(poll.FieldQuorumPercent, field.TypeInt, value)
+		_node.QuorumPercent = &value
+	}
+	if value, ok := _c.mutation.CloseOnDecisiveLead(); ok {
+		_spec.SetField(poll.FieldCloseOnDecisiveLead, field.TypeBool, value)
+		_node.CloseOnDecisiveLead = value
+	}
+	if value, ok := _c.mutation.ClosedReason(); ok {
+		_spec.SetField(poll.FieldClosedReason, field.TypeEnum, value)
+		_node.ClosedReason = &value
+	}
+	if value, ok := _c.mutation.ResultsVisibility(); ok {
+		_spec.SetField(poll.FieldResultsVisibility, field.TypeEnum, value)
+		_node.ResultsVisibility = value
+	}
+	if value, ok := _c.mutation.ResultsVisibleAt(); ok {
+		_spec.SetField(poll.FieldResultsVisibleAt, field.TypeTime, value)
+		_node.ResultsVisibleAt = &value
+	}
+	if value, ok := _c.mutation.Status(); ok {
+		_spec.SetField(poll.FieldStatus, field.TypeEnum, value)
+		_node.Status = value
+	}
+	if value, ok := _c.mutation.PublishedAt(); ok {
+		_spec.SetField(poll.FieldPublishedAt, field.TypeTime, value)
+		_node.PublishedAt = &value
+	}
+	if value, ok := _c.mutation.Anonymous(); ok {
+		_spec.SetField(poll.FieldAnonymous, field.TypeBool, value)
+		_node.Anonymous = value
+	}
+	if value, ok := _c.mutation.Embeddable(); ok {
+		_spec.SetField(poll.FieldEmbeddable, field.TypeBool, value)
+		_node.Embeddable = value
+	}
+	if value, ok := _c.mutation.AllowGuests(); ok {
+		_spec.SetField(poll.FieldAllowGuests, field.TypeBool, value)
+		_node.AllowGuests = value
+	}
 	if nodes := _c.mutation.OptionsIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -212,6 +624,71 @@ func (_c *PollCreate) createSpec() (*Poll, *sqlgraph.CreateSpec) {
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := _c.mutation.RevisionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.RevisionsTable,
+			Columns: []string{poll.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollrevision.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.TagsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.TagsTable,
+			Columns: poll.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.ShareLinksIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.ShareLinksTable,
+			Columns: []string{poll.ShareLinksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(sharelink.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.OwnerTable,
+			Columns: []string{poll.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.OwnerID = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -5,7 +5,11 @@ package ent
 import (
 	"api_voty/ent/poll"
 	"api_voty/ent/polloption"
+	"api_voty/ent/pollrevision"
 	"api_voty/ent/predicate"
+	"api_voty/ent/sharelink"
+	"api_voty/ent/tag"
+	"api_voty/ent/user"
 	"api_voty/ent/vote"
 	"context"
 	"errors"
@@ -44,6 +48,26 @@ func (_u *PollUpdate) SetNillableTitle(v *string) *PollUpdate {
 	return _u
 }
 
+// SetDescription sets the "description" field.
+func (_u *PollUpdate) SetDescription(v string) *PollUpdate {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableDescription(v *string) *PollUpdate {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// ClearDescription clears the value of the "description" field.
+func (_u *PollUpdate) ClearDescription() *PollUpdate {
+	_u.mutation.ClearDescription()
+	return _u
+}
+
 // SetIsOpen sets the "is_open" field.
 func (_u *PollUpdate) SetIsOpen(v bool) *PollUpdate {
 	_u.mutation.SetIsOpen(v)
@@ -72,6 +96,271 @@ func (_u *PollUpdate) SetNillableCreatedAt(v *time.Time) *PollUpdate {
 	return _u
 }
 
+// SetTotalVotes sets the "total_votes" field.
+func (_u *PollUpdate) SetTotalVotes(v int) *PollUpdate {
+	_u.mutation.ResetTotalVotes()
+	_u.mutation.SetTotalVotes(v)
+	return _u
+}
+
+// SetNillableTotalVotes sets the "total_votes" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableTotalVotes(v *int) *PollUpdate {
+	if v != nil {
+		_u.SetTotalVotes(*v)
+	}
+	return _u
+}
+
+// AddTotalVotes adds value to the "total_votes" field.
+func (_u *PollUpdate) AddTotalVotes(v int) *PollUpdate {
+	_u.mutation.AddTotalVotes(v)
+	return _u
+}
+
+// SetOpensAt sets the "opens_at" field.
+func (_u *PollUpdate) SetOpensAt(v time.Time) *PollUpdate {
+	_u.mutation.SetOpensAt(v)
+	return _u
+}
+
+// SetNillableOpensAt sets the "opens_at" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableOpensAt(v *time.Time) *PollUpdate {
+	if v != nil {
+		_u.SetOpensAt(*v)
+	}
+	return _u
+}
+
+// ClearOpensAt clears the value of the "opens_at" field.
+func (_u *PollUpdate) ClearOpensAt() *PollUpdate {
+	_u.mutation.ClearOpensAt()
+	return _u
+}
+
+// SetClosesAt sets the "closes_at" field.
+func (_u *PollUpdate) SetClosesAt(v time.Time) *PollUpdate {
+	_u.mutation.SetClosesAt(v)
+	return _u
+}
+
+// SetNillableClosesAt sets the "closes_at" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableClosesAt(v *time.Time) *PollUpdate {
+	if v != nil {
+		_u.SetClosesAt(*v)
+	}
+	return _u
+}
+
+// ClearClosesAt clears the value of the "closes_at" field.
+func (_u *PollUpdate) ClearClosesAt() *PollUpdate {
+	_u.mutation.ClearClosesAt()
+	return _u
+}
+
+// SetMaxVotes sets the "max_votes" field.
+func (_u *PollUpdate) SetMaxVotes(v int) *PollUpdate {
+	_u.mutation.ResetMaxVotes()
+	_u.mutation.SetMaxVotes(v)
+	return _u
+}
+
+// SetNillableMaxVotes sets the "max_votes" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableMaxVotes(v *int) *PollUpdate {
+	if v != nil {
+		_u.SetMaxVotes(*v)
+	}
+	return _u
+}
+
+// AddMaxVotes adds value to the "max_votes" field.
+func (_u *PollUpdate) AddMaxVotes(v int) *PollUpdate {
+	_u.mutation.AddMaxVotes(v)
+	return _u
+}
+
+// ClearMaxVotes clears the value of the "max_votes" field.
+func (_u *PollUpdate) ClearMaxVotes() *PollUpdate {
+	_u.mutation.ClearMaxVotes()
+	return _u
+}
+
+// SetQuorumPercent sets the "quorum_percent" field.
+func (_u *PollUpdate) SetQuorumPercent(v int) *PollUpdate {
+	_u.mutation.ResetQuorumPercent()
+	_u.mutation.SetQuorumPercent(v)
+	return _u
+}
+
+// SetNillableQuorumPercent sets the "quorum_percent" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableQuorumPercent(v *int) *PollUpdate {
+	if v != nil {
+		_u.SetQuorumPercent(*v)
+	}
+	return _u
+}
+
+// AddQuorumPercent adds value to the "quorum_percent" field.
+func (_u *PollUpdate) AddQuorumPercent(v int) *PollUpdate {
+	_u.mutation.AddQuorumPercent(v)
+	return _u
+}
+
+// ClearQuorumPercent clears the value of the "quorum_percent" field.
+func (_u *PollUpdate) ClearQuorumPercent() *PollUpdate {
+	_u.mutation.ClearQuorumPercent()
+	return _u
+}
+
+// SetCloseOnDecisiveLead sets the "close_on_decisive_lead" field.
+func (_u *PollUpdate) SetCloseOnDecisiveLead(v bool) *PollUpdate {
+	_u.mutation.SetCloseOnDecisiveLead(v)
+	return _u
+}
+
+// SetNillableCloseOnDecisiveLead sets the "close_on_decisive_lead" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableCloseOnDecisiveLead(v *bool) *PollUpdate {
+	if v != nil {
+		_u.SetCloseOnDecisiveLead(*v)
+	}
+	return _u
+}
+
+// SetClosedReason sets the "closed_reason" field.
+func (_u *PollUpdate) SetClosedReason(v poll.ClosedReason) *PollUpdate {
+	_u.mutation.SetClosedReason(v)
+	return _u
+}
+
+// SetNillableClosedReason sets the "closed_reason" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableClosedReason(v *poll.ClosedReason) *PollUpdate {
+	if v != nil {
+		_u.SetClosedReason(*v)
+	}
+	return _u
+}
+
+// ClearClosedReason clears the value of the "closed_reason" field.
+func (_u *PollUpdate) ClearClosedReason() *PollUpdate {
+	_u.mutation.ClearClosedReason()
+	return _u
+}
+
+// SetResultsVisibility sets the "results_visibility" field.
+func (_u *PollUpdate) SetResultsVisibility(v poll.ResultsVisibility) *PollUpdate {
+	_u.mutation.SetResultsVisibility(v)
+	return _u
+}
+
+// SetNillableResultsVisibility sets the "results_visibility" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableResultsVisibility(v *poll.ResultsVisibility) *PollUpdate {
+	if v != nil {
+		_u.SetResultsVisibility(*v)
+	}
+	return _u
+}
+
+// SetResultsVisibleAt sets the "results_visible_at" field.
+func (_u *PollUpdate) SetResultsVisibleAt(v time.Time) *PollUpdate {
+	_u.mutation.SetResultsVisibleAt(v)
+	return _u
+}
+
+// SetNillableResultsVisibleAt sets the "results_visible_at" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableResultsVisibleAt(v *time.Time) *PollUpdate {
+	if v != nil {
+		_u.SetResultsVisibleAt(*v)
+	}
+	return _u
+}
+
+// ClearResultsVisibleAt clears the value of the "results_visible_at" field.
+func (_u *PollUpdate) ClearResultsVisibleAt() *PollUpdate {
+	_u.mutation.ClearResultsVisibleAt()
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *PollUpdate) SetStatus(v poll.Status) *PollUpdate {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableStatus(v *poll.Status) *PollUpdate {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetPublishedAt sets the "published_at" field.
+func (_u *PollUpdate) SetPublishedAt(v time.Time) *PollUpdate {
+	_u.mutation.SetPublishedAt(v)
+	return _u
+}
+
+// SetNillablePublishedAt sets the "published_at" field if the given value is not nil.
+func (_u *PollUpdate) SetNillablePublishedAt(v *time.Time) *PollUpdate {
+	if v != nil {
+		_u.SetPublishedAt(*v)
+	}
+	return _u
+}
+
+// ClearPublishedAt clears the value of the "published_at" field.
+func (_u *PollUpdate) ClearPublishedAt() *PollUpdate {
+	_u.mutation.ClearPublishedAt()
+	return _u
+}
+
+// SetEmbeddable sets the "embeddable" field.
+func (_u *PollUpdate) SetEmbeddable(v bool) *PollUpdate {
+	_u.mutation.SetEmbeddable(v)
+	return _u
+}
+
+// SetNillableEmbeddable sets the "embeddable" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableEmbeddable(v *bool) *PollUpdate {
+	if v != nil {
+		_u.SetEmbeddable(*v)
+	}
+	return _u
+}
+
+// SetAllowGuests sets the "allow_guests" field.
+func (_u *PollUpdate) SetAllowGuests(v bool) *PollUpdate {
+	_u.mutation.SetAllowGuests(v)
+	return _u
+}
+
+// SetNillableAllowGuests sets the "allow_guests" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableAllowGuests(v *bool) *PollUpdate {
+	if v != nil {
+		_u.SetAllowGuests(*v)
+	}
+	return _u
+}
+
+// SetOwnerID sets the "owner_id" field.
+func (_u *PollUpdate) SetOwnerID(v string) *PollUpdate {
+	_u.mutation.SetOwnerID(v)
+	return _u
+}
+
+// SetNillableOwnerID sets the "owner_id" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableOwnerID(v *string) *PollUpdate {
+	if v != nil {
+		_u.SetOwnerID(*v)
+	}
+	return _u
+}
+
+// ClearOwnerID clears the value of the "owner_id" field.
+func (_u *PollUpdate) ClearOwnerID() *PollUpdate {
+	_u.mutation.ClearOwnerID()
+	return _u
+}
+
 // AddOptionIDs adds the "options" edge to the PollOption entity by IDs.
 func (_u *PollUpdate) AddOptionIDs(ids ...int) *PollUpdate {
 	_u.mutation.AddOptionIDs(ids...)
@@ -102,6 +391,56 @@ func (_u *PollUpdate) AddVotes(v ...*Vote) *PollUpdate {
 	return _u.AddVoteIDs(ids...)
 }
 
+// AddRevisionIDs adds the "revisions" edge to the PollRevision entity by IDs.
+func (_u *PollUpdate) AddRevisionIDs(ids ...int) *PollUpdate {
+	_u.mutation.AddRevisionIDs(ids...)
+	return _u
+}
+
+// AddRevisions adds the "revisions" edges to the PollRevision entity.
+func (_u *PollUpdate) AddRevisions(v ...*PollRevision) *PollUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddRevisionIDs(ids...)
+}
+
+// AddTagIDs adds the "tags" edge to the Tag entity by IDs.
+func (_u *PollUpdate) AddTagIDs(ids ...int) *PollUpdate {
+	_u.mutation.AddTagIDs(ids...)
+	return _u
+}
+
+// AddTags adds the "tags" edges to the Tag entity.
+func (_u *PollUpdate) AddTags(v ...*Tag) *PollUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddTagIDs(ids...)
+}
+
+// AddShareLinkIDs adds the "share_links" edge to the ShareLink entity by IDs.
+func (_u *PollUpdate) AddShareLinkIDs(ids ...int) *PollUpdate {
+	_u.mutation.AddShareLinkIDs(ids...)
+	return _u
+}
+
+// AddShareLinks adds the "share_links" edges to the ShareLink entity.
+func (_u *PollUpdate) AddShareLinks(v ...*ShareLink) *PollUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddShareLinkIDs(ids...)
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_u *PollUpdate) SetOwner(v *User) *PollUpdate {
+	return _u.SetOwnerID(v.ID)
+}
+
 // Mutation returns the PollMutation object of the builder.
 func (_u *PollUpdate) Mutation() *PollMutation {
 	return _u.mutation
@@ -149,6 +488,75 @@ func (_u *PollUpdate) RemoveVotes(v ...*Vote) *PollUpdate {
 	return _u.RemoveVoteIDs(ids...)
 }
 
+// ClearRevisions clears all "revisions" edges to the PollRevision entity.
+func (_u *PollUpdate) ClearRevisions() *PollUpdate {
+	_u.mutation.ClearRevisions()
+	return _u
+}
+
+// RemoveRevisionIDs removes the "revisions" edge to PollRevision entities by IDs.
+func (_u *PollUpdate) RemoveRevisionIDs(ids ...int) *PollUpdate {
+	_u.mutation.RemoveRevisionIDs(ids...)
+	return _u
+}
+
+// RemoveRevisions removes "revisions" edges to PollRevision entities.
+func (_u *PollUpdate) RemoveRevisions(v ...*PollRevision) *PollUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveRevisionIDs(ids...)
+}
+
+// ClearTags clears all "tags" edges to the Tag entity.
+func (_u *PollUpdate) ClearTags() *PollUpdate {
+	_u.mutation.ClearTags()
+	return _u
+}
+
+// RemoveTagIDs removes the "tags" edge to Tag entities by IDs.
+func (_u *PollUpdate) RemoveTagIDs(ids ...int) *PollUpdate {
+	_u.mutation.RemoveTagIDs(ids...)
+	return _u
+}
+
+// RemoveTags removes "tags" edges to Tag entities.
+func (_u *PollUpdate) RemoveTags(v ...*Tag) *PollUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveTagIDs(ids...)
+}
+
+// ClearShareLinks clears all "share_links" edges to the ShareLink entity.
+func (_u *PollUpdate) ClearShareLinks() *PollUpdate {
+	_u.mutation.ClearShareLinks()
+	return _u
+}
+
+// RemoveShareLinkIDs removes the "share_links" edge to ShareLink entities by IDs.
+func (_u *PollUpdate) RemoveShareLinkIDs(ids ...int) *PollUpdate {
+	_u.mutation.RemoveShareLinkIDs(ids...)
+	return _u
+}
+
+// RemoveShareLinks removes "share_links" edges to ShareLink entities.
+func (_u *PollUpdate) RemoveShareLinks(v ...*ShareLink) *PollUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveShareLinkIDs(ids...)
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (_u *PollUpdate) ClearOwner() *PollUpdate {
+	_u.mutation.ClearOwner()
+	return _u
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *PollUpdate) Save(ctx context.Context) (int, error) {
 	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
@@ -176,7 +584,40 @@ func (_u *PollUpdate) ExecX(ctx context.Context) {
 	}
 }
 
+// check runs all checks and user-defined validators on the builder.
+func (_u *PollUpdate) check() error {
+	if v, ok := _u.mutation.MaxVotes(); ok {
+		if err := poll.MaxVotesValidator(v); err != nil {
+			return &ValidationError{Name: "max_votes", err: fmt.Errorf(`ent: validator failed for field "Poll.max_votes": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.QuorumPercent(); ok {
+		if err := poll.QuorumPercentValidator(v); err != nil {
+			return &ValidationError{Name: "quorum_percent", err: fmt.Errorf(`ent: validator failed for field "Poll.quorum_percent": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.ClosedReason(); ok {
+		if err := poll.ClosedReasonValidator(v); err != nil {
+			return &ValidationError{Name: "closed_reason", err: fmt.Errorf(`ent: validator failed for field "Poll.closed_reason": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.ResultsVisibility(); ok {
+		if err := poll.ResultsVisibilityValidator(v); err != nil {
+			return &ValidationError{Name: "results_visibility", err: fmt.Errorf(`ent: validator failed for field "Poll.results_visibility": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Status(); ok {
+		if err := poll.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Poll.status": %w`, err)}
+		}
+	}
+	return nil
+}
+
 func (_u *PollUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
 	_spec := sqlgraph.NewUpdateSpec(poll.Table, poll.Columns, sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt))
 	if ps := _u.mutation.predicates; len(ps) > 0 {
 		_spec.Predicate = func(selector *sql.Selector) {
@@ -188,30 +629,105 @@ func (_u *PollUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.Title(); ok {
 		_spec.SetField(poll.FieldTitle, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.Description(); ok {
+		_spec.SetField(poll.FieldDescription, field.TypeString, value)
+	}
+	if _u.mutation.DescriptionCleared() {
+		_spec.ClearField(poll.FieldDescription, field.TypeString)
+	}
 	if value, ok := _u.mutation.IsOpen(); ok {
 		_spec.SetField(poll.FieldIsOpen, field.TypeBool, value)
 	}
 	if value, ok := _u.mutation.CreatedAt(); ok {
 		_spec.SetField(poll.FieldCreatedAt, field.TypeTime, value)
 	}
-	if _u.mutation.OptionsCleared() {
-		edge := &sqlgraph.EdgeSpec{
-			Rel:     sqlgraph.O2M,
-			Inverse: false,
-			Table:   poll.OptionsTable,
-			Columns: []string{poll.OptionsColumn},
-			Bidi:    false,
-			Target: &sqlgraph.EdgeTarget{
-				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeInt),
-			},
-		}
-		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	if value, ok := _u.mutation.TotalVotes(); ok {
+		_spec.SetField(poll.FieldTotalVotes, field.TypeInt, value)
 	}
-	if nodes := _u.mutation.RemovedOptionsIDs(); len(nodes) > 0 && !_u.mutation.OptionsCleared() {
-		edge := &sqlgraph.EdgeSpec{
-			Rel:     sqlgraph.O2M,
-			Inverse: false,
-			Table:   poll.OptionsTable,
+	if value, ok := _u.mutation.AddedTotalVotes(); ok {
+		_spec.AddField(poll.FieldTotalVotes, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.OpensAt(); ok {
+		_spec.SetField(poll.FieldOpensAt, field.TypeTime, value)
+	}
+	if _u.mutation.OpensAtCleared() {
+		_spec.ClearField(poll.FieldOpensAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.ClosesAt(); ok {
+		_spec.SetField(poll.FieldClosesAt, field.TypeTime, value)
+	}
+	if _u.mutation.ClosesAtCleared() {
+		_spec.ClearField(poll.FieldClosesAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.MaxVotes(); ok {
+		_spec.SetField(poll.FieldMaxVotes, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxVotes(); ok {
+		_spec.AddField(poll.FieldMaxVotes, field.TypeInt, value)
+	}
+	if _u.mutation.MaxVotesCleared() {
+		_spec.ClearField(poll.FieldMaxVotes, field.TypeInt)
+	}
+	if value, ok := _u.mutation.QuorumPercent(); ok {
+		_spec.SetField(poll.FieldQuorumPercent, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedQuorumPercent(); ok {
+		_spec.AddField(poll.FieldQuorumPercent, field.TypeInt, value)
+	}
+	if _u.mutation.QuorumPercentCleared() {
+		_spec.ClearField(poll.FieldQuorumPercent, field.TypeInt)
+	}
+	if value, ok := _u.mutation.CloseOnDecisiveLead(); ok {
+		_spec.SetField(poll.FieldCloseOnDecisiveLead, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.ClosedReason(); ok {
+		_spec.SetField(poll.FieldClosedReason, field.TypeEnum, value)
+	}
+	if _u.mutation.ClosedReasonCleared() {
+		_spec.ClearField(poll.FieldClosedReason, field.TypeEnum)
+	}
+	if value, ok := _u.mutation.ResultsVisibility(); ok {
+		_spec.SetField(poll.FieldResultsVisibility, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.ResultsVisibleAt(); ok {
+		_spec.SetField(poll.FieldResultsVisibleAt, field.TypeTime, value)
+	}
+	if _u.mutation.ResultsVisibleAtCleared() {
+		_spec.ClearField(poll.FieldResultsVisibleAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(poll.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.PublishedAt(); ok {
+		_spec.SetField(poll.FieldPublishedAt, field.TypeTime, value)
+	}
+	if _u.mutation.PublishedAtCleared() {
+		_spec.ClearField(poll.FieldPublishedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Embeddable(); ok {
+		_spec.SetField(poll.FieldEmbeddable, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.AllowGuests(); ok {
+		_spec.SetField(poll.FieldAllowGuests, field.TypeBool, value)
+	}
+	if _u.mutation.OptionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.OptionsTable,
+			Columns: []string{poll.OptionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedOptionsIDs(); len(nodes) > 0 && !_u.mutation.OptionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.OptionsTable,
 			Columns: []string{poll.OptionsColumn},
 			Bidi:    false,
 			Target: &sqlgraph.EdgeTarget{
@@ -284,6 +800,170 @@ func (_u *PollUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.RevisionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.RevisionsTable,
+			Columns: []string{poll.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollrevision.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedRevisionsIDs(); len(nodes) > 0 && !_u.mutation.RevisionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.RevisionsTable,
+			Columns: []string{poll.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollrevision.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RevisionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.RevisionsTable,
+			Columns: []string{poll.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollrevision.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.TagsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.TagsTable,
+			Columns: poll.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedTagsIDs(); len(nodes) > 0 && !_u.mutation.TagsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.TagsTable,
+			Columns: poll.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.TagsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.TagsTable,
+			Columns: poll.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ShareLinksCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.ShareLinksTable,
+			Columns: []string{poll.ShareLinksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(sharelink.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedShareLinksIDs(); len(nodes) > 0 && !_u.mutation.ShareLinksCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.ShareLinksTable,
+			Columns: []string{poll.ShareLinksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(sharelink.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ShareLinksIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.ShareLinksTable,
+			Columns: []string{poll.ShareLinksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(sharelink.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.OwnerTable,
+			Columns: []string{poll.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.OwnerTable,
+			Columns: []string{poll.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{poll.Label}
@@ -292,60 +972,345 @@ func (_u *PollUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 		}
 		return 0, err
 	}
-	_u.mutation.done = true
-	return _node, nil
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// PollUpdateOne is the builder for updating a single Poll entity.
+type PollUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *PollMutation
+}
+
+// SetTitle sets the "title" field.
+func (_u *PollUpdateOne) SetTitle(v string) *PollUpdateOne {
+	_u.mutation.SetTitle(v)
+	return _u
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableTitle(v *string) *PollUpdateOne {
+	if v != nil {
+		_u.SetTitle(*v)
+	}
+	return _u
+}
+
+// SetDescription sets the "description" field.
+func (_u *PollUpdateOne) SetDescription(v string) *PollUpdateOne {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableDescription(v *string) *PollUpdateOne {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// ClearDescription clears the value of the "description" field.
+func (_u *PollUpdateOne) ClearDescription() *PollUpdateOne {
+	_u.mutation.ClearDescription()
+	return _u
+}
+
+// SetIsOpen sets the "is_open" field.
+func (_u *PollUpdateOne) SetIsOpen(v bool) *PollUpdateOne {
+	_u.mutation.SetIsOpen(v)
+	return _u
+}
+
+// SetNillableIsOpen sets the "is_open" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableIsOpen(v *bool) *PollUpdateOne {
+	if v != nil {
+		_u.SetIsOpen(*v)
+	}
+	return _u
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_u *PollUpdateOne) SetCreatedAt(v time.Time) *PollUpdateOne {
+	_u.mutation.SetCreatedAt(v)
+	return _u
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableCreatedAt(v *time.Time) *PollUpdateOne {
+	if v != nil {
+		_u.SetCreatedAt(*v)
+	}
+	return _u
+}
+
+// SetTotalVotes sets the "total_votes" field.
+func (_u *PollUpdateOne) SetTotalVotes(v int) *PollUpdateOne {
+	_u.mutation.ResetTotalVotes()
+	_u.mutation.SetTotalVotes(v)
+	return _u
+}
+
+// SetNillableTotalVotes sets the "total_votes" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableTotalVotes(v *int) *PollUpdateOne {
+	if v != nil {
+		_u.SetTotalVotes(*v)
+	}
+	return _u
+}
+
+// AddTotalVotes adds value to the "total_votes" field.
+func (_u *PollUpdateOne) AddTotalVotes(v int) *PollUpdateOne {
+	_u.mutation.AddTotalVotes(v)
+	return _u
+}
+
+// SetOpensAt sets the "opens_at" field.
+func (_u *PollUpdateOne) SetOpensAt(v time.Time) *PollUpdateOne {
+	_u.mutation.SetOpensAt(v)
+	return _u
+}
+
+// SetNillableOpensAt sets the "opens_at" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableOpensAt(v *time.Time) *PollUpdateOne {
+	if v != nil {
+		_u.SetOpensAt(*v)
+	}
+	return _u
+}
+
+// ClearOpensAt clears the value of the "opens_at" field.
+func (_u *PollUpdateOne) ClearOpensAt() *PollUpdateOne {
+	_u.mutation.ClearOpensAt()
+	return _u
+}
+
+// SetClosesAt sets the "closes_at" field.
+func (_u *PollUpdateOne) SetClosesAt(v time.Time) *PollUpdateOne {
+	_u.mutation.SetClosesAt(v)
+	return _u
+}
+
+// SetNillableClosesAt sets the "closes_at" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableClosesAt(v *time.Time) *PollUpdateOne {
+	if v != nil {
+		_u.SetClosesAt(*v)
+	}
+	return _u
+}
+
+// ClearClosesAt clears the value of the "closes_at" field.
+func (_u *PollUpdateOne) ClearClosesAt() *PollUpdateOne {
+	_u.mutation.ClearClosesAt()
+	return _u
+}
+
+// SetMaxVotes sets the "max_votes" field.
+func (_u *PollUpdateOne) SetMaxVotes(v int) *PollUpdateOne {
+	_u.mutation.ResetMaxVotes()
+	_u.mutation.SetMaxVotes(v)
+	return _u
+}
+
+// SetNillableMaxVotes sets the "max_votes" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableMaxVotes(v *int) *PollUpdateOne {
+	if v != nil {
+		_u.SetMaxVotes(*v)
+	}
+	return _u
+}
+
+// AddMaxVotes adds value to the "max_votes" field.
+func (_u *PollUpdateOne) AddMaxVotes(v int) *PollUpdateOne {
+	_u.mutation.AddMaxVotes(v)
+	return _u
+}
+
+// ClearMaxVotes clears the value of the "max_votes" field.
+func (_u *PollUpdateOne) ClearMaxVotes() *PollUpdateOne {
+	_u.mutation.ClearMaxVotes()
+	return _u
+}
+
+// SetQuorumPercent sets the "quorum_percent" field.
+func (_u *PollUpdateOne) SetQuorumPercent(v int) *PollUpdateOne {
+	_u.mutation.ResetQuorumPercent()
+	_u.mutation.SetQuorumPercent(v)
+	return _u
+}
+
+// SetNillableQuorumPercent sets the "quorum_percent" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableQuorumPercent(v *int) *PollUpdateOne {
+	if v != nil {
+		_u.SetQuorumPercent(*v)
+	}
+	return _u
+}
+
+// AddQuorumPercent adds value to the "quorum_percent" field.
+func (_u *PollUpdateOne) AddQuorumPercent(v int) *PollUpdateOne {
+	_u.mutation.AddQuorumPercent(v)
+	return _u
+}
+
+// ClearQuorumPercent clears the value of the "quorum_percent" field.
+func (_u *PollUpdateOne) ClearQuorumPercent() *PollUpdateOne {
+	_u.mutation.ClearQuorumPercent()
+	return _u
+}
+
+// SetCloseOnDecisiveLead sets the "close_on_decisive_lead" field.
+func (_u *PollUpdateOne) SetCloseOnDecisiveLead(v bool) *PollUpdateOne {
+	_u.mutation.SetCloseOnDecisiveLead(v)
+	return _u
+}
+
+// SetNillableCloseOnDecisiveLead sets the "close_on_decisive_lead" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableCloseOnDecisiveLead(v *bool) *PollUpdateOne {
+	if v != nil {
+		_u.SetCloseOnDecisiveLead(*v)
+	}
+	return _u
+}
+
+// SetClosedReason sets the "closed_reason" field.
+func (_u *PollUpdateOne) SetClosedReason(v poll.ClosedReason) *PollUpdateOne {
+	_u.mutation.SetClosedReason(v)
+	return _u
+}
+
+// SetNillableClosedReason sets the "closed_reason" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableClosedReason(v *poll.ClosedReason) *PollUpdateOne {
+	if v != nil {
+		_u.SetClosedReason(*v)
+	}
+	return _u
+}
+
+// ClearClosedReason clears the value of the "closed_reason" field.
+func (_u *PollUpdateOne) ClearClosedReason() *PollUpdateOne {
+	_u.mutation.ClearClosedReason()
+	return _u
+}
+
+// SetResultsVisibility sets the "results_visibility" field.
+func (_u *PollUpdateOne) SetResultsVisibility(v poll.ResultsVisibility) *PollUpdateOne {
+	_u.mutation.SetResultsVisibility(v)
+	return _u
+}
+
+// SetNillableResultsVisibility sets the "results_visibility" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableResultsVisibility(v *poll.ResultsVisibility) *PollUpdateOne {
+	if v != nil {
+		_u.SetResultsVisibility(*v)
+	}
+	return _u
+}
+
+// SetResultsVisibleAt sets the "results_visible_at" field.
+func (_u *PollUpdateOne) SetResultsVisibleAt(v time.Time) *PollUpdateOne {
+	_u.mutation.SetResultsVisibleAt(v)
+	return _u
+}
+
+// SetNillableResultsVisibleAt sets the "results_visible_at" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableResultsVisibleAt(v *time.Time) *PollUpdateOne {
+	if v != nil {
+		_u.SetResultsVisibleAt(*v)
+	}
+	return _u
+}
+
+// ClearResultsVisibleAt clears the value of the "results_visible_at" field.
+func (_u *PollUpdateOne) ClearResultsVisibleAt() *PollUpdateOne {
+	_u.mutation.ClearResultsVisibleAt()
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *PollUpdateOne) SetStatus(v poll.Status) *PollUpdateOne {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableStatus(v *poll.Status) *PollUpdateOne {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetPublishedAt sets the "published_at" field.
+func (_u *PollUpdateOne) SetPublishedAt(v time.Time) *PollUpdateOne {
+	_u.mutation.SetPublishedAt(v)
+	return _u
+}
+
+// SetNillablePublishedAt sets the "published_at" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillablePublishedAt(v *time.Time) *PollUpdateOne {
+	if v != nil {
+		_u.SetPublishedAt(*v)
+	}
+	return _u
 }
 
-// PollUpdateOne is the builder for updating a single Poll entity.
-type PollUpdateOne struct {
-	config
-	fields   []string
-	hooks    []Hook
-	mutation *PollMutation
+// ClearPublishedAt clears the value of the "published_at" field.
+func (_u *PollUpdateOne) ClearPublishedAt() *PollUpdateOne {
+	_u.mutation.ClearPublishedAt()
+	return _u
 }
 
-// SetTitle sets the "title" field.
-func (_u *PollUpdateOne) SetTitle(v string) *PollUpdateOne {
-	_u.mutation.SetTitle(v)
+// SetEmbeddable sets the "embeddable" field.
+func (_u *PollUpdateOne) SetEmbeddable(v bool) *PollUpdateOne {
+	_u.mutation.SetEmbeddable(v)
 	return _u
 }
 
-// SetNillableTitle sets the "title" field if the given value is not nil.
-func (_u *PollUpdateOne) SetNillableTitle(v *string) *PollUpdateOne {
+// SetNillableEmbeddable sets the "embeddable" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableEmbeddable(v *bool) *PollUpdateOne {
 	if v != nil {
-		_u.SetTitle(*v)
+		_u.SetEmbeddable(*v)
 	}
 	return _u
 }
 
-// SetIsOpen sets the "is_open" field.
-func (_u *PollUpdateOne) SetIsOpen(v bool) *PollUpdateOne {
-	_u.mutation.SetIsOpen(v)
+// SetAllowGuests sets the "allow_guests" field.
+func (_u *PollUpdateOne) SetAllowGuests(v bool) *PollUpdateOne {
+	_u.mutation.SetAllowGuests(v)
 	return _u
 }
 
-// SetNillableIsOpen sets the "is_open" field if the given value is not nil.
-func (_u *PollUpdateOne) SetNillableIsOpen(v *bool) *PollUpdateOne {
+// SetNillableAllowGuests sets the "allow_guests" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableAllowGuests(v *bool) *PollUpdateOne {
 	if v != nil {
-		_u.SetIsOpen(*v)
+		_u.SetAllowGuests(*v)
 	}
 	return _u
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (_u *PollUpdateOne) SetCreatedAt(v time.Time) *PollUpdateOne {
-	_u.mutation.SetCreatedAt(v)
+// SetOwnerID sets the "owner_id" field.
+func (_u *PollUpdateOne) SetOwnerID(v string) *PollUpdateOne {
+	_u.mutation.SetOwnerID(v)
 	return _u
 }
 
-// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
-func (_u *PollUpdateOne) SetNillableCreatedAt(v *time.Time) *PollUpdateOne {
+// SetNillableOwnerID sets the "owner_id" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableOwnerID(v *string) *PollUpdateOne {
 	if v != nil {
-		_u.SetCreatedAt(*v)
+		_u.SetOwnerID(*v)
 	}
 	return _u
 }
 
+// ClearOwnerID clears the value of the "owner_id" field.
+func (_u *PollUpdateOne) ClearOwnerID() *PollUpdateOne {
+	_u.mutation.ClearOwnerID()
+	return _u
+}
+
 // AddOptionIDs adds the "options" edge to the PollOption entity by IDs.
 func (_u *PollUpdateOne) AddOptionIDs(ids ...int) *PollUpdateOne {
 	_u.mutation.AddOptionIDs(ids...)
@@ -376,6 +1341,56 @@ func (_u *PollUpdateOne) AddVotes(v ...*Vote) *PollUpdateOne {
 	return _u.AddVoteIDs(ids...)
 }
 
+// AddRevisionIDs adds the "revisions" edge to the PollRevision entity by IDs.
+func (_u *PollUpdateOne) AddRevisionIDs(ids ...int) *PollUpdateOne {
+	_u.mutation.AddRevisionIDs(ids...)
+	return _u
+}
+
+// AddRevisions adds the "revisions" edges to the PollRevision entity.
+func (_u *PollUpdateOne) AddRevisions(v ...*PollRevision) *PollUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddRevisionIDs(ids...)
+}
+
+// AddTagIDs adds the "tags" edge to the Tag entity by IDs.
+func (_u *PollUpdateOne) AddTagIDs(ids ...int) *PollUpdateOne {
+	_u.mutation.AddTagIDs(ids...)
+	return _u
+}
+
+// AddTags adds the "tags" edges to the Tag entity.
+func (_u *PollUpdateOne) AddTags(v ...*Tag) *PollUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddTagIDs(ids...)
+}
+
+// AddShareLinkIDs adds the "share_links" edge to the ShareLink entity by IDs.
+func (_u *PollUpdateOne) AddShareLinkIDs(ids ...int) *PollUpdateOne {
+	_u.mutation.AddShareLinkIDs(ids...)
+	return _u
+}
+
+// AddShareLinks adds the "share_links" edges to the ShareLink entity.
+func (_u *PollUpdateOne) AddShareLinks(v ...*ShareLink) *PollUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddShareLinkIDs(ids...)
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_u *PollUpdateOne) SetOwner(v *User) *PollUpdateOne {
+	return _u.SetOwnerID(v.ID)
+}
+
 // Mutation returns the PollMutation object of the builder.
 func (_u *PollUpdateOne) Mutation() *PollMutation {
 	return _u.mutation
@@ -423,6 +1438,75 @@ func (_u *PollUpdateOne) RemoveVotes(v ...*Vote) *PollUpdateOne {
 	return _u.RemoveVoteIDs(ids...)
 }
 
+// ClearRevisions clears all "revisions" edges to the PollRevision entity.
+func (_u *PollUpdateOne) ClearRevisions() *PollUpdateOne {
+	_u.mutation.ClearRevisions()
+	return _u
+}
+
+// RemoveRevisionIDs removes the "revisions" edge to PollRevision entities by IDs.
+func (_u *PollUpdateOne) RemoveRevisionIDs(ids ...int) *PollUpdateOne {
+	_u.mutation.RemoveRevisionIDs(ids...)
+	return _u
+}
+
+// RemoveRevisions removes "revisions" edges to PollRevision entities.
+func (_u *PollUpdateOne) RemoveRevisions(v ...*PollRevision) *PollUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveRevisionIDs(ids...)
+}
+
+// ClearTags clears all "tags" edges to the Tag entity.
+func (_u *PollUpdateOne) ClearTags() *PollUpdateOne {
+	_u.mutation.ClearTags()
+	return _u
+}
+
+// RemoveTagIDs removes the "tags" edge to Tag entities by IDs.
+func (_u *PollUpdateOne) RemoveTagIDs(ids ...int) *PollUpdateOne {
+	_u.mutation.RemoveTagIDs(ids...)
+	return _u
+}
+
+// RemoveTags removes "tags" edges to Tag entities.
+func (_u *PollUpdateOne) RemoveTags(v ...*Tag) *PollUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveTagIDs(ids...)
+}
+
+// ClearShareLinks clears all "share_links" edges to the ShareLink entity.
+func (_u *PollUpdateOne) ClearShareLinks() *PollUpdateOne {
+	_u.mutation.ClearShareLinks()
+	return _u
+}
+
+// RemoveShareLinkIDs removes the "share_links" edge to ShareLink entities by IDs.
+func (_u *PollUpdateOne) RemoveShareLinkIDs(ids ...int) *PollUpdateOne {
+	_u.mutation.RemoveShareLinkIDs(ids...)
+	return _u
+}
+
+// RemoveShareLinks removes "share_links" edges to ShareLink entities.
+func (_u *PollUpdateOne) RemoveShareLinks(v ...*ShareLink) *PollUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveShareLinkIDs(ids...)
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (_u *PollUpdateOne) ClearOwner() *PollUpdateOne {
+	_u.mutation.ClearOwner()
+	return _u
+}
+
 // Where appends a list predicates to the PollUpdate builder.
 func (_u *PollUpdateOne) Where(ps ...predicate.Poll) *PollUpdateOne {
 	_u.mutation.Where(ps...)
@@ -463,7 +1547,40 @@ func (_u *PollUpdateOne) ExecX(ctx context.Context) {
 	}
 }
 
+// check runs all checks and user-defined validators on the builder.
+func (_u *PollUpdateOne) check() error {
+	if v, ok := _u.mutation.MaxVotes(); ok {
+		if err := poll.MaxVotesValidator(v); err != nil {
+			return &ValidationError{Name: "max_votes", err: fmt.Errorf(`ent: validator failed for field "Poll.max_votes": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.QuorumPercent(); ok {
+		if err := poll.QuorumPercentValidator(v); err != nil {
+			return &ValidationError{Name: "quorum_percent", err: fmt.Errorf(`ent: validator failed for field "Poll.quorum_percent": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.ClosedReason(); ok {
+		if err := poll.ClosedReasonValidator(v); err != nil {
+			return &ValidationError{Name: "closed_reason", err: fmt.Errorf(`ent: validator failed for field "Poll.closed_reason": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.ResultsVisibility(); ok {
+		if err := poll.ResultsVisibilityValidator(v); err != nil {
+			return &ValidationError{Name: "results_visibility", err: fmt.Errorf(`ent: validator failed for field "Poll.results_visibility": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Status(); ok {
+		if err := poll.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Poll.status": %w`, err)}
+		}
+	}
+	return nil
+}
+
 func (_u *PollUpdateOne) sqlSave(ctx context.Context) (_node *Poll, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
 	_spec := sqlgraph.NewUpdateSpec(poll.Table, poll.Columns, sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt))
 	id, ok := _u.mutation.ID()
 	if !ok {
@@ -492,12 +1609,87 @@ func (_u *PollUpdateOne) sqlSave(ctx context.Context) (_node *Poll, err error) {
 	if value, ok := _u.mutation.Title(); ok {
 		_spec.SetField(poll.FieldTitle, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.Description(); ok {
+		_spec.SetField(poll.FieldDescription, field.TypeString, value)
+	}
+	if _u.mutation.DescriptionCleared() {
+		_spec.ClearField(poll.FieldDescription, field.TypeString)
+	}
 	if value, ok := _u.mutation.IsOpen(); ok {
 		_spec.SetField(poll.FieldIsOpen, field.TypeBool, value)
 	}
 	if value, ok := _u.mutation.CreatedAt(); ok {
 		_spec.SetField(poll.FieldCreatedAt, field.TypeTime, value)
 	}
+	if value, ok := _u.mutation.TotalVotes(); ok {
+		_spec.SetField(poll.FieldTotalVotes, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedTotalVotes(); ok {
+		_spec.AddField(poll.FieldTotalVotes, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.OpensAt(); ok {
+		_spec.SetField(poll.FieldOpensAt, field.TypeTime, value)
+	}
+	if _u.mutation.OpensAtCleared() {
+		_spec.ClearField(poll.FieldOpensAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.ClosesAt(); ok {
+		_spec.SetField(poll.FieldClosesAt, field.TypeTime, value)
+	}
+	if _u.mutation.ClosesAtCleared() {
+		_spec.ClearField(poll.FieldClosesAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.MaxVotes(); ok {
+		_spec.SetField(poll.FieldMaxVotes, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxVotes(); ok {
+		_spec.AddField(poll.FieldMaxVotes, field.TypeInt, value)
+	}
+	if _u.mutation.MaxVotesCleared() {
+		_spec.ClearField(poll.FieldMaxVotes, field.TypeInt)
+	}
+	if value, ok := _u.mutation.QuorumPercent(); ok {
+		_spec.SetField(poll.FieldQuorumPercent, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedQuorumPercent(); ok {
+		_spec.AddField(poll.FieldQuorumPercent, field.TypeInt, value)
+	}
+	if _u.mutation.QuorumPercentCleared() {
+		_spec.ClearField(poll.FieldQuorumPercent, field.TypeInt)
+	}
+	if value, ok := _u.mutation.CloseOnDecisiveLead(); ok {
+		_spec.SetField(poll.FieldCloseOnDecisiveLead, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.ClosedReason(); ok {
+		_spec.SetField(poll.FieldClosedReason, field.TypeEnum, value)
+	}
+	if _u.mutation.ClosedReasonCleared() {
+		_spec.ClearField(poll.FieldClosedReason, field.TypeEnum)
+	}
+	if value, ok := _u.mutation.ResultsVisibility(); ok {
+		_spec.SetField(poll.FieldResultsVisibility, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.ResultsVisibleAt(); ok {
+		_spec.SetField(poll.FieldResultsVisibleAt, field.TypeTime, value)
+	}
+	if _u.mutation.ResultsVisibleAtCleared() {
+		_spec.ClearField(poll.FieldResultsVisibleAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(poll.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.PublishedAt(); ok {
+		_spec.SetField(poll.FieldPublishedAt, field.TypeTime, value)
+	}
+	if _u.mutation.PublishedAtCleared() {
+		_spec.ClearField(poll.FieldPublishedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Embeddable(); ok {
+		_spec.SetField(poll.FieldEmbeddable, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.AllowGuests(); ok {
+		_spec.SetField(poll.FieldAllowGuests, field.TypeBool, value)
+	}
 	if _u.mutation.OptionsCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -588,6 +1780,170 @@ func (_u *PollUpdateOne) sqlSave(ctx context.Context) (_node *Poll, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.RevisionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.RevisionsTable,
+			Columns: []string{poll.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollrevision.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedRevisionsIDs(); len(nodes) > 0 && !_u.mutation.RevisionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.RevisionsTable,
+			Columns: []string{poll.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollrevision.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RevisionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.RevisionsTable,
+			Columns: []string{poll.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollrevision.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.TagsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.TagsTable,
+			Columns: poll.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedTagsIDs(); len(nodes) > 0 && !_u.mutation.TagsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.TagsTable,
+			Columns: poll.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.TagsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.TagsTable,
+			Columns: poll.TagsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(tag.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ShareLinksCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.ShareLinksTable,
+			Columns: []string{poll.ShareLinksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(sharelink.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedShareLinksIDs(); len(nodes) > 0 && !_u.mutation.ShareLinksCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.ShareLinksTable,
+			Columns: []string{poll.ShareLinksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(sharelink.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ShareLinksIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.ShareLinksTable,
+			Columns: []string{poll.ShareLinksColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(sharelink.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.OwnerTable,
+			Columns: []string{poll.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.OwnerTable,
+			Columns: []string{poll.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Poll{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
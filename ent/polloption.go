@@ -21,6 +21,10 @@ type PollOption struct {
 	Text string `json:"text,omitempty"`
 	// VotesCount holds the value of the "votes_count" field.
 	VotesCount int `json:"votes_count,omitempty"`
+	// Position holds the value of the "position" field.
+	Position int `json:"position,omitempty"`
+	// Archived holds the value of the "archived" field.
+	Archived bool `json:"archived,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the PollOptionQuery when eager-loading is set.
 	Edges        PollOptionEdges `json:"edges"`
@@ -64,7 +68,9 @@ func (*PollOption) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case polloption.FieldID, polloption.FieldVotesCount:
+		case polloption.FieldArchived:
+			values[i] = new(sql.NullBool)
+		case polloption.FieldID, polloption.FieldVotesCount, polloption.FieldPosition:
 			values[i] = new(sql.NullInt64)
 		case polloption.FieldText:
 			values[i] = new(sql.NullString)
@@ -103,6 +109,18 @@ func (_m *PollOption) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.VotesCount = int(value.Int64)
 			}
+		case polloption.FieldPosition:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field position", values[i])
+			} else if value.Valid {
+				_m.Position = int(value.Int64)
+			}
+		case polloption.FieldArchived:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field archived", values[i])
+			} else if value.Valid {
+				_m.Archived = value.Bool
+			}
 		case polloption.ForeignKeys[0]:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for edge-field poll_options", value)
@@ -161,6 +179,12 @@ func (_m *PollOption) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("votes_count=")
 	builder.WriteString(fmt.Sprintf("%v", _m.VotesCount))
+	builder.WriteString(", ")
+	builder.WriteString("position=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Position))
+	builder.WriteString(", ")
+	builder.WriteString("archived=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Archived))
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -16,6 +16,10 @@ const (
 	FieldText = "text"
 	// FieldVotesCount holds the string denoting the votes_count field in the database.
 	FieldVotesCount = "votes_count"
+	// FieldPosition holds the string denoting the position field in the database.
+	FieldPosition = "position"
+	// FieldArchived holds the string denoting the archived field in the database.
+	FieldArchived = "archived"
 	// EdgePoll holds the string denoting the poll edge name in mutations.
 	EdgePoll = "poll"
 	// EdgeVotes holds the string denoting the votes edge name in mutations.
@@ -43,6 +47,8 @@ var Columns = []string{
 	FieldID,
 	FieldText,
 	FieldVotesCount,
+	FieldPosition,
+	FieldArchived,
 }
 
 // ForeignKeys holds the SQL foreign-keys that are owned by the "poll_options"
@@ -69,6 +75,10 @@ func ValidColumn(column string) bool {
 var (
 	// DefaultVotesCount holds the default value on creation for the "votes_count" field.
 	DefaultVotesCount int
+	// DefaultPosition holds the default value on creation for the "position" field.
+	DefaultPosition int
+	// DefaultArchived holds the default value on creation for the "archived" field.
+	DefaultArchived bool
 )
 
 // OrderOption defines the ordering options for the PollOption queries.
@@ -89,6 +99,16 @@ func ByVotesCountField(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldVotesCount, opts...).ToFunc()
 }
 
+// ByPosition orders the results by the position field.
+func ByPosition(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPosition, opts...).ToFunc()
+}
+
+// ByArchived orders the results by the archived field.
+func ByArchived(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldArchived, opts...).ToFunc()
+}
+
 // ByPollField orders the results by poll field.
 func ByPollField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
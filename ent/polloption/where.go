@@ -64,6 +64,16 @@ func VotesCount(v int) predicate.PollOption {
 	return predicate.PollOption(sql.FieldEQ(FieldVotesCount, v))
 }
 
+// Position applies equality check predicate on the "position" field. It's identical to PositionEQ.
+func Position(v int) predicate.PollOption {
+	return predicate.PollOption(sql.FieldEQ(FieldPosition, v))
+}
+
+// Archived applies equality check predicate on the "archived" field. It's identical to ArchivedEQ.
+func Archived(v bool) predicate.PollOption {
+	return predicate.PollOption(sql.FieldEQ(FieldArchived, v))
+}
+
 // TextEQ applies the EQ predicate on the "text" field.
 func TextEQ(v string) predicate.PollOption {
 	return predicate.PollOption(sql.FieldEQ(FieldText, v))
@@ -169,6 +179,56 @@ func VotesCountLTE(v int) predicate.PollOption {
 	return predicate.PollOption(sql.FieldLTE(FieldVotesCount, v))
 }
 
+// PositionEQ applies the EQ predicate on the "position" field.
+func PositionEQ(v int) predicate.PollOption {
+	return predicate.PollOption(sql.FieldEQ(FieldPosition, v))
+}
+
+// PositionNEQ applies the NEQ predicate on the "position" field.
+func PositionNEQ(v int) predicate.PollOption {
+	return predicate.PollOption(sql.FieldNEQ(FieldPosition, v))
+}
+
+// PositionIn applies the In predicate on the "position" field.
+func PositionIn(vs ...int) predicate.PollOption {
+	return predicate.PollOption(sql.FieldIn(FieldPosition, vs...))
+}
+
+// PositionNotIn applies the NotIn predicate on the "position" field.
+func PositionNotIn(vs ...int) predicate.PollOption {
+	return predicate.PollOption(sql.FieldNotIn(FieldPosition, vs...))
+}
+
+// PositionGT applies the GT predicate on the "position" field.
+func PositionGT(v int) predicate.PollOption {
+	return predicate.PollOption(sql.FieldGT(FieldPosition, v))
+}
+
+// PositionGTE applies the GTE predicate on the "position" field.
+func PositionGTE(v int) predicate.PollOption {
+	return predicate.PollOption(sql.FieldGTE(FieldPosition, v))
+}
+
+// PositionLT applies the LT predicate on the "position" field.
+func PositionLT(v int) predicate.PollOption {
+	return predicate.PollOption(sql.FieldLT(FieldPosition, v))
+}
+
+// PositionLTE applies the LTE predicate on the "position" field.
+func PositionLTE(v int) predicate.PollOption {
+	return predicate.PollOption(sql.FieldLTE(FieldPosition, v))
+}
+
+// ArchivedEQ applies the EQ predicate on the "archived" field.
+func ArchivedEQ(v bool) predicate.PollOption {
+	return predicate.PollOption(sql.FieldEQ(FieldArchived, v))
+}
+
+// ArchivedNEQ applies the NEQ predicate on the "archived" field.
+func ArchivedNEQ(v bool) predicate.PollOption {
+	return predicate.PollOption(sql.FieldNEQ(FieldArchived, v))
+}
+
 // HasPoll applies the HasEdge predicate on the "poll" edge.
 func HasPoll() predicate.PollOption {
 	return predicate.PollOption(func(s *sql.Selector) {
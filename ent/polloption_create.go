@@ -41,6 +41,34 @@ func (_c *PollOptionCreate) SetNillableVotesCount(v *int) *PollOptionCreate {
 	return _c
 }
 
+// SetPosition sets the "position" field.
+func (_c *PollOptionCreate) SetPosition(v int) *PollOptionCreate {
+	_c.mutation.SetPosition(v)
+	return _c
+}
+
+// SetNillablePosition sets the "position" field if the given value is not nil.
+func (_c *PollOptionCreate) SetNillablePosition(v *int) *PollOptionCreate {
+	if v != nil {
+		_c.SetPosition(*v)
+	}
+	return _c
+}
+
+// SetArchived sets the "archived" field.
+func (_c *PollOptionCreate) SetArchived(v bool) *PollOptionCreate {
+	_c.mutation.SetArchived(v)
+	return _c
+}
+
+// SetNillableArchived sets the "archived" field if the given value is not nil.
+func (_c *PollOptionCreate) SetNillableArchived(v *bool) *PollOptionCreate {
+	if v != nil {
+		_c.SetArchived(*v)
+	}
+	return _c
+}
+
 // SetPollID sets the "poll" edge to the Poll entity by ID.
 func (_c *PollOptionCreate) SetPollID(id int) *PollOptionCreate {
 	_c.mutation.SetPollID(id)
@@ -114,6 +142,14 @@ func (_c *PollOptionCreate) defaults() {
 		v := polloption.DefaultVotesCount
 		_c.mutation.SetVotesCount(v)
 	}
+	if _, ok := _c.mutation.Position(); !ok {
+		v := polloption.DefaultPosition
+		_c.mutation.SetPosition(v)
+	}
+	if _, ok := _c.mutation.Archived(); !ok {
+		v := polloption.DefaultArchived
+		_c.mutation.SetArchived(v)
+	}
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -124,6 +160,12 @@ func (_c *PollOptionCreate) check() error {
 	if _, ok := _c.mutation.VotesCount(); !ok {
 		return &ValidationError{Name: "votes_count", err: errors.New(`ent: missing required field "PollOption.votes_count"`)}
 	}
+	if _, ok := _c.mutation.Position(); !ok {
+		return &ValidationError{Name: "position", err: errors.New(`ent: missing required field "PollOption.position"`)}
+	}
+	if _, ok := _c.mutation.Archived(); !ok {
+		return &ValidationError{Name: "archived", err: errors.New(`ent: missing required field "PollOption.archived"`)}
+	}
 	return nil
 }
 
@@ -158,6 +200,14 @@ func (_c *PollOptionCreate) createSpec() (*PollOption, *sqlgraph.CreateSpec) {
 		_spec.SetField(polloption.FieldVotesCount, field.TypeInt, value)
 		_node.VotesCount = value
 	}
+	if value, ok := _c.mutation.Position(); ok {
+		_spec.SetField(polloption.FieldPosition, field.TypeInt, value)
+		_node.Position = value
+	}
+	if value, ok := _c.mutation.Archived(); ok {
+		_spec.SetField(polloption.FieldArchived, field.TypeBool, value)
+		_node.Archived = value
+	}
 	if nodes := _c.mutation.PollIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -64,6 +64,41 @@ func (_u *PollOptionUpdate) AddVotesCount(v int) *PollOptionUpdate {
 	return _u
 }
 
+// SetPosition sets the "position" field.
+func (_u *PollOptionUpdate) SetPosition(v int) *PollOptionUpdate {
+	_u.mutation.ResetPosition()
+	_u.mutation.SetPosition(v)
+	return _u
+}
+
+// SetNillablePosition sets the "position" field if the given value is not nil.
+func (_u *PollOptionUpdate) SetNillablePosition(v *int) *PollOptionUpdate {
+	if v != nil {
+		_u.SetPosition(*v)
+	}
+	return _u
+}
+
+// AddPosition adds value to the "position" field.
+func (_u *PollOptionUpdate) AddPosition(v int) *PollOptionUpdate {
+	_u.mutation.AddPosition(v)
+	return _u
+}
+
+// SetArchived sets the "archived" field.
+func (_u *PollOptionUpdate) SetArchived(v bool) *PollOptionUpdate {
+	_u.mutation.SetArchived(v)
+	return _u
+}
+
+// SetNillableArchived sets the "archived" field if the given value is not nil.
+func (_u *PollOptionUpdate) SetNillableArchived(v *bool) *PollOptionUpdate {
+	if v != nil {
+		_u.SetArchived(*v)
+	}
+	return _u
+}
+
 // SetPollID sets the "poll" edge to the Poll entity by ID.
 func (_u *PollOptionUpdate) SetPollID(id int) *PollOptionUpdate {
 	_u.mutation.SetPollID(id)
@@ -175,6 +210,15 @@ func (_u *PollOptionUpdate) sqlSave(ctx context.Context) (_node int, err error)
 	if value, ok := _u.mutation.AddedVotesCount(); ok {
 		_spec.AddField(polloption.FieldVotesCount, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.Position(); ok {
+		_spec.SetField(polloption.FieldPosition, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedPosition(); ok {
+		_spec.AddField(polloption.FieldPosition, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Archived(); ok {
+		_spec.SetField(polloption.FieldArchived, field.TypeBool, value)
+	}
 	if _u.mutation.PollCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -304,6 +348,41 @@ func (_u *PollOptionUpdateOne) AddVotesCount(v int) *PollOptionUpdateOne {
 	return _u
 }
 
+// SetPosition sets the "position" field.
+func (_u *PollOptionUpdateOne) SetPosition(v int) *PollOptionUpdateOne {
+	_u.mutation.ResetPosition()
+	_u.mutation.SetPosition(v)
+	return _u
+}
+
+// SetNillablePosition sets the "position" field if the given value is not nil.
+func (_u *PollOptionUpdateOne) SetNillablePosition(v *int) *PollOptionUpdateOne {
+	if v != nil {
+		_u.SetPosition(*v)
+	}
+	return _u
+}
+
+// AddPosition adds value to the "position" field.
+func (_u *PollOptionUpdateOne) AddPosition(v int) *PollOptionUpdateOne {
+	_u.mutation.AddPosition(v)
+	return _u
+}
+
+// SetArchived sets the "archived" field.
+func (_u *PollOptionUpdateOne) SetArchived(v bool) *PollOptionUpdateOne {
+	_u.mutation.SetArchived(v)
+	return _u
+}
+
+// SetNillableArchived sets the "archived" field if the given value is not nil.
+func (_u *PollOptionUpdateOne) SetNillableArchived(v *bool) *PollOptionUpdateOne {
+	if v != nil {
+		_u.SetArchived(*v)
+	}
+	return _u
+}
+
 // SetPollID sets the "poll" edge to the Poll entity by ID.
 func (_u *PollOptionUpdateOne) SetPollID(id int) *PollOptionUpdateOne {
 	_u.mutation.SetPollID(id)
@@ -445,6 +524,15 @@ func (_u *PollOptionUpdateOne) sqlSave(ctx context.Context) (_node *PollOption,
 	if value, ok := _u.mutation.AddedVotesCount(); ok {
 		_spec.AddField(polloption.FieldVotesCount, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.Position(); ok {
+		_spec.SetField(polloption.FieldPosition, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedPosition(); ok {
+		_spec.AddField(polloption.FieldPosition, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Archived(); ok {
+		_spec.SetField(polloption.FieldArchived, field.TypeBool, value)
+	}
 	if _u.mutation.PollCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -0,0 +1,368 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"api_voty/ent/poll"
+	"api_voty/ent/pollrevision"
+	"api_voty/ent/schema"
+	"api_voty/ent/user"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// PollRevisionCreate is the builder for creating a PollRevision entity.
+type PollRevisionCreate struct {
+	config
+	mutation *PollRevisionMutation
+	hooks    []Hook
+}
+
+// SetPollID sets the "poll_id" field.
+func (_c *PollRevisionCreate) SetPollID(v int) *PollRevisionCreate {
+	_c.mutation.SetPollID(v)
+	return _c
+}
+
+// SetRevision sets the "revision" field.
+func (_c *PollRevisionCreate) SetRevision(v int) *PollRevisionCreate {
+	_c.mutation.SetRevision(v)
+	return _c
+}
+
+// SetTitle sets the "title" field.
+func (_c *PollRevisionCreate) SetTitle(v string) *PollRevisionCreate {
+	_c.mutation.SetTitle(v)
+	return _c
+}
+
+// SetDescription sets the "description" field.
+func (_c *PollRevisionCreate) SetDescription(v string) *PollRevisionCreate {
+	_c.mutation.SetDescription(v)
+	return _c
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_c *PollRevisionCreate) SetNillableDescription(v *string) *PollRevisionCreate {
+	if v != nil {
+		_c.SetDescription(*v)
+	}
+	return _c
+}
+
+// SetOptions sets the "options" field.
+func (_c *PollRevisionCreate) SetOptions(v []schema.OptionSnapshot) *PollRevisionCreate {
+	_c.mutation.SetOptions(v)
+	return _c
+}
+
+// SetSettings sets the "settings" field.
+func (_c *PollRevisionCreate) SetSettings(v schema.PollSettings) *PollRevisionCreate {
+	_c.mutation.SetSettings(v)
+	return _c
+}
+
+// SetAuthorID sets the "author_id" field.
+func (_c *PollRevisionCreate) SetAuthorID(v string) *PollRevisionCreate {
+	_c.mutation.SetAuthorID(v)
+	return _c
+}
+
+// SetNillableAuthorID sets the "author_id" field if the given value is not nil.
+func (_c *PollRevisionCreate) SetNillableAuthorID(v *string) *PollRevisionCreate {
+	if v != nil {
+		_c.SetAuthorID(*v)
+	}
+	return _c
+}
+
+// SetRestoredFrom sets the "restored_from" field.
+func (_c *PollRevisionCreate) SetRestoredFrom(v int) *PollRevisionCreate {
+	_c.mutation.SetRestoredFrom(v)
+	return _c
+}
+
+// SetNillableRestoredFrom sets the "restored_from" field if the given value is not nil.
+func (_c *PollRevisionCreate) SetNillableRestoredFrom(v *int) *PollRevisionCreate {
+	if v != nil {
+		_c.SetRestoredFrom(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *PollRevisionCreate) SetCreatedAt(v time.Time) *PollRevisionCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *PollRevisionCreate) SetNillableCreatedAt(v *time.Time) *PollRevisionCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_c *PollRevisionCreate) SetPoll(v *Poll) *PollRevisionCreate {
+	return _c.SetPollID(v.ID)
+}
+
+// SetAuthor sets the "author" edge to the User entity.
+func (_c *PollRevisionCreate) SetAuthor(v *User) *PollRevisionCreate {
+	return _c.SetAuthorID(v.ID)
+}
+
+// Mutation returns the PollRevisionMutation object of the builder.
+func (_c *PollRevisionCreate) Mutation() *PollRevisionMutation {
+	return _c.mutation
+}
+
+// Save creates the PollRevision in the database.
+func (_c *PollRevisionCreate) Save(ctx context.Context) (*PollRevision, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *PollRevisionCreate) SaveX(ctx context.Context) *PollRevision {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *PollRevisionCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *PollRevisionCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *PollRevisionCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := pollrevision.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *PollRevisionCreate) check() error {
+	if _, ok := _c.mutation.PollID(); !ok {
+		return &ValidationError{Name: "poll_id", err: errors.New(`ent: missing required field "PollRevision.poll_id"`)}
+	}
+	if _, ok := _c.mutation.Revision(); !ok {
+		return &ValidationError{Name: "revision", err: errors.New(`ent: missing required field "PollRevision.revision"`)}
+	}
+	if v, ok := _c.mutation.Revision(); ok {
+		if err := pollrevision.RevisionValidator(v); err != nil {
+			return &ValidationError{Name: "revision", err: fmt.Errorf(`ent: validator failed for field "PollRevision.revision": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Title(); !ok {
+		return &ValidationError{Name: "title", err: errors.New(`ent: missing required field "PollRevision.title"`)}
+	}
+	if _, ok := _c.mutation.Options(); !ok {
+		return &ValidationError{Name: "options", err: errors.New(`ent: missing required field "PollRevision.options"`)}
+	}
+	if _, ok := _c.mutation.Settings(); !ok {
+		return &ValidationError{Name: "settings", err: errors.New(`ent: missing required field "PollRevision.settings"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "PollRevision.created_at"`)}
+	}
+	if len(_c.mutation.PollIDs()) == 0 {
+		return &ValidationError{Name: "poll", err: errors.New(`ent: missing required edge "PollRevision.poll"`)}
+	}
+	return nil
+}
+
+func (_c *PollRevisionCreate) sqlSave(ctx context.Context) (*PollRevision, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *PollRevisionCreate) createSpec() (*PollRevision, *sqlgraph.CreateSpec) {
+	var (
+		_node = &PollRevision{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(pollrevision.Table, sqlgraph.NewFieldSpec(pollrevision.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.Revision(); ok {
+		_spec.SetField(pollrevision.FieldRevision, field.TypeInt, value)
+		_node.Revision = value
+	}
+	if value, ok := _c.mutation.Title(); ok {
+		_spec.SetField(pollrevision.FieldTitle, field.TypeString, value)
+		_node.Title = value
+	}
+	if value, ok := _c.mutation.Description(); ok {
+		_spec.SetField(pollrevision.FieldDescription, field.TypeString, value)
+		_node.Description = value
+	}
+	if value, ok := _c.mutation.Options(); ok {
+		_spec.SetField(pollrevision.FieldOptions, field.TypeJSON, value)
+		_node.Options = value
+	}
+	if value, ok := _c.mutation.Settings(); ok {
+		_spec.SetField(pollrevision.FieldSettings, field.TypeJSON, value)
+		_node.Settings = value
+	}
+	if value, ok := _c.mutation.RestoredFrom(); ok {
+		_spec.SetField(pollrevision.FieldRestoredFrom, field.TypeInt, value)
+		_node.RestoredFrom = &value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(pollrevision.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pollrevision.PollTable,
+			Columns: []string{pollrevision.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.PollID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.AuthorIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pollrevision.AuthorTable,
+			Columns: []string{pollrevision.AuthorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.AuthorID = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// PollRevisionCreateBulk is the builder for creating many PollRevision entities in bulk.
+type PollRevisionCreateBulk struct {
+	config
+	err      error
+	builders []*PollRevisionCreate
+}
+
+// Save creates the PollRevision entities in the database.
+func (_c *PollRevisionCreateBulk) Save(ctx context.Context) ([]*PollRevision, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*PollRevision, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*PollRevisionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *PollRevisionCreateBulk) SaveX(ctx context.Context) []*PollRevision {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *PollRevisionCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *PollRevisionCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
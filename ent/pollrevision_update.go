@@ -0,0 +1,725 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"api_voty/ent/poll"
+	"api_voty/ent/pollrevision"
+	"api_voty/ent/predicate"
+	"api_voty/ent/schema"
+	"api_voty/ent/user"
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+)
+
+// PollRevisionUpdate is the builder for updating PollRevision entities.
+type PollRevisionUpdate struct {
+	config
+	hooks    []Hook
+	mutation *PollRevisionMutation
+}
+
+// Where appends a list predicates to the PollRevisionUpdate builder.
+func (_u *PollRevisionUpdate) Where(ps ...predicate.PollRevision) *PollRevisionUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetPollID sets the "poll_id" field.
+func (_u *PollRevisionUpdate) SetPollID(v int) *PollRevisionUpdate {
+	_u.mutation.SetPollID(v)
+	return _u
+}
+
+// SetNillablePollID sets the "poll_id" field if the given value is not nil.
+func (_u *PollRevisionUpdate) SetNillablePollID(v *int) *PollRevisionUpdate {
+	if v != nil {
+		_u.SetPollID(*v)
+	}
+	return _u
+}
+
+// SetRevision sets the "revision" field.
+func (_u *PollRevisionUpdate) SetRevision(v int) *PollRevisionUpdate {
+	_u.mutation.ResetRevision()
+	_u.mutation.SetRevision(v)
+	return _u
+}
+
+// SetNillableRevision sets the "revision" field if the given value is not nil.
+func (_u *PollRevisionUpdate) SetNillableRevision(v *int) *PollRevisionUpdate {
+	if v != nil {
+		_u.SetRevision(*v)
+	}
+	return _u
+}
+
+// AddRevision adds value to the "revision" field.
+func (_u *PollRevisionUpdate) AddRevision(v int) *PollRevisionUpdate {
+	_u.mutation.AddRevision(v)
+	return _u
+}
+
+// SetTitle sets the "title" field.
+func (_u *PollRevisionUpdate) SetTitle(v string) *PollRevisionUpdate {
+	_u.mutation.SetTitle(v)
+	return _u
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (_u *PollRevisionUpdate) SetNillableTitle(v *string) *PollRevisionUpdate {
+	if v != nil {
+		_u.SetTitle(*v)
+	}
+	return _u
+}
+
+// SetDescription sets the "description" field.
+func (_u *PollRevisionUpdate) SetDescription(v string) *PollRevisionUpdate {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *PollRevisionUpdate) SetNillableDescription(v *string) *PollRevisionUpdate {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// ClearDescription clears the value of the "description" field.
+func (_u *PollRevisionUpdate) ClearDescription() *PollRevisionUpdate {
+	_u.mutation.ClearDescription()
+	return _u
+}
+
+// SetOptions sets the "options" field.
+func (_u *PollRevisionUpdate) SetOptions(v []schema.OptionSnapshot) *PollRevisionUpdate {
+	_u.mutation.SetOptions(v)
+	return _u
+}
+
+// AppendOptions appends value to the "options" field.
+func (_u *PollRevisionUpdate) AppendOptions(v []schema.OptionSnapshot) *PollRevisionUpdate {
+	_u.mutation.AppendOptions(v)
+	return _u
+}
+
+// SetSettings sets the "settings" field.
+func (_u *PollRevisionUpdate) SetSettings(v schema.PollSettings) *PollRevisionUpdate {
+	_u.mutation.SetSettings(v)
+	return _u
+}
+
+// SetNillableSettings sets the "settings" field if the given value is not nil.
+func (_u *PollRevisionUpdate) SetNillableSettings(v *schema.PollSettings) *PollRevisionUpdate {
+	if v != nil {
+		_u.SetSettings(*v)
+	}
+	return _u
+}
+
+// SetAuthorID sets the "author_id" field.
+func (_u *PollRevisionUpdate) SetAuthorID(v string) *PollRevisionUpdate {
+	_u.mutation.SetAuthorID(v)
+	return _u
+}
+
+// SetNillableAuthorID sets the "author_id" field if the given value is not nil.
+func (_u *PollRevisionUpdate) SetNillableAuthorID(v *string) *PollRevisionUpdate {
+	if v != nil {
+		_u.SetAuthorID(*v)
+	}
+	return _u
+}
+
+// ClearAuthorID clears the value of the "author_id" field.
+func (_u *PollRevisionUpdate) ClearAuthorID() *PollRevisionUpdate {
+	_u.mutation.ClearAuthorID()
+	return _u
+}
+
+// SetRestoredFrom sets the "restored_from" field.
+func (_u *PollRevisionUpdate) SetRestoredFrom(v int) *PollRevisionUpdate {
+	_u.mutation.ResetRestoredFrom()
+	_u.mutation.SetRestoredFrom(v)
+	return _u
+}
+
+// SetNillableRestoredFrom sets the "restored_from" field if the given value is not nil.
+func (_u *PollRevisionUpdate) SetNillableRestoredFrom(v *int) *PollRevisionUpdate {
+	if v != nil {
+		_u.SetRestoredFrom(*v)
+	}
+	return _u
+}
+
+// AddRestoredFrom adds value to the "restored_from" field.
+func (_u *PollRevisionUpdate) AddRestoredFrom(v int) *PollRevisionUpdate {
+	_u.mutation.AddRestoredFrom(v)
+	return _u
+}
+
+// ClearRestoredFrom clears the value of the "restored_from" field.
+func (_u *PollRevisionUpdate) ClearRestoredFrom() *PollRevisionUpdate {
+	_u.mutation.ClearRestoredFrom()
+	return _u
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_u *PollRevisionUpdate) SetPoll(v *Poll) *PollRevisionUpdate {
+	return _u.SetPollID(v.ID)
+}
+
+// SetAuthor sets the "author" edge to the User entity.
+func (_u *PollRevisionUpdate) SetAuthor(v *User) *PollRevisionUpdate {
+	return _u.SetAuthorID(v.ID)
+}
+
+// Mutation returns the PollRevisionMutation object of the builder.
+func (_u *PollRevisionUpdate) Mutation() *PollRevisionMutation {
+	return _u.mutation
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (_u *PollRevisionUpdate) ClearPoll() *PollRevisionUpdate {
+	_u.mutation.ClearPoll()
+	return _u
+}
+
+// ClearAuthor clears the "author" edge to the User entity.
+func (_u *PollRevisionUpdate) ClearAuthor() *PollRevisionUpdate {
+	_u.mutation.ClearAuthor()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *PollRevisionUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *PollRevisionUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *PollRevisionUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *PollRevisionUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *PollRevisionUpdate) check() error {
+	if v, ok := _u.mutation.Revision(); ok {
+		if err := pollrevision.RevisionValidator(v); err != nil {
+			return &ValidationError{Name: "revision", err: fmt.Errorf(`ent: validator failed for field "PollRevision.revision": %w`, err)}
+		}
+	}
+	if _u.mutation.PollCleared() && len(_u.mutation.PollIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "PollRevision.poll"`)
+	}
+	return nil
+}
+
+func (_u *PollRevisionUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(pollrevision.Table, pollrevision.Columns, sqlgraph.NewFieldSpec(pollrevision.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Revision(); ok {
+		_spec.SetField(pollrevision.FieldRevision, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRevision(); ok {
+		_spec.AddField(pollrevision.FieldRevision, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Title(); ok {
+		_spec.SetField(pollrevision.FieldTitle, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Description(); ok {
+		_spec.SetField(pollrevision.FieldDescription, field.TypeString, value)
+	}
+	if _u.mutation.DescriptionCleared() {
+		_spec.ClearField(pollrevision.FieldDescription, field.TypeString)
+	}
+	if value, ok := _u.mutation.Options(); ok {
+		_spec.SetField(pollrevision.FieldOptions, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedOptions(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, pollrevision.FieldOptions, value)
+		})
+	}
+	if value, ok := _u.mutation.Settings(); ok {
+		_spec.SetField(pollrevision.FieldSettings, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.RestoredFrom(); ok {
+		_spec.SetField(pollrevision.FieldRestoredFrom, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRestoredFrom(); ok {
+		_spec.AddField(pollrevision.FieldRestoredFrom, field.TypeInt, value)
+	}
+	if _u.mutation.RestoredFromCleared() {
+		_spec.ClearField(pollrevision.FieldRestoredFrom, field.TypeInt)
+	}
+	if _u.mutation.PollCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pollrevision.PollTable,
+			Columns: []string{pollrevision.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pollrevision.PollTable,
+			Columns: []string{pollrevision.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.AuthorCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pollrevision.AuthorTable,
+			Columns: []string{pollrevision.AuthorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.AuthorIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pollrevision.AuthorTable,
+			Columns: []string{pollrevision.AuthorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{pollrevision.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// PollRevisionUpdateOne is the builder for updating a single PollRevision entity.
+type PollRevisionUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *PollRevisionMutation
+}
+
+// SetPollID sets the "poll_id" field.
+func (_u *PollRevisionUpdateOne) SetPollID(v int) *PollRevisionUpdateOne {
+	_u.mutation.SetPollID(v)
+	return _u
+}
+
+// SetNillablePollID sets the "poll_id" field if the given value is not nil.
+func (_u *PollRevisionUpdateOne) SetNillablePollID(v *int) *PollRevisionUpdateOne {
+	if v != nil {
+		_u.SetPollID(*v)
+	}
+	return _u
+}
+
+// SetRevision sets the "revision" field.
+func (_u *PollRevisionUpdateOne) SetRevision(v int) *PollRevisionUpdateOne {
+	_u.mutation.ResetRevision()
+	_u.mutation.SetRevision(v)
+	return _u
+}
+
+// SetNillableRevision sets the "revision" field if the given value is not nil.
+func (_u *PollRevisionUpdateOne) SetNillableRevision(v *int) *PollRevisionUpdateOne {
+	if v != nil {
+		_u.SetRevision(*v)
+	}
+	return _u
+}
+
+// AddRevision adds value to the "revision" field.
+func (_u *PollRevisionUpdateOne) AddRevision(v int) *PollRevisionUpdateOne {
+	_u.mutation.AddRevision(v)
+	return _u
+}
+
+// SetTitle sets the "title" field.
+func (_u *PollRevisionUpdateOne) SetTitle(v string) *PollRevisionUpdateOne {
+	_u.mutation.SetTitle(v)
+	return _u
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (_u *PollRevisionUpdateOne) SetNillableTitle(v *string) *PollRevisionUpdateOne {
+	if v != nil {
+		_u.SetTitle(*v)
+	}
+	return _u
+}
+
+// SetDescription sets the "description" field.
+func (_u *PollRevisionUpdateOne) SetDescription(v string) *PollRevisionUpdateOne {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *PollRevisionUpdateOne) SetNillableDescription(v *string) *PollRevisionUpdateOne {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// ClearDescription clears the value of the "description" field.
+func (_u *PollRevisionUpdateOne) ClearDescription() *PollRevisionUpdateOne {
+	_u.mutation.ClearDescription()
+	return _u
+}
+
+// SetOptions sets the "options" field.
+func (_u *PollRevisionUpdateOne) SetOptions(v []schema.OptionSnapshot) *PollRevisionUpdateOne {
+	_u.mutation.SetOptions(v)
+	return _u
+}
+
+// AppendOptions appends value to the "options" field.
+func (_u *PollRevisionUpdateOne) AppendOptions(v []schema.OptionSnapshot) *PollRevisionUpdateOne {
+	_u.mutation.AppendOptions(v)
+	return _u
+}
+
+// SetSettings sets the "settings" field.
+func (_u *PollRevisionUpdateOne) SetSettings(v schema.PollSettings) *PollRevisionUpdateOne {
+	_u.mutation.SetSettings(v)
+	return _u
+}
+
+// SetNillableSettings sets the "settings" field if the given value is not nil.
+func (_u *PollRevisionUpdateOne) SetNillableSettings(v *schema.PollSettings) *PollRevisionUpdateOne {
+	if v != nil {
+		_u.SetSettings(*v)
+	}
+	return _u
+}
+
+// SetAuthorID sets the "author_id" field.
+func (_u *PollRevisionUpdateOne) SetAuthorID(v string) *PollRevisionUpdateOne {
+	_u.mutation.SetAuthorID(v)
+	return _u
+}
+
+// SetNillableAuthorID sets the "author_id" field if the given value is not nil.
+func (_u *PollRevisionUpdateOne) SetNillableAuthorID(v *string) *PollRevisionUpdateOne {
+	if v != nil {
+		_u.SetAuthorID(*v)
+	}
+	return _u
+}
+
+// ClearAuthorID clears the value of the "author_id" field.
+func (_u *PollRevisionUpdateOne) ClearAuthorID() *PollRevisionUpdateOne {
+	_u.mutation.ClearAuthorID()
+	return _u
+}
+
+// SetRestoredFrom sets the "restored_from" field.
+func (_u *PollRevisionUpdateOne) SetRestoredFrom(v int) *PollRevisionUpdateOne {
+	_u.mutation.ResetRestoredFrom()
+	_u.mutation.SetRestoredFrom(v)
+	return _u
+}
+
+// SetNillableRestoredFrom sets the "restored_from" field if the given value is not nil.
+func (_u *PollRevisionUpdateOne) SetNillableRestoredFrom(v *int) *PollRevisionUpdateOne {
+	if v != nil {
+		_u.SetRestoredFrom(*v)
+	}
+	return _u
+}
+
+// AddRestoredFrom adds value to the "restored_from" field.
+func (_u *PollRevisionUpdateOne) AddRestoredFrom(v int) *PollRevisionUpdateOne {
+	_u.mutation.AddRestoredFrom(v)
+	return _u
+}
+
+// ClearRestoredFrom clears the value of the "restored_from" field.
+func (_u *PollRevisionUpdateOne) ClearRestoredFrom() *PollRevisionUpdateOne {
+	_u.mutation.ClearRestoredFrom()
+	return _u
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_u *PollRevisionUpdateOne) SetPoll(v *Poll) *PollRevisionUpdateOne {
+	return _u.SetPollID(v.ID)
+}
+
+// SetAuthor sets the "author" edge to the User entity.
+func (_u *PollRevisionUpdateOne) SetAuthor(v *User) *PollRevisionUpdateOne {
+	return _u.SetAuthorID(v.ID)
+}
+
+// Mutation returns the PollRevisionMutation object of the builder.
+func (_u *PollRevisionUpdateOne) Mutation() *PollRevisionMutation {
+	return _u.mutation
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (_u *PollRevisionUpdateOne) ClearPoll() *PollRevisionUpdateOne {
+	_u.mutation.ClearPoll()
+	return _u
+}
+
+// ClearAuthor clears the "author" edge to the User entity.
+func (_u *PollRevisionUpdateOne) ClearAuthor() *PollRevisionUpdateOne {
+	_u.mutation.ClearAuthor()
+	return _u
+}
+
+// Where appends a list predicates to the PollRevisionUpdate builder.
+func (_u *PollRevisionUpdateOne) Where(ps ...predicate.PollRevision) *PollRevisionUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *PollRevisionUpdateOne) Select(field string, fields ...string) *PollRevisionUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated PollRevision entity.
+func (_u *PollRevisionUpdateOne) Save(ctx context.Context) (*PollRevision, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *PollRevisionUpdateOne) SaveX(ctx context.Context) *PollRevision {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *PollRevisionUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *PollRevisionUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *PollRevisionUpdateOne) check() error {
+	if v, ok := _u.mutation.Revision(); ok {
+		if err := pollrevision.RevisionValidator(v); err != nil {
+			return &ValidationError{Name: "revision", err: fmt.Errorf(`ent: validator failed for field "PollRevision.revision": %w`, err)}
+		}
+	}
+	if _u.mutation.PollCleared() && len(_u.mutation.PollIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "PollRevision.poll"`)
+	}
+	return nil
+}
+
+func (_u *PollRevisionUpdateOne) sqlSave(ctx context.Context) (_node *PollRevision, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(pollrevision.Table, pollrevision.Columns, sqlgraph.NewFieldSpec(pollrevision.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "PollRevision.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, pollrevision.FieldID)
+		for _, f := range fields {
+			if !pollrevision.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != pollrevision.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Revision(); ok {
+		_spec.SetField(pollrevision.FieldRevision, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRevision(); ok {
+		_spec.AddField(pollrevision.FieldRevision, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Title(); ok {
+		_spec.SetField(pollrevision.FieldTitle, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Description(); ok {
+		_spec.SetField(pollrevision.FieldDescription, field.TypeString, value)
+	}
+	if _u.mutation.DescriptionCleared() {
+		_spec.ClearField(pollrevision.FieldDescription, field.TypeString)
+	}
+	if value, ok := _u.mutation.Options(); ok {
+		_spec.SetField(pollrevision.FieldOptions, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedOptions(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, pollrevision.FieldOptions, value)
+		})
+	}
+	if value, ok := _u.mutation.Settings(); ok {
+		_spec.SetField(pollrevision.FieldSettings, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.RestoredFrom(); ok {
+		_spec.SetField(pollrevision.FieldRestoredFrom, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRestoredFrom(); ok {
+		_spec.AddField(pollrevision.FieldRestoredFrom, field.TypeInt, value)
+	}
+	if _u.mutation.RestoredFromCleared() {
+		_spec.ClearField(pollrevision.FieldRestoredFrom, field.TypeInt)
+	}
+	if _u.mutation.PollCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pollrevision.PollTable,
+			Columns: []string{pollrevision.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pollrevision.PollTable,
+			Columns: []string{pollrevision.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.AuthorCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pollrevision.AuthorTable,
+			Columns: []string{pollrevision.AuthorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.AuthorIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pollrevision.AuthorTable,
+			Columns: []string{pollrevision.AuthorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &PollRevision{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{pollrevision.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
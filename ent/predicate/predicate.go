@@ -6,12 +6,33 @@ import (
 	"entgo.io/ent/dialect/sql"
 )
 
+// LinkedIdentity is the predicate function for linkedidentity builders.
+type LinkedIdentity func(*sql.Selector)
+
+// LoginAttempt is the predicate function for loginattempt builders.
+type LoginAttempt func(*sql.Selector)
+
+// PasswordReset is the predicate function for passwordreset builders.
+type PasswordReset func(*sql.Selector)
+
 // Poll is the predicate function for poll builders.
 type Poll func(*sql.Selector)
 
 // PollOption is the predicate function for polloption builders.
 type PollOption func(*sql.Selector)
 
+// PollRevision is the predicate function for pollrevision builders.
+type PollRevision func(*sql.Selector)
+
+// RateLimitBucket is the predicate function for ratelimitbucket builders.
+type RateLimitBucket func(*sql.Selector)
+
+// ShareLink is the predicate function for sharelink builders.
+type ShareLink func(*sql.Selector)
+
+// Tag is the predicate function for tag builders.
+type Tag func(*sql.Selector)
+
 // User is the predicate function for user builders.
 type User func(*sql.Selector)
 
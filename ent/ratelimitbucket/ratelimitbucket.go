@@ -0,0 +1,60 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ratelimitbucket
+
+import (
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the ratelimitbucket type in the database.
+	Label = "rate_limit_bucket"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldTokens holds the string denoting the tokens field in the database.
+	FieldTokens = "tokens"
+	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
+	FieldUpdatedAt = "updated_at"
+	// Table holds the table name of the ratelimitbucket in the database.
+	Table = "rate_limit_buckets"
+)
+
+// Columns holds all SQL columns for ratelimitbucket fields.
+var Columns = []string{
+	FieldID,
+	FieldTokens,
+	FieldUpdatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// IDValidator is a validator for the "id" field. It is called by the builders before save.
+	IDValidator func(string) error
+)
+
+// OrderOption defines the ordering options for the RateLimitBucket queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByTokens orders the results by the tokens field.
+func ByTokens(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTokens, opts...).ToFunc()
+}
+
+// ByUpdatedAt orders the results by the updated_at field.
+func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
+}
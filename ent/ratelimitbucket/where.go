@@ -0,0 +1,170 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ratelimitbucket
+
+import (
+	"api_voty/ent/predicate"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id string) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id string) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id string) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...string) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...string) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id string) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id string) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id string) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id string) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldLTE(FieldID, id))
+}
+
+// IDEqualFold applies the EqualFold predicate on the ID field.
+func IDEqualFold(id string) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldEqualFold(FieldID, id))
+}
+
+// IDContainsFold applies the ContainsFold predicate on the ID field.
+func IDContainsFold(id string) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldContainsFold(FieldID, id))
+}
+
+// Tokens applies equality check predicate on the "tokens" field. It's identical to TokensEQ.
+func Tokens(v float64) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldEQ(FieldTokens, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// TokensEQ applies the EQ predicate on the "tokens" field.
+func TokensEQ(v float64) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldEQ(FieldTokens, v))
+}
+
+// TokensNEQ applies the NEQ predicate on the "tokens" field.
+func TokensNEQ(v float64) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldNEQ(FieldTokens, v))
+}
+
+// TokensIn applies the In predicate on the "tokens" field.
+func TokensIn(vs ...float64) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldIn(FieldTokens, vs...))
+}
+
+// TokensNotIn applies the NotIn predicate on the "tokens" field.
+func TokensNotIn(vs ...float64) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldNotIn(FieldTokens, vs...))
+}
+
+// TokensGT applies the GT predicate on the "tokens" field.
+func TokensGT(v float64) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldGT(FieldTokens, v))
+}
+
+// TokensGTE applies the GTE predicate on the "tokens" field.
+func TokensGTE(v float64) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldGTE(FieldTokens, v))
+}
+
+// TokensLT applies the LT predicate on the "tokens" field.
+func TokensLT(v float64) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldLT(FieldTokens, v))
+}
+
+// TokensLTE applies the LTE predicate on the "tokens" field.
+func TokensLTE(v float64) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldLTE(FieldTokens, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.RateLimitBucket) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.RateLimitBucket) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.RateLimitBucket) predicate.RateLimitBucket {
+	return predicate.RateLimitBucket(sql.NotPredicates(p))
+}
@@ -0,0 +1,264 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"api_voty/ent/predicate"
+	"api_voty/ent/ratelimitbucket"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// RateLimitBucketUpdate is the builder for updating RateLimitBucket entities.
+type RateLimitBucketUpdate struct {
+	config
+	hooks    []Hook
+	mutation *RateLimitBucketMutation
+}
+
+// Where appends a list predicates to the RateLimitBucketUpdate builder.
+func (_u *RateLimitBucketUpdate) Where(ps ...predicate.RateLimitBucket) *RateLimitBucketUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetTokens sets the "tokens" field.
+func (_u *RateLimitBucketUpdate) SetTokens(v float64) *RateLimitBucketUpdate {
+	_u.mutation.ResetTokens()
+	_u.mutation.SetTokens(v)
+	return _u
+}
+
+// SetNillableTokens sets the "tokens" field if the given value is not nil.
+func (_u *RateLimitBucketUpdate) SetNillableTokens(v *float64) *RateLimitBucketUpdate {
+	if v != nil {
+		_u.SetTokens(*v)
+	}
+	return _u
+}
+
+// AddTokens adds value to the "tokens" field.
+func (_u *RateLimitBucketUpdate) AddTokens(v float64) *RateLimitBucketUpdate {
+	_u.mutation.AddTokens(v)
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *RateLimitBucketUpdate) SetUpdatedAt(v time.Time) *RateLimitBucketUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_u *RateLimitBucketUpdate) SetNillableUpdatedAt(v *time.Time) *RateLimitBucketUpdate {
+	if v != nil {
+		_u.SetUpdatedAt(*v)
+	}
+	return _u
+}
+
+// Mutation returns the RateLimitBucketMutation object of the builder.
+func (_u *RateLimitBucketUpdate) Mutation() *RateLimitBucketMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *RateLimitBucketUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *RateLimitBucketUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *RateLimitBucketUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *RateLimitBucketUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *RateLimitBucketUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(ratelimitbucket.Table, ratelimitbucket.Columns, sqlgraph.NewFieldSpec(ratelimitbucket.FieldID, field.TypeString))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Tokens(); ok {
+		_spec.SetField(ratelimitbucket.FieldTokens, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedTokens(); ok {
+		_spec.AddField(ratelimitbucket.FieldTokens, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(ratelimitbucket.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{ratelimitbucket.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// RateLimitBucketUpdateOne is the builder for updating a single RateLimitBucket entity.
+type RateLimitBucketUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *RateLimitBucketMutation
+}
+
+// SetTokens sets the "tokens" field.
+func (_u *RateLimitBucketUpdateOne) SetTokens(v float64) *RateLimitBucketUpdateOne {
+	_u.mutation.ResetTokens()
+	_u.mutation.SetTokens(v)
+	return _u
+}
+
+// SetNillableTokens sets the "tokens" field if the given value is not nil.
+func (_u *RateLimitBucketUpdateOne) SetNillableTokens(v *float64) *RateLimitBucketUpdateOne {
+	if v != nil {
+		_u.SetTokens(*v)
+	}
+	return _u
+}
+
+// AddTokens adds value to the "tokens" field.
+func (_u *RateLimitBucketUpdateOne) AddTokens(v float64) *RateLimitBucketUpdateOne {
+	_u.mutation.AddTokens(v)
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *RateLimitBucketUpdateOne) SetUpdatedAt(v time.Time) *RateLimitBucketUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_u *RateLimitBucketUpdateOne) SetNillableUpdatedAt(v *time.Time) *RateLimitBucketUpdateOne {
+	if v != nil {
+		_u.SetUpdatedAt(*v)
+	}
+	return _u
+}
+
+// Mutation returns the RateLimitBucketMutation object of the builder.
+func (_u *RateLimitBucketUpdateOne) Mutation() *RateLimitBucketMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the RateLimitBucketUpdate builder.
+func (_u *RateLimitBucketUpdateOne) Where(ps ...predicate.RateLimitBucket) *RateLimitBucketUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *RateLimitBucketUpdateOne) Select(field string, fields ...string) *RateLimitBucketUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated RateLimitBucket entity.
+func (_u *RateLimitBucketUpdateOne) Save(ctx context.Context) (*RateLimitBucket, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *RateLimitBucketUpdateOne) SaveX(ctx context.Context) *RateLimitBucket {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *RateLimitBucketUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *RateLimitBucketUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *RateLimitBucketUpdateOne) sqlSave(ctx context.Context) (_node *RateLimitBucket, err error) {
+	_spec := sqlgraph.NewUpdateSpec(ratelimitbucket.Table, ratelimitbucket.Columns, sqlgraph.NewFieldSpec(ratelimitbucket.FieldID, field.TypeString))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "RateLimitBucket.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, ratelimitbucket.FieldID)
+		for _, f := range fields {
+			if !ratelimitbucket.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != ratelimitbucket.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Tokens(); ok {
+		_spec.SetField(ratelimitbucket.FieldTokens, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedTokens(); ok {
+		_spec.AddField(ratelimitbucket.FieldTokens, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(ratelimitbucket.FieldUpdatedAt, field.TypeTime, value)
+	}
+	_node = &RateLimitBucket{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{ratelimitbucket.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
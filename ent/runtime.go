@@ -3,9 +3,16 @@
 package ent
 
 import (
+	"api_voty/ent/linkedidentity"
+	"api_voty/ent/loginattempt"
+	"api_voty/ent/passwordreset"
 	"api_voty/ent/poll"
 	"api_voty/ent/polloption"
+	"api_voty/ent/pollrevision"
+	"api_voty/ent/ratelimitbucket"
 	"api_voty/ent/schema"
+	"api_voty/ent/sharelink"
+	"api_voty/ent/tag"
 	"api_voty/ent/user"
 	"api_voty/ent/vote"
 	"time"
@@ -15,34 +22,158 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	linkedidentityFields := schema.LinkedIdentity{}.Fields()
+	_ = linkedidentityFields
+	// linkedidentityDescProvider is the schema descriptor for provider field.
+	linkedidentityDescProvider := linkedidentityFields[1].Descriptor()
+	// linkedidentity.ProviderValidator is a validator for the "provider" field. It is called by the builders before save.
+	linkedidentity.ProviderValidator = linkedidentityDescProvider.Validators[0].(func(string) error)
+	// linkedidentityDescSubject is the schema descriptor for subject field.
+	linkedidentityDescSubject := linkedidentityFields[2].Descriptor()
+	// linkedidentity.SubjectValidator is a validator for the "subject" field. It is called by the builders before save.
+	linkedidentity.SubjectValidator = linkedidentityDescSubject.Validators[0].(func(string) error)
+	// linkedidentityDescEmail is the schema descriptor for email field.
+	linkedidentityDescEmail := linkedidentityFields[3].Descriptor()
+	// linkedidentity.DefaultEmail holds the default value on creation for the email field.
+	linkedidentity.DefaultEmail = linkedidentityDescEmail.Default.(string)
+	// linkedidentityDescCreatedAt is the schema descriptor for created_at field.
+	linkedidentityDescCreatedAt := linkedidentityFields[4].Descriptor()
+	// linkedidentity.DefaultCreatedAt holds the default value on creation for the created_at field.
+	linkedidentity.DefaultCreatedAt = linkedidentityDescCreatedAt.Default.(func() time.Time)
+	loginattemptFields := schema.LoginAttempt{}.Fields()
+	_ = loginattemptFields
+	// loginattemptDescUserAgent is the schema descriptor for user_agent field.
+	loginattemptDescUserAgent := loginattemptFields[3].Descriptor()
+	// loginattempt.DefaultUserAgent holds the default value on creation for the user_agent field.
+	loginattempt.DefaultUserAgent = loginattemptDescUserAgent.Default.(string)
+	// loginattempt.UserAgentValidator is a validator for the "user_agent" field. It is called by the builders before save.
+	loginattempt.UserAgentValidator = loginattemptDescUserAgent.Validators[0].(func(string) error)
+	// loginattemptDescCreatedAt is the schema descriptor for created_at field.
+	loginattemptDescCreatedAt := loginattemptFields[6].Descriptor()
+	// loginattempt.DefaultCreatedAt holds the default value on creation for the created_at field.
+	loginattempt.DefaultCreatedAt = loginattemptDescCreatedAt.Default.(func() time.Time)
+	passwordresetFields := schema.PasswordReset{}.Fields()
+	_ = passwordresetFields
+	// passwordresetDescIP is the schema descriptor for ip field.
+	passwordresetDescIP := passwordresetFields[2].Descriptor()
+	// passwordreset.DefaultIP holds the default value on creation for the ip field.
+	passwordreset.DefaultIP = passwordresetDescIP.Default.(string)
+	// passwordresetDescCreatedAt is the schema descriptor for created_at field.
+	passwordresetDescCreatedAt := passwordresetFields[3].Descriptor()
+	// passwordreset.DefaultCreatedAt holds the default value on creation for the created_at field.
+	passwordreset.DefaultCreatedAt = passwordresetDescCreatedAt.Default.(func() time.Time)
 	pollFields := schema.Poll{}.Fields()
 	_ = pollFields
 	// pollDescIsOpen is the schema descriptor for is_open field.
-	pollDescIsOpen := pollFields[1].Descriptor()
+	pollDescIsOpen := pollFields[2].Descriptor()
 	// poll.DefaultIsOpen holds the default value on creation for the is_open field.
 	poll.DefaultIsOpen = pollDescIsOpen.Default.(bool)
 	// pollDescCreatedAt is the schema descriptor for created_at field.
-	pollDescCreatedAt := pollFields[2].Descriptor()
+	pollDescCreatedAt := pollFields[3].Descriptor()
 	// poll.DefaultCreatedAt holds the default value on creation for the created_at field.
 	poll.DefaultCreatedAt = pollDescCreatedAt.Default.(func() time.Time)
+	// pollDescTotalVotes is the schema descriptor for total_votes field.
+	pollDescTotalVotes := pollFields[4].Descriptor()
+	// poll.DefaultTotalVotes holds the default value on creation for the total_votes field.
+	poll.DefaultTotalVotes = pollDescTotalVotes.Default.(int)
+	// pollDescMaxVotes is the schema descriptor for max_votes field.
+	pollDescMaxVotes := pollFields[7].Descriptor()
+	// poll.MaxVotesValidator is a validator for the "max_votes" field. It is called by the builders before save.
+	poll.MaxVotesValidator = pollDescMaxVotes.Validators[0].(func(int) error)
+	// pollDescQuorumPercent is the schema descriptor for quorum_percent field.
+	pollDescQuorumPercent := pollFields[8].Descriptor()
+	// poll.QuorumPercentValidator is a validator for the "quorum_percent" field. It is called by the builders before save.
+	poll.QuorumPercentValidator = pollDescQuorumPercent.Validators[0].(func(int) error)
+	// pollDescCloseOnDecisiveLead is the schema descriptor for close_on_decisive_lead field.
+	pollDescCloseOnDecisiveLead := pollFields[9].Descriptor()
+	// poll.DefaultCloseOnDecisiveLead holds the default value on creation for the close_on_decisive_lead field.
+	poll.DefaultCloseOnDecisiveLead = pollDescCloseOnDecisiveLead.Default.(bool)
+	// pollDescAnonymous is the schema descriptor for anonymous field.
+	pollDescAnonymous := pollFields[15].Descriptor()
+	// poll.DefaultAnonymous holds the default value on creation for the anonymous field.
+	poll.DefaultAnonymous = pollDescAnonymous.Default.(bool)
+	// pollDescEmbeddable is the schema descriptor for embeddable field.
+	pollDescEmbeddable := pollFields[16].Descriptor()
+	// poll.DefaultEmbeddable holds the default value on creation for the embeddable field.
+	poll.DefaultEmbeddable = pollDescEmbeddable.Default.(bool)
+	// pollDescAllowGuests is the schema descriptor for allow_guests field.
+	pollDescAllowGuests := pollFields[17].Descriptor()
+	// poll.DefaultAllowGuests holds the default value on creation for the allow_guests field.
+	poll.DefaultAllowGuests = pollDescAllowGuests.Default.(bool)
 	polloptionFields := schema.PollOption{}.Fields()
 	_ = polloptionFields
 	// polloptionDescVotesCount is the schema descriptor for votes_count field.
 	polloptionDescVotesCount := polloptionFields[1].Descriptor()
 	// polloption.DefaultVotesCount holds the default value on creation for the votes_count field.
 	polloption.DefaultVotesCount = polloptionDescVotesCount.Default.(int)
+	// polloptionDescPosition is the schema descriptor for position field.
+	polloptionDescPosition := polloptionFields[2].Descriptor()
+	// polloption.DefaultPosition holds the default value on creation for the position field.
+	polloption.DefaultPosition = polloptionDescPosition.Default.(int)
+	// polloptionDescArchived is the schema descriptor for archived field.
+	polloptionDescArchived := polloptionFields[3].Descriptor()
+	// polloption.DefaultArchived holds the default value on creation for the archived field.
+	polloption.DefaultArchived = polloptionDescArchived.Default.(bool)
+	pollrevisionFields := schema.PollRevision{}.Fields()
+	_ = pollrevisionFields
+	// pollrevisionDescRevision is the schema descriptor for revision field.
+	pollrevisionDescRevision := pollrevisionFields[1].Descriptor()
+	// pollrevision.RevisionValidator is a validator for the "revision" field. It is called by the builders before save.
+	pollrevision.RevisionValidator = pollrevisionDescRevision.Validators[0].(func(int) error)
+	// pollrevisionDescCreatedAt is the schema descriptor for created_at field.
+	pollrevisionDescCreatedAt := pollrevisionFields[8].Descriptor()
+	// pollrevision.DefaultCreatedAt holds the default value on creation for the created_at field.
+	pollrevision.DefaultCreatedAt = pollrevisionDescCreatedAt.Default.(func() time.Time)
+	ratelimitbucketFields := schema.RateLimitBucket{}.Fields()
+	_ = ratelimitbucketFields
+	// ratelimitbucketDescID is the schema descriptor for id field.
+	ratelimitbucketDescID := ratelimitbucketFields[0].Descriptor()
+	// ratelimitbucket.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	ratelimitbucket.IDValidator = ratelimitbucketDescID.Validators[0].(func(string) error)
+	sharelinkFields := schema.ShareLink{}.Fields()
+	_ = sharelinkFields
+	// sharelinkDescCreatedAt is the schema descriptor for created_at field.
+	sharelinkDescCreatedAt := sharelinkFields[4].Descriptor()
+	// sharelink.DefaultCreatedAt holds the default value on creation for the created_at field.
+	sharelink.DefaultCreatedAt = sharelinkDescCreatedAt.Default.(func() time.Time)
+	tagFields := schema.Tag{}.Fields()
+	_ = tagFields
+	// tagDescName is the schema descriptor for name field.
+	tagDescName := tagFields[0].Descriptor()
+	// tag.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	tag.NameValidator = tagDescName.Validators[0].(func(string) error)
+	// tagDescSlug is the schema descriptor for slug field.
+	tagDescSlug := tagFields[1].Descriptor()
+	// tag.SlugValidator is a validator for the "slug" field. It is called by the builders before save.
+	tag.SlugValidator = tagDescSlug.Validators[0].(func(string) error)
+	// tagDescCreatedAt is the schema descriptor for created_at field.
+	tagDescCreatedAt := tagFields[2].Descriptor()
+	// tag.DefaultCreatedAt holds the default value on creation for the created_at field.
+	tag.DefaultCreatedAt = tagDescCreatedAt.Default.(func() time.Time)
 	userFields := schema.User{}.Fields()
 	_ = userFields
 	// userDescActive is the schema descriptor for active field.
-	userDescActive := userFields[5].Descriptor()
+	userDescActive := userFields[7].Descriptor()
 	// user.DefaultActive holds the default value on creation for the active field.
 	user.DefaultActive = userDescActive.Default.(bool)
+	// userDescFailedLogins is the schema descriptor for failed_logins field.
+	userDescFailedLogins := userFields[10].Descriptor()
+	// user.DefaultFailedLogins holds the default value on creation for the failed_logins field.
+	user.DefaultFailedLogins = userDescFailedLogins.Default.(int)
+	// userDescTokenVersion is the schema descriptor for token_version field.
+	userDescTokenVersion := userFields[13].Descriptor()
+	// user.DefaultTokenVersion holds the default value on creation for the token_version field.
+	user.DefaultTokenVersion = userDescTokenVersion.Default.(int)
+	// userDescTotpLastStep is the schema descriptor for totp_last_step field.
+	userDescTotpLastStep := userFields[16].Descriptor()
+	// user.DefaultTotpLastStep holds the default value on creation for the totp_last_step field.
+	user.DefaultTotpLastStep = userDescTotpLastStep.Default.(int64)
 	// userDescCreatedAt is the schema descriptor for created_at field.
-	userDescCreatedAt := userFields[6].Descriptor()
+	userDescCreatedAt := userFields[18].Descriptor()
 	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
 	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
 	// userDescUpdatedAt is the schema descriptor for updated_at field.
-	userDescUpdatedAt := userFields[7].Descriptor()
+	userDescUpdatedAt := userFields[19].Descriptor()
 	// user.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	user.DefaultUpdatedAt = userDescUpdatedAt.Default.(func() time.Time)
 	// user.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// LinkedIdentity holds the schema definition for the LinkedIdentity entity.
+// Una cuenta de un proveedor OpenID Connect (Google, el corporativo...) con
+// la que el usuario puede iniciar sesión.
+type LinkedIdentity struct {
+	ent.Schema
+}
+
+func (LinkedIdentity) Fields() []ent.Field {
+    return []ent.Field{
+        field.String("user_id"),
+        // Nombre del proveedor en la configuración (google, corp...)
+        field.String("provider").
+            MaxLen(64),
+        // "sub" del ID token: estable, a diferencia del email
+        field.String("subject").
+            MaxLen(255),
+        // Email que dio el proveedor al vincular, solo informativo
+        field.String("email").
+            Default(""),
+        field.Time("created_at").
+            Default(time.Now).
+            Immutable(),
+        field.Time("last_login_at").
+            Optional().
+            Nillable(),
+    }
+}
+
+func (LinkedIdentity) Edges() []ent.Edge {
+    return []ent.Edge{
+        edge.From("user", User.Type).
+            Ref("identities").
+            Field("user_id").
+            Unique().
+            Required(),
+    }
+}
+
+func (LinkedIdentity) Indexes() []ent.Index {
+    return []ent.Index{
+        // Una cuenta del proveedor solo se vincula a un usuario
+        index.Fields("provider", "subject").
+            Unique(),
+    }
+}
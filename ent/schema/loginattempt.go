@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// LoginAttempt holds the schema definition for the LoginAttempt entity.
+// Cada intento de login, bueno o malo: historial del usuario y base de los
+// bloqueos por IP.
+type LoginAttempt struct {
+	ent.Schema
+}
+
+func (LoginAttempt) Fields() []ent.Field {
+    return []ent.Field{
+        // Vacío si el email no corresponde a ninguna cuenta
+        field.String("user_id").
+            Optional().
+            Nillable(),
+        // Lo que se escribió, normalizado, exista o no la cuenta
+        field.String("email"),
+        field.String("ip"),
+        field.String("user_agent").
+            MaxLen(512).
+            Default(""),
+        field.Bool("success"),
+        field.Enum("failure_reason").
+            Values("bad_password", "unknown_user", "inactive", "locked", "throttled", "ip_blocked", "bad_second_factor").
+            Optional().
+            Nillable(),
+        field.Time("created_at").
+            Default(time.Now).
+            Immutable(),
+    }
+}
+
+func (LoginAttempt) Edges() []ent.Edge {
+    return []ent.Edge{
+        edge.From("user", User.Type).
+            Ref("login_attempts").
+            Field("user_id").
+            Unique(),
+    }
+}
+
+func (LoginAttempt) Indexes() []ent.Index {
+    return []ent.Index{
+        // Historial de un usuario y detección de dispositivos nuevos
+        index.Fields("user_id", "created_at"),
+        // Fallos recientes desde una IP
+        index.Fields("ip", "created_at"),
+    }
+}
@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// PasswordReset holds the schema definition for the PasswordReset entity.
+// Un enlace de "he olvidado mi contraseña": de un solo uso y con caducidad.
+type PasswordReset struct {
+	ent.Schema
+}
+
+func (PasswordReset) Fields() []ent.Field {
+    return []ent.Field{
+        field.String("user_id"),
+        // Solo se guarda el hash: el token en claro va únicamente en el correo
+        field.String("token_hash").
+            Unique().
+            Sensitive(),
+        // Desde dónde se pidió, para el aviso y la auditoría
+        field.String("ip").
+            Default(""),
+        field.Time("created_at").
+            Default(time.Now).
+            Immutable(),
+        field.Time("expires_at"),
+        field.Time("used_at").
+            Optional().
+            Nillable(),
+    }
+}
+
+func (PasswordReset) Edges() []ent.Edge {
+    return []ent.Edge{
+        edge.From("user", User.Type).
+            Ref("password_resets").
+            Field("user_id").
+            Unique().
+            Required(),
+    }
+}
+
+func (PasswordReset) Indexes() []ent.Index {
+    return []ent.Index{
+        // Enlaces recientes de un usuario, para limitar las peticiones
+        index.Fields("user_id", "created_at"),
+    }
+}
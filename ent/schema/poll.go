@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 )
@@ -16,13 +17,79 @@ type Poll struct {
 func (Poll) Fields() []ent.Field {
     return []ent.Field{
         field.String("title"),
+        field.Text("description").
+            Optional(),
         field.Bool("is_open").Default(true),
         field.Time("created_at").Default(time.Now),
+        // Suma de votes_count de las opciones, para ordenar sin agregaciones
+        field.Int("total_votes").Default(0),
+        // Programación: el scheduler abre/cierra la encuesta en estos instantes
+        field.Time("opens_at").
+            Optional().
+            Nillable(),
+        field.Time("closes_at").
+            Optional().
+            Nillable(),
+        // Reglas de cierre automático (se evalúan tras cada voto)
+        field.Int("max_votes").
+            Optional().
+            Nillable().
+            Positive(),
+        field.Int("quorum_percent").
+            Optional().
+            Nillable().
+            Range(1, 100),
+        field.Bool("close_on_decisive_lead").Default(false),
+        field.Enum("closed_reason").
+            Values("manual", "schedule", "vote_cap", "quorum", "decisive_lead").
+            Optional().
+            Nillable(),
+        // Cuándo se muestran los recuentos a los votantes
+        field.Enum("results_visibility").
+            Values("always", "after_vote", "after_close", "embargo").
+            Default("always"),
+        field.Time("results_visible_at").
+            Optional().
+            Nillable(),
+        // Los borradores solo los ve su autor y no admiten votos
+        field.Enum("status").
+            Values("draft", "published").
+            Default("published"),
+        field.Time("published_at").
+            Optional().
+            Nillable(),
+        // En una encuesta anónima nadie, ni el autor, puede ver quién votó qué.
+        // Solo se fija al crearla: cambiarlo después expondría votos ya emitidos
+        field.Bool("anonymous").
+            Default(false).
+            Immutable(),
+        // Si el autor permite incrustarla en otras webs (widget y oEmbed)
+        field.Bool("embeddable").Default(false),
+        // Si admite votos de invitados sin cuenta (un voto por dispositivo)
+        field.Bool("allow_guests").Default(false),
+        // Opcional porque las encuestas antiguas no tienen autor
+        field.String("owner_id").
+            Optional().
+            Nillable(),
     }
 }
 func (Poll) Edges() []ent.Edge {
     return []ent.Edge{
         edge.To("options", PollOption.Type),
         edge.To("votes", Vote.Type),
+        // El historial se borra con la encuesta
+        edge.To("revisions", PollRevision.Type).
+            Annotations(entsql.Annotation{
+                OnDelete: entsql.Cascade,
+            }),
+        edge.To("tags", Tag.Type),
+        edge.To("share_links", ShareLink.Type).
+            Annotations(entsql.Annotation{
+                OnDelete: entsql.Cascade,
+            }),
+        edge.From("owner", User.Type).
+            Ref("polls").
+            Field("owner_id").
+            Unique(),
     }
-}
\ No newline at end of file
+}
@@ -15,6 +15,10 @@ func (PollOption) Fields() []ent.Field {
     return []ent.Field{
         field.String("text"),
         field.Int("votes_count").Default(0),
+        // Orden de presentación dentro de la encuesta
+        field.Int("position").Default(0),
+        // Una opción archivada conserva sus votos pero ya no admite nuevos
+        field.Bool("archived").Default(false),
     }
 }
 
@@ -0,0 +1,83 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// PollRevision guarda una foto de la encuesta tras cada edición.
+type PollRevision struct {
+	ent.Schema
+}
+
+// OptionSnapshot es una opción tal como estaba en la revisión (sin recuentos)
+type OptionSnapshot struct {
+	ID       int    `json:"id"`
+	Text     string `json:"text"`
+	Position int    `json:"position"`
+	Archived bool   `json:"archived,omitempty"`
+}
+
+// PollSettings son los ajustes de la encuesta en la revisión
+type PollSettings struct {
+	IsOpen              bool       `json:"is_open"`
+	OpensAt             *time.Time `json:"opens_at,omitempty"`
+	ClosesAt            *time.Time `json:"closes_at,omitempty"`
+	MaxVotes            *int       `json:"max_votes,omitempty"`
+	QuorumPercent       *int       `json:"quorum_percent,omitempty"`
+	CloseOnDecisiveLead bool       `json:"close_on_decisive_lead,omitempty"`
+	ResultsVisibility   string     `json:"results_visibility"`
+	ResultsVisibleAt    *time.Time `json:"results_visible_at,omitempty"`
+	Tags                []string   `json:"tags,omitempty"`
+	Anonymous           bool       `json:"anonymous,omitempty"`
+	Embeddable          bool       `json:"embeddable,omitempty"`
+	AllowGuests         bool       `json:"allow_guests,omitempty"`
+}
+
+func (PollRevision) Fields() []ent.Field {
+    return []ent.Field{
+        field.Int("poll_id"),
+        field.Int("revision").Positive(),
+        field.String("title"),
+        field.Text("description").
+            Optional(),
+        field.JSON("options", []OptionSnapshot{}),
+        field.JSON("settings", PollSettings{}),
+        // Quién hizo el cambio (nil en la revisión base de encuestas antiguas)
+        field.String("author_id").
+            Optional().
+            Nillable(),
+        // Si la revisión es una restauración, de qué revisión viene
+        field.Int("restored_from").
+            Optional().
+            Nillable(),
+        field.Time("created_at").
+            Default(time.Now).
+            Immutable(),
+    }
+}
+
+func (PollRevision) Edges() []ent.Edge {
+    return []ent.Edge{
+        edge.From("poll", Poll.Type).
+            Ref("revisions").
+            Field("poll_id").
+            Unique().
+            Required(),
+        edge.From("author", User.Type).
+            Ref("poll_revisions").
+            Field("author_id").
+            Unique(),
+    }
+}
+
+func (PollRevision) Indexes() []ent.Index {
+    return []ent.Index{
+        // Una encuesta no puede tener dos revisiones con el mismo número
+        index.Fields("poll_id", "revision").Unique(),
+    }
+}
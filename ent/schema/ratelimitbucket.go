@@ -0,0 +1,27 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/schema/field"
+)
+
+// RateLimitBucket holds the schema definition for the RateLimitBucket entity.
+// Lo usa ratelimit.SQLStore para compartir los límites entre instancias.
+type RateLimitBucket struct {
+	ent.Schema
+}
+
+func (RateLimitBucket) Fields() []ent.Field {
+    return []ent.Field{
+        // La clave del cubo: ruta|ip|1.2.3.4 o ruta|user|<id>
+        field.String("id").
+            MaxLen(191).
+            Immutable(),
+        // Fichas que quedaban en updated_at (con decimales: se rellenan poco a poco)
+        field.Float("tokens"),
+        // Con microsegundos: a segundos enteros el rellenado iría a saltos
+        field.Time("updated_at").
+            SchemaType(map[string]string{dialect.MySQL: "datetime(6)"}),
+    }
+}
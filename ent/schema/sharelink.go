@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// ShareLink holds the schema definition for the ShareLink entity.
+type ShareLink struct {
+	ent.Schema
+}
+
+func (ShareLink) Fields() []ent.Field {
+    return []ent.Field{
+        field.Int("poll_id"),
+        // Solo se guarda el hash: el token en claro se muestra una vez al crearlo
+        field.String("token_hash").
+            Unique().
+            Sensitive(),
+        // Primeros caracteres del token, para que el autor distinga sus enlaces
+        field.String("token_prefix"),
+        field.String("created_by").
+            Optional().
+            Nillable(),
+        field.Time("created_at").
+            Default(time.Now).
+            Immutable(),
+        field.Time("expires_at").
+            Optional().
+            Nillable(),
+        field.Time("revoked_at").
+            Optional().
+            Nillable(),
+    }
+}
+
+func (ShareLink) Edges() []ent.Edge {
+    return []ent.Edge{
+        edge.From("poll", Poll.Type).
+            Ref("share_links").
+            Field("poll_id").
+            Unique().
+            Required(),
+    }
+}
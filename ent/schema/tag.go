@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Tag holds the schema definition for the Tag entity.
+type Tag struct {
+	ent.Schema
+}
+
+func (Tag) Fields() []ent.Field {
+    return []ent.Field{
+        // Nombre tal como se muestra ("Política")
+        field.String("name").
+            NotEmpty(),
+        // Nombre normalizado sin tildes ni mayúsculas ("politica"): evita duplicados
+        field.String("slug").
+            NotEmpty().
+            Unique(),
+        field.Time("created_at").
+            Default(time.Now).
+            Immutable(),
+    }
+}
+
+func (Tag) Edges() []ent.Edge {
+    return []ent.Edge{
+        edge.From("polls", Poll.Type).Ref("tags"),
+        edge.From("followers", User.Type).Ref("followed_tags"),
+    }
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 	"github.com/google/uuid"
@@ -25,9 +26,48 @@ func (User) Fields() []ent.Field {
             Optional().
             Nillable(), // Permite que sea null en la BD
         field.String("email").Unique(),
+        // Nulo hasta que el usuario abre el enlace de verificación
+        field.Time("email_verified_at").
+            Optional().
+            Nillable(),
+        // Último envío del enlace, para limitar los reenvíos
+        field.Time("verification_sent_at").
+            Optional().
+            Nillable(),
         field.String("name"),
         field.String("password").Sensitive(),
         field.Bool("active").Default(true),
+        field.Enum("role").
+            Values("user", "admin").
+            Default("user"),
+        // Orígenes (https://blog.ejemplo.com) donde se pueden incrustar sus encuestas
+        field.JSON("embed_origins", []string{}).
+            Optional(),
+        // Bloqueo por intentos fallidos: fallos seguidos, el último y hasta cuándo
+        field.Int("failed_logins").Default(0),
+        field.Time("last_failed_login_at").
+            Optional().
+            Nillable(),
+        field.Time("locked_until").
+            Optional().
+            Nillable(),
+        // Versión de las sesiones: al subirla dejan de valer los tokens emitidos antes
+        field.Int("token_version").Default(0),
+        // Segundo factor (TOTP): el secreto existe desde el alta y vale desde
+        // que el usuario confirma un primer código
+        field.String("totp_secret").
+            Optional().
+            Nillable().
+            Sensitive(),
+        field.Time("totp_enabled_at").
+            Optional().
+            Nillable(),
+        // Último paso de 30 s aceptado: cada código sirve una sola vez
+        field.Int64("totp_last_step").Default(0),
+        // Hashes de los códigos de recuperación que quedan por usar
+        field.JSON("recovery_codes", []string{}).
+            Optional().
+            Sensitive(),
         field.Time("created_at").
             Default(time.Now). // Fecha automática al crear
             Immutable(),
@@ -41,6 +81,30 @@ func (User) Fields() []ent.Field {
 func (User) Edges() []ent.Edge {
     return []ent.Edge{
         // Añade esto para que User sepa que tiene muchos votos
-        edge.To("votes", Vote.Type),
+        // Los votos cuentan en los resultados: no se borra a quien ha votado
+        edge.To("votes", Vote.Type).
+            Annotations(entsql.Annotation{
+                OnDelete: entsql.Restrict,
+            }),
+        // Encuestas creadas por el usuario
+        edge.To("polls", Poll.Type),
+        edge.To("poll_revisions", PollRevision.Type),
+        // Temas que alimentan el feed personalizado
+        edge.To("followed_tags", Tag.Type),
+        // Historial de inicios de sesión
+        edge.To("login_attempts", LoginAttempt.Type).
+            Annotations(entsql.Annotation{
+                OnDelete: entsql.Cascade,
+            }),
+        // Enlaces de recuperación de contraseña
+        edge.To("password_resets", PasswordReset.Type).
+            Annotations(entsql.Annotation{
+                OnDelete: entsql.Cascade,
+            }),
+        // Cuentas de proveedores OpenID Connect vinculadas
+        edge.To("identities", LinkedIdentity.Type).
+            Annotations(entsql.Annotation{
+                OnDelete: entsql.Cascade,
+            }),
     }
 }
\ No newline at end of file
@@ -19,6 +19,10 @@ func (Vote) Indexes() []ent.Index {
     return []ent.Index{
         // Crea una restricción única: Un usuario solo un voto por encuesta
         index.Edges("user", "poll").Unique(),
+        // Y un dispositivo invitado, un voto por encuesta
+        index.Fields("guest_id").Edges("poll").Unique(),
+        // Analíticas: recorrer los votos de una encuesta en orden cronológico
+        index.Edges("poll").Fields("created_at"),
     }
 }
 
@@ -28,15 +32,20 @@ func (Vote) Fields() []ent.Field {
         field.Time("created_at").
             Default(time.Now).
             Immutable(), // El voto no se puede cambiar de fecha
+        // Voto de invitado: el dispositivo que lo emitió. Se vacía cuando el
+        // invitado se registra y el voto pasa a su usuario
+        field.String("guest_id").
+            Optional().
+            Nillable(),
     }
 }
 
 func (Vote) Edges() []ent.Edge {
     return []ent.Edge{
+        // Opcional: los votos de invitado no tienen usuario (llevan guest_id)
         edge.From("user", User.Type).
             Ref("votes").
-            Unique().
-            Required(),
+            Unique(),
         
         edge.From("poll", Poll.Type).
             Ref("votes").
@@ -0,0 +1,531 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"api_voty/ent/poll"
+	"api_voty/ent/predicate"
+	"api_voty/ent/sharelink"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ShareLinkUpdate is the builder for updating ShareLink entities.
+type ShareLinkUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ShareLinkMutation
+}
+
+// Where appends a list predicates to the ShareLinkUpdate builder.
+func (_u *ShareLinkUpdate) Where(ps ...predicate.ShareLink) *ShareLinkUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetPollID sets the "poll_id" field.
+func (_u *ShareLinkUpdate) SetPollID(v int) *ShareLinkUpdate {
+	_u.mutation.SetPollID(v)
+	return _u
+}
+
+// SetNillablePollID sets the "poll_id" field if the given value is not nil.
+func (_u *ShareLinkUpdate) SetNillablePollID(v *int) *ShareLinkUpdate {
+	if v != nil {
+		_u.SetPollID(*v)
+	}
+	return _u
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (_u *ShareLinkUpdate) SetTokenHash(v string) *ShareLinkUpdate {
+	_u.mutation.SetTokenHash(v)
+	return _u
+}
+
+// SetNillableTokenHash sets the "token_hash" field if the given value is not nil.
+func (_u *ShareLinkUpdate) SetNillableTokenHash(v *string) *ShareLinkUpdate {
+	if v != nil {
+		_u.SetTokenHash(*v)
+	}
+	return _u
+}
+
+// SetTokenPrefix sets the "token_prefix" field.
+func (_u *ShareLinkUpdate) SetTokenPrefix(v string) *ShareLinkUpdate {
+	_u.mutation.SetTokenPrefix(v)
+	return _u
+}
+
+// SetNillableTokenPrefix sets the "token_prefix" field if the given value is not nil.
+func (_u *ShareLinkUpdate) SetNillableTokenPrefix(v *string) *ShareLinkUpdate {
+	if v != nil {
+		_u.SetTokenPrefix(*v)
+	}
+	return _u
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (_u *ShareLinkUpdate) SetCreatedBy(v string) *ShareLinkUpdate {
+	_u.mutation.SetCreatedBy(v)
+	return _u
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (_u *ShareLinkUpdate) SetNillableCreatedBy(v *string) *ShareLinkUpdate {
+	if v != nil {
+		_u.SetCreatedBy(*v)
+	}
+	return _u
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (_u *ShareLinkUpdate) ClearCreatedBy() *ShareLinkUpdate {
+	_u.mutation.ClearCreatedBy()
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *ShareLinkUpdate) SetExpiresAt(v time.Time) *ShareLinkUpdate {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *ShareLinkUpdate) SetNillableExpiresAt(v *time.Time) *ShareLinkUpdate {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (_u *ShareLinkUpdate) ClearExpiresAt() *ShareLinkUpdate {
+	_u.mutation.ClearExpiresAt()
+	return _u
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (_u *ShareLinkUpdate) SetRevokedAt(v time.Time) *ShareLinkUpdate {
+	_u.mutation.SetRevokedAt(v)
+	return _u
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (_u *ShareLinkUpdate) SetNillableRevokedAt(v *time.Time) *ShareLinkUpdate {
+	if v != nil {
+		_u.SetRevokedAt(*v)
+	}
+	return _u
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (_u *ShareLinkUpdate) ClearRevokedAt() *ShareLinkUpdate {
+	_u.mutation.ClearRevokedAt()
+	return _u
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_u *ShareLinkUpdate) SetPoll(v *Poll) *ShareLinkUpdate {
+	return _u.SetPollID(v.ID)
+}
+
+// Mutation returns the ShareLinkMutation object of the builder.
+func (_u *ShareLinkUpdate) Mutation() *ShareLinkMutation {
+	return _u.mutation
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (_u *ShareLinkUpdate) ClearPoll() *ShareLinkUpdate {
+	_u.mutation.ClearPoll()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *ShareLinkUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *ShareLinkUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *ShareLinkUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *ShareLinkUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *ShareLinkUpdate) check() error {
+	if _u.mutation.PollCleared() && len(_u.mutation.PollIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "ShareLink.poll"`)
+	}
+	return nil
+}
+
+func (_u *ShareLinkUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(sharelink.Table, sharelink.Columns, sqlgraph.NewFieldSpec(sharelink.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.TokenHash(); ok {
+		_spec.SetField(sharelink.FieldTokenHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.TokenPrefix(); ok {
+		_spec.SetField(sharelink.FieldTokenPrefix, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.CreatedBy(); ok {
+		_spec.SetField(sharelink.FieldCreatedBy, field.TypeString, value)
+	}
+	if _u.mutation.CreatedByCleared() {
+		_spec.ClearField(sharelink.FieldCreatedBy, field.TypeString)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(sharelink.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _u.mutation.ExpiresAtCleared() {
+		_spec.ClearField(sharelink.FieldExpiresAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.RevokedAt(); ok {
+		_spec.SetField(sharelink.FieldRevokedAt, field.TypeTime, value)
+	}
+	if _u.mutation.RevokedAtCleared() {
+		_spec.ClearField(sharelink.FieldRevokedAt, field.TypeTime)
+	}
+	if _u.mutation.PollCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   sharelink.PollTable,
+			Columns: []string{sharelink.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   sharelink.PollTable,
+			Columns: []string{sharelink.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{sharelink.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// ShareLinkUpdateOne is the builder for updating a single ShareLink entity.
+type ShareLinkUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ShareLinkMutation
+}
+
+// SetPollID sets the "poll_id" field.
+func (_u *ShareLinkUpdateOne) SetPollID(v int) *ShareLinkUpdateOne {
+	_u.mutation.SetPollID(v)
+	return _u
+}
+
+// SetNillablePollID sets the "poll_id" field if the given value is not nil.
+func (_u *ShareLinkUpdateOne) SetNillablePollID(v *int) *ShareLinkUpdateOne {
+	if v != nil {
+		_u.SetPollID(*v)
+	}
+	return _u
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (_u *ShareLinkUpdateOne) SetTokenHash(v string) *ShareLinkUpdateOne {
+	_u.mutation.SetTokenHash(v)
+	return _u
+}
+
+// SetNillableTokenHash sets the "token_hash" field if the given value is not nil.
+func (_u *ShareLinkUpdateOne) SetNillableTokenHash(v *string) *ShareLinkUpdateOne {
+	if v != nil {
+		_u.SetTokenHash(*v)
+	}
+	return _u
+}
+
+// SetTokenPrefix sets the "token_prefix" field.
+func (_u *ShareLinkUpdateOne) SetTokenPrefix(v string) *ShareLinkUpdateOne {
+	_u.mutation.SetTokenPrefix(v)
+	return _u
+}
+
+// SetNillableTokenPrefix sets the "token_prefix" field if the given value is not nil.
+func (_u *ShareLinkUpdateOne) SetNillableTokenPrefix(v *string) *ShareLinkUpdateOne {
+	if v != nil {
+		_u.SetTokenPrefix(*v)
+	}
+	return _u
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (_u *ShareLinkUpdateOne) SetCreatedBy(v string) *ShareLinkUpdateOne {
+	_u.mutation.SetCreatedBy(v)
+	return _u
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (_u *ShareLinkUpdateOne) SetNillableCreatedBy(v *string) *ShareLinkUpdateOne {
+	if v != nil {
+		_u.SetCreatedBy(*v)
+	}
+	return _u
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (_u *ShareLinkUpdateOne) ClearCreatedBy() *ShareLinkUpdateOne {
+	_u.mutation.ClearCreatedBy()
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *ShareLinkUpdateOne) SetExpiresAt(v time.Time) *ShareLinkUpdateOne {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *ShareLinkUpdateOne) SetNillableExpiresAt(v *time.Time) *ShareLinkUpdateOne {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (_u *ShareLinkUpdateOne) ClearExpiresAt() *ShareLinkUpdateOne {
+	_u.mutation.ClearExpiresAt()
+	return _u
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (_u *ShareLinkUpdateOne) SetRevokedAt(v time.Time) *ShareLinkUpdateOne {
+	_u.mutation.SetRevokedAt(v)
+	return _u
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (_u *ShareLinkUpdateOne) SetNillableRevokedAt(v *time.Time) *ShareLinkUpdateOne {
+	if v != nil {
+		_u.SetRevokedAt(*v)
+	}
+	return _u
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (_u *ShareLinkUpdateOne) ClearRevokedAt() *ShareLinkUpdateOne {
+	_u.mutation.ClearRevokedAt()
+	return _u
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_u *ShareLinkUpdateOne) SetPoll(v *Poll) *ShareLinkUpdateOne {
+	return _u.SetPollID(v.ID)
+}
+
+// Mutation returns the ShareLinkMutation object of the builder.
+func (_u *ShareLinkUpdateOne) Mutation() *ShareLinkMutation {
+	return _u.mutation
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (_u *ShareLinkUpdateOne) ClearPoll() *ShareLinkUpdateOne {
+	_u.mutation.ClearPoll()
+	return _u
+}
+
+// Where appends a list predicates to the ShareLinkUpdate builder.
+func (_u *ShareLinkUpdateOne) Where(ps ...predicate.ShareLink) *ShareLinkUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *ShareLinkUpdateOne) Select(field string, fields ...string) *ShareLinkUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated ShareLink entity.
+func (_u *ShareLinkUpdateOne) Save(ctx context.Context) (*ShareLink, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *ShareLinkUpdateOne) SaveX(ctx context.Context) *ShareLink {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *ShareLinkUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *ShareLinkUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *ShareLinkUpdateOne) check() error {
+	if _u.mutation.PollCleared() && len(_u.mutation.PollIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "ShareLink.poll"`)
+	}
+	return nil
+}
+
+func (_u *ShareLinkUpdateOne) sqlSave(ctx context.Context) (_node *ShareLink, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(sharelink.Table, sharelink.Columns, sqlgraph.NewFieldSpec(sharelink.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ShareLink.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, sharelink.FieldID)
+		for _, f := range fields {
+			if !sharelink.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != sharelink.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.TokenHash(); ok {
+		_spec.SetField(sharelink.FieldTokenHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.TokenPrefix(); ok {
+		_spec.SetField(sharelink.FieldTokenPrefix, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.CreatedBy(); ok {
+		_spec.SetField(sharelink.FieldCreatedBy, field.TypeString, value)
+	}
+	if _u.mutation.CreatedByCleared() {
+		_spec.ClearField(sharelink.FieldCreatedBy, field.TypeString)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(sharelink.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _u.mutation.ExpiresAtCleared() {
+		_spec.ClearField(sharelink.FieldExpiresAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.RevokedAt(); ok {
+		_spec.SetField(sharelink.FieldRevokedAt, field.TypeTime, value)
+	}
+	if _u.mutation.RevokedAtCleared() {
+		_spec.ClearField(sharelink.FieldRevokedAt, field.TypeTime)
+	}
+	if _u.mutation.PollCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   sharelink.PollTable,
+			Columns: []string{sharelink.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   sharelink.PollTable,
+			Columns: []string{sharelink.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &ShareLink{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{sharelink.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -4,6 +4,7 @@ package ent
 
 import (
 	"api_voty/ent/user"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -21,12 +22,36 @@ type User struct {
 	AvatarImage *string `json:"avatar_image,omitempty"`
 	// Email holds the value of the "email" field.
 	Email string `json:"email,omitempty"`
+	// EmailVerifiedAt holds the value of the "email_verified_at" field.
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	// VerificationSentAt holds the value of the "verification_sent_at" field.
+	VerificationSentAt *time.Time `json:"verification_sent_at,omitempty"`
 	// Name holds the value of the "name" field.
 	Name string `json:"name,omitempty"`
 	// Password holds the value of the "password" field.
 	Password string `json:"-"`
 	// Active holds the value of the "active" field.
 	Active bool `json:"active,omitempty"`
+	// Role holds the value of the "role" field.
+	Role user.Role `json:"role,omitempty"`
+	// EmbedOrigins holds the value of the "embed_origins" field.
+	EmbedOrigins []string `json:"embed_origins,omitempty"`
+	// FailedLogins holds the value of the "failed_logins" field.
+	FailedLogins int `json:"failed_logins,omitempty"`
+	// LastFailedLoginAt holds the value of the "last_failed_login_at" field.
+	LastFailedLoginAt *time.Time `json:"last_failed_login_at,omitempty"`
+	// LockedUntil holds the value of the "locked_until" field.
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+	// TokenVersion holds the value of the "token_version" field.
+	TokenVersion int `json:"token_version,omitempty"`
+	// TotpSecret holds the value of the "totp_secret" field.
+	TotpSecret *string `json:"-"`
+	// TotpEnabledAt holds the value of the "totp_enabled_at" field.
+	TotpEnabledAt *time.Time `json:"totp_enabled_at,omitempty"`
+	// TotpLastStep holds the value of the "totp_last_step" field.
+	TotpLastStep int64 `json:"totp_last_step,omitempty"`
+	// RecoveryCodes holds the value of the "recovery_codes" field.
+	RecoveryCodes []string `json:"-"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// UpdatedAt holds the value of the "updated_at" field.
@@ -41,9 +66,21 @@ type User struct {
 type UserEdges struct {
 	// Votes holds the value of the votes edge.
 	Votes []*Vote `json:"votes,omitempty"`
+	// Polls holds the value of the polls edge.
+	Polls []*Poll `json:"polls,omitempty"`
+	// PollRevisions holds the value of the poll_revisions edge.
+	PollRevisions []*PollRevision `json:"poll_revisions,omitempty"`
+	// FollowedTags holds the value of the followed_tags edge.
+	FollowedTags []*Tag `json:"followed_tags,omitempty"`
+	// LoginAttempts holds the value of the login_attempts edge.
+	LoginAttempts []*LoginAttempt `json:"login_attempts,omitempty"`
+	// PasswordResets holds the value of the password_resets edge.
+	PasswordResets []*PasswordReset `json:"password_resets,omitempty"`
+	// Identities holds the value of the identities edge.
+	Identities []*LinkedIdentity `json:"identities,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [1]bool
+	loadedTypes [7]bool
 }
 
 // VotesOrErr returns the Votes value or an error if the edge
@@ -55,16 +92,74 @@ func (e UserEdges) VotesOrErr() ([]*Vote, error) {
 	return nil, &NotLoadedError{edge: "votes"}
 }
 
+// PollsOrErr returns the Polls value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) PollsOrErr() ([]*Poll, error) {
+	if e.loadedTypes[1] {
+		return e.Polls, nil
+	}
+	return nil, &NotLoadedError{edge: "polls"}
+}
+
+// PollRevisionsOrErr returns the PollRevisions value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) PollRevisionsOrErr() ([]*PollRevision, error) {
+	if e.loadedTypes[2] {
+		return e.PollRevisions, nil
+	}
+	return nil, &NotLoadedError{edge: "poll_revisions"}
+}
+
+// FollowedTagsOrErr returns the FollowedTags value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) FollowedTagsOrErr() ([]*Tag, error) {
+	if e.loadedTypes[3] {
+		return e.FollowedTags, nil
+	}
+	return nil, &NotLoadedError{edge: "followed_tags"}
+}
+
+// LoginAttemptsOrErr returns the LoginAttempts value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) LoginAttemptsOrErr() ([]*LoginAttempt, error) {
+	if e.loadedTypes[4] {
+		return e.LoginAttempts, nil
+	}
+	return nil, &NotLoadedError{edge: "login_attempts"}
+}
+
+// PasswordResetsOrErr returns the PasswordResets value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) PasswordResetsOrErr() ([]*PasswordReset, error) {
+	if e.loadedTypes[5] {
+		return e.PasswordResets, nil
+	}
+	return nil, &NotLoadedError{edge: "password_resets"}
+}
+
+// IdentitiesOrErr returns the Identities value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) IdentitiesOrErr() ([]*LinkedIdentity, error) {
+	if e.loadedTypes[6] {
+		return e.Identities, nil
+	}
+	return nil, &NotLoadedError{edge: "identities"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*User) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
+		case user.FieldEmbedOrigins, user.FieldRecoveryCodes:
+			values[i] = new([]byte)
 		case user.FieldActive:
 			values[i] = new(sql.NullBool)
-		case user.FieldID, user.FieldAvatarImage, user.FieldEmail, user.FieldName, user.FieldPassword:
+		case user.FieldFailedLogins, user.FieldTokenVersion, user.FieldTotpLastStep:
+			values[i] = new(sql.NullInt64)
+		case user.FieldID, user.FieldAvatarImage, user.FieldEmail, user.FieldName, user.FieldPassword, user.FieldRole, user.FieldTotpSecret:
 			values[i] = new(sql.NullString)
-		case user.FieldCreatedAt, user.FieldUpdatedAt:
+		case user.FieldEmailVerifiedAt, user.FieldVerificationSentAt, user.FieldLastFailedLoginAt, user.FieldLockedUntil, user.FieldTotpEnabledAt, user.FieldCreatedAt, user.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -100,6 +195,20 @@ func (_m *User) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Email = value.String
 			}
+		case user.FieldEmailVerifiedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field email_verified_at", values[i])
+			} else if value.Valid {
+				_m.EmailVerifiedAt = new(time.Time)
+				*_m.EmailVerifiedAt = value.Time
+			}
+		case user.FieldVerificationSentAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field verification_sent_at", values[i])
+			} else if value.Valid {
+				_m.VerificationSentAt = new(time.Time)
+				*_m.VerificationSentAt = value.Time
+			}
 		case user.FieldName:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field name", values[i])
@@ -118,6 +227,74 @@ func (_m *User) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Active = value.Bool
 			}
+		case user.FieldRole:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field role", values[i])
+			} else if value.Valid {
+				_m.Role = user.Role(value.String)
+			}
+		case user.FieldEmbedOrigins:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field embed_origins", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.EmbedOrigins); err != nil {
+					return fmt.Errorf("unmarshal field embed_origins: %w", err)
+				}
+			}
+		case user.FieldFailedLogins:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field failed_logins", values[i])
+			} else if value.Valid {
+				_m.FailedLogins = int(value.Int64)
+			}
+		case user.FieldLastFailedLoginAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_failed_login_at", values[i])
+			} else if value.Valid {
+				_m.LastFailedLoginAt = new(time.Time)
+				*_m.LastFailedLoginAt = value.Time
+			}
+		case user.FieldLockedUntil:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field locked_until", values[i])
+			} else if value.Valid {
+				_m.LockedUntil = new(time.Time)
+				*_m.LockedUntil = value.Time
+			}
+		case user.FieldTokenVersion:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field token_version", values[i])
+			} else if value.Valid {
+				_m.TokenVersion = int(value.Int64)
+			}
+		case user.FieldTotpSecret:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field totp_secret", values[i])
+			} else if value.Valid {
+				_m.TotpSecret = new(string)
+				*_m.TotpSecret = value.String
+			}
+		case user.FieldTotpEnabledAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field totp_enabled_at", values[i])
+			} else if value.Valid {
+				_m.TotpEnabledAt = new(time.Time)
+				*_m.TotpEnabledAt = value.Time
+			}
+		case user.FieldTotpLastStep:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field totp_last_step", values[i])
+			} else if value.Valid {
+				_m.TotpLastStep = value.Int64
+			}
+		case user.FieldRecoveryCodes:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field recovery_codes", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.RecoveryCodes); err != nil {
+					return fmt.Errorf("unmarshal field recovery_codes: %w", err)
+				}
+			}
 		case user.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -148,6 +325,36 @@ func (_m *User) QueryVotes() *VoteQuery {
 	return NewUserClient(_m.config).QueryVotes(_m)
 }
 
+// QueryPolls queries the "polls" edge of the User entity.
+func (_m *User) QueryPolls() *PollQuery {
+	return NewUserClient(_m.config).QueryPolls(_m)
+}
+
+// QueryPollRevisions queries the "poll_revisions" edge of the User entity.
+func (_m *User) QueryPollRevisions() *PollRevisionQuery {
+	return NewUserClient(_m.config).QueryPollRevisions(_m)
+}
+
+// QueryFollowedTags queries the "followed_tags" edge of the User entity.
+func (_m *User) QueryFollowedTags() *TagQuery {
+	return NewUserClient(_m.config).QueryFollowedTags(_m)
+}
+
+// QueryLoginAttempts queries the "login_attempts" edge of the User entity.
+func (_m *User) QueryLoginAttempts() *LoginAttemptQuery {
+	return NewUserClient(_m.config).QueryLoginAttempts(_m)
+}
+
+// QueryPasswordResets queries the "password_resets" edge of the User entity.
+func (_m *User) QueryPasswordResets() *PasswordResetQuery {
+	return NewUserClient(_m.config).QueryPasswordResets(_m)
+}
+
+// QueryIdentities queries the "identities" edge of the User entity.
+func (_m *User) QueryIdentities() *LinkedIdentityQuery {
+	return NewUserClient(_m.config).QueryIdentities(_m)
+}
+
 // Update returns a builder for updating this User.
 // Note that you need to call User.Unwrap() before calling this method if this User
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -179,6 +386,16 @@ func (_m *User) String() string {
 	builder.WriteString("email=")
 	builder.WriteString(_m.Email)
 	builder.WriteString(", ")
+	if v := _m.EmailVerifiedAt; v != nil {
+		builder.WriteString("email_verified_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := _m.VerificationSentAt; v != nil {
+		builder.WriteString("verification_sent_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
 	builder.WriteString("name=")
 	builder.WriteString(_m.Name)
 	builder.WriteString(", ")
@@ -187,6 +404,40 @@ func (_m *User) String() string {
 	builder.WriteString("active=")
 	builder.WriteString(fmt.Sprintf("%v", _m.Active))
 	builder.WriteString(", ")
+	builder.WriteString("role=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Role))
+	builder.WriteString(", ")
+	builder.WriteString("embed_origins=")
+	builder.WriteString(fmt.Sprintf("%v", _m.EmbedOrigins))
+	builder.WriteString(", ")
+	builder.WriteString("failed_logins=")
+	builder.WriteString(fmt.Sprintf("%v", _m.FailedLogins))
+	builder.WriteString(", ")
+	if v := _m.LastFailedLoginAt; v != nil {
+		builder.WriteString("last_failed_login_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := _m.LockedUntil; v != nil {
+		builder.WriteString("locked_until=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("token_version=")
+	builder.WriteString(fmt.Sprintf("%v", _m.TokenVersion))
+	builder.WriteString(", ")
+	builder.WriteString("totp_secret=<sensitive>")
+	builder.WriteString(", ")
+	if v := _m.TotpEnabledAt; v != nil {
+		builder.WriteString("totp_enabled_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("totp_last_step=")
+	builder.WriteString(fmt.Sprintf("%v", _m.TotpLastStep))
+	builder.WriteString(", ")
+	builder.WriteString("recovery_codes=<sensitive>")
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
 	builder.WriteString(", ")
@@ -22,6 +22,8 @@ type Vote struct {
 	ID int `json:"id,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
+	// GuestID holds the value of the "guest_id" field.
+	GuestID *string `json:"guest_id,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the VoteQuery when eager-loading is set.
 	Edges             VoteEdges `json:"edges"`
@@ -84,6 +86,8 @@ func (*Vote) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case vote.FieldID:
 			values[i] = new(sql.NullInt64)
+		case vote.FieldGuestID:
+			values[i] = new(sql.NullString)
 		case vote.FieldCreatedAt:
 			values[i] = new(sql.NullTime)
 		case vote.ForeignKeys[0]: // poll_votes
@@ -119,6 +123,13 @@ func (_m *Vote) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.CreatedAt = value.Time
 			}
+		case vote.FieldGuestID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field guest_id", values[i])
+			} else if value.Valid {
+				_m.GuestID = new(string)
+				*_m.GuestID = value.String
+			}
 		case vote.ForeignKeys[0]:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for edge-field poll_votes", value)
@@ -193,6 +204,11 @@ func (_m *Vote) String() string {
 	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := _m.GuestID; v != nil {
+		builder.WriteString("guest_id=")
+		builder.WriteString(*v)
+	}
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -16,6 +16,8 @@ const (
 	FieldID = "id"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
+	// FieldGuestID holds the string denoting the guest_id field in the database.
+	FieldGuestID = "guest_id"
 	// EdgeUser holds the string denoting the user edge name in mutations.
 	EdgeUser = "user"
 	// EdgePoll holds the string denoting the poll edge name in mutations.
@@ -51,6 +53,7 @@ const (
 var Columns = []string{
 	FieldID,
 	FieldCreatedAt,
+	FieldGuestID,
 }
 
 // ForeignKeys holds the SQL foreign-keys that are owned by the "votes"
@@ -94,6 +97,11 @@ func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
 }
 
+// ByGuestID orders the results by the guest_id field.
+func ByGuestID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldGuestID, opts...).ToFunc()
+}
+
 // ByUserField orders the results by user field.
 func ByUserField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
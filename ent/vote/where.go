@@ -60,6 +60,11 @@ func CreatedAt(v time.Time) predicate.Vote {
 	return predicate.Vote(sql.FieldEQ(FieldCreatedAt, v))
 }
 
+// GuestID applies equality check predicate on the "guest_id" field. It's identical to GuestIDEQ.
+func GuestID(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldGuestID, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Vote {
 	return predicate.Vote(sql.FieldEQ(FieldCreatedAt, v))
@@ -100,6 +105,81 @@ func CreatedAtLTE(v time.Time) predicate.Vote {
 	return predicate.Vote(sql.FieldLTE(FieldCreatedAt, v))
 }
 
+// GuestIDEQ applies the EQ predicate on the "guest_id" field.
+func GuestIDEQ(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldGuestID, v))
+}
+
+// GuestIDNEQ applies the NEQ predicate on the "guest_id" field.
+func GuestIDNEQ(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldNEQ(FieldGuestID, v))
+}
+
+// GuestIDIn applies the In predicate on the "guest_id" field.
+func GuestIDIn(vs ...string) predicate.Vote {
+	return predicate.Vote(sql.FieldIn(FieldGuestID, vs...))
+}
+
+// GuestIDNotIn applies the NotIn predicate on the "guest_id" field.
+func GuestIDNotIn(vs ...string) predicate.Vote {
+	return predicate.Vote(sql.FieldNotIn(FieldGuestID, vs...))
+}
+
+// GuestIDGT applies the GT predicate on the "guest_id" field.
+func GuestIDGT(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldGT(FieldGuestID, v))
+}
+
+// GuestIDGTE applies the GTE predicate on the "guest_id" field.
+func GuestIDGTE(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldGTE(FieldGuestID, v))
+}
+
+// GuestIDLT applies the LT predicate on the "guest_id" field.
+func GuestIDLT(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldLT(FieldGuestID, v))
+}
+
+// GuestIDLTE applies the LTE predicate on the "guest_id" field.
+func GuestIDLTE(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldLTE(FieldGuestID, v))
+}
+
+// GuestIDContains applies the Contains predicate on the "guest_id" field.
+func GuestIDContains(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldContains(FieldGuestID, v))
+}
+
+// GuestIDHasPrefix applies the HasPrefix predicate on the "guest_id" field.
+func GuestIDHasPrefix(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldHasPrefix(FieldGuestID, v))
+}
+
+// GuestIDHasSuffix applies the HasSuffix predicate on the "guest_id" field.
+func GuestIDHasSuffix(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldHasSuffix(FieldGuestID, v))
+}
+
+// GuestIDIsNil applies the IsNil predicate on the "guest_id" field.
+func GuestIDIsNil() predicate.Vote {
+	return predicate.Vote(sql.FieldIsNull(FieldGuestID))
+}
+
+// GuestIDNotNil applies the NotNil predicate on the "guest_id" field.
+func GuestIDNotNil() predicate.Vote {
+	return predicate.Vote(sql.FieldNotNull(FieldGuestID))
+}
+
+// GuestIDEqualFold applies the EqualFold predicate on the "guest_id" field.
+func GuestIDEqualFold(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldEqualFold(FieldGuestID, v))
+}
+
+// GuestIDContainsFold applies the ContainsFold predicate on the "guest_id" field.
+func GuestIDContainsFold(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldContainsFold(FieldGuestID, v))
+}
+
 // HasUser applies the HasEdge predicate on the "user" edge.
 func HasUser() predicate.Vote {
 	return predicate.Vote(func(s *sql.Selector) {
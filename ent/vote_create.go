@@ -37,12 +37,34 @@ func (_c *VoteCreate) SetNillableCreatedAt(v *time.Time) *VoteCreate {
 	return _c
 }
 
+// SetGuestID sets the "guest_id" field.
+func (_c *VoteCreate) SetGuestID(v string) *VoteCreate {
+	_c.mutation.SetGuestID(v)
+	return _c
+}
+
+// SetNillableGuestID sets the "guest_id" field if the given value is not nil.
+func (_c *VoteCreate) SetNillableGuestID(v *string) *VoteCreate {
+	if v != nil {
+		_c.SetGuestID(*v)
+	}
+	return _c
+}
+
 // SetUserID sets the "user" edge to the User entity by ID.
 func (_c *VoteCreate) SetUserID(id string) *VoteCreate {
 	_c.mutation.SetUserID(id)
 	return _c
 }
 
+// SetNillableUserID sets the "user" edge to the User entity by ID if the given value is not nil.
+func (_c *VoteCreate) SetNillableUserID(id *string) *VoteCreate {
+	if id != nil {
+		_c = _c.SetUserID(*id)
+	}
+	return _c
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_c *VoteCreate) SetUser(v *User) *VoteCreate {
 	return _c.SetUserID(v.ID)
@@ -116,9 +138,6 @@ func (_c *VoteCreate) check() error {
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Vote.created_at"`)}
 	}
-	if len(_c.mutation.UserIDs()) == 0 {
-		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "Vote.user"`)}
-	}
 	if len(_c.mutation.PollIDs()) == 0 {
 		return &ValidationError{Name: "poll", err: errors.New(`ent: missing required edge "Vote.poll"`)}
 	}
@@ -155,6 +174,10 @@ func (_c *VoteCreate) createSpec() (*Vote, *sqlgraph.CreateSpec) {
 		_spec.SetField(vote.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
 	}
+	if value, ok := _c.mutation.GuestID(); ok {
+		_spec.SetField(vote.FieldGuestID, field.TypeString, value)
+		_node.GuestID = &value
+	}
 	if nodes := _c.mutation.UserIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -30,12 +30,40 @@ func (_u *VoteUpdate) Where(ps ...predicate.Vote) *VoteUpdate {
 	return _u
 }
 
+// SetGuestID sets the "guest_id" field.
+func (_u *VoteUpdate) SetGuestID(v string) *VoteUpdate {
+	_u.mutation.SetGuestID(v)
+	return _u
+}
+
+// SetNillableGuestID sets the "guest_id" field if the given value is not nil.
+func (_u *VoteUpdate) SetNillableGuestID(v *string) *VoteUpdate {
+	if v != nil {
+		_u.SetGuestID(*v)
+	}
+	return _u
+}
+
+// ClearGuestID clears the value of the "guest_id" field.
+func (_u *VoteUpdate) ClearGuestID() *VoteUpdate {
+	_u.mutation.ClearGuestID()
+	return _u
+}
+
 // SetUserID sets the "user" edge to the User entity by ID.
 func (_u *VoteUpdate) SetUserID(id string) *VoteUpdate {
 	_u.mutation.SetUserID(id)
 	return _u
 }
 
+// SetNillableUserID sets the "user" edge to the User entity by ID if the given value is not nil.
+func (_u *VoteUpdate) SetNillableUserID(id *string) *VoteUpdate {
+	if id != nil {
+		_u = _u.SetUserID(*id)
+	}
+	return _u
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_u *VoteUpdate) SetUser(v *User) *VoteUpdate {
 	return _u.SetUserID(v.ID)
@@ -115,9 +143,6 @@ func (_u *VoteUpdate) ExecX(ctx context.Context) {
 
 // check runs all checks and user-defined validators on the builder.
 func (_u *VoteUpdate) check() error {
-	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
-		return errors.New(`ent: clearing a required unique edge "Vote.user"`)
-	}
 	if _u.mutation.PollCleared() && len(_u.mutation.PollIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Vote.poll"`)
 	}
@@ -139,6 +164,12 @@ func (_u *VoteUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 			}
 		}
 	}
+	if value, ok := _u.mutation.GuestID(); ok {
+		_spec.SetField(vote.FieldGuestID, field.TypeString, value)
+	}
+	if _u.mutation.GuestIDCleared() {
+		_spec.ClearField(vote.FieldGuestID, field.TypeString)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -246,12 +277,40 @@ type VoteUpdateOne struct {
 	mutation *VoteMutation
 }
 
+// SetGuestID sets the "guest_id" field.
+func (_u *VoteUpdateOne) SetGuestID(v string) *VoteUpdateOne {
+	_u.mutation.SetGuestID(v)
+	return _u
+}
+
+// SetNillableGuestID sets the "guest_id" field if the given value is not nil.
+func (_u *VoteUpdateOne) SetNillableGuestID(v *string) *VoteUpdateOne {
+	if v != nil {
+		_u.SetGuestID(*v)
+	}
+	return _u
+}
+
+// ClearGuestID clears the value of the "guest_id" field.
+func (_u *VoteUpdateOne) ClearGuestID() *VoteUpdateOne {
+	_u.mutation.ClearGuestID()
+	return _u
+}
+
 // SetUserID sets the "user" edge to the User entity by ID.
 func (_u *VoteUpdateOne) SetUserID(id string) *VoteUpdateOne {
 	_u.mutation.SetUserID(id)
 	return _u
 }
 
+// SetNillableUserID sets the "user" edge to the User entity by ID if the given value is not nil.
+func (_u *VoteUpdateOne) SetNillableUserID(id *string) *VoteUpdateOne {
+	if id != nil {
+		_u = _u.SetUserID(*id)
+	}
+	return _u
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_u *VoteUpdateOne) SetUser(v *User) *VoteUpdateOne {
 	return _u.SetUserID(v.ID)
@@ -344,9 +403,6 @@ func (_u *VoteUpdateOne) ExecX(ctx context.Context) {
 
 // check runs all checks and user-defined validators on the builder.
 func (_u *VoteUpdateOne) check() error {
-	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
-		return errors.New(`ent: clearing a required unique edge "Vote.user"`)
-	}
 	if _u.mutation.PollCleared() && len(_u.mutation.PollIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Vote.poll"`)
 	}
@@ -385,6 +441,12 @@ func (_u *VoteUpdateOne) sqlSave(ctx context.Context) (_node *Vote, err error) {
 			}
 		}
 	}
+	if value, ok := _u.mutation.GuestID(); ok {
+		_spec.SetField(vote.FieldGuestID, field.TypeString, value)
+	}
+	if _u.mutation.GuestIDCleared() {
+		_spec.ClearField(vote.FieldGuestID, field.TypeString)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
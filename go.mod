@@ -10,7 +10,11 @@ require (
 	github.com/google/uuid v1.6.0
 	github.com/gorilla/websocket v1.5.3
 	github.com/joho/godotenv v1.5.1
+	github.com/mattn/go-sqlite3 v1.14.17
 	golang.org/x/crypto v0.48.0
+	golang.org/x/image v0.36.0
+	golang.org/x/text v0.34.0
+	rsc.io/qr v0.2.0
 )
 
 require (
@@ -29,5 +33,5 @@ require (
 	github.com/zclconf/go-cty-yaml v1.1.0 // indirect
 	golang.org/x/mod v0.32.0 // indirect
 	golang.org/x/net v0.49.0 // indirect
-	golang.org/x/text v0.34.0 // indirect
+	golang.org/x/sys v0.41.0 // indirect
 )
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"api_voty/internal/models"
+	"api_voty/internal/oidc"
 	"api_voty/internal/utils"
 
 	"github.com/danielgtaylor/huma/v2"
@@ -12,6 +13,10 @@ import (
 type AuthAPI struct {
 	authModel *models.AuthModel
 	userModel *models.UserModel
+	// OIDC son los proveedores de login social por nombre; vacío lo desactiva
+	OIDC map[string]*oidc.Provider
+	// OIDCKey firma la cookie del login en curso con un proveedor
+	OIDCKey []byte
 }
 
 func NewAuthAPI(authModel *models.AuthModel, userModel *models.UserModel) *AuthAPI {
@@ -24,6 +29,16 @@ type RegisterRequest struct {
 
 type LoginRequest struct {
 	Body models.LoginRequest
+	meta models.LoginMeta
+}
+
+// Resolve guarda la IP y el user agent para el historial de logins (huma.Resolver)
+func (r *LoginRequest) Resolve(ctx huma.Context) []error {
+	r.meta = models.LoginMeta{
+		IP:        utils.ClientIP(ctx.RemoteAddr(), ctx.Header("X-Forwarded-For")),
+		UserAgent: ctx.Header("User-Agent"),
+	}
+	return nil
 }
 
 type AuthResponse struct {
@@ -32,6 +47,9 @@ type AuthResponse struct {
 
 func (a *AuthAPI) Register(ctx context.Context, req *RegisterRequest) (*AuthResponse, error) {
 	result, err := a.authModel.Register(ctx, req.Body)
+	if policyErr := passwordPolicyError(err); policyErr != nil {
+		return nil, policyErr
+	}
 	if err != nil {
 		return nil, huma.Error400BadRequest("Registration failed", err)
 	}
@@ -40,7 +58,10 @@ func (a *AuthAPI) Register(ctx context.Context, req *RegisterRequest) (*AuthResp
 
 // Login handler
 func (a *AuthAPI) Login(ctx context.Context, req *LoginRequest) (*AuthResponse, error) {
-	result, err := a.authModel.Login(ctx, req.Body)
+	result, err := a.authModel.Login(ctx, req.Body, req.meta)
+	if lockedErr := loginLockedError(err); lockedErr != nil {
+		return nil, lockedErr
+	}
 	if err != nil {
 		return nil, huma.Error401Unauthorized("Login failed", err)
 	}
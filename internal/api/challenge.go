@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"api_voty/internal/pow"
+	"api_voty/internal/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type GetChallengeRequest struct {
+	Scope string `query:"scope" required:"true" enum:"register,vote,guest" doc:"Operación para la que se pide el reto"`
+	ip    string
+}
+
+// Resolve guarda la IP, de la que depende la dificultad (huma.Resolver)
+func (r *GetChallengeRequest) Resolve(ctx huma.Context) []error {
+	r.ip = utils.ClientIP(ctx.RemoteAddr(), ctx.Header("X-Forwarded-For"))
+	return nil
+}
+
+type GetChallengeResponse struct {
+	Body pow.Challenge
+}
+
+// GetChallenge emite un reto de prueba de trabajo. Con dificultad 0 el ámbito
+// no pide reto y las cabeceras X-PoW-* se pueden omitir.
+func (a *UserAPI) GetChallenge(ctx context.Context, input *GetChallengeRequest) (*GetChallengeResponse, error) {
+	if a.Challenges == nil {
+		return &GetChallengeResponse{Body: pow.Challenge{Algorithm: "sha256"}}, nil
+	}
+	c, err := a.Challenges.Issue(input.Scope, input.ip)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Ámbito de reto inválido", err)
+	}
+	return &GetChallengeResponse{Body: *c}, nil
+}
+
+// verifyChallenge comprueba las cabeceras X-PoW-* de una petición que no pasa
+// por Huma (el widget). Devuelve false si ya se ha escrito el error.
+func (a *UserAPI) verifyChallenge(w http.ResponseWriter, r *http.Request, scope string) bool {
+	if a.Challenges == nil {
+		return true
+	}
+	ip := utils.ClientIP(r.RemoteAddr, r.Header.Get("X-Forwarded-For"))
+	err := a.Challenges.Verify(r.Context(), scope, ip, r.Header.Get(pow.ChallengeHeader), r.Header.Get(pow.SolutionHeader))
+	if err != nil {
+		w.Header().Set("X-PoW-Difficulty", strconv.Itoa(a.Challenges.Difficulty(scope, ip)))
+		http.Error(w, err.Error(), http.StatusPreconditionRequired)
+		return false
+	}
+	return true
+}
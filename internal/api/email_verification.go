@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"api_voty/internal/models"
+	"api_voty/internal/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type VerifyEmailRequest struct {
+	Token string `query:"token" required:"true" doc:"Token del enlace enviado por correo"`
+}
+
+type ResendVerificationResponse struct {
+	Body struct {
+		Sent bool `json:"sent"`
+	}
+}
+
+// VerifyEmail confirma el email con el token del enlace
+func (a *AuthAPI) VerifyEmail(ctx context.Context, input *VerifyEmailRequest) (*ProfileResponse, error) {
+	u, err := a.authModel.VerifyEmail(ctx, input.Token)
+	if err != nil {
+		if err.Error() == "INVALID_VERIFICATION_TOKEN" {
+			return nil, huma.Error400BadRequest("Invalid or expired verification link", err)
+		}
+		return nil, huma.Error500InternalServerError("Error verifying email", err)
+	}
+	return &ProfileResponse{Body: *u}, nil
+}
+
+// ResendVerification vuelve a enviar el enlace de verificación al usuario
+func (a *AuthAPI) ResendVerification(ctx context.Context, input *struct{}) (*ResendVerificationResponse, error) {
+	err := a.authModel.SendVerificationEmail(ctx, utils.GetUserIDFromContext(ctx))
+	var retry *models.RetryLaterError
+	if errors.As(err, &retry) {
+		return nil, huma.ErrorWithHeaders(
+			huma.Error429TooManyRequests("Verification email sent recently, retry later", err),
+			http.Header{"Retry-After": {strconv.Itoa(int(math.Ceil(retry.RetryAfter.Seconds())))}},
+		)
+	}
+	if err != nil {
+		if err.Error() == "EMAIL_ALREADY_VERIFIED" {
+			return nil, huma.Error409Conflict("Email already verified", err)
+		}
+		return nil, huma.Error500InternalServerError("Error sending verification email", err)
+	}
+	resp := &ResendVerificationResponse{}
+	resp.Body.Sent = true
+	return resp, nil
+}
@@ -0,0 +1,606 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/internal/models"
+	"api_voty/internal/pow"
+	"api_voty/internal/ratelimit"
+	"api_voty/internal/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// Tamaño por defecto del iframe
+const (
+	embedDefaultWidth  = 480
+	embedDefaultHeight = 360
+)
+
+// EmbedPollOutput es lo que recibe el widget: la encuesta sin datos del autor
+type EmbedPollOutput struct {
+	ID               string         `json:"id"`
+	Title            string         `json:"title"`
+	Description      string         `json:"description,omitempty"`
+	Options          []OptionOutput `json:"options"`
+	IsOpen           bool           `json:"is_open"`
+	ClosesAt         *time.Time     `json:"closes_at,omitempty"`
+	ResultsVisible   bool           `json:"results_visible"`
+	AllowGuests      bool           `json:"allow_guests" doc:"Se puede votar con un token de invitado"`
+	Voted            bool           `json:"voted"`
+	SelectedOptionID string         `json:"selected_option_id,omitempty"`
+}
+
+type EmbedSettingsBody struct {
+	Origins []string `json:"origins" maxItems:"20" doc:"Orígenes (https://blog.example.com) donde se pueden incrustar tus encuestas. Vacío: solo en este sitio" example:"[\"https://blog.example.com\"]"`
+}
+
+type EmbedSettingsResponse struct {
+	Body EmbedSettingsBody
+}
+
+type UpdateEmbedSettingsRequest struct {
+	Body EmbedSettingsBody
+}
+
+type OEmbedRequest struct {
+	URL       string `query:"url" required:"true" doc:"URL del widget o de la encuesta"`
+	Format    string `query:"format" enum:"json,xml" default:"json"`
+	MaxWidth  int    `query:"maxwidth" minimum:"0"`
+	MaxHeight int    `query:"maxheight" minimum:"0"`
+}
+
+type OEmbedResponse struct {
+	Body struct {
+		Version      string `json:"version"`
+		Type         string `json:"type"`
+		ProviderName string `json:"provider_name"`
+		ProviderURL  string `json:"provider_url"`
+		Title        string `json:"title"`
+		HTML         string `json:"html"`
+		Width        int    `json:"width"`
+		Height       int    `json:"height"`
+	}
+}
+
+// GetEmbedSettings devuelve los orígenes permitidos del usuario
+func (a *UserAPI) GetEmbedSettings(ctx context.Context, input *struct{}) (*EmbedSettingsResponse, error) {
+	origins, err := a.userModel.EmbedOrigins(ctx, utils.GetUserIDFromContext(ctx))
+	if err != nil {
+		return nil, huma.Error404NotFound("User not found", err)
+	}
+	return &EmbedSettingsResponse{Body: EmbedSettingsBody{Origins: origins}}, nil
+}
+
+// UpdateEmbedSettings reemplaza los orígenes permitidos del usuario
+func (a *UserAPI) UpdateEmbedSettings(ctx context.Context, input *UpdateEmbedSettingsRequest) (*EmbedSettingsResponse, error) {
+	origins, err := a.userModel.SetEmbedOrigins(ctx, utils.GetUserIDFromContext(ctx), input.Body.Origins)
+	if err != nil {
+		switch err.Error() {
+		case "INVALID_ORIGIN":
+			return nil, huma.Error400BadRequest("Invalid origin: use scheme://host[:port] without path", err)
+		case "TOO_MANY_ORIGINS":
+			return nil, huma.Error400BadRequest("Too many origins", err)
+		}
+		return nil, huma.Error500InternalServerError("Error saving embed settings", err)
+	}
+	return &EmbedSettingsResponse{Body: EmbedSettingsBody{Origins: origins}}, nil
+}
+
+// embedPathPattern reconoce las URLs de encuesta que acepta oEmbed
+var embedPathPattern = regexp.MustCompile(`^/(?:embed/)?polls/(\d+)/?$`)
+
+// OEmbed implementa el endpoint de descubrimiento oEmbed (tipo "rich")
+func (a *UserAPI) OEmbed(ctx context.Context, input *OEmbedRequest) (*OEmbedResponse, error) {
+	if input.Format != "json" {
+		return nil, huma.NewError(http.StatusNotImplemented, "Solo se admite format=json")
+	}
+	base, err := url.Parse(a.PublicBaseURL)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("URL pública mal configurada", err)
+	}
+	target, err := url.Parse(input.URL)
+	if err != nil || target.Host != base.Host {
+		return nil, huma.Error404NotFound("URL no reconocida")
+	}
+	m := embedPathPattern.FindStringSubmatch(target.Path)
+	if m == nil {
+		return nil, huma.Error404NotFound("URL no reconocida")
+	}
+	pollID, _ := strconv.Atoi(m[1])
+	p, err := a.pollModel.EmbeddablePoll(ctx, pollID, models.Voter{})
+	if err != nil {
+		return nil, huma.Error404NotFound("Encuesta no disponible para incrustar")
+	}
+
+	width, height := embedDefaultWidth, embedDefaultHeight
+	if input.MaxWidth > 0 {
+		width = min(width, input.MaxWidth)
+	}
+	if input.MaxHeight > 0 {
+		height = min(height, input.MaxHeight)
+	}
+
+	resp := &OEmbedResponse{}
+	resp.Body.Version = "1.0"
+	resp.Body.Type = "rich"
+	resp.Body.ProviderName = "Voty"
+	resp.Body.ProviderURL = strings.TrimSuffix(a.PublicBaseURL, "/")
+	resp.Body.Title = p.Title
+	resp.Body.Width, resp.Body.Height = width, height
+	resp.Body.HTML = fmt.Sprintf(`<iframe src="%s" width="%d" height="%d" frameborder="0" style="border:0;max-width:100%%" loading="lazy" title="%s"></iframe>`,
+		html.EscapeString(a.embedURL(p.ID)), width, height, html.EscapeString(p.Title))
+	return resp, nil
+}
+
+func (a *UserAPI) embedURL(pollID int) string {
+	return fmt.Sprintf("%s/embed/polls/%d", strings.TrimSuffix(a.PublicBaseURL, "/"), pollID)
+}
+
+// bearerVoter devuelve el usuario o el invitado de la cabecera Authorization,
+// o un Voter vacío si no hay token. Un token inválido es un error.
+func (a *UserAPI) bearerVoter(r *http.Request) (models.Voter, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return models.Voter{}, nil
+	}
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return models.Voter{}, fmt.Errorf("invalid authorization header")
+	}
+	claims, err := utils.ValidateToken(token)
+	if errors.Is(err, utils.ErrGuestToken) {
+		guestID, err := utils.ValidateGuestToken(token)
+		return models.GuestVoter(guestID), err
+	}
+	if err != nil {
+		return models.Voter{}, err
+	}
+	if err := a.userModel.CheckSession(r.Context(), claims.UserID, claims.Version); err != nil {
+		return models.Voter{}, err
+	}
+	return models.UserVoter(claims.UserID), nil
+}
+
+// allowEmbedCORS responde a orígenes permitidos por el autor de la encuesta.
+// Devuelve false si la petición trae un Origin ajeno.
+func allowEmbedCORS(w http.ResponseWriter, r *http.Request, origins []string) bool {
+	w.Header().Add("Vary", "Origin")
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if !slices.Contains(origins, strings.ToLower(origin)) {
+		return false
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-PoW-Challenge, X-PoW-Solution")
+	w.Header().Set("Access-Control-Expose-Headers", "X-PoW-Difficulty, Retry-After, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset")
+	w.Header().Set("Access-Control-Max-Age", "600")
+	return true
+}
+
+// embedPoll resuelve la encuesta de una ruta /embed/polls/{id}/... y aplica CORS.
+// Si devuelve nil ya se ha escrito la respuesta.
+func (a *UserAPI) embedPoll(w http.ResponseWriter, r *http.Request, voter models.Voter) *ent.Poll {
+	pollID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Encuesta no encontrada", http.StatusNotFound)
+		return nil
+	}
+	p, err := a.pollModel.EmbeddablePoll(r.Context(), pollID, voter)
+	if err != nil {
+		if err.Error() != "POLL_NOT_EMBEDDABLE" {
+			log.Printf("error cargando la encuesta incrustada %d: %v", pollID, err)
+		}
+		http.Error(w, "Encuesta no disponible", http.StatusNotFound)
+		return nil
+	}
+	if !allowEmbedCORS(w, r, models.EmbedOriginsOf(p)) {
+		http.Error(w, "Origen no permitido", http.StatusForbidden)
+		return nil
+	}
+	return p
+}
+
+// embedVoteLimits son los mismos límites que los de post-vote
+var embedVoteLimits = []ratelimit.Rule{
+	ratelimit.PerUser(ratelimit.PerMinute(30)),
+	ratelimit.PerIP(ratelimit.PerMinute(120)),
+}
+
+// allowRequest aplica límites de peticiones en rutas que no pasan por Huma.
+// Devuelve false si ya se ha escrito el 429.
+func (a *UserAPI) allowRequest(w http.ResponseWriter, r *http.Request, route string, voter models.Voter, rules []ratelimit.Rule) bool {
+	if a.Limiter == nil {
+		return true
+	}
+	subject := ratelimit.Subject{
+		Route:  route,
+		IP:     utils.ClientIP(r.RemoteAddr, r.Header.Get("X-Forwarded-For")),
+		UserID: voter.UserID,
+	}
+	if voter.IsGuest() {
+		subject.UserID = "guest:" + voter.GuestID
+	}
+	res, applied := a.Limiter.Check(r.Context(), subject, rules)
+	if !applied {
+		return true
+	}
+	for k, v := range rateLimitHeaders(res) {
+		w.Header().Set(k, v)
+	}
+	if !res.Allowed {
+		http.Error(w, "Too many requests, retry later", http.StatusTooManyRequests)
+		return false
+	}
+	return true
+}
+
+func writeEmbedJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// EmbedPreflight contesta las peticiones OPTIONS de CORS del widget
+func (a *UserAPI) EmbedPreflight(w http.ResponseWriter, r *http.Request) {
+	if a.embedPoll(w, r, models.Voter{}) == nil {
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EmbedData devuelve la encuesta para el widget; con token incluye el voto
+// del usuario o del dispositivo invitado
+func (a *UserAPI) EmbedData(w http.ResponseWriter, r *http.Request) {
+	voter, err := a.bearerVoter(r)
+	if err != nil {
+		http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+		return
+	}
+	p := a.embedPoll(w, r, voter)
+	if p == nil {
+		return
+	}
+	viewer := models.Viewer{}
+	if voter.UserID != "" {
+		viewer = a.userModel.Viewer(r.Context(), voter.UserID)
+	}
+	full := toPollOutput(p, viewer, time.Now())
+	writeEmbedJSON(w, http.StatusOK, EmbedPollOutput{
+		ID:               full.ID,
+		Title:            full.Title,
+		Description:      full.Description,
+		Options:          full.Options,
+		IsOpen:           full.IsOpen,
+		ClosesAt:         full.ClosesAt,
+		ResultsVisible:   full.ResultsVisible,
+		AllowGuests:      full.AllowGuests,
+		Voted:            full.Voted,
+		SelectedOptionID: full.SelectedOptionID,
+	})
+}
+
+// EmbedVote vota desde el widget; requiere sesión o, si la encuesta lo admite,
+// un token de invitado
+func (a *UserAPI) EmbedVote(w http.ResponseWriter, r *http.Request) {
+	voter, err := a.bearerVoter(r)
+	if err != nil || voter == (models.Voter{}) {
+		http.Error(w, "Authorization header required", http.StatusUnauthorized)
+		return
+	}
+	p := a.embedPoll(w, r, voter)
+	if p == nil || !a.allowRequest(w, r, "embed-vote", voter, embedVoteLimits) ||
+		!a.verifyChallenge(w, r, pow.ScopeVote) {
+		return
+	}
+	if _, err := a.castVote(r.Context(), strconv.Itoa(p.ID), r.PathValue("option_id"), voter); err != nil {
+		status, code := embedVoteError(err)
+		if status == http.StatusInternalServerError {
+			log.Printf("error votando en la encuesta incrustada %d: %v", p.ID, err)
+		}
+		writeEmbedJSON(w, status, map[string]string{"error": code})
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// embedVoteError traduce los errores del voto a un estado HTTP y un código
+// para el widget. Solo se devuelven los códigos del dominio; el resto de
+// errores (base de datos, etc.) se quedan en el log.
+func embedVoteError(err error) (int, string) {
+	switch code := err.Error(); code {
+	case "INVALID_OPTION", "OPTION_ARCHIVED":
+		return http.StatusNotFound, code
+	case "ALREADY_VOTED":
+		return http.StatusConflict, code
+	case "POLL_CLOSED", "POLL_NOT_OPEN_YET", "POLL_NOT_PUBLISHED", "GUESTS_NOT_ALLOWED", "EMAIL_NOT_VERIFIED":
+		return http.StatusForbidden, code
+	}
+	if ent.IsNotFound(err) {
+		return http.StatusNotFound, "NOT_FOUND"
+	}
+	return http.StatusInternalServerError, "INTERNAL_ERROR"
+}
+
+type embedWidgetPage struct {
+	Poll      *ent.Poll
+	PollID    int
+	EmbedURL  string
+	OEmbedURL string
+}
+
+var embedWidgetTemplate = template.Must(template.New("embed").Parse(`<!DOCTYPE html>
+<html lang="es">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Poll.Title}}</title>
+<link rel="alternate" type="application/json+oembed" href="{{.OEmbedURL}}" title="{{.Poll.Title}}">
+<style>
+body{font-family:Helvetica,Arial,sans-serif;margin:0;padding:12px;color:#1f2933;font-size:14px}
+h1{font-size:16px;margin:0 0 8px}
+button.opt{display:block;width:100%;text-align:left;margin:6px 0;padding:8px;border:1px solid #cbd2d9;border-radius:4px;background:#fff;cursor:pointer;position:relative;overflow:hidden}
+button.opt:disabled{cursor:default}
+button.opt .fill{position:absolute;left:0;top:0;bottom:0;background:#dbeafe;z-index:0}
+button.opt span{position:relative;z-index:1}
+button.opt.mine{border-color:#2563eb}
+.muted{color:#616e7c;font-size:12px}
+form{margin-top:8px}input{display:block;width:100%;margin:4px 0;padding:6px;box-sizing:border-box}
+</style>
+</head>
+<body>
+<h1>{{.Poll.Title}}</h1>
+<div id="poll" data-id="{{.PollID}}" data-url="{{.EmbedURL}}"></div>
+<p class="muted" id="status"></p>
+<form id="login" hidden>
+<p class="muted">Inicia sesión para votar</p>
+<input type="email" name="email" placeholder="Email" required autocomplete="email">
+<input type="password" name="password" placeholder="Contraseña" required autocomplete="current-password">
+<button type="submit">Entrar</button>
+</form>
+<form id="twofactor" hidden>
+<p class="muted">Introduce el código de tu app de autenticación o uno de recuperación</p>
+<input type="text" name="code" placeholder="Código" required autocomplete="one-time-code" inputmode="numeric">
+<button type="submit">Verificar</button>
+</form>
+<script>
+(function () {
+  var root = document.getElementById("poll");
+  var base = root.dataset.url;
+  var status = document.getElementById("status");
+  var login = document.getElementById("login");
+  var twofactor = document.getElementById("twofactor");
+  var challenge = null; // token del paso de 2FA devuelto por /login
+  var pending = null;
+  var allowGuests = false;
+  var notice = ""; // mensaje para el próximo render, p. ej. por qué se rechazó el voto
+
+  function stored(key) { try { return localStorage.getItem(key); } catch (e) { return null; } }
+  function store(key, value) { try { localStorage.setItem(key, value); } catch (e) {} }
+  // La sesión de usuario tiene prioridad; si no hay, el token de invitado del dispositivo
+  function token() { return stored("voty_token") || stored("voty_guest_token"); }
+  function headers() { var t = token(); return t ? { Authorization: "Bearer " + t } : {}; }
+  function resize() { parent.postMessage({ type: "voty:resize", poll: root.dataset.id, height: document.body.scrollHeight }, "*"); }
+
+  function render(poll) {
+    allowGuests = poll.allow_guests;
+    root.textContent = "";
+    var total = 0;
+    poll.options.forEach(function (o) { total += o.votes_count || 0; });
+    poll.options.forEach(function (o) {
+      if (o.archived) { return; }
+      var b = document.createElement("button");
+      b.className = "opt" + (o.id === poll.selected_option_id ? " mine" : "");
+      b.disabled = poll.voted || !poll.is_open;
+      if (poll.results_visible && total > 0) {
+        var fill = document.createElement("div");
+        fill.className = "fill";
+        fill.style.width = Math.round((o.votes_count || 0) * 100 / total) + "%";
+        b.appendChild(fill);
+      }
+      var label = document.createElement("span");
+      label.textContent = o.text + (poll.results_visible ? " · " + (o.votes_count || 0) : "");
+      b.appendChild(label);
+      b.onclick = function () { vote(o.id); };
+      root.appendChild(b);
+    });
+    status.textContent = notice || (!poll.is_open ? "Encuesta cerrada" : poll.voted ? "Gracias por votar" : "");
+    notice = "";
+    resize();
+  }
+
+  function load() {
+    fetch(base + "/data", { headers: headers() })
+      .then(function (r) {
+        if (r.status === 401) {
+          try { localStorage.removeItem("voty_token"); localStorage.removeItem("voty_guest_token"); } catch (e) {}
+          return fetch(base + "/data");
+        }
+        return r;
+      })
+      .then(function (r) { return r.json(); })
+      .then(render)
+      .catch(function () { status.textContent = "No se pudo cargar la encuesta"; resize(); });
+  }
+
+  // solve pide un reto anti-bots y busca n tal que sha256(reto + n) empiece por
+  // tantos bits a cero como pida; devuelve las cabeceras X-PoW-*
+  function solve(scope) {
+    return fetch("/challenge?scope=" + scope)
+      .then(function (r) { return r.json(); })
+      .then(function (c) {
+        if (!c.difficulty) { return {}; }
+        var enc = new TextEncoder();
+        function zeros(buf) {
+          var bytes = new Uint8Array(buf), n = 0;
+          for (var i = 0; i < bytes.length; i++) {
+            if (bytes[i] === 0) { n += 8; continue; }
+            return n + Math.clz32(bytes[i]) - 24;
+          }
+          return n;
+        }
+        async function search() {
+          for (var n = 0; ; n++) {
+            var h = await crypto.subtle.digest("SHA-256", enc.encode(c.challenge + n));
+            if (zeros(h) >= c.difficulty) { return String(n); }
+          }
+        }
+        status.textContent = "Comprobando…";
+        return search().then(function (s) {
+          status.textContent = "";
+          return { "X-PoW-Challenge": c.challenge, "X-PoW-Solution": s };
+        });
+      });
+  }
+
+  // guestToken pide un token de invitado para este dispositivo
+  function guestToken() {
+    return solve("guest")
+      .then(function (pow) { return fetch("/guest/token", { method: "POST", headers: pow }); })
+      .then(function (r) { if (!r.ok) { throw new Error(); } return r.json(); })
+      .then(function (res) { store("voty_guest_token", res.token); });
+  }
+
+  function vote(optionId) {
+    if (!token() && allowGuests) {
+      guestToken().then(function () { vote(optionId); }, function () { status.textContent = "No se pudo votar"; });
+      return;
+    }
+    if (!token()) { pending = optionId; login.hidden = false; resize(); return; }
+    solve("vote")
+      .then(function (pow) {
+        var h = headers();
+        Object.keys(pow).forEach(function (k) { h[k] = pow[k]; });
+        return fetch(base + "/vote/" + encodeURIComponent(optionId), { method: "POST", headers: h });
+      })
+      .then(function (r) {
+        if (r.status === 401) { pending = optionId; login.hidden = false; resize(); return; }
+        if (r.ok) { load(); return; }
+        return r.json().catch(function () { return {}; }).then(function (res) {
+          notice = voteErrors[res.error] || "Voto rechazado";
+          load();
+        });
+      });
+  }
+
+  var voteErrors = {
+    ALREADY_VOTED: "Ya has votado en esta encuesta",
+    POLL_CLOSED: "Encuesta cerrada",
+    EMAIL_NOT_VERIFIED: "Verifica tu email para poder votar",
+    INTERNAL_ERROR: "No se pudo votar, inténtalo de nuevo"
+  };
+
+  login.onsubmit = function (e) {
+    e.preventDefault();
+    fetch("/login", {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({ email: login.email.value, password: login.password.value })
+    })
+      .then(function (r) { if (!r.ok) { throw new Error(); } return r.json(); })
+      .then(function (res) {
+        login.hidden = true;
+        if (res.two_factor_required) {
+          // Falta el segundo factor: se pide el código y se sigue con /login/2fa
+          challenge = res.challenge_token;
+          status.textContent = "";
+          twofactor.hidden = false;
+          twofactor.code.focus();
+          resize();
+          return;
+        }
+        loggedIn(res);
+      })
+      .catch(function () { status.textContent = "Credenciales incorrectas"; resize(); });
+  };
+
+  twofactor.onsubmit = function (e) {
+    e.preventDefault();
+    fetch("/login/2fa", {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({ challenge_token: challenge, code: twofactor.code.value })
+    })
+      .then(function (r) {
+        return r.json().then(function (res) {
+          if (r.ok) { return res; }
+          var expired = (res.errors || []).some(function (d) { return d.message === "invalid or expired challenge"; });
+          if (expired) {
+            // El reto caduca: se vuelve a empezar por el email y la contraseña
+            challenge = null;
+            twofactor.hidden = true;
+            login.hidden = false;
+            throw new Error("Vuelve a iniciar sesión");
+          }
+          throw new Error(r.status === 429 ? "Demasiados intentos, prueba más tarde" : "Código incorrecto");
+        });
+      })
+      .then(function (res) {
+        challenge = null;
+        twofactor.code.value = "";
+        twofactor.hidden = true;
+        loggedIn(res);
+      })
+      .catch(function (err) {
+        // Los Error son los nuestros; un fallo de red o de JSON trae otro tipo
+        status.textContent = err.name === "Error" ? err.message : "No se pudo verificar el código";
+        resize();
+      });
+  };
+
+  // loggedIn guarda la sesión y retoma el voto pendiente. Las cuentas de
+  // administrador sin 2FA configurada deben hacerlo en el sitio principal.
+  function loggedIn(res) {
+    if (!res.token) { status.textContent = "Completa el acceso en el sitio principal"; resize(); return; }
+    store("voty_token", res.token);
+    if (pending) { var id = pending; pending = null; vote(id); } else { load(); }
+  }
+
+  load();
+})();
+</script>
+</body>
+</html>
+`))
+
+// EmbedWidget sirve el widget HTML que se incrusta en un iframe. La política
+// frame-ancestors sale de los orígenes que permite el autor de la encuesta.
+func (a *UserAPI) EmbedWidget(w http.ResponseWriter, r *http.Request) {
+	p := a.embedPoll(w, r, models.Voter{})
+	if p == nil {
+		return
+	}
+	ancestors := append([]string{"'self'"}, models.EmbedOriginsOf(p)...)
+	w.Header().Set("Content-Security-Policy", "frame-ancestors "+strings.Join(ancestors, " "))
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	page := embedWidgetPage{
+		Poll:     p,
+		PollID:   p.ID,
+		EmbedURL: a.embedURL(p.ID),
+		OEmbedURL: strings.TrimSuffix(a.PublicBaseURL, "/") + "/oembed?format=json&url=" +
+			url.QueryEscape(a.embedURL(p.ID)),
+	}
+	if err := embedWidgetTemplate.Execute(w, page); err != nil {
+		log.Printf("error pintando el widget: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"api_voty/internal/models"
+	"api_voty/internal/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type GuestTokenResponse struct {
+	Body struct {
+		Token     string    `json:"token" doc:"Token de invitado: guárdalo en el dispositivo y envíalo como Bearer"`
+		GuestID   string    `json:"guest_id"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+}
+
+type GuestVoteInput struct {
+	PollID   string `path:"poll_id" doc:"ID de la encuesta"`
+	OptionID string `path:"option_id" doc:"ID de la opción elegida"`
+}
+
+type ClaimGuestVotesRequest struct {
+	Body struct {
+		GuestToken string `json:"guest_token" doc:"Token de invitado del dispositivo"`
+	}
+}
+
+type ClaimGuestVotesResponse struct {
+	Body struct {
+		Claimed int `json:"claimed" doc:"Votos que pasan a tu cuenta"`
+	}
+}
+
+// CreateGuestToken emite un token de invitado para un dispositivo sin cuenta
+func (a *UserAPI) CreateGuestToken(ctx context.Context, input *struct{}) (*GuestTokenResponse, error) {
+	token, guestID, expiresAt, err := utils.GenerateGuestToken()
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Error generando el token de invitado", err)
+	}
+	resp := &GuestTokenResponse{}
+	resp.Body.Token, resp.Body.GuestID, resp.Body.ExpiresAt = token, guestID, expiresAt
+	return resp, nil
+}
+
+// GetGuestPoll devuelve una encuesta abierta a invitados con el voto del dispositivo
+func (a *UserAPI) GetGuestPoll(ctx context.Context, input *GetPollRequest) (*GetPollResponse, error) {
+	pollID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, huma.Error400BadRequest("ID de encuesta inválido", err)
+	}
+	guestID := utils.GetGuestIDFromContext(ctx)
+
+	p, err := a.pollModel.GuestPoll(ctx, pollID, guestID)
+	if err != nil {
+		return nil, huma.Error404NotFound("Encuesta no disponible para invitados", err)
+	}
+	a.Trending.RecordView(p.ID, "guest:"+guestID)
+	return &GetPollResponse{Body: toPollOutput(p, models.Viewer{}, time.Now())}, nil
+}
+
+// PostGuestVote vota como invitado: un voto por encuesta y dispositivo
+func (a *UserAPI) PostGuestVote(ctx context.Context, input *GuestVoteInput) (*struct{}, error) {
+	voter := models.GuestVoter(utils.GetGuestIDFromContext(ctx))
+	if _, err := a.castVote(ctx, input.PollID, input.OptionID, voter); err != nil {
+		return nil, huma.Error403Forbidden("Voto rechazado", err)
+	}
+	return nil, nil
+}
+
+// ClaimGuestVotes pasa a la cuenta los votos emitidos como invitado en este dispositivo
+func (a *UserAPI) ClaimGuestVotes(ctx context.Context, input *ClaimGuestVotesRequest) (*ClaimGuestVotesResponse, error) {
+	guestID, err := utils.ValidateGuestToken(input.Body.GuestToken)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Token de invitado inválido", err)
+	}
+	n, err := a.pollModel.ClaimGuestVotes(ctx, guestID, utils.GetUserIDFromContext(ctx))
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Error traspasando los votos", err)
+	}
+	resp := &ClaimGuestVotesResponse{}
+	resp.Body.Claimed = n
+	return resp, nil
+}
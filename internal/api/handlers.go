@@ -1,12 +1,21 @@
 package api
 
 import (
+	"api_voty/ent"
+	"api_voty/ent/poll"
+	"api_voty/internal/charts"
 	"api_voty/internal/models"
+	"api_voty/internal/pow"
+	"api_voty/internal/ratelimit"
+	"api_voty/internal/search"
+	"api_voty/internal/trending"
 	"api_voty/internal/utils"
 	"context"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
@@ -18,16 +27,36 @@ var upgrader = websocket.Upgrader{
 }
 
 type UserAPI struct {
-	userModel *models.UserModel
-	pollModel *models.PollModel
-	Hub       *Hub
+	userModel      *models.UserModel
+	pollModel      *models.PollModel
+	tagModel       *models.TagModel
+	analyticsModel *models.AnalyticsModel
+	Hub            *Hub
+	Scheduler      *PollScheduler
+	Search         *search.Index
+	Trending       *trending.Tracker
+	Charts         *charts.Cache
+	// PublicBaseURL es la raíz pública del servidor, para los enlaces compartidos
+	PublicBaseURL string
+	// Challenges emite y verifica los retos anti-bots; nil no pide ninguno
+	Challenges *pow.Issuer
+	// Limiter aplica los límites de peticiones de SetupRoutes; nil no limita
+	Limiter *ratelimit.Limiter
+	// RequireVerifiedEmail impide votar a los usuarios sin el email verificado
+	RequireVerifiedEmail bool
 }
 
-func NewUserAPI(userModel *models.UserModel, pollModel *models.PollModel, hub *Hub) *UserAPI {
+func NewUserAPI(userModel *models.UserModel, pollModel *models.PollModel, tagModel *models.TagModel, analyticsModel *models.AnalyticsModel, hub *Hub, scheduler *PollScheduler, searchIndex *search.Index, tracker *trending.Tracker, chartCache *charts.Cache) *UserAPI {
 	return &UserAPI{
-		userModel: userModel,
-		pollModel: pollModel,
-		Hub:       hub,
+		userModel:      userModel,
+		pollModel:      pollModel,
+		tagModel:       tagModel,
+		analyticsModel: analyticsModel,
+		Hub:            hub,
+		Scheduler:      scheduler,
+		Search:         searchIndex,
+		Trending:       tracker,
+		Charts:         chartCache,
 	}
 }
 
@@ -44,8 +73,18 @@ type UserResponse struct {
 	Body models.UserResponse
 }
 
+type ListUsersRequest struct {
+	Cursor string `query:"cursor" doc:"Cursor devuelto en page.next_cursor"`
+	Limit  int    `query:"limit" minimum:"1" maximum:"100" default:"20" doc:"Tamaño de página"`
+	Sort   string `query:"sort" enum:"newest,oldest,name" default:"newest"`
+	Active string `query:"active" enum:"true,false" doc:"Filtrar por estado"`
+}
+
 type UsersResponse struct {
-	Body []models.UserResponse
+	Body struct {
+		Items []models.UserResponse `json:"items"`
+		Page  models.PageInfo       `json:"page"`
+	}
 }
 
 type GetUserRequest struct {
@@ -69,47 +108,174 @@ type DeleteUserRequest struct {
 
 // Estructura de salida para la API
 type PollOutput struct {
-	ID               string         `json:"id"`
-	Title            string         `json:"title"`
-	Options          []OptionOutput `json:"options"`
-	Voted            bool           `json:"voted"`
-	SelectedOptionID string         `json:"selected_option_id,omitempty"`
-	IsOpen           bool           `json:"is_open"`
+	ID               string            `json:"id"`
+	Title            string            `json:"title"`
+	Description      string            `json:"description,omitempty"`
+	Options          []OptionOutput    `json:"options"`
+	Voted            bool              `json:"voted"`
+	SelectedOptionID string            `json:"selected_option_id,omitempty"`
+	IsOpen           bool              `json:"is_open"`
+	OpensAt          *time.Time        `json:"opens_at,omitempty"`
+	ClosesAt         *time.Time        `json:"closes_at,omitempty"`
+	ClosesInSeconds  *int64            `json:"closes_in_seconds,omitempty" doc:"Segundos hasta el cierre programado"`
+	CloseRules       models.CloseRules `json:"close_rules"`
+	ClosedReason     string            `json:"closed_reason,omitempty" enum:"manual,schedule,vote_cap,quorum,decisive_lead" doc:"Por qué se cerró la encuesta"`
+	OwnerID          string            `json:"owner_id,omitempty"`
+	Tags             []TagOutput       `json:"tags"`
+	Anonymous        bool              `json:"anonymous" doc:"Si es true no se puede exportar quién votó qué"`
+	Embeddable       bool              `json:"embeddable"`
+	AllowGuests      bool              `json:"allow_guests"`
+	Status           string            `json:"status" enum:"draft,published"`
+	PublishedAt      *time.Time        `json:"published_at,omitempty"`
+
+	ResultsVisibility string     `json:"results_visibility" enum:"always,after_vote,after_close,embargo"`
+	ResultsVisibleAt  *time.Time `json:"results_visible_at,omitempty"`
+	ResultsVisible    bool       `json:"results_visible" doc:"Si es false, votes_count se omite en las opciones"`
 }
 
 type OptionOutput struct {
 	ID         string `json:"id"`
 	Text       string `json:"text"`
-	VotesCount int    `json:"votes_count"`
+	VotesCount *int   `json:"votes_count,omitempty"`
+	Archived   bool   `json:"archived,omitempty" doc:"Conserva sus votos pero no admite nuevos"`
+}
+
+type ListPollsRequest struct {
+	Cursor        string    `query:"cursor" doc:"Cursor devuelto en page.next_cursor"`
+	Limit         int       `query:"limit" minimum:"1" maximum:"100" default:"20" doc:"Tamaño de página"`
+	Sort          string    `query:"sort" enum:"newest,most_votes,closing_soon" default:"newest" doc:"closing_soon solo incluye encuestas con cierre pendiente; most_votes, las de resultados públicos y las propias"`
+	State         string    `query:"state" enum:"open,closed" doc:"Filtrar por estado"`
+	Voted         string    `query:"voted" enum:"true,false" doc:"Filtrar por si ya voté"`
+	Owner         string    `query:"owner" doc:"ID del autor, o \"me\""`
+	CreatedAfter  time.Time `query:"created_after" doc:"Creadas desde (RFC 3339)"`
+	CreatedBefore time.Time `query:"created_before" doc:"Creadas antes de (RFC 3339)"`
+	Tag           string    `query:"tag" doc:"Slug o nombre de etiqueta"`
 }
 
 type ListPollsResponse struct {
-	Body []PollOutput
+	Body struct {
+		Items []PollOutput    `json:"items"`
+		Page  models.PageInfo `json:"page"`
+	}
+}
+
+// parseBoolFilter interpreta los filtros booleanos opcionales ("", "true", "false")
+func parseBoolFilter(v string) *bool {
+	if v == "" {
+		return nil
+	}
+	b := v == "true"
+	return &b
 }
 
 type UpdatePollRequest struct {
 	ID   string `path:"id"`
 	Body struct {
-		Title   string   `json:"title"`
-		IsOpen  bool     `json:"is_open"`
-		Options []string `json:"options,omitempty"`
+		Title       string            `json:"title"`
+		Description string            `json:"description,omitempty"`
+		IsOpen      bool              `json:"is_open"`
+		Options     []string          `json:"options,omitempty"`
+		OpensAt     *time.Time        `json:"opens_at,omitempty" doc:"Apertura programada (se omite para quitarla)"`
+		ClosesAt    *time.Time        `json:"closes_at,omitempty" doc:"Cierre programado (se omite para quitarlo)"`
+		CloseRules  models.CloseRules `json:"close_rules,omitempty" doc:"Reglas de cierre automático (se omiten para quitarlas)"`
+
+		ResultsVisibility string     `json:"results_visibility,omitempty" enum:"always,after_vote,after_close,embargo" doc:"Se omite para conservar la actual"`
+		ResultsVisibleAt  *time.Time `json:"results_visible_at,omitempty" doc:"Fin del embargo de resultados; se omite para conservar el actual"`
+
+		ForceOptions bool     `json:"force_options,omitempty" doc:"Reemplazar options aunque haya votos (se pierden)"`
+		Tags         []string `json:"tags,omitempty" maxItems:"5" doc:"Reemplaza las etiquetas (se omite para conservarlas)"`
+		Embeddable   *bool    `json:"embeddable,omitempty" doc:"Permitir incrustarla (se omite para conservarlo)"`
+		AllowGuests  *bool    `json:"allow_guests,omitempty" doc:"Admitir votos de invitados sin cuenta (se omite para conservarlo)"`
 	}
 }
 
 func (a *UserAPI) UpdatePoll(ctx context.Context, input *UpdatePollRequest) (*GetPollResponse, error) {
-	pollID, _ := strconv.Atoi(input.ID)
-	
-	p, err := a.pollModel.Update(ctx, pollID, input.Body.Title, input.Body.IsOpen, input.Body.Options)
+	p, err := a.ownedPoll(ctx, input.ID, "Solo el autor puede editar la encuesta")
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Error al actualizar", err)
+		return nil, err
 	}
-	println(p)
+	pollID := p.ID
+
+	_, changes, err := a.pollModel.Update(ctx, pollID, models.PollInput{
+		Title:       input.Body.Title,
+		Description: input.Body.Description,
+		IsOpen:      input.Body.IsOpen,
+		Options:     input.Body.Options,
+		OpensAt:     input.Body.OpensAt,
+		ClosesAt:    input.Body.ClosesAt,
+		Rules:       input.Body.CloseRules,
+
+		ResultsVisibility: input.Body.ResultsVisibility,
+		ResultsVisibleAt:  input.Body.ResultsVisibleAt,
+		ForceOptions:      input.Body.ForceOptions,
+		Tags:              input.Body.Tags,
+		Embeddable:        input.Body.Embeddable,
+		AllowGuests:       input.Body.AllowGuests,
+		EditorID:          utils.GetUserIDFromContext(ctx),
+	})
+	if err != nil {
+		return nil, pollError("Error al actualizar", err)
+	}
+	a.Scheduler.Reschedule()
+	a.notifyOptionChanges(input.ID, changes)
+	a.Charts.Invalidate(pollID) // el título sale en el gráfico
 
 	// Mapeamos a PollOutput (Reutilizando la lógica de GetPoll)
     // Esto asegura que el "voted" y "selected_option_id" se mantengan correctos
 	return a.GetPoll(ctx, &GetPollRequest{ID: input.ID}) 
 }
 
+// pollError traduce los códigos de error del modelo de encuestas a respuestas HTTP
+func pollError(msg string, err error) error {
+	switch err.Error() {
+	case "INVALID_SCHEDULE":
+		return huma.Error400BadRequest("Programación inválida", err)
+	case "INVALID_VISIBILITY":
+		return huma.Error400BadRequest("Política de resultados inválida: embargo requiere results_visible_at", err)
+	case "INVALID_OPTION":
+		return huma.Error400BadRequest("Opción inválida", err)
+	case "POLL_HAS_VOTES", "OPTION_HAS_VOTES":
+		return huma.Error409Conflict("La encuesta ya tiene votos: usa force para confirmar el cambio", err)
+	case "EMPTY_TITLE":
+		return huma.Error422UnprocessableEntity("La encuesta necesita un título", err)
+	case "TOO_FEW_OPTIONS":
+		return huma.Error422UnprocessableEntity("La encuesta necesita al menos dos opciones", err)
+	case "DUPLICATE_OPTIONS":
+		return huma.Error422UnprocessableEntity("Las opciones no pueden repetirse", err)
+	case "ALREADY_PUBLISHED":
+		return huma.Error409Conflict("La encuesta ya está publicada", err)
+	case "INVALID_TAG":
+		return huma.Error400BadRequest("Etiqueta inválida: debe tener letras o números y como mucho 40 caracteres", err)
+	case "TOO_MANY_TAGS":
+		return huma.Error422UnprocessableEntity("Una encuesta admite como mucho 5 etiquetas", err)
+	case "NOT_POLL_OWNER":
+		return huma.Error403Forbidden("Solo el autor puede hacer esto", err)
+	}
+	if ent.IsNotFound(err) {
+		return huma.Error404NotFound("Encuesta no encontrada", err)
+	}
+	return huma.Error500InternalServerError(msg, err)
+}
+
+// ownedPoll carga la encuesta y comprueba que quien la pide es su autor o un
+// administrador; forbidden es el mensaje si no lo es
+func (a *UserAPI) ownedPoll(ctx context.Context, id, forbidden string) (*ent.Poll, error) {
+	pollID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, huma.Error400BadRequest("ID de encuesta inválido", err)
+	}
+	userID := utils.GetUserIDFromContext(ctx)
+	p, err := a.pollModel.GetByIDWithUserStatus(ctx, pollID, userID)
+	if err != nil {
+		return nil, huma.Error404NotFound("Encuesta no encontrada", err)
+	}
+	viewer := a.userModel.Viewer(ctx, userID)
+	if !viewer.IsAdmin && !viewer.IsOwner(p) {
+		return nil, huma.Error403Forbidden(forbidden)
+	}
+	return p, nil
+}
+
 type GetPollRequest struct {
 	ID string `path:"id" doc:"ID de la encuesta"`
 }
@@ -132,32 +298,75 @@ func (a *UserAPI) GetPoll(ctx context.Context, input *GetPollRequest) (*GetPollR
 		return nil, huma.Error404NotFound("Encuesta no encontrada", err)
 	}
 
+	if p.Status == poll.StatusPublished {
+		a.Trending.RecordView(p.ID, userID)
+	}
+
 	// Reutilizamos la lógica de mapeo
+	viewer := a.userModel.Viewer(ctx, userID)
+	return &GetPollResponse{Body: toPollOutput(p, viewer, time.Now())}, nil
+}
+
+// toPollOutput mapea una encuesta (con opciones y el voto del usuario cargados).
+// Los recuentos solo se incluyen si la política de la encuesta deja verlos al viewer.
+func toPollOutput(p *ent.Poll, viewer models.Viewer, now time.Time) PollOutput {
 	voted := len(p.Edges.Votes) > 0
 	var selectedID string
-	if voted && p.Edges.Votes[0].Edges.PollOption != nil {
-		selectedID = fmt.Sprintf("%d", p.Edges.Votes[0].Edges.PollOption.ID)
+	if voted {
+		// p.Edges.Votes[0] es el voto del usuario
+		// .Edges.PollOption es la relación cargada gracias al .WithPollOption() anterior
+		if p.Edges.Votes[0].Edges.PollOption != nil {
+			selectedID = fmt.Sprintf("%d", p.Edges.Votes[0].Edges.PollOption.ID)
+		}
 	}
 
+	showResults := models.ResultsVisible(p, viewer, voted, now)
 	opts := make([]OptionOutput, len(p.Edges.Options))
 	for j, o := range p.Edges.Options {
-		opts[j] = OptionOutput{
-			ID:         fmt.Sprintf("%d", o.ID),
-			Text:       o.Text,
-			VotesCount: o.VotesCount,
+		opts[j] = OptionOutput{ID: fmt.Sprintf("%d", o.ID), Text: o.Text, Archived: o.Archived}
+		if showResults {
+			count := o.VotesCount
+			opts[j].VotesCount = &count
 		}
 	}
 
-	return &GetPollResponse{
-		Body: PollOutput{
-			ID:               fmt.Sprintf("%d", p.ID),
-			Title:            p.Title,
-			Options:          opts,
-			Voted:            voted,
-			SelectedOptionID: selectedID,
-			IsOpen:           p.IsOpen,
+	out := PollOutput{
+		ID:               fmt.Sprintf("%d", p.ID),
+		Title:            p.Title,
+		Description:      p.Description,
+		Options:          opts,
+		Voted:            voted,
+		SelectedOptionID: selectedID,
+		IsOpen:           p.IsOpen,
+		OpensAt:          p.OpensAt,
+		ClosesAt:         p.ClosesAt,
+		CloseRules: models.CloseRules{
+			MaxVotes:      p.MaxVotes,
+			QuorumPercent: p.QuorumPercent,
+			DecisiveLead:  p.CloseOnDecisiveLead,
 		},
-	}, nil
+		ResultsVisibility: p.ResultsVisibility.String(),
+		ResultsVisibleAt:  p.ResultsVisibleAt,
+		ResultsVisible:    showResults,
+		Status:            p.Status.String(),
+		PublishedAt:       p.PublishedAt,
+		Tags:              toTagOutputs(p.Edges.Tags),
+		Anonymous:         p.Anonymous,
+		Embeddable:        p.Embeddable,
+		AllowGuests:       p.AllowGuests,
+	}
+	if p.OwnerID != nil {
+		out.OwnerID = *p.OwnerID
+	}
+	if p.ClosedReason != nil {
+		out.ClosedReason = p.ClosedReason.String()
+	}
+	// Cuenta atrás solo mientras el cierre sea futuro
+	if p.ClosesAt != nil && p.ClosesAt.After(now) {
+		secs := int64(p.ClosesAt.Sub(now).Seconds())
+		out.ClosesInSeconds = &secs
+	}
+	return out
 }
 
 
@@ -166,69 +375,96 @@ type DeletePollRequest struct {
 }
 
 func (a *UserAPI) DeletePoll(ctx context.Context, input *DeletePollRequest) (*struct{}, error) {
-	err := a.pollModel.Delete(ctx, input.ID)
+	p, err := a.ownedPoll(ctx, input.ID, "Solo el autor puede eliminar la encuesta")
 	if err != nil {
+		return nil, err
+	}
+	if err := a.pollModel.Delete(ctx, input.ID); err != nil {
 		return nil, huma.Error500InternalServerError("Error al eliminar encuesta", err)
 	}
+	a.Trending.Forget(p.ID)
+	a.Charts.Invalidate(p.ID)
 	return nil, nil
 }
 
-func (a *UserAPI) ListPolls(ctx context.Context, input *struct{}) (*ListPollsResponse, error) {
+func (a *UserAPI) ListPolls(ctx context.Context, input *ListPollsRequest) (*ListPollsResponse, error) {
 	// Obtenemos el ID del usuario desde el JWT
 	userID := utils.GetUserIDFromContext(ctx)
-	polls, err := a.pollModel.ListAllWithUserStatus(ctx, userID)
+	owner := input.Owner
+	if owner == "me" {
+		owner = userID
+	}
+
+	polls, page, err := a.pollModel.ListPage(ctx, models.PollListParams{
+		UserID:        userID,
+		Cursor:        input.Cursor,
+		Limit:         input.Limit,
+		Sort:          input.Sort,
+		State:         input.State,
+		Voted:         parseBoolFilter(input.Voted),
+		OwnerID:       owner,
+		CreatedAfter:  input.CreatedAfter,
+		CreatedBefore: input.CreatedBefore,
+		Tag:           input.Tag,
+	})
 	if err != nil {
+		if err.Error() == "INVALID_CURSOR" {
+			return nil, huma.Error400BadRequest("Cursor inválido", err)
+		}
 		return nil, huma.Error500InternalServerError("Error al listar", err)
 	}
 
-	output := make([]PollOutput, len(polls))
+	now := time.Now()
+	viewer := a.userModel.Viewer(ctx, userID)
+	resp := &ListPollsResponse{}
+	resp.Body.Items = make([]PollOutput, len(polls))
 	for i, p := range polls {
-		voted := len(p.Edges.Votes) > 0
-		var selectedID string
-		if voted {
-			// p.Edges.Votes[0] es el voto del usuario
-			// .Edges.PollOption es la relación cargada gracias al .WithPollOption() anterior
-			if p.Edges.Votes[0].Edges.PollOption != nil {
-				selectedID = fmt.Sprintf("%d", p.Edges.Votes[0].Edges.PollOption.ID)
-			}
-		}
-
-		opts := make([]OptionOutput, len(p.Edges.Options))
-		for j, o := range p.Edges.Options {
-			opts[j] = OptionOutput{ID: fmt.Sprintf("%d", o.ID), Text: o.Text, VotesCount: o.VotesCount}
-		}
-
-		output[i] = PollOutput{
-			ID:               fmt.Sprintf("%d", p.ID),
-			Title:            p.Title,
-			Options:          opts,
-			Voted:            voted,
-			SelectedOptionID: selectedID,
-			IsOpen:           p.IsOpen,
-		}
+		resp.Body.Items[i] = toPollOutput(p, viewer, now)
 	}
+	resp.Body.Page = page
 
-	return &ListPollsResponse{Body: output}, nil
+	return resp, nil
 }
 
 func (a *UserAPI) SubscribeVotes(w http.ResponseWriter, r *http.Request) {
+	// El token es opcional: sin él solo se reciben los recuentos públicos.
+	// Los navegadores no pueden poner cabeceras en un WebSocket, así que
+	// también se acepta como ?token=
+	var client Client
+	token := r.URL.Query().Get("token")
+	if header := r.Header.Get("Authorization"); header != "" {
+		token = strings.TrimPrefix(header, "Bearer ")
+	}
+	if token != "" {
+		claims, err := utils.ValidateToken(token)
+		if err == nil {
+			err = a.userModel.CheckSession(r.Context(), claims.UserID, claims.Version)
+		}
+		if err != nil {
+			http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+			return
+		}
+		viewer := a.userModel.Viewer(r.Context(), claims.UserID)
+		client.UserID, client.IsAdmin = viewer.UserID, viewer.IsAdmin
+	}
+
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		return
 	}
 
 	// Canal local para este cliente específico
-	clientChan := make(chan VoteUpdate)
-	a.Hub.Register <- clientChan
+	client.Send = make(chan VoteUpdate, ClientBuffer)
+	a.Hub.Register <- &client
 
 	// Asegurar limpieza al desconectar
 	defer func() {
-		a.Hub.Unregister <- clientChan
+		a.Hub.Unregister <- &client
 		conn.Close()
 	}()
 
 	// Escuchar actualizaciones del Hub y enviarlas al móvil
-	for update := range clientChan {
+	for update := range client.Send {
 		err := conn.WriteJSON(update)
 		if err != nil {
 			break // Si falla la escritura (ej: el móvil perdió señal), cerramos
@@ -239,26 +475,72 @@ func (a *UserAPI) SubscribeVotes(w http.ResponseWriter, r *http.Request) {
 // Estructura para recibir los datos
 type CreatePollRequest struct {
 	Body struct {
-		Title   string   `json:"title" doc:"Título de la encuesta" example:"¿Cuál es el mejor lenguaje?"`
-		Options []string `json:"options" doc:"Lista de opciones" example:"[\"Go\", \"Kotlin\"]"`
+		Title       string            `json:"title" doc:"Título de la encuesta" example:"¿Cuál es el mejor lenguaje?"`
+		Description string            `json:"description,omitempty" doc:"Texto explicativo opcional"`
+		Options     []string          `json:"options" doc:"Lista de opciones" example:"[\"Go\", \"Kotlin\"]"`
+		OpensAt     *time.Time        `json:"opens_at,omitempty" doc:"Apertura programada; si es futura la encuesta nace cerrada"`
+		ClosesAt    *time.Time        `json:"closes_at,omitempty" doc:"Cierre programado"`
+		CloseRules  models.CloseRules `json:"close_rules,omitempty" doc:"Reglas de cierre automático"`
+
+		ResultsVisibility string     `json:"results_visibility,omitempty" enum:"always,after_vote,after_close,embargo" default:"always" doc:"Cuándo se muestran los recuentos"`
+		ResultsVisibleAt  *time.Time `json:"results_visible_at,omitempty" doc:"Fin del embargo (requerido con embargo)"`
+
+		Draft bool     `json:"draft,omitempty" doc:"Guardar como borrador (solo visible para el autor) en lugar de publicar"`
+		Tags  []string `json:"tags,omitempty" maxItems:"5" doc:"Etiquetas; las que no existen se crean" example:"[\"Política\"]"`
+
+		Anonymous   bool `json:"anonymous,omitempty" doc:"Votos anónimos: no se podrá exportar quién votó qué. No se puede cambiar después"`
+		Embeddable  bool `json:"embeddable,omitempty" doc:"Permitir incrustarla en las webs de tu lista de orígenes"`
+		AllowGuests bool `json:"allow_guests,omitempty" doc:"Admitir votos de invitados sin cuenta, uno por dispositivo"`
 	}
 }
 
-func (a *UserAPI) CreatePoll(ctx context.Context, input *CreatePollRequest) (*struct{}, error) {
-	// 1. Crear la encuesta
-	p, err := a.pollModel.Create(ctx, input.Body.Title)
+func (a *UserAPI) CreatePoll(ctx context.Context, input *CreatePollRequest) (*GetPollResponse, error) {
+	// La encuesta y sus opciones se crean juntas en una transacción
+	p, err := a.pollModel.Create(ctx, models.PollInput{
+		Title:       input.Body.Title,
+		Description: input.Body.Description,
+		Options:     input.Body.Options,
+		Draft:       input.Body.Draft,
+		Tags:        input.Body.Tags,
+		Anonymous:   input.Body.Anonymous,
+		Embeddable:  &input.Body.Embeddable,
+		AllowGuests: &input.Body.AllowGuests,
+		OpensAt:     input.Body.OpensAt,
+		ClosesAt:    input.Body.ClosesAt,
+		Rules:       input.Body.CloseRules,
+		OwnerID:     utils.GetUserIDFromContext(ctx),
+
+		ResultsVisibility: input.Body.ResultsVisibility,
+		ResultsVisibleAt:  input.Body.ResultsVisibleAt,
+	})
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Error al crear la encuesta", err)
+		return nil, pollError("Error al crear la encuesta", err)
 	}
 
-	// 2. Crear las opciones
-	for _, optText := range input.Body.Options {
-		if err := a.pollModel.AddOption(ctx, fmt.Sprintf("%d", p.ID), optText); err != nil {
-			return nil, huma.Error500InternalServerError("Error al crear las opciones", err)
-		}
+	if !input.Body.Draft && (p.OpensAt != nil || p.ClosesAt != nil) {
+		a.Scheduler.Reschedule()
 	}
+	return a.GetPoll(ctx, &GetPollRequest{ID: fmt.Sprintf("%d", p.ID)})
+}
 
-	return nil, nil
+type PublishPollRequest struct {
+	ID string `path:"id" doc:"ID del borrador"`
+}
+
+func (a *UserAPI) PublishPoll(ctx context.Context, input *PublishPollRequest) (*GetPollResponse, error) {
+	pollID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, huma.Error400BadRequest("ID de encuesta inválido", err)
+	}
+
+	p, err := a.pollModel.Publish(ctx, pollID, utils.GetUserIDFromContext(ctx))
+	if err != nil {
+		return nil, pollError("Error al publicar la encuesta", err)
+	}
+	if p.OpensAt != nil || p.ClosesAt != nil {
+		a.Scheduler.Reschedule()
+	}
+	return a.GetPoll(ctx, &GetPollRequest{ID: input.ID})
 }
 func (a *UserAPI) CreateUser(ctx context.Context, req *CreateUserRequest) (*UserResponse, error) {
 	input := models.UserInput{
@@ -269,6 +551,9 @@ func (a *UserAPI) CreateUser(ctx context.Context, req *CreateUserRequest) (*User
 	}
 
 	user, err := a.userModel.Create(ctx, input)
+	if policyErr := passwordPolicyError(err); policyErr != nil {
+		return nil, policyErr
+	}
 	if err != nil {
 		return nil, huma.Error400BadRequest("Error creating user", err)
 	}
@@ -276,18 +561,28 @@ func (a *UserAPI) CreateUser(ctx context.Context, req *CreateUserRequest) (*User
 	return &UserResponse{Body: *user}, nil
 }
 
-func (a *UserAPI) ListUsers(ctx context.Context, req *struct{}) (*UsersResponse, error) {
-	users, err := a.userModel.GetAll(ctx)
+func (a *UserAPI) ListUsers(ctx context.Context, req *ListUsersRequest) (*UsersResponse, error) {
+	users, page, err := a.userModel.GetPage(ctx, models.UserListParams{
+		Cursor: req.Cursor,
+		Limit:  req.Limit,
+		Sort:   req.Sort,
+		Active: parseBoolFilter(req.Active),
+	})
 	if err != nil {
+		if err.Error() == "INVALID_CURSOR" {
+			return nil, huma.Error400BadRequest("Invalid cursor", err)
+		}
 		return nil, huma.Error500InternalServerError("Error fetching users", err)
 	}
 
-	responseUsers := make([]models.UserResponse, len(users))
+	resp := &UsersResponse{}
+	resp.Body.Items = make([]models.UserResponse, len(users))
 	for i, u := range users {
-		responseUsers[i] = *u
+		resp.Body.Items[i] = *u
 	}
+	resp.Body.Page = page
 
-	return &UsersResponse{Body: responseUsers}, nil
+	return resp, nil
 }
 
 func (a *UserAPI) GetUser(ctx context.Context, req *GetUserRequest) (*UserResponse, error) {
@@ -308,6 +603,9 @@ func (a *UserAPI) UpdateUser(ctx context.Context, req *UpdateUserRequest) (*User
 	}
 
 	user, err := a.userModel.Update(ctx, req.ID, input)
+	if policyErr := passwordPolicyError(err); policyErr != nil {
+		return nil, policyErr
+	}
 	if err != nil {
 		return nil, huma.Error400BadRequest("Error updating user", err)
 	}
@@ -317,6 +615,9 @@ func (a *UserAPI) UpdateUser(ctx context.Context, req *UpdateUserRequest) (*User
 func (a *UserAPI) DeleteUser(ctx context.Context, req *DeleteUserRequest) (*struct{}, error) {
 	err := a.userModel.Delete(ctx, req.ID)
 	if err != nil {
+		if err.Error() == "USER_HAS_VOTES" {
+			return nil, huma.Error409Conflict("User has cast votes and cannot be deleted")
+		}
 		return nil, huma.Error404NotFound("User not found", err)
 	}
 	return nil, nil
@@ -338,6 +639,14 @@ func SetupRoutes(router *http.ServeMux, userAPI *UserAPI, authAPI *AuthAPI) {
 
 	app := humago.New(router, config)
 
+	// auth exige sesión; un cambio de contraseña por recuperación la revoca
+	auth := AuthMiddleware(app, userAPI.userModel)
+
+	// limit crea el middleware de límites de una operación
+	limit := func(rules ...ratelimit.Rule) func(huma.Context, func(huma.Context)) {
+		return RateLimitMiddleware(app, userAPI.Limiter, rules...)
+	}
+
 	huma.Register(app, huma.Operation{
 		OperationID: "register",
 		Method:      http.MethodPost,
@@ -345,14 +654,32 @@ func SetupRoutes(router *http.ServeMux, userAPI *UserAPI, authAPI *AuthAPI) {
 		Description: "Registra un nuevo usuario en el sistema",
 		Summary:     "Register new user",
 		Tags:        []string{"Auth"},
+		Middlewares: huma.Middlewares{
+			limit(ratelimit.PerIP(ratelimit.PerHour(10))),
+			ChallengeMiddleware(app, userAPI.Challenges, pow.ScopeRegister),
+		},
 	}, authAPI.Register)
 
+	huma.Register(app, huma.Operation{
+		OperationID: "get-challenge",
+		Method:      http.MethodGet,
+		Path:        "/challenge",
+		Summary:     "Obtener un reto anti-bots",
+		Description: "Prueba de trabajo: busca una solución tal que sha256(challenge + solution) empiece por `difficulty` bits a cero " +
+			"y envía ambos en las cabeceras X-PoW-Challenge y X-PoW-Solution. Cada reto vale una vez. " +
+			"Lo piden el registro, el voto y el token de invitado; si falta o no vale, la respuesta es 428.",
+		Tags:        []string{"Auth"},
+		Middlewares: huma.Middlewares{limit(ratelimit.PerIP(ratelimit.PerMinute(60)))},
+	}, userAPI.GetChallenge)
+
 	huma.Register(app, huma.Operation{
 		OperationID: "login",
 		Method:      http.MethodPost,
 		Path:        "/login",
 		Summary:     "Login user",
+		Description: "Repeated failures slow down and then temporarily lock the account (and the client IP): the response is 429 with Retry-After.",
 		Tags:        []string{"Auth"},
+		Middlewares: huma.Middlewares{limit(ratelimit.PerIP(ratelimit.Limit{Rate: 10, Per: time.Minute, Burst: 5}))},
 	}, authAPI.Login)
 
 	huma.Register(app, huma.Operation{
@@ -363,19 +690,205 @@ func SetupRoutes(router *http.ServeMux, userAPI *UserAPI, authAPI *AuthAPI) {
 		Tags:        []string{"Users"},
 		Security:    []map[string][]string{{"bearerAuth": {}}},
 		Middlewares: huma.Middlewares{
-			AuthMiddleware(app),
+			auth,
 		},
 	}, authAPI.GetProfile)
 
+	huma.Register(app, huma.Operation{
+		OperationID: "verify-email",
+		Method:      http.MethodGet,
+		Path:        "/verify-email",
+		Summary:     "Verify email",
+		Description: "Target of the link sent by email after registering. The link expires after 48 hours.",
+		Tags:        []string{"Auth"},
+		Middlewares: huma.Middlewares{limit(ratelimit.PerIP(ratelimit.PerMinute(20)))},
+	}, authAPI.VerifyEmail)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "login-2fa",
+		Method:      http.MethodPost,
+		Path:        "/login/2fa",
+		Summary:     "Complete login with a second factor",
+		Description: "Second step of the login for accounts with two-factor authentication: exchanges the challenge token returned by /login and a TOTP or recovery code for a session token.",
+		Tags:        []string{"Auth"},
+		Middlewares: huma.Middlewares{limit(ratelimit.PerIP(ratelimit.PerMinute(10)))},
+	}, authAPI.LoginTwoFactor)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "get-2fa",
+		Method:      http.MethodGet,
+		Path:        "/profile/2fa",
+		Summary:     "Get two-factor status",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, authAPI.GetTwoFactor)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "setup-2fa",
+		Method:      http.MethodPost,
+		Path:        "/profile/2fa/setup",
+		Summary:     "Start two-factor setup",
+		Description: "Generates a TOTP secret with its otpauth URI and QR code. It is not required at login until confirmed.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, authAPI.SetupTwoFactor)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "confirm-2fa",
+		Method:      http.MethodPost,
+		Path:        "/profile/2fa/confirm",
+		Summary:     "Enable two-factor authentication",
+		Description: "Confirms the setup with a code from the authenticator app and returns the recovery codes, which are shown only once.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth, limit(ratelimit.PerUser(ratelimit.PerMinute(10)))},
+	}, authAPI.ConfirmTwoFactor)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "disable-2fa",
+		Method:      http.MethodPost,
+		Path:        "/profile/2fa/disable",
+		Summary:     "Disable two-factor authentication",
+		Description: "Requires the password and a TOTP or recovery code.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth, limit(ratelimit.PerUser(ratelimit.PerMinute(10)))},
+	}, authAPI.DisableTwoFactor)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "regenerate-recovery-codes",
+		Method:      http.MethodPost,
+		Path:        "/profile/2fa/recovery-codes",
+		Summary:     "Regenerate recovery codes",
+		Description: "Replaces all recovery codes. Requires a code from the authenticator app.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth, limit(ratelimit.PerUser(ratelimit.PerMinute(10)))},
+	}, authAPI.RegenerateRecoveryCodes)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "list-oidc-providers",
+		Method:      http.MethodGet,
+		Path:        "/auth/oidc",
+		Summary:     "List identity providers",
+		Description: "Names of the OpenID Connect providers available for social login.",
+		Tags:        []string{"Auth"},
+	}, authAPI.ListOIDCProviders)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "start-oidc-login",
+		Method:      http.MethodGet,
+		Path:        "/auth/oidc/{provider}/start",
+		Summary:     "Start login with an identity provider",
+		Description: "Redirects the browser to the provider (authorization code flow with PKCE). The provider sends it back to the callback.",
+		Tags:        []string{"Auth"},
+		Middlewares: huma.Middlewares{limit(ratelimit.PerIP(ratelimit.PerMinute(20)))},
+	}, authAPI.StartOIDC)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "oidc-callback",
+		Method:      http.MethodGet,
+		Path:        "/auth/oidc/{provider}/callback",
+		Summary:     "Identity provider callback",
+		Description: "Completes the login started at /start. The identity is linked to the account with the same email if the provider verified it, or a new account is created. Returns the same response as /login.",
+		Tags:        []string{"Auth"},
+		Middlewares: huma.Middlewares{limit(ratelimit.PerIP(ratelimit.PerMinute(20)))},
+	}, authAPI.OIDCCallback)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "list-linked-identities",
+		Method:      http.MethodGet,
+		Path:        "/profile/identities",
+		Summary:     "List linked identity providers",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.ListLinkedIdentities)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "unlink-identity",
+		Method:      http.MethodDelete,
+		Path:        "/profile/identities/{id}",
+		Summary:     "Unlink an identity provider",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.UnlinkIdentity)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "get-password-policy",
+		Method:      http.MethodGet,
+		Path:        "/password-policy",
+		Summary:     "Get password policy",
+		Description: "Rules that new passwords must meet. Violations are reported as 422 errors with one entry per rule, whose value is the rule code.",
+		Tags:        []string{"Auth"},
+	}, authAPI.GetPasswordPolicy)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "forgot-password",
+		Method:      http.MethodPost,
+		Path:        "/forgot-password",
+		Summary:     "Request password reset",
+		Description: "Sends a single-use reset link, valid for one hour, if the email belongs to an active account. The response is the same either way.",
+		Tags:        []string{"Auth"},
+		Middlewares: huma.Middlewares{limit(ratelimit.PerIP(ratelimit.PerHour(10)))},
+	}, authAPI.ForgotPassword)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "reset-password",
+		Method:      http.MethodPost,
+		Path:        "/reset-password",
+		Summary:     "Reset password",
+		Description: "Sets a new password with the token from the reset link. All existing sessions are revoked.",
+		Tags:        []string{"Auth"},
+		Middlewares: huma.Middlewares{limit(ratelimit.PerIP(ratelimit.PerMinute(10)))},
+	}, authAPI.ResetPassword)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "resend-verification-email",
+		Method:      http.MethodPost,
+		Path:        "/verify-email/resend",
+		Summary:     "Resend verification email",
+		Description: "At most once per minute and five times per hour.",
+		Tags:        []string{"Auth"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth, limit(ratelimit.PerUser(ratelimit.PerHour(5)))},
+	}, authAPI.ResendVerification)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "get-login-history",
+		Method:      http.MethodGet,
+		Path:        "/profile/logins",
+		Summary:     "Get login history",
+		Description: "Recent sign-in attempts on your account, successful or not, with IP and user agent.",
+		Tags:        []string{"Users"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.LoginHistory)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "unlock-user",
+		Method:      http.MethodPost,
+		Path:        "/users/{id}/unlock",
+		Summary:     "Unlock a user",
+		Description: "Clears the temporary lock after repeated failed logins. Admins only.",
+		Tags:        []string{"Users"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.UnlockUser)
+
 	huma.Register(app, huma.Operation{
 		OperationID: "list-users",
 		Method:      http.MethodGet,
 		Path:        "/users",
-		Summary:     "List all users",
+		Summary:     "List users",
+		Description: "Paginated with an opaque cursor: pass page.next_cursor to get the next page.",
 		Tags:        []string{"Users"},
 		Security:    []map[string][]string{{"bearerAuth": {}}},
 		Middlewares: huma.Middlewares{
-			AuthMiddleware(app),
+			auth,
 		},
 	}, userAPI.ListUsers)
 
@@ -387,7 +900,7 @@ func SetupRoutes(router *http.ServeMux, userAPI *UserAPI, authAPI *AuthAPI) {
 		Tags:        []string{"Users"},
 		Security:    []map[string][]string{{"bearerAuth": {}}},
 		Middlewares: huma.Middlewares{
-			AuthMiddleware(app), // <-- Pásalo directamente así
+			auth, // <-- Pásalo directamente así
 		},
 	}, userAPI.GetUser)
 
@@ -399,7 +912,7 @@ func SetupRoutes(router *http.ServeMux, userAPI *UserAPI, authAPI *AuthAPI) {
 		Tags:        []string{"Users"},
 		Security:    []map[string][]string{{"bearerAuth": {}}},
 		Middlewares: huma.Middlewares{
-			AuthMiddleware(app),
+			auth,
 		},
 	}, userAPI.UpdateUser)
 
@@ -411,7 +924,7 @@ func SetupRoutes(router *http.ServeMux, userAPI *UserAPI, authAPI *AuthAPI) {
 		Tags:        []string{"Users"},
 		Security:    []map[string][]string{{"bearerAuth": {}}},
 		Middlewares: huma.Middlewares{
-			AuthMiddleware(app),
+			auth,
 		},
 	}, userAPI.DeleteUser)
 
@@ -420,22 +933,174 @@ func SetupRoutes(router *http.ServeMux, userAPI *UserAPI, authAPI *AuthAPI) {
 		Method:      http.MethodPost,
 		Path:        "/polls/{poll_id}/vote/{option_id}",
 		Summary:     "Emitir un voto",
+		Description: "Requiere un reto resuelto (GET /challenge?scope=vote) si el servidor lo pide.",
 		Tags:        []string{"Voting"},
 		Security:    []map[string][]string{{"bearerAuth": {}}},
-		Middlewares: huma.Middlewares{AuthMiddleware(app)},
+		Middlewares: huma.Middlewares{
+			auth,
+			limit(ratelimit.PerUser(ratelimit.PerMinute(30)), ratelimit.PerIP(ratelimit.PerMinute(120))),
+			ChallengeMiddleware(app, userAPI.Challenges, pow.ScopeVote),
+		},
 	}, userAPI.PostVote)
 
+	// Invitados: un token por dispositivo para votar en encuestas que lo admiten
+	huma.Register(app, huma.Operation{
+		OperationID: "create-guest-token",
+		Method:      http.MethodPost,
+		Path:        "/guest/token",
+		Summary:     "Obtener un token de invitado",
+		Description: "Identifica al dispositivo, no a una persona: solo sirve para votar en encuestas con allow_guests. Al registrarte, envía guest_token para quedarte con esos votos. Requiere un reto resuelto (scope=guest).",
+		Tags:        []string{"Voting"},
+		Middlewares: huma.Middlewares{
+			limit(ratelimit.PerIP(ratelimit.PerHour(30))),
+			ChallengeMiddleware(app, userAPI.Challenges, pow.ScopeGuest),
+		},
+	}, userAPI.CreateGuestToken)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "get-guest-poll",
+		Method:      http.MethodGet,
+		Path:        "/guest/polls/{id}",
+		Summary:     "Ver una encuesta como invitado",
+		Tags:        []string{"Voting"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{GuestMiddleware(app)},
+	}, userAPI.GetGuestPoll)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "post-guest-vote",
+		Method:      http.MethodPost,
+		Path:        "/guest/polls/{poll_id}/vote/{option_id}",
+		Summary:     "Votar como invitado",
+		Description: "Un voto por encuesta y dispositivo. Requiere un token de invitado, una encuesta con allow_guests y un reto resuelto (scope=vote).",
+		Tags:        []string{"Voting"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{
+			GuestMiddleware(app),
+			limit(ratelimit.PerUser(ratelimit.PerMinute(30)), ratelimit.PerIP(ratelimit.PerMinute(120))),
+			ChallengeMiddleware(app, userAPI.Challenges, pow.ScopeVote),
+		},
+	}, userAPI.PostGuestVote)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "claim-guest-votes",
+		Method:      http.MethodPost,
+		Path:        "/profile/guest-votes",
+		Summary:     "Traspasar votos de invitado a tu cuenta",
+		Description: "Para cuentas que ya existían: los votos del dispositivo pasan a tu usuario, salvo en encuestas donde ya votaste.",
+		Tags:        []string{"Voting"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.ClaimGuestVotes)
+
 	router.HandleFunc("/ws/votes", userAPI.SubscribeVotes)
 
+	// Enlaces públicos: no requieren sesión, el token del enlace es la credencial
+	router.HandleFunc("GET /s/{token}", userAPI.SharePreview)
+
+	// Widget incrustable: HTML para el iframe y endpoints con CORS según el autor
+	router.HandleFunc("GET /embed/polls/{id}", userAPI.EmbedWidget)
+	router.HandleFunc("GET /embed/polls/{id}/data", userAPI.EmbedData)
+	router.HandleFunc("OPTIONS /embed/polls/{id}/data", userAPI.EmbedPreflight)
+	router.HandleFunc("POST /embed/polls/{id}/vote/{option_id}", userAPI.EmbedVote)
+	router.HandleFunc("OPTIONS /embed/polls/{id}/vote/{option_id}", userAPI.EmbedPreflight)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "oembed",
+		Method:      http.MethodGet,
+		Path:        "/oembed",
+		Summary:     "oEmbed de una encuesta incrustable",
+		Description: "Devuelve el iframe del widget para una URL de encuesta (https://oembed.com). Solo encuestas publicadas con embeddable activado.",
+		Tags:        []string{"Sharing"},
+	}, userAPI.OEmbed)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "get-embed-settings",
+		Method:      http.MethodGet,
+		Path:        "/profile/embed",
+		Summary:     "Get embed settings",
+		Description: "Origins where your embeddable polls may be framed and called from (CORS).",
+		Tags:        []string{"Users"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.GetEmbedSettings)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "update-embed-settings",
+		Method:      http.MethodPut,
+		Path:        "/profile/embed",
+		Summary:     "Update embed settings",
+		Tags:        []string{"Users"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.UpdateEmbedSettings)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "get-shared-poll",
+		Method:      http.MethodGet,
+		Path:        "/share/{token}",
+		Summary:     "Leer una encuesta compartida",
+		Description: "Acceso público con el token de un enlace. Los recuentos solo aparecen si la política de resultados los hace públicos.",
+		Tags:        []string{"Sharing"},
+	}, userAPI.GetSharedPoll)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "get-shared-poll-chart",
+		Method:      http.MethodGet,
+		Path:        "/share/{token}/chart",
+		Summary:     "Gráfico de una encuesta compartida",
+		Tags:        []string{"Sharing"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Imagen del gráfico",
+				Content: map[string]*huma.MediaType{
+					"image/png":     {},
+					"image/svg+xml": {},
+				},
+			},
+		},
+	}, userAPI.GetSharedChart)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "create-share-link",
+		Method:      http.MethodPost,
+		Path:        "/polls/{id}/share-links",
+		Summary:     "Crear un enlace público",
+		Description: "Genera un token revocable que permite leer la encuesta sin sesión. El token solo se muestra en esta respuesta.",
+		Tags:        []string{"Sharing"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.CreateShareLink)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "list-share-links",
+		Method:      http.MethodGet,
+		Path:        "/polls/{id}/share-links",
+		Summary:     "Listar los enlaces públicos de una encuesta",
+		Tags:        []string{"Sharing"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.ListShareLinks)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "revoke-share-link",
+		Method:      http.MethodDelete,
+		Path:        "/polls/{id}/share-links/{link_id}",
+		Summary:     "Revocar un enlace público",
+		Tags:        []string{"Sharing"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.RevokeShareLink)
+
 	huma.Register(app, huma.Operation{
 		OperationID: "create-poll",
 		Method:      http.MethodPost,
 		Path:        "/polls",
 		Summary:     "Crear una nueva encuesta",
-		Description: "Crea una encuesta con sus opciones iniciales. Solo para administradores (en el futuro).",
+		Description: "Crea una encuesta con sus opciones iniciales en una sola operación, publicada o como borrador. Solo para administradores (en el futuro).",
 		Tags:        []string{"Voting"},
 		Security:    []map[string][]string{{"bearerAuth": {}}},
-		Middlewares: huma.Middlewares{AuthMiddleware(app)},
+		Middlewares: huma.Middlewares{auth},
 	}, userAPI.CreatePoll)
 
 	huma.Register(app, huma.Operation{
@@ -443,11 +1108,216 @@ func SetupRoutes(router *http.ServeMux, userAPI *UserAPI, authAPI *AuthAPI) {
 		Method:      http.MethodGet,
 		Path:        "/polls",
 		Summary:     "Listar encuestas",
+		Description: "Paginado por cursor opaco: pasa page.next_cursor para obtener la siguiente página.",
 		Tags:        []string{"Voting"},
 		Security:    []map[string][]string{{"bearerAuth": {}}},
-		Middlewares: huma.Middlewares{AuthMiddleware(app)},
+		Middlewares: huma.Middlewares{auth},
 	}, userAPI.ListPolls)
 
+	huma.Register(app, huma.Operation{
+		OperationID: "search-polls",
+		Method:      http.MethodGet,
+		Path:        "/polls/search",
+		Summary:     "Buscar encuestas",
+		Description: "Busca en títulos, descripciones y opciones, sin distinguir mayúsculas ni tildes, ordenando por relevancia.",
+		Tags:        []string{"Voting"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth, limit(ratelimit.PerUser(ratelimit.PerMinute(120)))},
+	}, userAPI.SearchPolls)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "suggest-poll-terms",
+		Method:      http.MethodGet,
+		Path:        "/polls/search/suggest",
+		Summary:     "Autocompletar búsqueda",
+		Tags:        []string{"Voting"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.SuggestPolls)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "trending-polls",
+		Method:      http.MethodGet,
+		Path:        "/polls/trending",
+		Summary:     "Encuestas en tendencia",
+		Description: "Ordenadas por una puntuación que suma votos y visitas recientes, con decaimiento exponencial dentro de la ventana elegida.",
+		Tags:        []string{"Voting"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.TrendingPolls)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "poll-feed",
+		Method:      http.MethodGet,
+		Path:        "/polls/feed",
+		Summary:     "Feed de encuestas de mis etiquetas",
+		Description: "Encuestas abiertas con alguna de las etiquetas que sigue el usuario.",
+		Tags:        []string{"Voting"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.PollFeed)
+
+	// Etiquetas
+	huma.Register(app, huma.Operation{
+		OperationID: "list-tags",
+		Method:      http.MethodGet,
+		Path:        "/tags",
+		Summary:     "Listar etiquetas",
+		Description: "Con el número de encuestas publicadas de cada una; q autocompleta por prefijo.",
+		Tags:        []string{"Tags"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.ListTags)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "create-tag",
+		Method:      http.MethodPost,
+		Path:        "/tags",
+		Summary:     "Crear etiqueta (solo administradores)",
+		Tags:        []string{"Tags"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.CreateTag)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "rename-tag",
+		Method:      http.MethodPatch,
+		Path:        "/tags/{slug}",
+		Summary:     "Renombrar etiqueta (solo administradores)",
+		Tags:        []string{"Tags"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.RenameTag)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "delete-tag",
+		Method:      http.MethodDelete,
+		Path:        "/tags/{slug}",
+		Summary:     "Eliminar etiqueta (solo administradores)",
+		Tags:        []string{"Tags"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.DeleteTag)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "follow-tag",
+		Method:      http.MethodPut,
+		Path:        "/tags/{slug}/follow",
+		Summary:     "Seguir una etiqueta",
+		Tags:        []string{"Tags"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.FollowTag)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "unfollow-tag",
+		Method:      http.MethodDelete,
+		Path:        "/tags/{slug}/follow",
+		Summary:     "Dejar de seguir una etiqueta",
+		Tags:        []string{"Tags"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.UnfollowTag)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "publish-poll",
+		Method:      http.MethodPost,
+		Path:        "/polls/{id}/publish",
+		Summary:     "Publicar un borrador",
+		Description: "Valida el borrador (título, al menos dos opciones distintas y programación coherente) y lo hace visible.",
+		Tags:        []string{"Voting"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.PublishPoll)
+
+	// Editar opciones sin perder votos
+	huma.Register(app, huma.Operation{
+		OperationID: "update-poll-options",
+		Method:      http.MethodPatch,
+		Path:        "/polls/{id}/options",
+		Summary:     "Editar opciones de una encuesta",
+		Description: "Añade, renombra, reordena o archiva opciones por ID conservando los votos.",
+		Tags:        []string{"Voting"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.UpdatePollOptions)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "get-poll-analytics",
+		Method:      http.MethodGet,
+		Path:        "/polls/{id}/analytics",
+		Summary:     "Evolución de los votos de una encuesta",
+		Description: "Votos por opción en intervalos de minuto, hora o día, curvas acumuladas, participación sobre los usuarios activos, picos de actividad y mediana del tiempo hasta votar. Solo si los resultados son visibles para quien consulta.",
+		Tags:        []string{"Voting"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.GetPollAnalytics)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "get-poll-chart",
+		Method:      http.MethodGet,
+		Path:        "/polls/{id}/chart",
+		Summary:     "Gráfico de resultados",
+		Description: "Dibuja los resultados actuales como barras o tarta, en SVG o PNG. Solo si los resultados son visibles para quien consulta.",
+		Tags:        []string{"Voting"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Imagen del gráfico",
+				Content: map[string]*huma.MediaType{
+					"image/svg+xml": {},
+					"image/png":     {},
+				},
+			},
+		},
+	}, userAPI.GetPollChart)
+
+	// Exportación (solo autor o administradores)
+	huma.Register(app, huma.Operation{
+		OperationID: "export-poll-results",
+		Method:      http.MethodGet,
+		Path:        "/polls/{id}/export/results",
+		Summary:     "Exportar resultados",
+		Description: "Recuento por opción en CSV, JSON Lines o XLSX.",
+		Tags:        []string{"Voting"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.ExportPollResults)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "export-poll-ballots",
+		Method:      http.MethodGet,
+		Path:        "/polls/{id}/export/ballots",
+		Summary:     "Exportar votos individuales",
+		Description: "Cada voto con su votante, en orden cronológico. Se genera en streaming, sin cargar todos los votos en memoria. No disponible en encuestas anónimas.",
+		Tags:        []string{"Voting"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.ExportPollBallots)
+
+	// Historial de revisiones
+	huma.Register(app, huma.Operation{
+		OperationID: "list-poll-revisions",
+		Method:      http.MethodGet,
+		Path:        "/polls/{id}/revisions",
+		Summary:     "Historial de cambios de una encuesta",
+		Description: "Cada revisión incluye las diferencias con la anterior.",
+		Tags:        []string{"Voting"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.ListPollRevisions)
+
+	huma.Register(app, huma.Operation{
+		OperationID: "restore-poll-revision",
+		Method:      http.MethodPost,
+		Path:        "/polls/{id}/revisions/{revision}/restore",
+		Summary:     "Restaurar una revisión (solo administradores)",
+		Tags:        []string{"Voting"},
+		Security:    []map[string][]string{{"bearerAuth": {}}},
+		Middlewares: huma.Middlewares{auth},
+	}, userAPI.RestorePollRevision)
+
 	// Actualizar Encuesta
 	huma.Register(app, huma.Operation{
 		OperationID: "update-poll",
@@ -456,7 +1326,7 @@ func SetupRoutes(router *http.ServeMux, userAPI *UserAPI, authAPI *AuthAPI) {
 		Summary:     "Actualizar encuesta",
 		Tags:        []string{"Voting"},
 		Security:    []map[string][]string{{"bearerAuth": {}}},
-		Middlewares: huma.Middlewares{AuthMiddleware(app)},
+		Middlewares: huma.Middlewares{auth},
 	}, userAPI.UpdatePoll)
 
 	// Eliminar Encuesta
@@ -467,7 +1337,7 @@ func SetupRoutes(router *http.ServeMux, userAPI *UserAPI, authAPI *AuthAPI) {
 		Summary:     "Eliminar encuesta",
 		Tags:        []string{"Voting"},
 		Security:    []map[string][]string{{"bearerAuth": {}}},
-		Middlewares: huma.Middlewares{AuthMiddleware(app)},
+		Middlewares: huma.Middlewares{auth},
 	}, userAPI.DeletePoll)
 	
 	huma.Register(app, huma.Operation{
@@ -477,6 +1347,6 @@ func SetupRoutes(router *http.ServeMux, userAPI *UserAPI, authAPI *AuthAPI) {
     Summary:     "Obtener detalle de una encuesta",
     Tags:        []string{"Voting"},
     Security:    []map[string][]string{{"bearerAuth": {}}},
-    Middlewares: huma.Middlewares{AuthMiddleware(app)},
+    Middlewares: huma.Middlewares{auth},
 }, userAPI.GetPoll)
 }
@@ -1,52 +1,142 @@
 package api
 
 import (
-	"sync"
+	"slices"
+	"sync/atomic"
+
+	"api_voty/internal/models"
+)
+
+// ClientBuffer es la cola de mensajes de cada socket. Si un cliente lento la
+// llena, los mensajes siguientes se le descartan en vez de frenar al Hub.
+const ClientBuffer = 32
+
+// Tipos de evento que viajan por el socket
+const (
+	EventVote       = "vote"
+	EventPollOpened = "poll_opened"
+	EventPollClosed = "poll_closed"
+
+	EventOptionsChanged = "options_changed"
 )
 
 // VoteUpdate es lo que el móvil recibirá por el socket
 type VoteUpdate struct {
+	Type     string `json:"type"`
 	PollID   string `json:"poll_id"`
-	OptionID string `json:"option_id"`
-	NewCount int    `json:"new_count"`
+	OptionID string `json:"option_id,omitempty"`
+	NewCount int    `json:"new_count,omitempty"`
+	IsOpen   *bool  `json:"is_open,omitempty"`
+
+	ClosedReason  string                `json:"closed_reason,omitempty"`
+	ResultsHidden bool                  `json:"results_hidden,omitempty"`
+	Changes       []models.OptionChange `json:"changes,omitempty"`
+
+	// audience decide quién puede ver el recuento; nil significa todos
+	audience func(c *Client) bool
+}
+
+// Client es una conexión de socket; UserID vacío si no se autenticó
+type Client struct {
+	UserID  string
+	IsAdmin bool
+	Send    chan VoteUpdate
 }
 
 type Hub struct {
 	// Canales de comunicación
 	Broadcast  chan VoteUpdate
-	Register   chan chan VoteUpdate
-	Unregister chan chan VoteUpdate
-	clients    map[chan VoteUpdate]bool
-	mu         sync.Mutex
+	Register   chan *Client
+	Unregister chan *Client
+	// clients solo lo toca el bucle de Run
+	clients map[*Client]bool
+	// users cuenta los sockets por usuario; connected es su copia inmutable
+	// para los handlers, que así no esperan al bucle del Hub
+	users     map[string]int
+	connected atomic.Pointer[[]string]
+	// observers ven cada mensaje difundido; se registran antes de Run
+	observers []func(VoteUpdate)
 }
 
 func NewHub() *Hub {
 	return &Hub{
 		Broadcast:  make(chan VoteUpdate),
-		Register:   make(chan chan VoteUpdate),
-		Unregister: make(chan chan VoteUpdate),
-		clients:    make(map[chan VoteUpdate]bool),
+		Register:   make(chan *Client),
+		Unregister: make(chan *Client),
+		clients:    make(map[*Client]bool),
+		users:      make(map[string]int),
+	}
+}
+
+// Observe registra fn para que reciba cada mensaje difundido. Debe llamarse
+// antes de Run y fn no debe bloquear, porque se ejecuta en el bucle del Hub.
+func (h *Hub) Observe(fn func(VoteUpdate)) {
+	h.observers = append(h.observers, fn)
+}
+
+// ConnectedUserIDs devuelve los usuarios autenticados con socket abierto.
+// La lista es compartida y no debe modificarse.
+func (h *Hub) ConnectedUserIDs() []string {
+	if ids := h.connected.Load(); ids != nil {
+		return *ids
+	}
+	return nil
+}
+
+// trackUser actualiza el recuento de sockets del usuario y, si aparece o
+// desaparece, la copia de usuarios conectados
+func (h *Hub) trackUser(userID string, delta int) {
+	if userID == "" {
+		return
+	}
+	before := h.users[userID]
+	if h.users[userID] += delta; h.users[userID] <= 0 {
+		delete(h.users, userID)
+	}
+	if (before == 0) == (h.users[userID] == 0) {
+		return
+	}
+	ids := make([]string, 0, len(h.users))
+	for id := range h.users {
+		ids = append(ids, id)
 	}
+	slices.Sort(ids)
+	h.connected.Store(&ids)
 }
 
 func (h *Hub) Run() {
 	for {
 		select {
 		case client := <-h.Register:
-			h.mu.Lock()
 			h.clients[client] = true
-			h.mu.Unlock()
+			h.trackUser(client.UserID, 1)
 		case client := <-h.Unregister:
-			h.mu.Lock()
-			delete(h.clients, client)
-			close(client)
-			h.mu.Unlock()
+			if h.clients[client] {
+				delete(h.clients, client)
+				h.trackUser(client.UserID, -1)
+				close(client.Send)
+			}
 		case update := <-h.Broadcast:
-			h.mu.Lock()
+			for _, observe := range h.observers {
+				observe(update)
+			}
 			for client := range h.clients {
-				client <- update
+				select {
+				case client.Send <- update.visibleTo(client):
+				default:
+					// Cola llena: este cliente se pierde el mensaje
+				}
 			}
-			h.mu.Unlock()
 		}
 	}
-}
\ No newline at end of file
+}
+
+// visibleTo quita el recuento si el cliente no puede verlo
+func (u VoteUpdate) visibleTo(c *Client) VoteUpdate {
+	if u.audience == nil || u.audience(c) {
+		return u
+	}
+	u.NewCount = 0
+	u.ResultsHidden = true
+	return u
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+
+	"api_voty/internal/models"
+	"api_voty/internal/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type LoginHistoryRequest struct {
+	Limit int `query:"limit" minimum:"1" maximum:"100" default:"20"`
+}
+
+type LoginHistoryResponse struct {
+	Body struct {
+		Items []models.LoginAttemptResponse `json:"items"`
+	}
+}
+
+type UnlockUserRequest struct {
+	ID string `path:"id"`
+}
+
+// LoginHistory devuelve los últimos intentos de login en la cuenta del usuario
+func (a *UserAPI) LoginHistory(ctx context.Context, input *LoginHistoryRequest) (*LoginHistoryResponse, error) {
+	items, err := a.userModel.LoginHistory(ctx, utils.GetUserIDFromContext(ctx), input.Limit)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Error fetching login history", err)
+	}
+	resp := &LoginHistoryResponse{}
+	resp.Body.Items = items
+	return resp, nil
+}
+
+// UnlockUser quita a mano el bloqueo por intentos fallidos (solo administradores)
+func (a *UserAPI) UnlockUser(ctx context.Context, input *UnlockUserRequest) (*struct{}, error) {
+	if err := a.requireAdmin(ctx, "Only an administrator can unlock accounts"); err != nil {
+		return nil, err
+	}
+	if err := a.userModel.Unlock(ctx, input.ID); err != nil {
+		if err.Error() == "USER_NOT_FOUND" {
+			return nil, huma.Error404NotFound("User not found", err)
+		}
+		return nil, huma.Error500InternalServerError("Error unlocking user", err)
+	}
+	return nil, nil
+}
@@ -1,15 +1,27 @@
 package api
 
 import (
+	"context"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"api_voty/internal/pow"
+	"api_voty/internal/ratelimit"
 	"api_voty/internal/utils"
 
 	"github.com/danielgtaylor/huma/v2"
 )
 
-func AuthMiddleware(api huma.API) func(ctx huma.Context, next func(huma.Context)) {
+// SessionChecker comprueba que la sesión de un token no se ha revocado
+type SessionChecker interface {
+	CheckSession(ctx context.Context, userID string, version int) error
+}
+
+// AuthMiddleware exige un token de sesión válido y deja el usuario en el
+// contexto. Con sessions comprueba además que la sesión no se ha revocado.
+func AuthMiddleware(api huma.API, sessions SessionChecker) func(ctx huma.Context, next func(huma.Context)) {
 	return func(ctx huma.Context, next func(huma.Context)) {
 		authHeader := ctx.Header("Authorization")
 		if authHeader == "" {
@@ -28,6 +40,16 @@ func AuthMiddleware(api huma.API) func(ctx huma.Context, next func(huma.Context)
 			huma.WriteErr(api, ctx, http.StatusUnauthorized, "Invalid or expired token")
 			return
 		}
+		if sessions != nil {
+			if err := sessions.CheckSession(ctx.Context(), claims.UserID, claims.Version); err != nil {
+				if err.Error() == "SESSION_REVOKED" {
+					huma.WriteErr(api, ctx, http.StatusUnauthorized, "Session revoked, log in again")
+				} else {
+					huma.WriteErr(api, ctx, http.StatusInternalServerError, "Error checking session", err)
+				}
+				return
+			}
+		}
 
 		reqCtx := ctx.Context()
 		newCtx := utils.SetUserInContext(reqCtx, claims.UserID, claims.Email)
@@ -36,3 +58,100 @@ func AuthMiddleware(api huma.API) func(ctx huma.Context, next func(huma.Context)
 		next(newHumaCtx)
 	}
 }
+
+// GuestMiddleware admite solo tokens de invitado (POST /guest/token) y deja el
+// guest ID en el contexto
+func GuestMiddleware(api huma.API) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		parts := strings.Split(ctx.Header("Authorization"), " ")
+		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+			huma.WriteErr(api, ctx, http.StatusUnauthorized, "Guest token required")
+			return
+		}
+
+		guestID, err := utils.ValidateGuestToken(parts[1])
+		if err != nil {
+			huma.WriteErr(api, ctx, http.StatusUnauthorized, "Invalid or expired guest token")
+			return
+		}
+
+		next(huma.WithContext(ctx, utils.SetGuestInContext(ctx.Context(), guestID)))
+	}
+}
+
+// ChallengeMiddleware exige un reto de prueba de trabajo resuelto en las
+// cabeceras X-PoW-Challenge y X-PoW-Solution. Con issuer nil no pide nada.
+func ChallengeMiddleware(api huma.API, issuer *pow.Issuer, scope string) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		if issuer == nil {
+			next(ctx)
+			return
+		}
+		ip := utils.ClientIP(ctx.RemoteAddr(), ctx.Header("X-Forwarded-For"))
+		err := issuer.Verify(ctx.Context(), scope, ip, ctx.Header(pow.ChallengeHeader), ctx.Header(pow.SolutionHeader))
+		if err != nil {
+			ctx.SetHeader("X-PoW-Difficulty", strconv.Itoa(issuer.Difficulty(scope, ip)))
+			huma.WriteErr(api, ctx, http.StatusPreconditionRequired, "Proof of work required: get one at /challenge?scope="+scope, err)
+			return
+		}
+		next(ctx)
+	}
+}
+
+// RateLimitMiddleware aplica límites de peticiones por IP y por usuario a la
+// operación. Las reglas por usuario necesitan ir detrás de AuthMiddleware o
+// GuestMiddleware. Con limiter nil no limita nada.
+func RateLimitMiddleware(api huma.API, limiter *ratelimit.Limiter, rules ...ratelimit.Rule) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		if limiter == nil {
+			next(ctx)
+			return
+		}
+		subject := ratelimit.Subject{
+			Route:  ctx.Operation().OperationID,
+			IP:     utils.ClientIP(ctx.RemoteAddr(), ctx.Header("X-Forwarded-For")),
+			UserID: requester(ctx.Context()),
+		}
+		r, applied := limiter.Check(ctx.Context(), subject, rules)
+		if !applied {
+			next(ctx)
+			return
+		}
+		for k, v := range rateLimitHeaders(r) {
+			ctx.SetHeader(k, v)
+		}
+		if !r.Allowed {
+			huma.WriteErr(api, ctx, http.StatusTooManyRequests, "Too many requests, retry later")
+			return
+		}
+		next(ctx)
+	}
+}
+
+// requester es el usuario o el invitado de la petición, para los límites por usuario
+func requester(ctx context.Context) string {
+	if id := utils.GetUserIDFromContext(ctx); id != "" {
+		return id
+	}
+	if id := utils.GetGuestIDFromContext(ctx); id != "" {
+		return "guest:" + id
+	}
+	return ""
+}
+
+// rateLimitHeaders son las cabeceras RateLimit-* (y Retry-After al rechazar)
+func rateLimitHeaders(r ratelimit.Result) map[string]string {
+	h := map[string]string{
+		"RateLimit-Limit":     strconv.Itoa(r.Limit),
+		"RateLimit-Remaining": strconv.Itoa(r.Remaining),
+		"RateLimit-Reset":     strconv.Itoa(ceilSeconds(r.Reset)),
+	}
+	if !r.Allowed {
+		h["Retry-After"] = strconv.Itoa(max(ceilSeconds(r.RetryAfter), 1))
+	}
+	return h
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
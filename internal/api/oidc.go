@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"api_voty/internal/models"
+	"api_voty/internal/oidc"
+	"api_voty/internal/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// oidcCookie guarda el login en curso entre el inicio y el callback
+const oidcCookie = "voty_oidc"
+
+type OIDCProvidersResponse struct {
+	Body struct {
+		Providers []string `json:"providers"`
+	}
+}
+
+type OIDCStartRequest struct {
+	Provider string `path:"provider" example:"google"`
+}
+
+type OIDCStartResponse struct {
+	Status    int
+	Location  string      `header:"Location"`
+	SetCookie http.Cookie `header:"Set-Cookie"`
+}
+
+type OIDCCallbackRequest struct {
+	Provider string `path:"provider" example:"google"`
+	Code     string `query:"code"`
+	State    string `query:"state"`
+	// Si el usuario cancela, el proveedor vuelve con error en vez de code
+	Error string `query:"error"`
+	Flow  string `cookie:"voty_oidc"`
+	meta  models.LoginMeta
+}
+
+// Resolve guarda la IP y el user agent para el historial de logins (huma.Resolver)
+func (r *OIDCCallbackRequest) Resolve(ctx huma.Context) []error {
+	r.meta = models.LoginMeta{
+		IP:        utils.ClientIP(ctx.RemoteAddr(), ctx.Header("X-Forwarded-For")),
+		UserAgent: ctx.Header("User-Agent"),
+	}
+	return nil
+}
+
+type OIDCCallbackResponse struct {
+	SetCookie http.Cookie `header:"Set-Cookie"`
+	Body      models.AuthResponse
+}
+
+type LinkedIdentitiesResponse struct {
+	Body struct {
+		Items []models.LinkedIdentityResponse `json:"items"`
+	}
+}
+
+type UnlinkIdentityRequest struct {
+	ID int `path:"id"`
+}
+
+func (a *AuthAPI) ListOIDCProviders(ctx context.Context, input *struct{}) (*OIDCProvidersResponse, error) {
+	resp := &OIDCProvidersResponse{}
+	resp.Body.Providers = []string{}
+	for name := range a.OIDC {
+		resp.Body.Providers = append(resp.Body.Providers, name)
+	}
+	slices.Sort(resp.Body.Providers)
+	return resp, nil
+}
+
+// flowCookie es la cookie del login en curso; con maxAge negativo la borra.
+// Solo viaja por HTTPS si el callback es HTTPS.
+func flowCookie(p *oidc.Provider, value string, maxAge int) http.Cookie {
+	return http.Cookie{
+		Name:     oidcCookie,
+		Value:    value,
+		Path:     "/auth/oidc/" + p.Name(),
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(p.RedirectURL(), "https://"),
+		// Lax: el navegador la manda en la redirección de vuelta del proveedor
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// StartOIDC manda al usuario al proveedor con un state, un nonce y un reto
+// PKCE nuevos
+func (a *AuthAPI) StartOIDC(ctx context.Context, input *OIDCStartRequest) (*OIDCStartResponse, error) {
+	p, ok := a.OIDC[input.Provider]
+	if !ok {
+		return nil, huma.Error404NotFound("Unknown identity provider")
+	}
+	flow, err := oidc.NewFlow(p.Name(), time.Now())
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Error starting login", err)
+	}
+	target, err := p.AuthCodeURL(ctx, flow.State, flow.Nonce, flow.Verifier)
+	if err != nil {
+		return nil, huma.Error502BadGateway("Identity provider unavailable", err)
+	}
+	sealed, err := flow.Seal(a.OIDCKey)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Error starting login", err)
+	}
+	return &OIDCStartResponse{
+		Status:    http.StatusFound,
+		Location:  target,
+		SetCookie: flowCookie(p, sealed, int(oidc.FlowTTL/time.Second)),
+	}, nil
+}
+
+// OIDCCallback recibe al usuario de vuelta, canjea el código y abre sesión
+// como /login (incluido el segundo factor si lo tiene activado)
+func (a *AuthAPI) OIDCCallback(ctx context.Context, input *OIDCCallbackRequest) (*OIDCCallbackResponse, error) {
+	p, ok := a.OIDC[input.Provider]
+	if !ok {
+		return nil, huma.Error404NotFound("Unknown identity provider")
+	}
+	if input.Error != "" {
+		return nil, huma.Error401Unauthorized("Login cancelled at the identity provider: " + input.Error)
+	}
+	flow, err := oidc.OpenFlow(a.OIDCKey, input.Flow, p.Name(), input.State, time.Now())
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid or expired login, start again", err)
+	}
+	identity, err := p.Exchange(ctx, input.Code, flow.Verifier, flow.Nonce)
+	if errors.Is(err, oidc.ErrIDToken) || errors.Is(err, oidc.ErrGrant) {
+		return nil, huma.Error401Unauthorized("Login failed", err)
+	}
+	if err != nil {
+		return nil, huma.Error502BadGateway("Identity provider unavailable", err)
+	}
+
+	result, err := a.authModel.LoginWithIdentity(ctx, *identity, input.meta)
+	if err != nil {
+		if err.Error() == "IDENTITY_EMAIL_NOT_VERIFIED" {
+			return nil, huma.Error403Forbidden("The identity provider has not verified your email", err)
+		}
+		return nil, huma.Error401Unauthorized("Login failed", err)
+	}
+	return &OIDCCallbackResponse{
+		SetCookie: flowCookie(p, "", -1),
+		Body:      *result,
+	}, nil
+}
+
+func (a *UserAPI) ListLinkedIdentities(ctx context.Context, input *struct{}) (*LinkedIdentitiesResponse, error) {
+	items, err := a.userModel.LinkedIdentities(ctx, utils.GetUserIDFromContext(ctx))
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Error fetching linked identities", err)
+	}
+	resp := &LinkedIdentitiesResponse{}
+	resp.Body.Items = items
+	return resp, nil
+}
+
+func (a *UserAPI) UnlinkIdentity(ctx context.Context, input *UnlinkIdentityRequest) (*struct{}, error) {
+	err := a.userModel.UnlinkIdentity(ctx, utils.GetUserIDFromContext(ctx), input.ID)
+	if err != nil {
+		switch err.Error() {
+		case "IDENTITY_NOT_FOUND":
+			return nil, huma.Error404NotFound("Linked identity not found", err)
+		case "LAST_LOGIN_METHOD":
+			return nil, huma.Error409Conflict("Set a password before unlinking your last identity provider", err)
+		}
+		return nil, huma.Error500InternalServerError("Error unlinking identity", err)
+	}
+	return nil, nil
+}
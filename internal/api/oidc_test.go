@@ -0,0 +1,324 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/ent/enttest"
+	"api_voty/ent/linkedidentity"
+	"api_voty/internal/models"
+	"api_voty/internal/oidc"
+	"api_voty/internal/oidc/mock"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// oidcEnv es la API con un proveedor mock, cada uno en su servidor httptest
+type oidcEnv struct {
+	client   *ent.Client
+	provider *mock.Provider
+	app      *httptest.Server
+	// http no sigue redirecciones: cada salto del flujo se hace a mano
+	http *http.Client
+}
+
+func newOIDCEnv(t *testing.T, users ...mock.User) *oidcEnv {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+
+	var idp *mock.Provider
+	idpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idp.ServeHTTP(w, r)
+	}))
+	t.Cleanup(idpServer.Close)
+	idp, err := mock.New(idpServer.URL, users...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp.ClientID = "voty-test"
+
+	mux := http.NewServeMux()
+	app := httptest.NewServer(mux)
+	t.Cleanup(app.Close)
+
+	authAPI := NewAuthAPI(models.NewAuthModel(client, nil), models.NewUserModel(client, nil))
+	authAPI.OIDCKey = []byte("0123456789abcdef0123456789abcdef")
+	authAPI.OIDC = map[string]*oidc.Provider{
+		"mock": oidc.NewProvider(oidc.Config{
+			Name:        "mock",
+			Issuer:      idpServer.URL,
+			ClientID:    "voty-test",
+			RedirectURL: app.URL + "/auth/oidc/mock/callback",
+		}, idpServer.Client()),
+	}
+	SetupRoutes(mux, &UserAPI{}, authAPI)
+
+	return &oidcEnv{
+		client:   client,
+		provider: idp,
+		app:      app,
+		http: &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}},
+	}
+}
+
+// start abre el login en la API y devuelve la URL de autorización del
+// proveedor y la cookie con el flujo
+func (e *oidcEnv) start(t *testing.T) (*url.URL, *http.Cookie) {
+	t.Helper()
+	resp, err := e.http.Get(e.app.URL + "/auth/oidc/mock/start")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("start: status %d, want 302", resp.StatusCode)
+	}
+	var flow *http.Cookie
+	for _, c := range resp.Cookies() {
+		if c.Name == oidcCookie {
+			flow = c
+		}
+	}
+	if flow == nil || !flow.HttpOnly {
+		t.Fatalf("start: missing HttpOnly %s cookie", oidcCookie)
+	}
+	target, err := resp.Location()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return target, flow
+}
+
+// authorize pasa por el proveedor y devuelve la URL de callback con code y state
+func (e *oidcEnv) authorize(t *testing.T, target *url.URL, loginHint string) *url.URL {
+	t.Helper()
+	q := target.Query()
+	q.Set("login_hint", loginHint)
+	target.RawQuery = q.Encode()
+	resp, err := e.http.Get(target.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize: status %d, want 302", resp.StatusCode)
+	}
+	callback, err := resp.Location()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return callback
+}
+
+// callback vuelve a la API con la cookie del flujo y decodifica la respuesta
+func (e *oidcEnv) callback(t *testing.T, callback *url.URL, flow *http.Cookie) (int, models.AuthResponse) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, callback.String(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.AddCookie(flow)
+	resp, err := e.http.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var body models.AuthResponse
+	if resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return resp.StatusCode, body
+}
+
+// login recorre el flujo completo con la cuenta del proveedor de ese email
+func (e *oidcEnv) login(t *testing.T, email string) (int, models.AuthResponse) {
+	t.Helper()
+	target, flow := e.start(t)
+	return e.callback(t, e.authorize(t, target, email), flow)
+}
+
+func setQuery(u *url.URL, key, value string) {
+	q := u.Query()
+	q.Set(key, value)
+	u.RawQuery = q.Encode()
+}
+
+func TestOIDCLoginCreatesAccount(t *testing.T) {
+	env := newOIDCEnv(t, mock.User{Subject: "sub-ana", Email: "ana@example.com", EmailVerified: true, Name: "Ana"})
+
+	status, res := env.login(t, "ana@example.com")
+	if status != http.StatusOK {
+		t.Fatalf("callback: status %d, want 200", status)
+	}
+	if res.Token == "" || res.User == nil || res.User.Email != "ana@example.com" {
+		t.Fatalf("callback: unexpected response %+v", res)
+	}
+
+	// El segundo login entra en la misma cuenta por la identidad vinculada
+	status, again := env.login(t, "ana@example.com")
+	if status != http.StatusOK || again.User == nil || again.User.ID != res.User.ID {
+		t.Fatalf("second login: status %d, response %+v", status, again)
+	}
+	if n := env.client.LinkedIdentity.Query().CountX(context.Background()); n != 1 {
+		t.Errorf("linked identities = %d, want 1", n)
+	}
+}
+
+func TestOIDCStateMismatch(t *testing.T) {
+	env := newOIDCEnv(t)
+	target, flow := env.start(t)
+	callback := env.authorize(t, target, "")
+	setQuery(callback, "state", "not-the-state")
+
+	if status, _ := env.callback(t, callback, flow); status != http.StatusBadRequest {
+		t.Errorf("callback with another state: status %d, want 400", status)
+	}
+}
+
+func TestOIDCFlowCookieFromAnotherLogin(t *testing.T) {
+	env := newOIDCEnv(t)
+	target, _ := env.start(t)
+	_, otherFlow := env.start(t)
+	callback := env.authorize(t, target, "")
+
+	// La cookie de otro login no casa con el state del proveedor
+	if status, _ := env.callback(t, callback, otherFlow); status != http.StatusBadRequest {
+		t.Errorf("callback with another login's cookie: status %d, want 400", status)
+	}
+}
+
+func TestOIDCPKCEVerifierMismatch(t *testing.T) {
+	env := newOIDCEnv(t)
+	target, flow := env.start(t)
+	// El proveedor guarda un reto que no corresponde al verificador de la cookie
+	setQuery(target, "code_challenge", oidc.S256Challenge("another-verifier-0123456789-0123456789-abcdef"))
+	callback := env.authorize(t, target, "")
+
+	if status, _ := env.callback(t, callback, flow); status != http.StatusUnauthorized {
+		t.Errorf("callback with a mismatched PKCE verifier: status %d, want 401", status)
+	}
+}
+
+func TestOIDCNonceMismatch(t *testing.T) {
+	env := newOIDCEnv(t)
+	target, flow := env.start(t)
+	// El ID token llevará un nonce distinto del guardado en la cookie
+	setQuery(target, "nonce", "another-nonce")
+	callback := env.authorize(t, target, "")
+
+	if status, _ := env.callback(t, callback, flow); status != http.StatusUnauthorized {
+		t.Errorf("callback with a mismatched nonce: status %d, want 401", status)
+	}
+}
+
+func TestOIDCCodeReplay(t *testing.T) {
+	env := newOIDCEnv(t)
+	target, flow := env.start(t)
+	callback := env.authorize(t, target, "")
+
+	if status, _ := env.callback(t, callback, flow); status != http.StatusOK {
+		t.Fatalf("first callback: status %d, want 200", status)
+	}
+	if status, _ := env.callback(t, callback, flow); status != http.StatusUnauthorized {
+		t.Errorf("replayed callback: status %d, want 401", status)
+	}
+}
+
+func TestOIDCLinksByVerifiedEmail(t *testing.T) {
+	env := newOIDCEnv(t, mock.User{Subject: "sub-bea", Email: "bea@example.com", EmailVerified: true})
+	ctx := context.Background()
+
+	// Cuenta previa con contraseña, segundo factor y email sin verificar: el
+	// proveedor demuestra quién es el dueño, así que la cuenta se vincula y
+	// pierde lo que dejó configurado quien la registró
+	existing := env.client.User.Create().
+		SetEmail("Bea@example.com").
+		SetName("Bea").
+		SetPassword("$2a$10$previous-password-hash").
+		SetTotpSecret("JBSWY3DPEHPK3PXP").
+		SetTotpEnabledAt(time.Now().Add(-time.Hour)).
+		SetRecoveryCodes([]string{"recovery-code-hash"}).
+		SetEmbedOrigins([]string{"https://squatter.example"}).
+		SaveX(ctx)
+
+	status, res := env.login(t, "bea@example.com")
+	if status != http.StatusOK {
+		t.Fatalf("callback: status %d, want 200", status)
+	}
+	if res.User == nil || res.User.ID != existing.ID {
+		t.Fatalf("callback: logged into %+v, want the existing account %s", res.User, existing.ID)
+	}
+
+	link := env.client.LinkedIdentity.Query().
+		Where(linkedidentity.Provider("mock"), linkedidentity.Subject("sub-bea")).
+		OnlyX(ctx)
+	if link.UserID != existing.ID {
+		t.Errorf("identity linked to %s, want %s", link.UserID, existing.ID)
+	}
+	u := env.client.User.GetX(ctx, existing.ID)
+	if u.EmailVerifiedAt == nil || u.Password != "" || u.TokenVersion != existing.TokenVersion+1 {
+		t.Errorf("unverified account not taken over: verified=%v password=%q version=%d",
+			u.EmailVerifiedAt, u.Password, u.TokenVersion)
+	}
+	if u.TotpSecret != nil || u.TotpEnabledAt != nil || len(u.RecoveryCodes) != 0 || len(u.EmbedOrigins) != 0 {
+		t.Errorf("unverified account kept the previous settings: totp=%v enabled=%v recovery=%v origins=%v",
+			u.TotpSecret != nil, u.TotpEnabledAt, u.RecoveryCodes, u.EmbedOrigins)
+	}
+}
+
+func TestOIDCKeepsVerifiedAccountPassword(t *testing.T) {
+	env := newOIDCEnv(t, mock.User{Subject: "sub-eva", Email: "eva@example.com", EmailVerified: true})
+	ctx := context.Background()
+
+	verifiedAt := time.Now().Add(-time.Hour)
+	existing := env.client.User.Create().
+		SetEmail("eva@example.com").
+		SetName("Eva").
+		SetPassword("$2a$10$eva-password-hash").
+		SetEmailVerifiedAt(verifiedAt).
+		SaveX(ctx)
+
+	status, res := env.login(t, "eva@example.com")
+	if status != http.StatusOK || res.User == nil || res.User.ID != existing.ID {
+		t.Fatalf("callback: status %d, response %+v", status, res)
+	}
+	if u := env.client.User.GetX(ctx, existing.ID); u.Password != existing.Password || u.TokenVersion != existing.TokenVersion {
+		t.Error("linking to a verified account must keep its password and sessions")
+	}
+}
+
+func TestOIDCRejectsUnverifiedEmail(t *testing.T) {
+	env := newOIDCEnv(t, mock.User{Subject: "sub-leo", Email: "leo@example.com", EmailVerified: false})
+	ctx := context.Background()
+
+	existing := env.client.User.Create().
+		SetEmail("leo@example.com").
+		SetName("Leo").
+		SetPassword("$2a$10$leo-password-hash").
+		SaveX(ctx)
+
+	// IDENTITY_EMAIL_NOT_VERIFIED: ni se vincula ni se crea cuenta
+	if status, _ := env.login(t, "leo@example.com"); status != http.StatusForbidden {
+		t.Fatalf("callback: status %d, want 403", status)
+	}
+	if n := env.client.LinkedIdentity.Query().CountX(ctx); n != 0 {
+		t.Errorf("linked identities = %d, want 0", n)
+	}
+	if n := env.client.User.Query().CountX(ctx); n != 1 {
+		t.Errorf("users = %d, want 1", n)
+	}
+	if u := env.client.User.GetX(ctx, existing.ID); u.Password != existing.Password {
+		t.Error("an unverified identity must not touch the existing account")
+	}
+}
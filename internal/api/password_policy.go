@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"api_voty/internal/password"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type PasswordPolicyResponse struct {
+	Body struct {
+		MinLength  int      `json:"min_length"`
+		MaxLength  int      `json:"max_length" doc:"In bytes"`
+		MinEntropy float64  `json:"min_entropy" doc:"Minimum estimated entropy in bits"`
+		Breached   bool     `json:"breached_check" doc:"Whether passwords found in data breaches are rejected"`
+		Rules      []string `json:"rules" doc:"Rule codes that can appear in validation errors"`
+	}
+}
+
+// GetPasswordPolicy expone las reglas para que la app las valide antes de enviar
+func (a *AuthAPI) GetPasswordPolicy(ctx context.Context, input *struct{}) (*PasswordPolicyResponse, error) {
+	p := a.authModel.PasswordPolicy()
+	resp := &PasswordPolicyResponse{}
+	resp.Body.MinLength = p.MinLength
+	resp.Body.MaxLength = p.MaxLength
+	resp.Body.MinEntropy = p.MinEntropy
+	resp.Body.Breached = p.Breached != nil
+	resp.Body.Rules = []string{password.RuleMinLength, password.RuleMaxLength, password.RuleEntropy, password.RulePersonal}
+	if resp.Body.Breached {
+		resp.Body.Rules = append(resp.Body.Rules, password.RuleBreached)
+	}
+	return resp, nil
+}
+
+// passwordPolicyError traduce un *password.PolicyError en un 422 con una
+// entrada por regla incumplida (el código de la regla va en value); nil si
+// err es de otro tipo
+func passwordPolicyError(err error) error {
+	var policyErr *password.PolicyError
+	if !errors.As(err, &policyErr) {
+		return nil
+	}
+	details := make([]error, len(policyErr.Violations))
+	for i, v := range policyErr.Violations {
+		details[i] = &huma.ErrorDetail{
+			Message:  "password " + v.Message,
+			Location: "body.password",
+			Value:    v.Rule,
+		}
+	}
+	return huma.Error422UnprocessableEntity("Password does not meet the policy", details...)
+}
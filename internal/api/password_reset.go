@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+
+	"api_voty/internal/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type ForgotPasswordRequest struct {
+	Body struct {
+		Email string `json:"email" format:"email" doc:"Email de la cuenta"`
+	}
+	ip string
+}
+
+// Resolve guarda la IP desde la que se pide el enlace (huma.Resolver)
+func (r *ForgotPasswordRequest) Resolve(ctx huma.Context) []error {
+	r.ip = utils.ClientIP(ctx.RemoteAddr(), ctx.Header("X-Forwarded-For"))
+	return nil
+}
+
+type ResetPasswordRequest struct {
+	Body struct {
+		Token    string `json:"token" doc:"Token del enlace enviado por correo"`
+		Password string `json:"password" doc:"Contraseña nueva"`
+	}
+}
+
+type PasswordResetResponse struct {
+	Body struct {
+		Message string `json:"message"`
+	}
+}
+
+// ForgotPassword responde siempre lo mismo, exista o no el email
+func (a *AuthAPI) ForgotPassword(ctx context.Context, input *ForgotPasswordRequest) (*PasswordResetResponse, error) {
+	if err := a.authModel.ForgotPassword(ctx, input.Body.Email, input.ip); err != nil {
+		if err.Error() == "MAILER_NOT_CONFIGURED" {
+			return nil, huma.Error503ServiceUnavailable("Password recovery is not available", err)
+		}
+		return nil, huma.Error500InternalServerError("Error requesting password reset", err)
+	}
+	resp := &PasswordResetResponse{}
+	resp.Body.Message = "If the email belongs to an account, a reset link has been sent"
+	return resp, nil
+}
+
+// ResetPassword fija la contraseña nueva y cierra todas las sesiones
+func (a *AuthAPI) ResetPassword(ctx context.Context, input *ResetPasswordRequest) (*PasswordResetResponse, error) {
+	if err := a.authModel.ResetPassword(ctx, input.Body.Token, input.Body.Password); err != nil {
+		if policyErr := passwordPolicyError(err); policyErr != nil {
+			return nil, policyErr
+		}
+		if err.Error() == "INVALID_RESET_TOKEN" {
+			return nil, huma.Error400BadRequest("Invalid or expired reset link", err)
+		}
+		return nil, huma.Error500InternalServerError("Error resetting password", err)
+	}
+	resp := &PasswordResetResponse{}
+	resp.Body.Message = "Password changed, log in again"
+	return resp, nil
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"api_voty/internal/models"
+	"api_voty/internal/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type PollAnalyticsRequest struct {
+	ID     string `path:"id" doc:"ID de la encuesta"`
+	Bucket string `query:"bucket" enum:"minute,hour,day" default:"hour" doc:"Tamaño de cada intervalo (UTC)"`
+}
+
+type PollAnalyticsResponse struct {
+	Body *models.PollAnalytics
+}
+
+// GetPollAnalytics devuelve la evolución de los votos. Como deja deducir los
+// recuentos, solo la ve quien puede ver los resultados de la encuesta.
+func (a *UserAPI) GetPollAnalytics(ctx context.Context, input *PollAnalyticsRequest) (*PollAnalyticsResponse, error) {
+	pollID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, huma.Error400BadRequest("ID de encuesta inválido", err)
+	}
+	userID := utils.GetUserIDFromContext(ctx)
+	p, err := a.pollModel.GetByIDWithUserStatus(ctx, pollID, userID)
+	if err != nil {
+		return nil, huma.Error404NotFound("Encuesta no encontrada", err)
+	}
+	viewer := a.userModel.Viewer(ctx, userID)
+	if !models.ResultsVisible(p, viewer, len(p.Edges.Votes) > 0, time.Now()) {
+		return nil, huma.Error403Forbidden("Los resultados de esta encuesta aún no son visibles")
+	}
+
+	analytics, err := a.analyticsModel.PollAnalytics(ctx, p, input.Bucket)
+	if err != nil {
+		switch err.Error() {
+		case "TOO_MANY_BUCKETS":
+			return nil, huma.Error400BadRequest("Demasiados intervalos: usa un bucket mayor", err)
+		case "INVALID_BUCKET":
+			return nil, huma.Error400BadRequest("Intervalo inválido", err)
+		}
+		return nil, huma.Error500InternalServerError("Error al calcular las analíticas", err)
+	}
+	return &PollAnalyticsResponse{Body: analytics}, nil
+}
@@ -1,8 +1,14 @@
 package api
 
 import (
+	"api_voty/ent/poll"
+	"api_voty/internal/models"
 	"api_voty/internal/utils"
 	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 )
@@ -16,18 +22,79 @@ func (a *UserAPI) PostVote(ctx context.Context, input *VoteInput) (*struct{}, er
 	// Obtenemos el ID del usuario desde el JWT (Context)
 	userID := utils.GetUserIDFromContext(ctx)
 
-	newCount, err := a.pollModel.CastVote(ctx, input.PollID, input.OptionID, userID)
-	if err != nil {
+	if _, err := a.castVote(ctx, input.PollID, input.OptionID, models.UserVoter(userID)); err != nil {
+		if err.Error() == "EMAIL_NOT_VERIFIED" {
+			return nil, huma.Error403Forbidden("Verifica tu email para poder votar", err)
+		}
 		// Retornamos 403 para que el móvil sepa que debe revertir su estado local
 		return nil, huma.Error403Forbidden("Voto rechazado", err)
 	}
+	return nil, nil
+}
 
-	// Si todo salió bien, enviamos el broadcast por el Hub
+// castVote registra el voto (de usuario o de invitado) y lo difunde por el Hub.
+// Lo usan la API y el widget.
+func (a *UserAPI) castVote(ctx context.Context, pollIDStr, optionIDStr string, voter models.Voter) (*models.VoteResult, error) {
+	if a.RequireVerifiedEmail && !voter.IsGuest() {
+		verified, err := a.userModel.EmailVerified(ctx, voter.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if !verified {
+			return nil, errors.New("EMAIL_NOT_VERIFIED")
+		}
+	}
+	result, err := a.pollModel.CastVote(ctx, pollIDStr, optionIDStr, voter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Si todo salió bien, enviamos el broadcast por el Hub,
+	// ocultando el recuento a quien la política no deje verlo
 	a.Hub.Broadcast <- VoteUpdate{
-		PollID:   input.PollID,
-		OptionID: input.OptionID,
-		NewCount: newCount,
+		Type:     EventVote,
+		PollID:   pollIDStr,
+		OptionID: optionIDStr,
+		NewCount: result.NewCount,
+		audience: a.resultsAudience(ctx, pollIDStr),
 	}
 
-	return nil, nil
+	// El voto pudo cumplir una regla de cierre automático
+	if result.ClosedReason != "" {
+		isOpen := false
+		a.Hub.Broadcast <- VoteUpdate{
+			Type:         EventPollClosed,
+			PollID:       pollIDStr,
+			IsOpen:       &isOpen,
+			ClosedReason: result.ClosedReason,
+		}
+	}
+	return result, nil
+}
+
+// resultsAudience calcula quién puede ver el recuento de un voto por el socket
+func (a *UserAPI) resultsAudience(ctx context.Context, pollIDStr string) func(c *Client) bool {
+	pollID, _ := strconv.Atoi(pollIDStr)
+	p, err := a.pollModel.Get(ctx, pollID)
+	if err != nil {
+		// Ante la duda no mostramos recuentos
+		log.Printf("error cargando la encuesta %d: %v", pollID, err)
+		return func(c *Client) bool { return c.IsAdmin }
+	}
+	if models.ResultsPublic(p, time.Now()) {
+		return nil
+	}
+
+	// Con after_vote solo importan los votantes que están conectados ahora
+	voters := map[string]bool{}
+	if p.ResultsVisibility == poll.ResultsVisibilityAfterVote {
+		voters, err = a.pollModel.VotersAmong(ctx, pollID, a.Hub.ConnectedUserIDs())
+		if err != nil {
+			log.Printf("error cargando votantes de la encuesta %d: %v", pollID, err)
+		}
+	}
+	return func(c *Client) bool {
+		return c.IsAdmin || voters[c.UserID] ||
+			models.Viewer{UserID: c.UserID}.IsOwner(p)
+	}
 }
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/internal/charts"
+	"api_voty/internal/models"
+	"api_voty/internal/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type PollChartRequest struct {
+	ID     string `path:"id" doc:"ID de la encuesta"`
+	Format string `query:"format" enum:"svg,png" default:"svg"`
+	Kind   string `query:"kind" enum:"bar,pie" default:"bar"`
+	Theme  string `query:"theme" enum:"light,dark" default:"light"`
+	Width  int    `query:"width" minimum:"200" maximum:"1600" default:"800"`
+	Height int    `query:"height" minimum:"150" maximum:"1200" default:"450"`
+}
+
+type PollChartResponse struct {
+	ContentType  string `header:"Content-Type"`
+	CacheControl string `header:"Cache-Control"`
+	Body         []byte
+}
+
+// GetPollChart dibuja los resultados actuales. Los gráficos se guardan en caché
+// hasta que el Hub ve un cambio en la encuesta (ver InvalidateChartsOn).
+func (a *UserAPI) GetPollChart(ctx context.Context, input *PollChartRequest) (*PollChartResponse, error) {
+	pollID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, huma.Error400BadRequest("ID de encuesta inválido", err)
+	}
+	userID := utils.GetUserIDFromContext(ctx)
+	p, err := a.pollModel.GetByIDWithUserStatus(ctx, pollID, userID)
+	if err != nil {
+		return nil, huma.Error404NotFound("Encuesta no encontrada", err)
+	}
+	viewer := a.userModel.Viewer(ctx, userID)
+	if !models.ResultsVisible(p, viewer, len(p.Edges.Votes) > 0, time.Now()) {
+		return nil, huma.Error403Forbidden("Los resultados de esta encuesta aún no son visibles")
+	}
+
+	opts := charts.Options{Kind: input.Kind, Theme: input.Theme, Width: input.Width, Height: input.Height}
+	data, err := a.renderChart(p, opts, input.Format)
+	if err != nil {
+		return nil, huma.Error400BadRequest("No se pudo dibujar el gráfico", err)
+	}
+	return &PollChartResponse{
+		ContentType:  charts.ContentType(input.Format),
+		CacheControl: "private, max-age=15",
+		Body:         data,
+	}, nil
+}
+
+// renderChart dibuja (o recupera de la caché) el gráfico de una encuesta con
+// las opciones cargadas. No comprueba la visibilidad de los resultados.
+func (a *UserAPI) renderChart(p *ent.Poll, opts charts.Options, format string) ([]byte, error) {
+	key := charts.Key{PollID: p.ID, Format: format, Options: opts}
+	if data, ok := a.Charts.Get(key); ok {
+		return data, nil
+	}
+	data, err := charts.Render(chartData(p), opts, format)
+	if err != nil {
+		return nil, err
+	}
+	a.Charts.Put(key, data)
+	return data, nil
+}
+
+// chartData toma las opciones activas y las archivadas que recibieron votos
+func chartData(p *ent.Poll) charts.Data {
+	d := charts.Data{Title: p.Title}
+	for _, o := range p.Edges.Options {
+		if o.Archived && o.VotesCount == 0 {
+			continue
+		}
+		d.Labels = append(d.Labels, o.Text)
+		d.Values = append(d.Values, o.VotesCount)
+	}
+	return d
+}
+
+// InvalidateChartsOn descarta los gráficos de una encuesta cada vez que el Hub
+// difunde un cambio suyo (votos, aperturas, cierres, opciones)
+func InvalidateChartsOn(hub *Hub, cache *charts.Cache) {
+	hub.Observe(func(u VoteUpdate) {
+		if pollID, err := strconv.Atoi(u.PollID); err == nil {
+			cache.Invalidate(pollID)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"api_voty/internal/export"
+	"api_voty/internal/models"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type ExportPollRequest struct {
+	ID     string `path:"id" doc:"ID de la encuesta"`
+	Format string `query:"format" enum:"csv,jsonl,xlsx" default:"csv"`
+}
+
+// streamExport prepara las cabeceras de descarga y llama a rows con el Writer
+// del formato pedido. Una vez empezado el envío ya no se puede cambiar el
+// código de estado, así que los errores a mitad solo se registran.
+func streamExport(name, format string, columns []string, rows func(export.Writer) error) *huma.StreamResponse {
+	return &huma.StreamResponse{
+		Body: func(hctx huma.Context) {
+			hctx.SetHeader("Content-Type", export.ContentType(format))
+			hctx.SetHeader("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+
+			w, err := export.NewWriter(format, hctx.BodyWriter(), columns)
+			if err == nil {
+				err = rows(w)
+				if cerr := w.Close(); err == nil {
+					err = cerr
+				}
+			}
+			if err != nil {
+				log.Printf("error exportando %s: %v", name, err)
+			}
+		},
+	}
+}
+
+// ExportPollResults descarga el recuento por opción
+func (a *UserAPI) ExportPollResults(ctx context.Context, input *ExportPollRequest) (*huma.StreamResponse, error) {
+	p, err := a.ownedPoll(ctx, input.ID, "Solo el autor puede exportar la encuesta")
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0
+	for _, o := range p.Edges.Options {
+		total += o.VotesCount
+	}
+	columns := []string{"option_id", "option", "archived", "votes", "percent"}
+	return streamExport(fmt.Sprintf("poll-%d-results", p.ID), input.Format, columns, func(w export.Writer) error {
+		for _, o := range p.Edges.Options {
+			percent := 0.0
+			if total > 0 {
+				percent = float64(o.VotesCount) * 100 / float64(total)
+			}
+			if err := w.WriteRow(o.ID, o.Text, o.Archived, o.VotesCount, percent); err != nil {
+				return err
+			}
+		}
+		return nil
+	}), nil
+}
+
+// ExportPollBallots descarga cada voto con su votante, salvo en encuestas anónimas
+func (a *UserAPI) ExportPollBallots(ctx context.Context, input *ExportPollRequest) (*huma.StreamResponse, error) {
+	p, err := a.ownedPoll(ctx, input.ID, "Solo el autor puede exportar la encuesta")
+	if err != nil {
+		return nil, err
+	}
+	if p.Anonymous {
+		return nil, huma.Error403Forbidden("La encuesta es anónima: solo se pueden exportar los resultados")
+	}
+
+	columns := []string{"vote_id", "cast_at", "user_id", "user_name", "guest_id", "option_id", "option"}
+	// El contexto de la petición sigue vivo mientras se escribe el cuerpo
+	return streamExport(fmt.Sprintf("poll-%d-ballots", p.ID), input.Format, columns, func(w export.Writer) error {
+		return a.analyticsModel.StreamBallots(ctx, p.ID, func(b models.Ballot) error {
+			return w.WriteRow(b.VoteID, b.CastAt, b.UserID, b.UserName, b.GuestID, b.OptionID, b.OptionText)
+		})
+	}), nil
+}
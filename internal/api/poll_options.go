@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+
+	"api_voty/internal/models"
+	"api_voty/internal/utils"
+)
+
+type UpdatePollOptionsRequest struct {
+	ID   string `path:"id" doc:"ID de la encuesta"`
+	Body struct {
+		Operations []models.OptionOp `json:"operations" minItems:"1" doc:"Se aplican en orden y de forma atómica"`
+		Force      bool              `json:"force,omitempty" doc:"Permite renombrar opciones que ya tienen votos"`
+	}
+}
+
+type UpdatePollOptionsResponse struct {
+	Body struct {
+		Poll    PollOutput            `json:"poll"`
+		Changes []models.OptionChange `json:"changes"`
+	}
+}
+
+func (a *UserAPI) UpdatePollOptions(ctx context.Context, input *UpdatePollOptionsRequest) (*UpdatePollOptionsResponse, error) {
+	owned, err := a.ownedPoll(ctx, input.ID, "Solo el autor puede editar las opciones")
+	if err != nil {
+		return nil, err
+	}
+	pollID := owned.ID
+
+	editorID := utils.GetUserIDFromContext(ctx)
+	changes, err := a.pollModel.ApplyOptionOps(ctx, pollID, input.Body.Operations, input.Body.Force, editorID)
+	if err != nil {
+		return nil, pollError("Error al editar las opciones", err)
+	}
+	a.notifyOptionChanges(input.ID, changes)
+
+	p, err := a.GetPoll(ctx, &GetPollRequest{ID: input.ID})
+	if err != nil {
+		return nil, err
+	}
+	resp := &UpdatePollOptionsResponse{}
+	resp.Body.Poll = p.Body
+	resp.Body.Changes = changes
+	if resp.Body.Changes == nil {
+		resp.Body.Changes = []models.OptionChange{}
+	}
+	return resp, nil
+}
+
+// notifyOptionChanges avisa por el socket de los cambios en las opciones
+func (a *UserAPI) notifyOptionChanges(pollID string, changes []models.OptionChange) {
+	if len(changes) == 0 {
+		return
+	}
+	a.Hub.Broadcast <- VoteUpdate{
+		Type:    EventOptionsChanged,
+		PollID:  pollID,
+		Changes: changes,
+	}
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/ent/schema"
+	"api_voty/internal/models"
+	"api_voty/internal/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type RevisionOutput struct {
+	Revision     int                     `json:"revision"`
+	Title        string                  `json:"title"`
+	Description  string                  `json:"description,omitempty"`
+	Options      []schema.OptionSnapshot `json:"options"`
+	Settings     schema.PollSettings     `json:"settings"`
+	AuthorID     string                  `json:"author_id,omitempty"`
+	RestoredFrom *int                    `json:"restored_from,omitempty"`
+	CreatedAt    time.Time               `json:"created_at"`
+	Changes      []models.RevisionChange `json:"changes" doc:"Diferencias con la revisión anterior"`
+}
+
+type ListPollRevisionsRequest struct {
+	ID string `path:"id" doc:"ID de la encuesta"`
+}
+
+type ListPollRevisionsResponse struct {
+	Body []RevisionOutput
+}
+
+type RestorePollRevisionRequest struct {
+	ID       string `path:"id" doc:"ID de la encuesta"`
+	Revision int    `path:"revision" doc:"Número de revisión a restaurar"`
+	Force    bool   `query:"force" doc:"Permite devolver su texto anterior a opciones que ya tienen votos"`
+}
+
+type RestorePollRevisionResponse struct {
+	Body RevisionOutput
+}
+
+func toRevisionOutput(prev, rev *ent.PollRevision) RevisionOutput {
+	out := RevisionOutput{
+		Revision:     rev.Revision,
+		Title:        rev.Title,
+		Description:  rev.Description,
+		Options:      rev.Options,
+		Settings:     rev.Settings,
+		RestoredFrom: rev.RestoredFrom,
+		CreatedAt:    rev.CreatedAt,
+		Changes:      models.DiffRevisions(prev, rev),
+	}
+	if rev.AuthorID != nil {
+		out.AuthorID = *rev.AuthorID
+	}
+	return out
+}
+
+func (a *UserAPI) ListPollRevisions(ctx context.Context, input *ListPollRevisionsRequest) (*ListPollRevisionsResponse, error) {
+	pollID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, huma.Error400BadRequest("ID de encuesta inválido", err)
+	}
+	// Los borradores solo los ve su autor
+	if _, err := a.pollModel.GetByIDWithUserStatus(ctx, pollID, utils.GetUserIDFromContext(ctx)); err != nil {
+		return nil, huma.Error404NotFound("Encuesta no encontrada", err)
+	}
+
+	revisions, err := a.pollModel.Revisions(ctx, pollID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Error al cargar el historial", err)
+	}
+
+	output := make([]RevisionOutput, len(revisions))
+	var prev *ent.PollRevision
+	for i, rev := range revisions {
+		output[i] = toRevisionOutput(prev, rev)
+		prev = rev
+	}
+	return &ListPollRevisionsResponse{Body: output}, nil
+}
+
+func (a *UserAPI) RestorePollRevision(ctx context.Context, input *RestorePollRevisionRequest) (*RestorePollRevisionResponse, error) {
+	pollID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, huma.Error400BadRequest("ID de encuesta inválido", err)
+	}
+	if err := a.requireAdmin(ctx, "Solo un administrador puede restaurar revisiones"); err != nil {
+		return nil, err
+	}
+	userID := utils.GetUserIDFromContext(ctx)
+
+	rev, changes, err := a.pollModel.RestoreRevision(ctx, pollID, input.Revision, input.Force, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, huma.Error404NotFound("Revisión no encontrada", err)
+		}
+		return nil, pollError("Error al restaurar la revisión", err)
+	}
+	a.Scheduler.Reschedule()
+	a.notifyOptionChanges(input.ID, changes)
+	a.Charts.Invalidate(pollID)
+
+	// La diferencia se calcula contra la revisión inmediatamente anterior
+	revisions, err := a.pollModel.Revisions(ctx, pollID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Error al cargar el historial", err)
+	}
+	var prev *ent.PollRevision
+	for _, r := range revisions {
+		if r.Revision == rev.Revision {
+			break
+		}
+		prev = r
+	}
+	return &RestorePollRevisionResponse{Body: toRevisionOutput(prev, rev)}, nil
+}
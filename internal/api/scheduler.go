@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"api_voty/ent/poll"
+	"api_voty/internal/models"
+)
+
+// maxSchedulerWait acota la espera entre revisiones, por si otra instancia
+// programó algo que este proceso no conoce.
+const maxSchedulerWait = time.Minute
+
+// PollScheduler abre y cierra encuestas según opens_at/closes_at
+// y publica los cambios de estado por el Hub.
+type PollScheduler struct {
+	pollModel *models.PollModel
+	hub       *Hub
+	wake      chan struct{}
+}
+
+func NewPollScheduler(pollModel *models.PollModel, hub *Hub) *PollScheduler {
+	return &PollScheduler{
+		pollModel: pollModel,
+		hub:       hub,
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Reschedule avisa al scheduler de que la programación cambió
+func (s *PollScheduler) Reschedule() {
+	select {
+	case s.wake <- struct{}{}:
+	default: // ya hay un aviso pendiente
+	}
+}
+
+func (s *PollScheduler) Run(ctx context.Context) {
+	for {
+		now := time.Now()
+		s.tick(ctx, now)
+
+		wait := maxSchedulerWait
+		next, err := s.pollModel.NextScheduleChange(ctx, now)
+		if err != nil {
+			log.Printf("scheduler: error buscando la próxima programación: %v", err)
+		} else if next != nil {
+			if d := time.Until(*next); d < wait {
+				wait = max(d, 0)
+			}
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+func (s *PollScheduler) tick(ctx context.Context, now time.Time) {
+	opened, closed, err := s.pollModel.ApplySchedule(ctx, now)
+	if err != nil {
+		log.Printf("scheduler: error aplicando la programación: %v", err)
+	}
+
+	isOpen, isClosed := true, false
+	for _, p := range opened {
+		s.hub.Broadcast <- VoteUpdate{Type: EventPollOpened, PollID: fmt.Sprintf("%d", p.ID), IsOpen: &isOpen}
+	}
+	for _, p := range closed {
+		s.hub.Broadcast <- VoteUpdate{
+			Type:         EventPollClosed,
+			PollID:       fmt.Sprintf("%d", p.ID),
+			IsOpen:       &isClosed,
+			ClosedReason: string(poll.ClosedReasonSchedule),
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"api_voty/internal/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type SearchPollsRequest struct {
+	Query string `query:"q" required:"true" minLength:"1" doc:"Texto a buscar; la última palabra se completa como prefijo"`
+	Limit int    `query:"limit" minimum:"1" maximum:"50" default:"20"`
+}
+
+type SearchHit struct {
+	Score float64    `json:"score" doc:"Relevancia (mayor es mejor)"`
+	Poll  PollOutput `json:"poll"`
+}
+
+type SearchPollsResponse struct {
+	Body struct {
+		Items []SearchHit `json:"items"`
+	}
+}
+
+type SuggestRequest struct {
+	Query string `query:"q" required:"true" minLength:"1"`
+	Limit int    `query:"limit" minimum:"1" maximum:"20" default:"8"`
+}
+
+type SuggestResponse struct {
+	Body struct {
+		Suggestions []string `json:"suggestions"`
+	}
+}
+
+// SearchPolls busca en títulos, descripciones y opciones sin distinguir tildes ni mayúsculas
+func (a *UserAPI) SearchPolls(ctx context.Context, input *SearchPollsRequest) (*SearchPollsResponse, error) {
+	userID := utils.GetUserIDFromContext(ctx)
+	hits := a.Search.Search(input.Query, userID, input.Limit)
+
+	ids := make([]int, len(hits))
+	scores := make(map[int]float64, len(hits))
+	for i, h := range hits {
+		ids[i] = h.PollID
+		scores[h.PollID] = h.Score
+	}
+	polls, err := a.pollModel.GetManyWithUserStatus(ctx, ids, userID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Error al buscar", err)
+	}
+
+	now := time.Now()
+	viewer := a.userModel.Viewer(ctx, userID)
+	resp := &SearchPollsResponse{}
+	resp.Body.Items = make([]SearchHit, len(polls))
+	for i, p := range polls {
+		resp.Body.Items[i] = SearchHit{Score: scores[p.ID], Poll: toPollOutput(p, viewer, now)}
+	}
+	return resp, nil
+}
+
+// SuggestPolls autocompleta la última palabra escrita
+func (a *UserAPI) SuggestPolls(ctx context.Context, input *SuggestRequest) (*SuggestResponse, error) {
+	resp := &SuggestResponse{}
+	resp.Body.Suggestions = a.Search.Suggest(input.Query, utils.GetUserIDFromContext(ctx), input.Limit)
+	if resp.Body.Suggestions == nil {
+		resp.Body.Suggestions = []string{}
+	}
+	return resp, nil
+}
@@ -0,0 +1,331 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/internal/charts"
+	"api_voty/internal/models"
+	"api_voty/internal/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type ShareLinkOutput struct {
+	ID          int        `json:"id"`
+	TokenPrefix string     `json:"token_prefix" doc:"Primeros caracteres del token, para reconocer el enlace"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	Active      bool       `json:"active"`
+}
+
+type CreateShareLinkRequest struct {
+	ID   string `path:"id" doc:"ID de la encuesta"`
+	Body struct {
+		ExpiresAt *time.Time `json:"expires_at,omitempty" doc:"Caducidad opcional del enlace"`
+	}
+}
+
+type CreateShareLinkResponse struct {
+	Body struct {
+		ShareLinkOutput
+		Token    string `json:"token" doc:"Solo se muestra ahora; después no se puede recuperar"`
+		URL      string `json:"url" doc:"Página pública con vista previa para redes y mensajería"`
+		APIURL   string `json:"api_url" doc:"Lectura pública en JSON"`
+		ImageURL string `json:"image_url" doc:"Gráfico PNG de resultados (si son públicos)"`
+	}
+}
+
+type ListShareLinksRequest struct {
+	ID string `path:"id" doc:"ID de la encuesta"`
+}
+
+type ListShareLinksResponse struct {
+	Body struct {
+		Items []ShareLinkOutput `json:"items"`
+	}
+}
+
+type RevokeShareLinkRequest struct {
+	ID     string `path:"id" doc:"ID de la encuesta"`
+	LinkID int    `path:"link_id" doc:"ID del enlace"`
+}
+
+type SharedPollRequest struct {
+	Token string `path:"token"`
+}
+
+// SharedPollOutput es lo que ve cualquiera con el enlace: sin autor ni datos de usuario
+type SharedPollOutput struct {
+	Title          string         `json:"title"`
+	Description    string         `json:"description,omitempty"`
+	Options        []OptionOutput `json:"options"`
+	Tags           []TagOutput    `json:"tags"`
+	IsOpen         bool           `json:"is_open"`
+	ClosesAt       *time.Time     `json:"closes_at,omitempty"`
+	ResultsVisible bool           `json:"results_visible" doc:"Si es false, votes_count se omite"`
+	ImageURL       string         `json:"image_url,omitempty"`
+}
+
+type SharedPollResponse struct {
+	Body SharedPollOutput
+}
+
+type SharedChartRequest struct {
+	Token  string `path:"token"`
+	Format string `query:"format" enum:"svg,png" default:"png"`
+	Kind   string `query:"kind" enum:"bar,pie" default:"bar"`
+	Theme  string `query:"theme" enum:"light,dark" default:"light"`
+	Width  int    `query:"width" minimum:"200" maximum:"1600" default:"1200"`
+	Height int    `query:"height" minimum:"150" maximum:"1200" default:"630"`
+}
+
+func toShareLinkOutput(l *ent.ShareLink, now time.Time) ShareLinkOutput {
+	return ShareLinkOutput{
+		ID:          l.ID,
+		TokenPrefix: l.TokenPrefix,
+		CreatedAt:   l.CreatedAt,
+		ExpiresAt:   l.ExpiresAt,
+		RevokedAt:   l.RevokedAt,
+		Active:      models.ShareLinkActive(l, now),
+	}
+}
+
+// shareURLs son las direcciones públicas de un token
+func (a *UserAPI) shareURLs(token string) (page, api, image string) {
+	base := strings.TrimSuffix(a.PublicBaseURL, "/")
+	return base + "/s/" + token, base + "/share/" + token, base + "/share/" + token + "/chart"
+}
+
+func (a *UserAPI) CreateShareLink(ctx context.Context, input *CreateShareLinkRequest) (*CreateShareLinkResponse, error) {
+	p, err := a.ownedPoll(ctx, input.ID, "Solo el autor puede compartir la encuesta")
+	if err != nil {
+		return nil, err
+	}
+	token, link, err := a.pollModel.CreateShareLink(ctx, p.ID, utils.GetUserIDFromContext(ctx), input.Body.ExpiresAt)
+	if err != nil {
+		switch err.Error() {
+		case "INVALID_EXPIRY":
+			return nil, huma.Error400BadRequest("La caducidad debe ser futura", err)
+		case "POLL_NOT_PUBLISHED":
+			return nil, huma.Error409Conflict("Publica la encuesta antes de compartirla", err)
+		}
+		return nil, huma.Error500InternalServerError("Error al crear el enlace", err)
+	}
+
+	resp := &CreateShareLinkResponse{}
+	resp.Body.ShareLinkOutput = toShareLinkOutput(link, time.Now())
+	resp.Body.Token = token
+	resp.Body.URL, resp.Body.APIURL, resp.Body.ImageURL = a.shareURLs(token)
+	return resp, nil
+}
+
+func (a *UserAPI) ListShareLinks(ctx context.Context, input *ListShareLinksRequest) (*ListShareLinksResponse, error) {
+	p, err := a.ownedPoll(ctx, input.ID, "Solo el autor puede ver los enlaces de la encuesta")
+	if err != nil {
+		return nil, err
+	}
+	links, err := a.pollModel.ShareLinks(ctx, p.ID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Error al listar los enlaces", err)
+	}
+	now := time.Now()
+	resp := &ListShareLinksResponse{}
+	resp.Body.Items = make([]ShareLinkOutput, len(links))
+	for i, l := range links {
+		resp.Body.Items[i] = toShareLinkOutput(l, now)
+	}
+	return resp, nil
+}
+
+func (a *UserAPI) RevokeShareLink(ctx context.Context, input *RevokeShareLinkRequest) (*struct{}, error) {
+	p, err := a.ownedPoll(ctx, input.ID, "Solo el autor puede revocar enlaces")
+	if err != nil {
+		return nil, err
+	}
+	if err := a.pollModel.RevokeShareLink(ctx, p.ID, input.LinkID); err != nil {
+		if ent.IsNotFound(err) {
+			return nil, huma.Error404NotFound("Enlace no encontrado", err)
+		}
+		return nil, huma.Error500InternalServerError("Error al revocar el enlace", err)
+	}
+	return nil, nil
+}
+
+// sharedPoll resuelve el token o responde 404 sin dar más detalles
+func (a *UserAPI) sharedPoll(ctx context.Context, token string) (*ent.Poll, error) {
+	p, err := a.pollModel.ResolveShareLink(ctx, token)
+	if err != nil {
+		if err.Error() == "SHARE_LINK_INVALID" {
+			return nil, huma.Error404NotFound("Enlace no válido o caducado")
+		}
+		return nil, huma.Error500InternalServerError("Error al abrir el enlace", err)
+	}
+	return p, nil
+}
+
+// toSharedPollOutput muestra los recuentos solo si son públicos: quien abre el
+// enlace es anónimo y no ha votado
+func (a *UserAPI) toSharedPollOutput(p *ent.Poll, token string, now time.Time) SharedPollOutput {
+	full := toPollOutput(p, models.Viewer{}, now)
+	out := SharedPollOutput{
+		Title:          full.Title,
+		Description:    full.Description,
+		Options:        full.Options,
+		Tags:           full.Tags,
+		IsOpen:         full.IsOpen,
+		ClosesAt:       full.ClosesAt,
+		ResultsVisible: full.ResultsVisible,
+	}
+	if out.ResultsVisible {
+		_, _, out.ImageURL = a.shareURLs(token)
+	}
+	return out
+}
+
+// GetSharedPoll es la lectura pública (sin token de sesión) de una encuesta compartida
+func (a *UserAPI) GetSharedPoll(ctx context.Context, input *SharedPollRequest) (*SharedPollResponse, error) {
+	p, err := a.sharedPoll(ctx, input.Token)
+	if err != nil {
+		return nil, err
+	}
+	return &SharedPollResponse{Body: a.toSharedPollOutput(p, input.Token, time.Now())}, nil
+}
+
+// GetSharedChart es el gráfico público de una encuesta compartida (og:image)
+func (a *UserAPI) GetSharedChart(ctx context.Context, input *SharedChartRequest) (*PollChartResponse, error) {
+	p, err := a.sharedPoll(ctx, input.Token)
+	if err != nil {
+		return nil, err
+	}
+	if !models.ResultsPublic(p, time.Now()) {
+		return nil, huma.Error403Forbidden("Los resultados de esta encuesta aún no son públicos")
+	}
+	opts := charts.Options{Kind: input.Kind, Theme: input.Theme, Width: input.Width, Height: input.Height}
+	data, err := a.renderChart(p, opts, input.Format)
+	if err != nil {
+		return nil, huma.Error400BadRequest("No se pudo dibujar el gráfico", err)
+	}
+	return &PollChartResponse{
+		ContentType:  charts.ContentType(input.Format),
+		CacheControl: "public, max-age=60",
+		Body:         data,
+	}, nil
+}
+
+// sharePreviewOption es una fila de la página de vista previa
+type sharePreviewOption struct {
+	Text    string
+	Votes   int
+	Percent int
+}
+
+type sharePreviewPage struct {
+	Poll           SharedPollOutput
+	URL            string
+	Summary        string
+	ResultsVisible bool
+	Options        []sharePreviewOption
+}
+
+var sharePreviewTemplate = template.Must(template.New("share").Parse(`<!DOCTYPE html>
+<html lang="es">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Poll.Title}}</title>
+<meta name="description" content="{{.Summary}}">
+<meta property="og:type" content="website">
+<meta property="og:title" content="{{.Poll.Title}}">
+<meta property="og:description" content="{{.Summary}}">
+<meta property="og:url" content="{{.URL}}">
+{{- if .Poll.ImageURL}}
+<meta property="og:image" content="{{.Poll.ImageURL}}?format=png&width=1200&height=630">
+<meta property="og:image:width" content="1200">
+<meta property="og:image:height" content="630">
+<meta name="twitter:card" content="summary_large_image">
+<meta name="twitter:image" content="{{.Poll.ImageURL}}?format=png&width=1200&height=630">
+{{- else}}
+<meta name="twitter:card" content="summary">
+{{- end}}
+<meta name="twitter:title" content="{{.Poll.Title}}">
+<meta name="twitter:description" content="{{.Summary}}">
+<style>
+body{font-family:Helvetica,Arial,sans-serif;max-width:640px;margin:2rem auto;padding:0 1rem;color:#1f2933}
+.opt{margin:.75rem 0}.bar{background:#e4e7eb;border-radius:3px;height:10px}.fill{background:#2563eb;border-radius:3px;height:10px}
+.muted{color:#616e7c}
+</style>
+</head>
+<body>
+<h1>{{.Poll.Title}}</h1>
+{{with .Poll.Description}}<p>{{.}}</p>{{end}}
+<p class="muted">{{if .Poll.IsOpen}}Encuesta abierta{{else}}Encuesta cerrada{{end}}</p>
+{{- range .Options}}
+<div class="opt">
+<div>{{.Text}}{{if $.ResultsVisible}} <span class="muted">· {{.Votes}} ({{.Percent}}%)</span>{{end}}</div>
+{{if $.ResultsVisible}}<div class="bar"><div class="fill" style="width:{{.Percent}}%"></div></div>{{end}}
+</div>
+{{- end}}
+{{if not .ResultsVisible}}<p class="muted">Los resultados aún no son públicos.</p>{{end}}
+</body>
+</html>
+`))
+
+// SharePreview sirve la página HTML de un enlace compartido, con las etiquetas
+// OpenGraph y Twitter que usan las apps de mensajería para desplegar el enlace
+func (a *UserAPI) SharePreview(w http.ResponseWriter, r *http.Request) {
+	token := r.PathValue("token")
+	p, err := a.pollModel.ResolveShareLink(r.Context(), token)
+	if err != nil {
+		if err.Error() == "SHARE_LINK_INVALID" {
+			http.Error(w, "Enlace no válido o caducado", http.StatusNotFound)
+			return
+		}
+		log.Printf("error abriendo el enlace compartido: %v", err)
+		http.Error(w, "Error al abrir el enlace", http.StatusInternalServerError)
+		return
+	}
+
+	shared := a.toSharedPollOutput(p, token, time.Now())
+	page := sharePreviewPage{Poll: shared, ResultsVisible: shared.ResultsVisible}
+	page.URL, _, _ = a.shareURLs(token)
+
+	total := 0
+	for _, o := range shared.Options {
+		if o.VotesCount != nil {
+			total += *o.VotesCount
+		}
+	}
+	texts := make([]string, len(shared.Options))
+	for i, o := range shared.Options {
+		texts[i] = o.Text
+		row := sharePreviewOption{Text: o.Text}
+		if o.VotesCount != nil {
+			row.Votes = *o.VotesCount
+			if total > 0 {
+				row.Percent = row.Votes * 100 / total
+			}
+		}
+		page.Options = append(page.Options, row)
+	}
+	page.Summary = shared.Description
+	if page.Summary == "" {
+		page.Summary = strings.Join(texts, " · ")
+	}
+	if shared.ResultsVisible {
+		page.Summary = fmt.Sprintf("%s (%d votos)", page.Summary, total)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	if err := sharePreviewTemplate.Execute(w, page); err != nil {
+		log.Printf("error pintando la vista previa: %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/internal/models"
+	"api_voty/internal/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type TagOutput struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+type ListTagsRequest struct {
+	Query    string `query:"q" doc:"Prefijo para autocompletar (sin distinguir tildes ni mayúsculas)"`
+	Limit    int    `query:"limit" minimum:"1" maximum:"100" default:"20"`
+	Sort     string `query:"sort" enum:"popular,name" default:"popular"`
+	Followed string `query:"followed" enum:"true" doc:"Solo las etiquetas que sigo"`
+}
+
+type ListTagsResponse struct {
+	Body struct {
+		Items []models.TagResponse `json:"items"`
+	}
+}
+
+type TagRequest struct {
+	Slug string `path:"slug" doc:"Slug de la etiqueta (también se acepta el nombre)"`
+}
+
+type CreateTagRequest struct {
+	Body struct {
+		Name string `json:"name" minLength:"1" maxLength:"40" example:"Política"`
+	}
+}
+
+type RenameTagRequest struct {
+	Slug string `path:"slug"`
+	Body struct {
+		Name string `json:"name" minLength:"1" maxLength:"40"`
+	}
+}
+
+type TagResponse struct {
+	Body TagOutput
+}
+
+type PollFeedRequest struct {
+	Cursor string `query:"cursor" doc:"Cursor devuelto en page.next_cursor"`
+	Limit  int    `query:"limit" minimum:"1" maximum:"100" default:"20"`
+	Sort   string `query:"sort" enum:"newest,most_votes,closing_soon" default:"newest"`
+}
+
+// tagError traduce los códigos de error del modelo de etiquetas a respuestas HTTP
+func tagError(msg string, err error) error {
+	switch err.Error() {
+	case "INVALID_TAG":
+		return huma.Error400BadRequest("Etiqueta inválida: debe tener letras o números y como mucho 40 caracteres", err)
+	case "TAG_EXISTS":
+		return huma.Error409Conflict("Ya existe una etiqueta equivalente", err)
+	case "INVALID_SORT":
+		return huma.Error400BadRequest("Orden inválido", err)
+	}
+	if ent.IsNotFound(err) {
+		return huma.Error404NotFound("Etiqueta no encontrada", err)
+	}
+	return huma.Error500InternalServerError(msg, err)
+}
+
+func (a *UserAPI) requireAdmin(ctx context.Context, msg string) error {
+	if !a.userModel.Viewer(ctx, utils.GetUserIDFromContext(ctx)).IsAdmin {
+		return huma.Error403Forbidden(msg)
+	}
+	return nil
+}
+
+func (a *UserAPI) ListTags(ctx context.Context, input *ListTagsRequest) (*ListTagsResponse, error) {
+	tags, err := a.tagModel.List(ctx, models.TagListParams{
+		UserID:   utils.GetUserIDFromContext(ctx),
+		Prefix:   input.Query,
+		Limit:    input.Limit,
+		Sort:     input.Sort,
+		Followed: input.Followed == "true",
+	})
+	if err != nil {
+		return nil, tagError("Error al listar etiquetas", err)
+	}
+	resp := &ListTagsResponse{}
+	resp.Body.Items = tags
+	return resp, nil
+}
+
+func (a *UserAPI) CreateTag(ctx context.Context, input *CreateTagRequest) (*TagResponse, error) {
+	if err := a.requireAdmin(ctx, "Solo un administrador puede gestionar etiquetas"); err != nil {
+		return nil, err
+	}
+	t, err := a.tagModel.Create(ctx, input.Body.Name)
+	if err != nil {
+		return nil, tagError("Error al crear la etiqueta", err)
+	}
+	return &TagResponse{Body: TagOutput{Slug: t.Slug, Name: t.Name}}, nil
+}
+
+func (a *UserAPI) RenameTag(ctx context.Context, input *RenameTagRequest) (*TagResponse, error) {
+	if err := a.requireAdmin(ctx, "Solo un administrador puede gestionar etiquetas"); err != nil {
+		return nil, err
+	}
+	t, err := a.tagModel.Rename(ctx, input.Slug, input.Body.Name)
+	if err != nil {
+		return nil, tagError("Error al renombrar la etiqueta", err)
+	}
+	return &TagResponse{Body: TagOutput{Slug: t.Slug, Name: t.Name}}, nil
+}
+
+func (a *UserAPI) DeleteTag(ctx context.Context, input *TagRequest) (*struct{}, error) {
+	if err := a.requireAdmin(ctx, "Solo un administrador puede gestionar etiquetas"); err != nil {
+		return nil, err
+	}
+	if err := a.tagModel.Delete(ctx, input.Slug); err != nil {
+		return nil, tagError("Error al eliminar la etiqueta", err)
+	}
+	return nil, nil
+}
+
+func (a *UserAPI) FollowTag(ctx context.Context, input *TagRequest) (*struct{}, error) {
+	if err := a.tagModel.Follow(ctx, utils.GetUserIDFromContext(ctx), input.Slug); err != nil {
+		return nil, tagError("Error al seguir la etiqueta", err)
+	}
+	return nil, nil
+}
+
+func (a *UserAPI) UnfollowTag(ctx context.Context, input *TagRequest) (*struct{}, error) {
+	if err := a.tagModel.Unfollow(ctx, utils.GetUserIDFromContext(ctx), input.Slug); err != nil {
+		return nil, tagError("Error al dejar de seguir la etiqueta", err)
+	}
+	return nil, nil
+}
+
+// PollFeed lista las encuestas de las etiquetas que sigue el usuario
+func (a *UserAPI) PollFeed(ctx context.Context, input *PollFeedRequest) (*ListPollsResponse, error) {
+	userID := utils.GetUserIDFromContext(ctx)
+	polls, page, err := a.pollModel.ListPage(ctx, models.PollListParams{
+		UserID:     userID,
+		Cursor:     input.Cursor,
+		Limit:      input.Limit,
+		Sort:       input.Sort,
+		State:      "open",
+		FollowedBy: userID,
+	})
+	if err != nil {
+		if err.Error() == "INVALID_CURSOR" {
+			return nil, huma.Error400BadRequest("Cursor inválido", err)
+		}
+		return nil, huma.Error500InternalServerError("Error al cargar el feed", err)
+	}
+
+	now := time.Now()
+	viewer := a.userModel.Viewer(ctx, userID)
+	resp := &ListPollsResponse{}
+	resp.Body.Items = make([]PollOutput, len(polls))
+	for i, p := range polls {
+		resp.Body.Items[i] = toPollOutput(p, viewer, now)
+	}
+	resp.Body.Page = page
+	return resp, nil
+}
+
+func toTagOutputs(tags []*ent.Tag) []TagOutput {
+	out := make([]TagOutput, len(tags))
+	for i, t := range tags {
+		out[i] = TagOutput{Slug: t.Slug, Name: t.Name}
+	}
+	return out
+}
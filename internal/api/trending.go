@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"api_voty/ent/poll"
+	"api_voty/internal/models"
+	"api_voty/internal/trending"
+	"api_voty/internal/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type TrendingPollsRequest struct {
+	Window string `query:"window" enum:"hour,day,week" default:"day" doc:"Periodo considerado; los votos recientes pesan más"`
+	Limit  int    `query:"limit" minimum:"1" maximum:"50" default:"20"`
+}
+
+type TrendingItem struct {
+	Score float64    `json:"score" doc:"Puntuación de tendencia (votos y visitas con decaimiento temporal)"`
+	Poll  PollOutput `json:"poll"`
+}
+
+type TrendingPollsResponse struct {
+	Body struct {
+		Window string         `json:"window"`
+		Items  []TrendingItem `json:"items"`
+	}
+}
+
+// TrendingPolls ordena las encuestas publicadas por actividad reciente
+func (a *UserAPI) TrendingPolls(ctx context.Context, input *TrendingPollsRequest) (*TrendingPollsResponse, error) {
+	userID := utils.GetUserIDFromContext(ctx)
+	window := trending.Window(input.Window)
+
+	// Pedimos de más porque los borradores propios y las encuestas con
+	// resultados ocultos se descartan abajo
+	top := a.Trending.Top(window, input.Limit*3)
+	ids := make([]int, len(top))
+	scores := make(map[int]float64, len(top))
+	for i, e := range top {
+		ids[i] = e.PollID
+		scores[e.PollID] = e.Score
+	}
+	polls, err := a.pollModel.GetManyWithUserStatus(ctx, ids, userID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Error al cargar tendencias", err)
+	}
+
+	now := time.Now()
+	viewer := a.userModel.Viewer(ctx, userID)
+	resp := &TrendingPollsResponse{}
+	resp.Body.Window = input.Window
+	resp.Body.Items = []TrendingItem{}
+	for _, p := range polls {
+		if p.Status != poll.StatusPublished {
+			continue
+		}
+		// La puntuación sale de los votos: si el viewer no puede ver el recuento
+		// (after_close, embargo) tampoco debe deducirlo del ranking. Con
+		// after_vote basta con votar para verlo, así que la encuesta se mantiene.
+		voted := len(p.Edges.Votes) > 0
+		if p.ResultsVisibility != poll.ResultsVisibilityAfterVote && !models.ResultsVisible(p, viewer, voted, now) {
+			continue
+		}
+		resp.Body.Items = append(resp.Body.Items, TrendingItem{Score: scores[p.ID], Poll: toPollOutput(p, viewer, now)})
+		if len(resp.Body.Items) == input.Limit {
+			break
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"api_voty/internal/models"
+	"api_voty/internal/utils"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type LoginTwoFactorRequest struct {
+	Body struct {
+		ChallengeToken string `json:"challenge_token" doc:"Token devuelto por /login"`
+		Code           string `json:"code" example:"123456" doc:"Código de la app de autenticación o de recuperación"`
+	}
+	meta models.LoginMeta
+}
+
+// Resolve guarda la IP y el user agent para el historial de logins (huma.Resolver)
+func (r *LoginTwoFactorRequest) Resolve(ctx huma.Context) []error {
+	r.meta = models.LoginMeta{
+		IP:        utils.ClientIP(ctx.RemoteAddr(), ctx.Header("X-Forwarded-For")),
+		UserAgent: ctx.Header("User-Agent"),
+	}
+	return nil
+}
+
+type TwoFactorCodeRequest struct {
+	Body struct {
+		Code string `json:"code" example:"123456"`
+	}
+}
+
+type DisableTwoFactorRequest struct {
+	Body struct {
+		Password string `json:"password"`
+		Code     string `json:"code" doc:"Código de la app de autenticación o de recuperación"`
+	}
+}
+
+type TwoFactorStatusResponse struct {
+	Body models.TwoFactorStatus
+}
+
+type TwoFactorSetupResponse struct {
+	Body models.TwoFactorSetup
+}
+
+type RecoveryCodesResponse struct {
+	Body struct {
+		RecoveryCodes []string `json:"recovery_codes" doc:"Cada código sirve una vez; no se vuelven a mostrar"`
+	}
+}
+
+// loginLockedError traduce un bloqueo de login en un 429 con Retry-After; nil
+// si err es de otro tipo
+func loginLockedError(err error) error {
+	var locked *models.LoginLockedError
+	if !errors.As(err, &locked) {
+		return nil
+	}
+	retry := strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds())))
+	return huma.ErrorWithHeaders(
+		huma.Error429TooManyRequests("Login temporarily blocked: "+locked.Reason, err),
+		http.Header{"Retry-After": {retry}},
+	)
+}
+
+// twoFactorError traduce los códigos de error del segundo factor
+func twoFactorError(msg string, err error) error {
+	switch err.Error() {
+	case "TWO_FACTOR_ALREADY_ENABLED":
+		return huma.Error409Conflict("Two-factor authentication is already enabled", err)
+	case "TWO_FACTOR_NOT_ENABLED":
+		return huma.Error409Conflict("Two-factor authentication is not enabled", err)
+	case "TWO_FACTOR_NOT_SET_UP":
+		return huma.Error409Conflict("Start the setup first", err)
+	case "TWO_FACTOR_REQUIRED":
+		return huma.Error403Forbidden("Administrators must keep two-factor authentication enabled", err)
+	case "INVALID_CODE":
+		return huma.Error400BadRequest("Invalid code", err)
+	case "INVALID_PASSWORD":
+		return huma.Error400BadRequest("Invalid password", err)
+	}
+	return huma.Error500InternalServerError(msg, err)
+}
+
+// LoginTwoFactor completa el login con el código del segundo factor
+func (a *AuthAPI) LoginTwoFactor(ctx context.Context, input *LoginTwoFactorRequest) (*AuthResponse, error) {
+	result, err := a.authModel.LoginTwoFactor(ctx, input.Body.ChallengeToken, input.Body.Code, input.meta)
+	if lockedErr := loginLockedError(err); lockedErr != nil {
+		return nil, lockedErr
+	}
+	if err != nil {
+		return nil, huma.Error401Unauthorized("Login failed", err)
+	}
+	return &AuthResponse{Body: *result}, nil
+}
+
+func (a *AuthAPI) GetTwoFactor(ctx context.Context, input *struct{}) (*TwoFactorStatusResponse, error) {
+	status, err := a.authModel.TwoFactorStatus(ctx, utils.GetUserIDFromContext(ctx))
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Error fetching two-factor status", err)
+	}
+	return &TwoFactorStatusResponse{Body: *status}, nil
+}
+
+// SetupTwoFactor genera el secreto y el QR para la app de autenticación
+func (a *AuthAPI) SetupTwoFactor(ctx context.Context, input *struct{}) (*TwoFactorSetupResponse, error) {
+	setup, err := a.authModel.SetupTwoFactor(ctx, utils.GetUserIDFromContext(ctx))
+	if err != nil {
+		return nil, twoFactorError("Error setting up two-factor authentication", err)
+	}
+	return &TwoFactorSetupResponse{Body: *setup}, nil
+}
+
+func (a *AuthAPI) ConfirmTwoFactor(ctx context.Context, input *TwoFactorCodeRequest) (*RecoveryCodesResponse, error) {
+	codes, err := a.authModel.ConfirmTwoFactor(ctx, utils.GetUserIDFromContext(ctx), input.Body.Code)
+	if err != nil {
+		return nil, twoFactorError("Error enabling two-factor authentication", err)
+	}
+	resp := &RecoveryCodesResponse{}
+	resp.Body.RecoveryCodes = codes
+	return resp, nil
+}
+
+func (a *AuthAPI) DisableTwoFactor(ctx context.Context, input *DisableTwoFactorRequest) (*struct{}, error) {
+	err := a.authModel.DisableTwoFactor(ctx, utils.GetUserIDFromContext(ctx), input.Body.Password, input.Body.Code)
+	if err != nil {
+		return nil, twoFactorError("Error disabling two-factor authentication", err)
+	}
+	return nil, nil
+}
+
+func (a *AuthAPI) RegenerateRecoveryCodes(ctx context.Context, input *TwoFactorCodeRequest) (*RecoveryCodesResponse, error) {
+	codes, err := a.authModel.RegenerateRecoveryCodes(ctx, utils.GetUserIDFromContext(ctx), input.Body.Code)
+	if err != nil {
+		return nil, twoFactorError("Error generating recovery codes", err)
+	}
+	resp := &RecoveryCodesResponse{}
+	resp.Body.RecoveryCodes = codes
+	return resp, nil
+}
@@ -0,0 +1,50 @@
+package charts
+
+import "sync"
+
+// maxCacheEntries limita la memoria de la caché; al llenarse se vacía entera
+const maxCacheEntries = 1000
+
+// Key identifica un gráfico ya dibujado
+type Key struct {
+	PollID int
+	Format string
+	Options
+}
+
+// Cache guarda gráficos dibujados hasta que cambian los datos de su encuesta
+type Cache struct {
+	mu      sync.Mutex
+	entries map[Key][]byte
+}
+
+func NewCache() *Cache {
+	return &Cache{entries: map[Key][]byte{}}
+}
+
+func (c *Cache) Get(k Key) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, ok := c.entries[k]
+	return data, ok
+}
+
+func (c *Cache) Put(k Key, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxCacheEntries {
+		clear(c.entries)
+	}
+	c.entries[k] = data
+}
+
+// Invalidate descarta todos los gráficos de una encuesta
+func (c *Cache) Invalidate(pollID int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k := range c.entries {
+		if k.PollID == pollID {
+			delete(c.entries, k)
+		}
+	}
+}
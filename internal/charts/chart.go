@@ -0,0 +1,170 @@
+// Package charts dibuja los resultados de una encuesta como gráfico de barras
+// o de tarta, en SVG o PNG, sin dependencias externas más allá de la fuente
+// básica de golang.org/x/image.
+package charts
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+)
+
+// Tipos de gráfico
+const (
+	KindBar = "bar"
+	KindPie = "pie"
+)
+
+// Formatos de salida
+const (
+	FormatSVG = "svg"
+	FormatPNG = "png"
+)
+
+// Límites de tamaño en píxeles
+const (
+	MinWidth  = 200
+	MaxWidth  = 1600
+	MinHeight = 150
+	MaxHeight = 1200
+)
+
+// Data son los resultados a dibujar: una etiqueta y un valor por opción
+type Data struct {
+	Title  string
+	Labels []string
+	Values []int
+}
+
+func (d Data) total() int {
+	t := 0
+	for _, v := range d.Values {
+		t += v
+	}
+	return t
+}
+
+// Options controla el aspecto del gráfico
+type Options struct {
+	Kind   string
+	Theme  string
+	Width  int
+	Height int
+}
+
+func (o Options) validate() error {
+	if o.Kind != KindBar && o.Kind != KindPie {
+		return errors.New("INVALID_CHART_KIND")
+	}
+	if _, ok := themes[o.Theme]; !ok {
+		return errors.New("INVALID_THEME")
+	}
+	if o.Width < MinWidth || o.Width > MaxWidth || o.Height < MinHeight || o.Height > MaxHeight {
+		return errors.New("INVALID_SIZE")
+	}
+	return nil
+}
+
+// Theme son los colores de un gráfico
+type Theme struct {
+	Background color.RGBA
+	Text       color.RGBA
+	Muted      color.RGBA
+	Track      color.RGBA // fondo de las barras
+	Palette    []color.RGBA
+}
+
+var themes = map[string]Theme{
+	"light": {
+		Background: rgb(0xffffff),
+		Text:       rgb(0x1f2933),
+		Muted:      rgb(0x616e7c),
+		Track:      rgb(0xe4e7eb),
+		Palette:    []color.RGBA{rgb(0x2563eb), rgb(0xf97316), rgb(0x16a34a), rgb(0xdc2626), rgb(0x9333ea), rgb(0x0891b2), rgb(0xca8a04), rgb(0xdb2777)},
+	},
+	"dark": {
+		Background: rgb(0x111827),
+		Text:       rgb(0xf9fafb),
+		Muted:      rgb(0x9ca3af),
+		Track:      rgb(0x374151),
+		Palette:    []color.RGBA{rgb(0x60a5fa), rgb(0xfb923c), rgb(0x4ade80), rgb(0xf87171), rgb(0xc084fc), rgb(0x22d3ee), rgb(0xfacc15), rgb(0xf472b6)},
+	},
+}
+
+// Themes son los nombres de tema disponibles
+var Themes = []string{"light", "dark"}
+
+func rgb(hex uint32) color.RGBA {
+	return color.RGBA{R: uint8(hex >> 16), G: uint8(hex >> 8), B: uint8(hex), A: 0xff}
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func (t Theme) color(i int) color.RGBA {
+	return t.Palette[i%len(t.Palette)]
+}
+
+// Render dibuja el gráfico en el formato pedido
+func Render(d Data, o Options, format string) ([]byte, error) {
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	switch format {
+	case FormatSVG:
+		return renderSVG(d, o), nil
+	case FormatPNG:
+		return renderPNG(d, o)
+	}
+	return nil, errors.New("INVALID_FORMAT")
+}
+
+// ContentType es el tipo MIME de cada formato
+func ContentType(format string) string {
+	if format == FormatPNG {
+		return "image/png"
+	}
+	return "image/svg+xml"
+}
+
+// layout son las medidas comunes a SVG y PNG
+type layout struct {
+	pad      int
+	titleH   int
+	rowH     int
+	barH     int
+	labelW   int
+	valueW   int
+	fontSize int
+}
+
+func newLayout(d Data, o Options) layout {
+	l := layout{pad: max(o.Width/40, 12)}
+	l.fontSize = min(max(o.Height/22, 11), 22)
+	l.titleH = l.fontSize * 2
+	n := max(len(d.Labels), 1)
+	l.rowH = (o.Height - 2*l.pad - l.titleH) / n
+	l.barH = max(l.rowH*6/10, 2)
+	l.labelW = o.Width * 3 / 10
+	l.valueW = l.fontSize * 6
+	return l
+}
+
+// percentLabel es el texto que acompaña a cada valor
+func percentLabel(v, total int) string {
+	if total == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%d (%.0f%%)", v, float64(v)*100/float64(total))
+}
+
+// truncate recorta s a n caracteres terminando en ellipsis
+func truncate(s string, n int, ellipsis string) string {
+	r := []rune(s)
+	e := len([]rune(ellipsis))
+	if n <= e || len(r) <= n {
+		return s
+	}
+	return string(r[:n-e]) + ellipsis
+}
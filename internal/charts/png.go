@@ -0,0 +1,167 @@
+package charts
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strings"
+
+	"api_voty/internal/utils"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// La fuente básica solo tiene ASCII y mide 7x13: para tamaños mayores se
+// dibuja escalada por un factor entero
+const (
+	glyphW = 7
+	glyphH = 13
+)
+
+func renderPNG(d Data, o Options) ([]byte, error) {
+	t := themes[o.Theme]
+	l := newLayout(d, o)
+	img := image.NewRGBA(image.Rect(0, 0, o.Width, o.Height))
+	fill(img, img.Bounds(), t.Background)
+
+	scale := max((l.fontSize+glyphH/2)/glyphH, 1)
+	drawText(img, l.pad, l.pad, truncate(asciiText(d.Title), (o.Width-2*l.pad)/(glyphW*scale), "..."), t.Text, scale)
+
+	if o.Kind == KindPie {
+		pngPie(img, d, o, t, l, scale)
+	} else {
+		pngBars(img, d, o, t, l, scale)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func pngBars(img *image.RGBA, d Data, o Options, t Theme, l layout, scale int) {
+	total := d.total()
+	maxV := 0
+	for _, v := range d.Values {
+		maxV = max(maxV, v)
+	}
+	barX := l.pad + l.labelW
+	barW := o.Width - barX - l.pad - l.valueW
+	textH := glyphH * scale
+
+	for i, label := range d.Labels {
+		y := l.pad + l.titleH + i*l.rowH
+		mid := y + l.rowH/2
+		drawText(img, l.pad, mid-textH/2, truncate(asciiText(label), l.labelW/(glyphW*scale)-1, "..."), t.Text, scale)
+		fill(img, image.Rect(barX, mid-l.barH/2, barX+barW, mid+l.barH/2), t.Track)
+		if maxV > 0 && d.Values[i] > 0 {
+			w := max(barW*d.Values[i]/maxV, 1)
+			fill(img, image.Rect(barX, mid-l.barH/2, barX+w, mid+l.barH/2), t.color(i))
+		}
+		drawText(img, barX+barW+l.pad/2, mid-textH/2, percentLabel(d.Values[i], total), t.Muted, scale)
+	}
+}
+
+func pngPie(img *image.RGBA, d Data, o Options, t Theme, l layout, scale int) {
+	total := d.total()
+	top := l.pad + l.titleH
+	r := min(o.Width/2-l.pad, o.Height-top-l.pad) / 2
+	cx, cy := l.pad+r, top+(o.Height-top-l.pad)/2
+
+	// Ángulo final de cada porción, empezando a las 12 en sentido horario
+	ends := make([]float64, len(d.Values))
+	acc := 0
+	for i, v := range d.Values {
+		acc += v
+		if total > 0 {
+			ends[i] = 2 * math.Pi * float64(acc) / float64(total)
+		}
+	}
+
+	r2 := r * r
+	for y := cy - r; y <= cy+r; y++ {
+		for x := cx - r; x <= cx+r; x++ {
+			dx, dy := x-cx, y-cy
+			if dx*dx+dy*dy > r2 {
+				continue
+			}
+			c := t.Track
+			if total > 0 {
+				a := math.Atan2(float64(dx), float64(-dy)) // 0 arriba, creciente a la derecha
+				if a < 0 {
+					a += 2 * math.Pi
+				}
+				for i, end := range ends {
+					if a < end {
+						c = t.color(i)
+						break
+					}
+				}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+
+	legendX := cx + r + l.pad*2
+	textH := glyphH * scale
+	rowH := min(textH*2, (o.Height-top-l.pad)/max(len(d.Labels), 1))
+	chars := (o.Width - legendX - l.pad - textH*3/2) / (glyphW * scale)
+	for i, label := range d.Labels {
+		y := top + i*rowH + rowH/2
+		fill(img, image.Rect(legendX, y-textH/2, legendX+textH, y+textH/2), t.color(i))
+		text := asciiText(label) + " - " + percentLabel(d.Values[i], total)
+		drawText(img, legendX+textH*3/2, y-textH/2, truncate(text, chars, "..."), t.Text, scale)
+	}
+}
+
+func fill(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// asciiText adapta s a la fuente, que solo tiene ASCII: quita tildes, los
+// signos de apertura (¿ ¡) y sustituye el resto de caracteres por "?"
+func asciiText(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '¿' || r == '¡':
+			return -1
+		case r > '~':
+			return '?'
+		}
+		return r
+	}, utils.StripAccents(s))
+}
+
+// drawText escribe s (ya pasado por asciiText) con la esquina superior
+// izquierda en (x, y), ampliando cada píxel de la fuente a scale x scale
+func drawText(img *image.RGBA, x, y int, s string, c color.RGBA, scale int) {
+	w := font.MeasureString(basicfont.Face7x13, s).Ceil()
+	if w == 0 {
+		return
+	}
+	mask := image.NewAlpha(image.Rect(0, 0, w, glyphH))
+	dr := font.Drawer{
+		Dst:  mask,
+		Src:  image.Opaque,
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(0, basicfont.Face7x13.Ascent),
+	}
+	dr.DrawString(s)
+
+	src := image.NewUniform(c)
+	for my := 0; my < glyphH; my++ {
+		for mx := 0; mx < w; mx++ {
+			if mask.AlphaAt(mx, my).A == 0 {
+				continue
+			}
+			px := image.Rect(x+mx*scale, y+my*scale, x+(mx+1)*scale, y+(my+1)*scale)
+			draw.Draw(img, px.Intersect(img.Bounds()), src, image.Point{}, draw.Over)
+		}
+	}
+}
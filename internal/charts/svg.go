@@ -0,0 +1,101 @@
+package charts
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"math"
+)
+
+func renderSVG(d Data, o Options) []byte {
+	t := themes[o.Theme]
+	l := newLayout(d, o)
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Helvetica, Arial, sans-serif">`,
+		o.Width, o.Height, o.Width, o.Height)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`, hexColor(t.Background))
+	fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="%d" font-weight="bold" fill="%s">%s</text>`,
+		l.pad, l.pad+l.fontSize, l.fontSize+2, hexColor(t.Text), html.EscapeString(truncate(d.Title, o.Width/(l.fontSize/2+1), "…")))
+
+	if o.Kind == KindPie {
+		svgPie(&b, d, o, t, l)
+	} else {
+		svgBars(&b, d, o, t, l)
+	}
+	b.WriteString(`</svg>`)
+	return b.Bytes()
+}
+
+func svgBars(b *bytes.Buffer, d Data, o Options, t Theme, l layout) {
+	total := d.total()
+	maxV := 0
+	for _, v := range d.Values {
+		maxV = max(maxV, v)
+	}
+	barX := l.pad + l.labelW
+	barW := o.Width - barX - l.pad - l.valueW
+	chars := l.labelW / (l.fontSize/2 + 1)
+
+	for i, label := range d.Labels {
+		y := l.pad + l.titleH + i*l.rowH
+		mid := y + l.rowH/2
+		fmt.Fprintf(b, `<text x="%d" y="%d" font-size="%d" fill="%s" dominant-baseline="middle">%s</text>`,
+			l.pad, mid, l.fontSize, hexColor(t.Text), html.EscapeString(truncate(label, chars, "…")))
+		fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" rx="3" fill="%s"/>`,
+			barX, mid-l.barH/2, barW, l.barH, hexColor(t.Track))
+		if maxV > 0 && d.Values[i] > 0 {
+			w := max(barW*d.Values[i]/maxV, 1)
+			fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" rx="3" fill="%s"/>`,
+				barX, mid-l.barH/2, w, l.barH, hexColor(t.color(i)))
+		}
+		fmt.Fprintf(b, `<text x="%d" y="%d" font-size="%d" fill="%s" dominant-baseline="middle">%s</text>`,
+			barX+barW+l.pad/2, mid, l.fontSize, hexColor(t.Muted), percentLabel(d.Values[i], total))
+	}
+}
+
+func svgPie(b *bytes.Buffer, d Data, o Options, t Theme, l layout) {
+	total := d.total()
+	top := l.pad + l.titleH
+	r := min(o.Width/2-l.pad, o.Height-top-l.pad) / 2
+	cx, cy := l.pad+r, top+(o.Height-top-l.pad)/2
+
+	switch {
+	case total == 0:
+		fmt.Fprintf(b, `<circle cx="%d" cy="%d" r="%d" fill="%s"/>`, cx, cy, r, hexColor(t.Track))
+	default:
+		angle := -math.Pi / 2 // empezamos a las 12
+		for i, v := range d.Values {
+			if v == 0 {
+				continue
+			}
+			if v == total {
+				fmt.Fprintf(b, `<circle cx="%d" cy="%d" r="%d" fill="%s"/>`, cx, cy, r, hexColor(t.color(i)))
+				break
+			}
+			sweep := 2 * math.Pi * float64(v) / float64(total)
+			x1, y1 := float64(cx)+float64(r)*math.Cos(angle), float64(cy)+float64(r)*math.Sin(angle)
+			angle += sweep
+			x2, y2 := float64(cx)+float64(r)*math.Cos(angle), float64(cy)+float64(r)*math.Sin(angle)
+			large := 0
+			if sweep > math.Pi {
+				large = 1
+			}
+			fmt.Fprintf(b, `<path d="M%d,%d L%.2f,%.2f A%d,%d 0 %d,1 %.2f,%.2f Z" fill="%s"/>`,
+				cx, cy, x1, y1, r, r, large, x2, y2, hexColor(t.color(i)))
+		}
+	}
+
+	// Leyenda a la derecha
+	legendX := cx + r + l.pad*2
+	rowH := min(l.fontSize*2, (o.Height-top-l.pad)/max(len(d.Labels), 1))
+	chars := (o.Width - legendX - l.pad) / (l.fontSize/2 + 1)
+	for i, label := range d.Labels {
+		y := top + i*rowH + rowH/2
+		fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`,
+			legendX, y-l.fontSize/2, l.fontSize, l.fontSize, hexColor(t.color(i)))
+		text := label + " · " + percentLabel(d.Values[i], total)
+		fmt.Fprintf(b, `<text x="%d" y="%d" font-size="%d" fill="%s" dominant-baseline="middle">%s</text>`,
+			legendX+l.fontSize*3/2, y, l.fontSize, hexColor(t.Text), html.EscapeString(truncate(text, chars, "…")))
+	}
+}
@@ -0,0 +1,124 @@
+// Package export escribe tablas fila a fila en CSV, JSON Lines o XLSX, sin
+// acumularlas en memoria, para poder volcar encuestas con millones de votos
+// directamente sobre la respuesta HTTP.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Formatos soportados
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+	FormatXLSX  = "xlsx"
+)
+
+// Writer recibe las filas de una tabla. Cada fila tiene una celda por columna;
+// las celdas pueden ser string, int, float64, bool, time.Time o nil.
+type Writer interface {
+	WriteRow(cells ...any) error
+	// Close termina el documento; sin él el XLSX queda corrupto
+	Close() error
+}
+
+// NewWriter crea un Writer del formato pedido con las columnas indicadas
+func NewWriter(format string, w io.Writer, columns []string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, columns)
+	case FormatJSONL:
+		return &jsonlWriter{enc: json.NewEncoder(w), columns: columns}, nil
+	case FormatXLSX:
+		return newXLSXWriter(w, columns)
+	}
+	return nil, errors.New("INVALID_FORMAT")
+}
+
+// ContentType es el tipo MIME de cada formato
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatJSONL:
+		return "application/x-ndjson"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "application/octet-stream"
+}
+
+// formulaPrefixes son los caracteres con los que Excel y compañía interpretan
+// un texto como fórmula al abrir o editar la celda
+const formulaPrefixes = "=+-@\t\r"
+
+// cellString da la representación textual de una celda (CSV y textos de XLSX).
+// Los textos que empiezan como una fórmula llevan delante un apóstrofo para
+// que la hoja de cálculo los trate como texto: los escriben los usuarios
+// (títulos, opciones) y no deben ejecutarse en el equipo de quien exporta.
+func cellString(v any) string {
+	switch c := v.(type) {
+	case nil:
+		return ""
+	case string:
+		if c != "" && strings.ContainsRune(formulaPrefixes, rune(c[0])) {
+			return "'" + c
+		}
+		return c
+	case time.Time:
+		return c.UTC().Format(time.RFC3339)
+	default:
+		return fmt.Sprint(c)
+	}
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, columns []string) (*csvWriter, error) {
+	// BOM para que Excel detecte UTF-8 al abrir el CSV
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, err
+	}
+	cw := &csvWriter{w: csv.NewWriter(w)}
+	return cw, cw.w.Write(columns)
+}
+
+func (c *csvWriter) WriteRow(cells ...any) error {
+	record := make([]string, len(cells))
+	for i, v := range cells {
+		record[i] = cellString(v)
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonlWriter escribe un objeto JSON por línea, con las columnas como claves
+type jsonlWriter struct {
+	enc     *json.Encoder
+	columns []string
+}
+
+func (j *jsonlWriter) WriteRow(cells ...any) error {
+	row := make(map[string]any, len(cells))
+	for i, v := range cells {
+		if t, ok := v.(time.Time); ok {
+			v = t.UTC()
+		}
+		row[j.columns[i]] = v
+	}
+	return j.enc.Encode(row)
+}
+
+func (j *jsonlWriter) Close() error { return nil }
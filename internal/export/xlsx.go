@@ -0,0 +1,203 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// xlsxMaxRows es el límite de filas de una hoja de Excel
+const xlsxMaxRows = 1048576
+
+// xlsxWriter genera un libro XLSX. Un XLSX es un ZIP de XML: cada hoja se va
+// escribiendo fila a fila dentro de su entrada del ZIP, así que nunca se guarda
+// entera en memoria. Cuando una hoja llega al límite de filas de Excel se abre
+// otra (con la cabecera repetida); el libro y los tipos de contenido, que
+// enumeran las hojas, se escriben al cerrar. Los textos van como inlineStr
+// para no necesitar la tabla de cadenas compartidas.
+type xlsxWriter struct {
+	zip     *zip.Writer
+	sheet   *bufio.Writer
+	header  []any
+	sheets  int
+	row     int
+	maxRows int
+}
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+%s<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+</Types>`
+
+const xlsxSheetContentType = `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+const xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets>%s</sheets>
+</workbook>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rStyles" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+%s</Relationships>`
+
+const xlsxSheetRel = `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>
+`
+
+// Estilos mínimos: el 1 es el formato de fecha y hora para las celdas time.Time
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>
+<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>
+</styleSheet>`
+
+func newXLSXWriter(w io.Writer, columns []string) (*xlsxWriter, error) {
+	zw := zip.NewWriter(w)
+	if err := writePart(zw, "_rels/.rels", xlsxRootRels); err != nil {
+		return nil, err
+	}
+	if err := writePart(zw, "xl/styles.xml", xlsxStyles); err != nil {
+		return nil, err
+	}
+
+	x := &xlsxWriter{zip: zw, maxRows: xlsxMaxRows}
+	x.header = make([]any, len(columns))
+	for i, c := range columns {
+		x.header[i] = c
+	}
+	return x, x.nextSheet()
+}
+
+func writePart(zw *zip.Writer, name, body string) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(f, body)
+	return err
+}
+
+// nextSheet cierra la hoja en curso (si la hay) y abre la siguiente con la
+// cabecera. La hoja abierta es siempre la última entrada del ZIP.
+func (x *xlsxWriter) nextSheet() error {
+	if x.sheet != nil {
+		if err := x.closeSheet(); err != nil {
+			return err
+		}
+	}
+	x.sheets++
+	f, err := x.zip.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", x.sheets))
+	if err != nil {
+		return err
+	}
+	x.sheet = bufio.NewWriter(f)
+	x.row = 0
+	x.sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	return x.writeRow(x.header)
+}
+
+func (x *xlsxWriter) closeSheet() error {
+	x.sheet.WriteString(`</sheetData></worksheet>`)
+	return x.sheet.Flush()
+}
+
+func (x *xlsxWriter) WriteRow(cells ...any) error {
+	if x.row >= x.maxRows {
+		if err := x.nextSheet(); err != nil {
+			return err
+		}
+	}
+	return x.writeRow(cells)
+}
+
+func (x *xlsxWriter) writeRow(cells []any) error {
+	x.row++
+	fmt.Fprintf(x.sheet, `<row r="%d">`, x.row)
+	for i, v := range cells {
+		ref := columnName(i) + strconv.Itoa(x.row)
+		switch c := v.(type) {
+		case nil:
+			continue
+		case int:
+			fmt.Fprintf(x.sheet, `<c r="%s"><v>%d</v></c>`, ref, c)
+		case float64:
+			fmt.Fprintf(x.sheet, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(c, 'f', -1, 64))
+		case bool:
+			b := 0
+			if c {
+				b = 1
+			}
+			fmt.Fprintf(x.sheet, `<c r="%s" t="b"><v>%d</v></c>`, ref, b)
+		case time.Time:
+			fmt.Fprintf(x.sheet, `<c r="%s" s="1"><v>%s</v></c>`, ref, strconv.FormatFloat(excelSerial(c), 'f', -1, 64))
+		default:
+			fmt.Fprintf(x.sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+			xml.EscapeText(x.sheet, []byte(cellString(c)))
+			x.sheet.WriteString(`</t></is></c>`)
+		}
+	}
+	_, err := x.sheet.WriteString(`</row>`)
+	return err
+}
+
+func (x *xlsxWriter) Close() error {
+	if err := x.closeSheet(); err != nil {
+		return err
+	}
+
+	var types, sheets, rels strings.Builder
+	for i := 1; i <= x.sheets; i++ {
+		name := "Export"
+		if i > 1 {
+			name = fmt.Sprintf("Export %d", i)
+		}
+		fmt.Fprintf(&types, xlsxSheetContentType, i)
+		fmt.Fprintf(&sheets, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, name, i, i)
+		fmt.Fprintf(&rels, xlsxSheetRel, i, i)
+	}
+	parts := []struct{ name, body string }{
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, sheets.String())},
+		{"xl/_rels/workbook.xml.rels", fmt.Sprintf(xlsxWorkbookRels, rels.String())},
+		{"[Content_Types].xml", fmt.Sprintf(xlsxContentTypes, types.String())},
+	}
+	for _, p := range parts {
+		if err := writePart(x.zip, p.name, p.body); err != nil {
+			return err
+		}
+	}
+	return x.zip.Close()
+}
+
+// columnName convierte un índice desde 0 en la letra de columna de Excel (A, B, ..., AA)
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// excelSerial expresa t (en UTC) como número de serie de Excel: días desde 1899-12-30
+func excelSerial(t time.Time) float64 {
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	return t.UTC().Sub(epoch).Hours() / 24
+}
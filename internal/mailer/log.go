@@ -0,0 +1,66 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// LogMailer no envía nada: escribe cada correo en el log y, si Dir no está
+// vacío, lo guarda como un fichero .eml que se puede abrir con cualquier
+// cliente de correo. Sent guarda los últimos enviados para las pruebas.
+type LogMailer struct {
+	From string
+	Dir  string
+
+	mu   sync.Mutex
+	Sent []Message
+}
+
+// maxKeptMessages limita los correos que guarda Sent
+const maxKeptMessages = 100
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	now := time.Now()
+	body, err := build(m.From, msg, now)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.Sent = append(m.Sent, msg)
+	if len(m.Sent) > maxKeptMessages {
+		m.Sent = m.Sent[len(m.Sent)-maxKeptMessages:]
+	}
+	m.mu.Unlock()
+
+	if m.Dir == "" {
+		log.Printf("correo para %s: %s\n%s", msg.To, msg.Subject, msg.Text)
+		return nil
+	}
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return err
+	}
+	name := filepath.Join(m.Dir, fmt.Sprintf("%s-%s.eml", now.Format("20060102T150405.000000000"), randomID()[:6]))
+	if err := os.WriteFile(name, body, 0o644); err != nil {
+		return err
+	}
+	log.Printf("correo para %s guardado en %s", msg.To, name)
+	return nil
+}
+
+// Last devuelve el último correo enviado a to, si lo hay
+func (m *LogMailer) Last(to string) (Message, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.Sent) - 1; i >= 0; i-- {
+		if m.Sent[i].To == to {
+			return m.Sent[i], true
+		}
+	}
+	return Message{}, false
+}
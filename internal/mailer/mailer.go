@@ -0,0 +1,64 @@
+// Package mailer envía los correos de la aplicación (verificación de email,
+// avisos de seguridad...). Mailer es la interfaz; SMTPMailer los envía de
+// verdad y LogMailer los deja en el log o en ficheros .eml, para desarrollo y
+// pruebas.
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Message es un correo de texto plano, con una alternativa HTML opcional
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Mailer envía correos
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// FromEnv crea el Mailer configurado en el entorno:
+//
+//	MAIL_DRIVER   smtp | log (por defecto log)
+//	MAIL_FROM     remitente, p. ej. "Voty <no-reply@voty.app>"
+//	SMTP_HOST, SMTP_PORT (587), SMTP_USER, SMTP_PASSWORD
+//	MAIL_DIR      con log, carpeta donde guardar cada correo como .eml
+func FromEnv() (Mailer, error) {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "Voty <no-reply@localhost>"
+	}
+	switch driver := os.Getenv("MAIL_DRIVER"); driver {
+	case "", "log":
+		return &LogMailer{From: from, Dir: os.Getenv("MAIL_DIR")}, nil
+	case "smtp":
+		host := os.Getenv("SMTP_HOST")
+		if host == "" {
+			return nil, fmt.Errorf("SMTP_HOST is required with MAIL_DRIVER=smtp")
+		}
+		port := 587
+		if v := os.Getenv("SMTP_PORT"); v != "" {
+			p, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SMTP_PORT: %w", err)
+			}
+			port = p
+		}
+		return &SMTPMailer{
+			Host:     host,
+			Port:     port,
+			Username: os.Getenv("SMTP_USER"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     from,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown MAIL_DRIVER %q", driver)
+	}
+}
@@ -4,14 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"log"
 	"time"
 
 	"api_voty/ent"
+	"api_voty/ent/loginattempt"
 	"api_voty/ent/user"
+	"api_voty/internal/mailer"
 	"api_voty/internal/utils"
 
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type LoginRequest struct {
@@ -23,20 +25,39 @@ type RegisterRequest struct {
 	Email    string `json:"email"`
 	Name     string `json:"name"`
 	Password string `json:"password"`
+	// Token de invitado del dispositivo: sus votos pasan a la cuenta nueva
+	GuestToken string `json:"guest_token,omitempty"`
 }
 
 type AuthResponse struct {
-	Token string       `json:"token"`
-	User  UserResponse `json:"user"`
+	Token        string        `json:"token,omitempty"`
+	User         *UserResponse `json:"user,omitempty"`
+	ClaimedVotes int           `json:"claimed_votes,omitempty"`
+	// Con el segundo factor activado el login se completa en /login/2fa con
+	// este token y un código
+	TwoFactorRequired bool       `json:"two_factor_required,omitempty"`
+	ChallengeToken    string     `json:"challenge_token,omitempty"`
+	ChallengeExpires  *time.Time `json:"challenge_expires_at,omitempty"`
+	// El administrador debe activar el segundo factor para usar sus permisos
+	TwoFactorSetupRequired bool `json:"two_factor_setup_required,omitempty"`
 }
 
 type AuthModel struct {
-	client *ent.Client
-	db     *sql.DB
+	client   *ent.Client
+	db       *sql.DB
+	notifier LoginNotifier
+	mailer   mailer.Mailer
+	baseURL  string // raíz pública para los enlaces de los correos
+	passwords
+}
+
+// NotifyLogins registra n para avisar de logins desde dispositivos o redes nuevos
+func (m *AuthModel) NotifyLogins(n LoginNotifier) {
+	m.notifier = n
 }
 
 func NewAuthModel(client *ent.Client, db *sql.DB) *AuthModel {
-	return &AuthModel{client: client, db: db}
+	return &AuthModel{client: client, db: db, passwords: defaultPasswords()}
 }
 
 func (m *AuthModel) Register(ctx context.Context, req RegisterRequest) (*AuthResponse, error) {
@@ -50,7 +71,14 @@ func (m *AuthModel) Register(ctx context.Context, req RegisterRequest) (*AuthRes
 		return nil, errors.New("email already registered")
 	}
 
-	hashedPass, err := bcrypt.GenerateFromPassword([]byte(req.Password), 14)
+	var guestID string
+	if req.GuestToken != "" {
+		if guestID, err = utils.ValidateGuestToken(req.GuestToken); err != nil {
+			return nil, errors.New("invalid guest token")
+		}
+	}
+
+	hashedPass, err := m.hashNew(req.Password, req.Email, req.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +88,7 @@ func (m *AuthModel) Register(ctx context.Context, req RegisterRequest) (*AuthRes
 		SetID(uuid.New().String()).
 		SetEmail(req.Email).
 		SetName(req.Name).
-		SetPassword(string(hashedPass)).
+		SetPassword(hashedPass).
 		SetActive(true).
 		SetCreatedAt(time.Now()).
 		SetUpdatedAt(time.Now()).
@@ -69,53 +97,130 @@ func (m *AuthModel) Register(ctx context.Context, req RegisterRequest) (*AuthRes
 		return nil, err
 	}
 
+	m.sendVerificationAfterRegister(ctx, newUser)
+
+	// La cuenta ya existe: si el traspaso falla, los votos siguen como invitado
+	// y se pueden reclamar después desde el perfil
+	claimed, err := claimGuestVotes(ctx, m.client, guestID, newUser.ID)
+	if err != nil {
+		log.Printf("error traspasando los votos del invitado %s: %v", guestID, err)
+	}
+
 	return &AuthResponse{
-		User: UserResponse{
-			ID:        newUser.ID,
-			Email:     newUser.Email,
-			Name:      newUser.Name,
-			Active:    newUser.Active,
-			CreatedAt: newUser.CreatedAt,
-			UpdatedAt: newUser.UpdatedAt,
-		},
+		User:         toUserResponse(newUser),
+		ClaimedVotes: claimed,
 	}, nil
 }
 
-func (m *AuthModel) Login(ctx context.Context, req LoginRequest) (*AuthResponse, error) {
+// Login comprueba las credenciales y deja el intento en el historial. Los
+// bloqueos (por IP, por cuenta o por intentos demasiado seguidos) se
+// devuelven como *LoginLockedError antes de mirar la contraseña.
+func (m *AuthModel) Login(ctx context.Context, req LoginRequest, meta LoginMeta) (*AuthResponse, error) {
+	now := time.Now()
+	wait, err := m.ipBlocked(ctx, meta.IP, now)
+	if err != nil {
+		return nil, err
+	}
+	if wait > 0 {
+		m.recordAttempt(ctx, nil, req.Email, meta, failure(loginattempt.FailureReasonIPBlocked))
+		return nil, &LoginLockedError{Reason: "too many failed logins from this address", RetryAfter: wait}
+	}
+
 	user, err := m.client.User.Query().
 		Where(user.Email(req.Email)).
 		Only(ctx)
 	if err != nil {
+		m.recordAttempt(ctx, nil, req.Email, meta, failure(loginattempt.FailureReasonUnknownUser))
 		return nil, errors.New("invalid credentials")
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
-	if err != nil {
+	if locked := accountLocked(user, now); locked != nil {
+		m.recordAttempt(ctx, user, req.Email, meta, failure(locked.failure))
+		return nil, locked
+	}
+
+	// Las cuentas creadas con un proveedor OIDC no tienen contraseña
+	ok := false
+	if user.Password != "" {
+		if ok, err = m.hasher.Verify(req.Password, user.Password); err != nil {
+			return nil, err
+		}
+	}
+	if !ok {
+		if err := m.registerFailure(ctx, user, now); err != nil {
+			log.Printf("error contando el fallo de login de %s: %v", user.ID, err)
+		}
+		m.recordAttempt(ctx, user, req.Email, meta, failure(loginattempt.FailureReasonBadPassword))
 		return nil, errors.New("invalid credentials")
 	}
 
 	if !user.Active {
+		m.recordAttempt(ctx, user, req.Email, meta, failure(loginattempt.FailureReasonInactive))
 		return nil, errors.New("user is inactive")
 	}
 
-	token, err := utils.GenerateToken(user.ID, user.Email)
+	m.rehash(ctx, user, req.Password)
+	return m.startSession(ctx, user, req.Email, meta)
+}
+
+// startSession sigue a un primer factor correcto (contraseña o proveedor
+// OIDC): pide el segundo si está activado y si no, completa el login
+func (m *AuthModel) startSession(ctx context.Context, user *ent.User, email string, meta LoginMeta) (*AuthResponse, error) {
+	if user.TotpEnabledAt != nil {
+		// Los fallos no se ponen a cero hasta pasar el segundo factor
+		challenge, expiresAt, err := utils.GenerateTwoFactorChallenge(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		return &AuthResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    challenge,
+			ChallengeExpires:  &expiresAt,
+		}, nil
+	}
+	return m.completeLogin(ctx, user, email, meta)
+}
+
+// completeLogin cierra un login correcto: pone a cero los fallos, lo guarda
+// en el historial, avisa si es un dispositivo nuevo y emite la sesión
+func (m *AuthModel) completeLogin(ctx context.Context, user *ent.User, email string, meta LoginMeta) (*AuthResponse, error) {
+	if err := m.registerSuccess(ctx, user); err != nil {
+		return nil, err
+	}
+	reasons, err := m.newLoginReasons(ctx, user.ID, meta)
+	if err != nil {
+		log.Printf("error comparando el login de %s con los anteriores: %v", user.ID, err)
+	}
+	m.notifyNewLogin(user, m.recordAttempt(ctx, user, email, meta, nil), reasons)
+
+	token, err := utils.GenerateToken(user.ID, user.Email, user.TokenVersion)
 	if err != nil {
 		return nil, err
 	}
 
 	return &AuthResponse{
-		Token: token,
-		User: UserResponse{
-			ID:        user.ID,
-			Email:     user.Email,
-			Name:      user.Name,
-			Active:    user.Active,
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
-		},
+		Token:                  token,
+		User:                   toUserResponse(user),
+		TwoFactorSetupRequired: m.mustEnrollTwoFactor(user),
 	}, nil
 }
 
+// rehash renueva el hash de la contraseña si se calculó con otro algoritmo o
+// parámetros. Es el único momento en que se tiene la contraseña en claro; si
+// falla, se intentará en el siguiente login.
+func (m *AuthModel) rehash(ctx context.Context, u *ent.User, plain string) {
+	if !m.hasher.NeedsRehash(u.Password) {
+		return
+	}
+	hashed, err := m.hasher.Hash(plain)
+	if err == nil {
+		err = m.client.User.UpdateOne(u).SetPassword(hashed).Exec(ctx)
+	}
+	if err != nil {
+		log.Printf("error renovando el hash de la contraseña de %s: %v", u.ID, err)
+	}
+}
+
 func (m *AuthModel) GetUserByID(ctx context.Context, id string) (*UserResponse, error) {
 	u, err := m.client.User.Query().
 		Where(user.ID(id)).
@@ -125,12 +230,5 @@ func (m *AuthModel) GetUserByID(ctx context.Context, id string) (*UserResponse,
 		return nil, err
 	}
 
-	return &UserResponse{
-		ID:        u.ID,
-		Email:     u.Email,
-		Name:      u.Name,
-		Active:    u.Active,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
-	}, nil
+	return toUserResponse(u), nil
 }
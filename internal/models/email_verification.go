@@ -0,0 +1,141 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/ent/user"
+	"api_voty/internal/mailer"
+	"api_voty/internal/utils"
+)
+
+const (
+	// resendInterval es el tiempo mínimo entre dos envíos del enlace de verificación
+	resendInterval = time.Minute
+	mailTimeout    = 30 * time.Second
+)
+
+// RetryLaterError rechaza una operación que se puede repetir pasado RetryAfter
+type RetryLaterError struct {
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e *RetryLaterError) Error() string { return e.Reason }
+
+// UseMailer configura el envío de correos; baseURL es la raíz pública para
+// los enlaces. Sin mailer no se envía nada.
+func (m *AuthModel) UseMailer(mm mailer.Mailer, baseURL string) {
+	m.mailer = mm
+	m.baseURL = strings.TrimSuffix(baseURL, "/")
+}
+
+// SendVerificationEmail envía (o reenvía) el enlace de verificación del email
+func (m *AuthModel) SendVerificationEmail(ctx context.Context, userID string) error {
+	u, err := m.client.User.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if u.EmailVerifiedAt != nil {
+		return errors.New("EMAIL_ALREADY_VERIFIED")
+	}
+	now := time.Now()
+	if u.VerificationSentAt != nil {
+		if wait := u.VerificationSentAt.Add(resendInterval).Sub(now); wait > 0 {
+			return &RetryLaterError{Reason: "verification email sent recently", RetryAfter: wait}
+		}
+	}
+	if m.mailer == nil {
+		return errors.New("MAILER_NOT_CONFIGURED")
+	}
+
+	msg, err := m.verificationMessage(u)
+	if err != nil {
+		return err
+	}
+	if err := m.mailer.Send(ctx, msg); err != nil {
+		return err
+	}
+	return m.client.User.UpdateOne(u).SetVerificationSentAt(now).Exec(ctx)
+}
+
+// verificationMessage prepara el correo con el enlace de verificación de u
+func (m *AuthModel) verificationMessage(u *ent.User) (mailer.Message, error) {
+	token, err := utils.GenerateEmailVerificationToken(u.ID, u.Email)
+	if err != nil {
+		return mailer.Message{}, err
+	}
+	link := m.baseURL + "/verify-email?token=" + url.QueryEscape(token)
+	return mailer.Message{
+		To:      u.Email,
+		Subject: "Confirma tu email en Voty",
+		Text: fmt.Sprintf("Hola %s,\n\nPara confirmar tu email abre este enlace (caduca en 48 horas):\n\n%s\n\n"+
+			"Si no has creado una cuenta en Voty, ignora este mensaje.\n", u.Name, link),
+	}, nil
+}
+
+// VerifyEmail marca como verificado el email del token. El token deja de
+// valer si el usuario cambió de email después de pedirlo.
+func (m *AuthModel) VerifyEmail(ctx context.Context, token string) (*UserResponse, error) {
+	userID, email, err := utils.ValidateEmailVerificationToken(token)
+	if err != nil {
+		return nil, errors.New("INVALID_VERIFICATION_TOKEN")
+	}
+	u, err := m.client.User.Get(ctx, userID)
+	if err != nil || !strings.EqualFold(u.Email, email) {
+		return nil, errors.New("INVALID_VERIFICATION_TOKEN")
+	}
+	if u.EmailVerifiedAt == nil {
+		if u, err = m.client.User.UpdateOne(u).SetEmailVerifiedAt(time.Now()).Save(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return toUserResponse(u), nil
+}
+
+// sendVerificationAfterRegister no hace fallar ni esperar al registro: el
+// correo sale en segundo plano y, si se pierde, el usuario puede pedir el
+// reenvío pasado resendInterval
+func (m *AuthModel) sendVerificationAfterRegister(ctx context.Context, u *ent.User) {
+	if m.mailer == nil {
+		return
+	}
+	msg, err := m.verificationMessage(u)
+	if err == nil {
+		err = m.client.User.UpdateOne(u).SetVerificationSentAt(time.Now()).Exec(ctx)
+	}
+	if err != nil {
+		log.Printf("error preparando la verificación de %s: %v", u.Email, err)
+		return
+	}
+	m.sendLater(ctx, msg)
+}
+
+// sendLater envía el correo sin hacer esperar a la petición. Los fallos solo
+// se registran en el log.
+func (m *AuthModel) sendLater(ctx context.Context, msg mailer.Message) {
+	if m.mailer == nil {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, mailTimeout)
+		defer cancel()
+		if err := m.mailer.Send(ctx, msg); err != nil {
+			log.Printf("error enviando \"%s\" a %s: %v", msg.Subject, msg.To, err)
+		}
+	}()
+}
+
+// EmailVerified indica si el usuario confirmó su email
+func (m *UserModel) EmailVerified(ctx context.Context, userID string) (bool, error) {
+	return m.client.User.Query().
+		Where(user.ID(userID), user.EmailVerifiedAtNotNil()).
+		Exist(ctx)
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+
+	"api_voty/ent"
+	"api_voty/ent/poll"
+)
+
+// maxEmbedOrigins limita la lista de orígenes de un autor
+const maxEmbedOrigins = 20
+
+// normalizeOrigin reduce una URL a su origen (esquema://host[:puerto]) y
+// rechaza lo que no lo sea, para no meter rutas ni comodines en la CSP
+func normalizeOrigin(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" ||
+		u.User != nil || strings.Trim(u.Path, "/") != "" || u.RawQuery != "" || u.Fragment != "" ||
+		strings.ContainsAny(u.Host, "*; ") {
+		return "", errors.New("INVALID_ORIGIN")
+	}
+	return u.Scheme + "://" + strings.ToLower(u.Host), nil
+}
+
+// EmbedOrigins devuelve los orígenes donde el usuario permite incrustar sus encuestas
+func (m *UserModel) EmbedOrigins(ctx context.Context, userID string) ([]string, error) {
+	u, err := m.client.User.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u.EmbedOrigins == nil {
+		return []string{}, nil
+	}
+	return u.EmbedOrigins, nil
+}
+
+// SetEmbedOrigins reemplaza la lista de orígenes, normalizada y sin duplicados
+func (m *UserModel) SetEmbedOrigins(ctx context.Context, userID string, origins []string) ([]string, error) {
+	clean := make([]string, 0, len(origins))
+	for _, raw := range origins {
+		origin, err := normalizeOrigin(raw)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(clean, origin) {
+			clean = append(clean, origin)
+		}
+	}
+	if len(clean) > maxEmbedOrigins {
+		return nil, errors.New("TOO_MANY_ORIGINS")
+	}
+	if err := m.client.User.UpdateOneID(userID).SetEmbedOrigins(clean).Exec(ctx); err != nil {
+		return nil, err
+	}
+	return clean, nil
+}
+
+// EmbeddablePoll carga una encuesta publicada e incrustable con sus opciones,
+// el voto de voter (si lo hay) y su autor, cuyos orígenes rigen CORS y CSP.
+// Cualquier otro caso es POLL_NOT_EMBEDDABLE.
+func (m *PollModel) EmbeddablePoll(ctx context.Context, pollID int, voter Voter) (*ent.Poll, error) {
+	p, err := m.client.Poll.Query().
+		Where(poll.ID(pollID), poll.StatusEQ(poll.StatusPublished), poll.Embeddable(true)).
+		WithOptions(orderedOptions).
+		WithTags(orderedTags).
+		WithOwner().
+		WithVotes(func(q *ent.VoteQuery) {
+			q.Where(voter.ballots()).WithPollOption()
+		}).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, errors.New("POLL_NOT_EMBEDDABLE")
+	}
+	return p, err
+}
+
+// EmbedOriginsOf son los orígenes permitidos para una encuesta cargada con WithOwner
+func EmbedOriginsOf(p *ent.Poll) []string {
+	if p.Edges.Owner == nil {
+		return nil
+	}
+	return p.Edges.Owner.EmbedOrigins
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"context"
+	"errors"
+
+	"api_voty/ent"
+	"api_voty/ent/poll"
+	"api_voty/ent/predicate"
+	"api_voty/ent/user"
+	"api_voty/ent/vote"
+)
+
+// Voter es quien emite un voto: un usuario registrado o un dispositivo
+// invitado (GuestID, sacado de un token de invitado). Nunca ambos.
+type Voter struct {
+	UserID  string
+	GuestID string
+}
+
+// UserVoter y GuestVoter construyen un Voter de cada tipo
+func UserVoter(userID string) Voter   { return Voter{UserID: userID} }
+func GuestVoter(guestID string) Voter { return Voter{GuestID: guestID} }
+
+func (v Voter) IsGuest() bool { return v.GuestID != "" }
+
+// ballots filtra los votos emitidos por v. Un Voter vacío no tiene votos.
+func (v Voter) ballots() predicate.Vote {
+	if v.IsGuest() {
+		return vote.GuestID(v.GuestID)
+	}
+	return vote.HasUserWith(user.ID(v.UserID))
+}
+
+// GuestPoll carga una encuesta publicada que admite invitados, con sus
+// opciones y el voto del dispositivo. Cualquier otro caso es GUESTS_NOT_ALLOWED.
+func (m *PollModel) GuestPoll(ctx context.Context, pollID int, guestID string) (*ent.Poll, error) {
+	p, err := m.client.Poll.Query().
+		Where(poll.ID(pollID), poll.StatusEQ(poll.StatusPublished), poll.AllowGuests(true)).
+		WithOptions(orderedOptions).
+		WithTags(orderedTags).
+		WithVotes(func(q *ent.VoteQuery) {
+			q.Where(GuestVoter(guestID).ballots()).WithPollOption()
+		}).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, errors.New("GUESTS_NOT_ALLOWED")
+	}
+	return p, err
+}
+
+// ClaimGuestVotes pasa al usuario los votos que emitió como invitado desde un
+// dispositivo. Si el usuario ya había votado en alguna de esas encuestas, el
+// voto de invitado se queda como estaba para no romper un voto por usuario.
+// Devuelve cuántos votos se traspasaron.
+func (m *PollModel) ClaimGuestVotes(ctx context.Context, guestID, userID string) (int, error) {
+	return claimGuestVotes(ctx, m.client, guestID, userID)
+}
+
+// claimGuestVotes lo comparten ClaimGuestVotes y el registro (AuthModel)
+func claimGuestVotes(ctx context.Context, client *ent.Client, guestID, userID string) (int, error) {
+	if guestID == "" {
+		return 0, nil
+	}
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	// Se buscan primero los IDs: MySQL no deja actualizar votes filtrando por
+	// una subconsulta sobre la propia tabla votes
+	ids, err := tx.Vote.Query().
+		Where(
+			vote.GuestID(guestID),
+			vote.Not(vote.HasPollWith(poll.HasVotesWith(vote.HasUserWith(user.ID(userID))))),
+		).
+		IDs(ctx)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, tx.Rollback()
+	}
+	n, err := tx.Vote.Update().
+		Where(vote.IDIn(ids...)).
+		SetUserID(userID).
+		ClearGuestID().
+		Save(ctx)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	return n, tx.Commit()
+}
@@ -0,0 +1,175 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/ent/linkedidentity"
+	"api_voty/ent/loginattempt"
+	"api_voty/ent/user"
+	"api_voty/internal/oidc"
+
+	"github.com/google/uuid"
+)
+
+// LinkedIdentityResponse es una cuenta de proveedor vinculada
+type LinkedIdentityResponse struct {
+	ID          int        `json:"id"`
+	Provider    string     `json:"provider"`
+	Email       string     `json:"email"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
+
+// LoginWithIdentity inicia sesión con una identidad ya verificada por un
+// proveedor OIDC. Si no está vinculada, se vincula al usuario con el mismo
+// email o se crea uno nuevo; en ambos casos el proveedor debe haber
+// verificado el email.
+func (m *AuthModel) LoginWithIdentity(ctx context.Context, id oidc.Identity, meta LoginMeta) (*AuthResponse, error) {
+	link, err := m.client.LinkedIdentity.Query().
+		Where(linkedidentity.Provider(id.Provider), linkedidentity.Subject(id.Subject)).
+		WithUser().
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		link, err = m.linkIdentity(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	u := link.Edges.User
+	if !u.Active {
+		m.recordAttempt(ctx, u, u.Email, meta, failure(loginattempt.FailureReasonInactive))
+		return nil, errors.New("user is inactive")
+	}
+	if err := m.client.LinkedIdentity.UpdateOne(link).SetLastLoginAt(time.Now()).Exec(ctx); err != nil {
+		return nil, err
+	}
+	return m.startSession(ctx, u, u.Email, meta)
+}
+
+// linkIdentity vincula la identidad al usuario con su email o crea uno nuevo
+func (m *AuthModel) linkIdentity(ctx context.Context, id oidc.Identity) (*ent.LinkedIdentity, error) {
+	if !id.EmailVerified || id.Email == "" {
+		return nil, errors.New("IDENTITY_EMAIL_NOT_VERIFIED")
+	}
+	now := time.Now()
+	tx, err := m.client.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u, err := tx.User.Query().
+		Where(user.EmailEqualFold(id.Email)).
+		Only(ctx)
+	switch {
+	case ent.IsNotFound(err):
+		name := id.Name
+		if name == "" {
+			name, _, _ = strings.Cut(id.Email, "@")
+		}
+		u, err = tx.User.Create().
+			SetID(uuid.New().String()).
+			SetEmail(id.Email).
+			SetName(name).
+			SetPassword("").
+			SetActive(true).
+			SetEmailVerifiedAt(now).
+			SetCreatedAt(now).
+			SetUpdatedAt(now).
+			Save(ctx)
+	case err == nil && u.EmailVerifiedAt == nil:
+		// Nadie había demostrado ser dueño del email: quien registró la
+		// cuenta pudo ser otro, así que pierde todo lo que dejó configurado
+		// para volver a entrar o actuar en su nombre: contraseña, sesiones,
+		// segundo factor y orígenes de incrustación
+		u, err = tx.User.UpdateOne(u).
+			SetEmailVerifiedAt(now).
+			SetPassword("").
+			AddTokenVersion(1).
+			ClearTotpSecret().
+			ClearTotpEnabledAt().
+			SetTotpLastStep(0).
+			ClearRecoveryCodes().
+			ClearEmbedOrigins().
+			Save(ctx)
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	link, err := tx.LinkedIdentity.Create().
+		SetUserID(u.ID).
+		SetProvider(id.Provider).
+		SetSubject(id.Subject).
+		SetEmail(id.Email).
+		Save(ctx)
+	if err != nil {
+		tx.Rollback()
+		// Otra petición con la misma identidad llegó antes
+		if ent.IsConstraintError(err) {
+			return m.client.LinkedIdentity.Query().
+				Where(linkedidentity.Provider(id.Provider), linkedidentity.Subject(id.Subject)).
+				WithUser().
+				Only(ctx)
+		}
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	link.Edges.User = u
+	return link, nil
+}
+
+// LinkedIdentities lista las cuentas de proveedores vinculadas al usuario
+func (m *UserModel) LinkedIdentities(ctx context.Context, userID string) ([]LinkedIdentityResponse, error) {
+	links, err := m.client.LinkedIdentity.Query().
+		Where(linkedidentity.UserID(userID)).
+		Order(ent.Asc(linkedidentity.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]LinkedIdentityResponse, len(links))
+	for i, l := range links {
+		items[i] = LinkedIdentityResponse{
+			ID:          l.ID,
+			Provider:    l.Provider,
+			Email:       l.Email,
+			CreatedAt:   l.CreatedAt,
+			LastLoginAt: l.LastLoginAt,
+		}
+	}
+	return items, nil
+}
+
+// UnlinkIdentity desvincula una cuenta de proveedor. No deja al usuario sin
+// forma de entrar: sin contraseña, la última identidad no se puede quitar.
+func (m *UserModel) UnlinkIdentity(ctx context.Context, userID string, identityID int) error {
+	link, err := m.client.LinkedIdentity.Query().
+		Where(linkedidentity.ID(identityID), linkedidentity.UserID(userID)).
+		WithUser().
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return errors.New("IDENTITY_NOT_FOUND")
+	}
+	if err != nil {
+		return err
+	}
+	if link.Edges.User.Password == "" {
+		n, err := m.client.LinkedIdentity.Query().
+			Where(linkedidentity.UserID(userID)).
+			Count(ctx)
+		if err != nil {
+			return err
+		}
+		if n == 1 {
+			return errors.New("LAST_LOGIN_METHOD")
+		}
+	}
+	return m.client.LinkedIdentity.DeleteOne(link).Exec(ctx)
+}
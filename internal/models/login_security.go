@@ -0,0 +1,303 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/ent/loginattempt"
+	"api_voty/internal/mailer"
+)
+
+// Política de bloqueo: tras delayAfter fallos seguidos cada intento debe
+// esperar el doble que el anterior (hasta maxLoginDelay), y cada
+// lockoutThreshold fallos la cuenta se bloquea, el doble de tiempo cada vez.
+// Por IP cuentan los fallos de los últimos ipWindow minutos, sea cual sea la cuenta.
+const (
+	delayAfter       = 3
+	maxLoginDelay    = 30 * time.Second
+	lockoutThreshold = 5
+	baseLockout      = 15 * time.Minute
+	maxLockout       = 24 * time.Hour
+	ipWindow         = 15 * time.Minute
+	ipMaxFailures    = 20
+	// maxShift evita que las duplicaciones desborden time.Duration
+	maxShift = 16
+
+	// knownLoginsScanned limita cuántos logins previos se miran para decidir
+	// si un dispositivo o una red son nuevos
+	knownLoginsScanned = 100
+)
+
+// Motivos de aviso de un login
+const (
+	LoginNewDevice   = "new_device"
+	LoginNewLocation = "new_location"
+)
+
+// LoginMeta es de dónde viene un intento de login
+type LoginMeta struct {
+	IP        string
+	UserAgent string
+}
+
+// LoginLockedError rechaza un login sin mirar la contraseña: cuenta
+// bloqueada, intentos demasiado seguidos o demasiados fallos desde la IP
+type LoginLockedError struct {
+	Reason     string
+	RetryAfter time.Duration
+	failure    loginattempt.FailureReason // para el historial
+}
+
+func (e *LoginLockedError) Error() string { return e.Reason }
+
+// LoginNotifier avisa al usuario de un login desde un dispositivo o una red
+// que no había usado antes
+type LoginNotifier interface {
+	NotifyNewLogin(ctx context.Context, u *ent.User, attempt *ent.LoginAttempt, reasons []string) error
+}
+
+// LogLoginNotifier solo deja el aviso en el log
+type LogLoginNotifier struct{}
+
+func (LogLoginNotifier) NotifyNewLogin(ctx context.Context, u *ent.User, a *ent.LoginAttempt, reasons []string) error {
+	log.Printf("login de %s desde %s (%s): %s", u.Email, a.IP, a.UserAgent, strings.Join(reasons, ", "))
+	return nil
+}
+
+// MailLoginNotifier avisa por correo
+type MailLoginNotifier struct {
+	Mailer mailer.Mailer
+}
+
+func (n MailLoginNotifier) NotifyNewLogin(ctx context.Context, u *ent.User, a *ent.LoginAttempt, reasons []string) error {
+	what := "un dispositivo nuevo"
+	if slices.Contains(reasons, LoginNewLocation) {
+		what = "una ubicación nueva"
+		if slices.Contains(reasons, LoginNewDevice) {
+			what = "un dispositivo y una ubicación nuevos"
+		}
+	}
+	return n.Mailer.Send(ctx, mailer.Message{
+		To:      u.Email,
+		Subject: "Nuevo inicio de sesión en Voty",
+		Text: fmt.Sprintf("Hola %s,\n\nAlguien ha entrado en tu cuenta desde %s:\n\n"+
+			"  Fecha: %s\n  IP: %s\n  Navegador: %s\n\n"+
+			"Si has sido tú, no tienes que hacer nada. Si no, cambia tu contraseña cuanto antes.\n",
+			u.Name, what, a.CreatedAt.UTC().Format("2006-01-02 15:04 MST"), a.IP, a.UserAgent),
+	})
+}
+
+// LoginAttemptResponse es una entrada del historial de logins
+type LoginAttemptResponse struct {
+	ID            int       `json:"id"`
+	CreatedAt     time.Time `json:"created_at"`
+	IP            string    `json:"ip"`
+	UserAgent     string    `json:"user_agent"`
+	Success       bool      `json:"success"`
+	FailureReason string    `json:"failure_reason,omitempty" enum:"bad_password,inactive,locked,throttled,ip_blocked,bad_second_factor"`
+}
+
+// ipBlocked devuelve cuánto queda de bloqueo para la IP, o 0. Solo cuentan
+// los intentos que probaron una contraseña o un código, no los ya rechazados.
+func (m *AuthModel) ipBlocked(ctx context.Context, ip string, now time.Time) (time.Duration, error) {
+	recent := m.client.LoginAttempt.Query().
+		Where(
+			loginattempt.IP(ip),
+			loginattempt.Success(false),
+			loginattempt.FailureReasonIn(loginattempt.FailureReasonBadPassword, loginattempt.FailureReasonUnknownUser,
+				loginattempt.FailureReasonBadSecondFactor),
+			loginattempt.CreatedAtGT(now.Add(-ipWindow)),
+		)
+	n, err := recent.Clone().Count(ctx)
+	if err != nil || n < ipMaxFailures {
+		return 0, err
+	}
+	// Se desbloquea cuando el fallo más antiguo de la ventana sale de ella
+	oldest, err := recent.Order(ent.Asc(loginattempt.FieldCreatedAt)).First(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return max(oldest.CreatedAt.Add(ipWindow).Sub(now), time.Second), nil
+}
+
+// accountLocked devuelve el error de bloqueo o espera de la cuenta, o nil
+func accountLocked(u *ent.User, now time.Time) *LoginLockedError {
+	if u.LockedUntil != nil && now.Before(*u.LockedUntil) {
+		return &LoginLockedError{Reason: "account temporarily locked", RetryAfter: u.LockedUntil.Sub(now), failure: loginattempt.FailureReasonLocked}
+	}
+	if u.FailedLogins >= delayAfter && u.LastFailedLoginAt != nil {
+		delay := min(time.Second<<min(u.FailedLogins-delayAfter, maxShift), maxLoginDelay)
+		if wait := u.LastFailedLoginAt.Add(delay).Sub(now); wait > 0 {
+			return &LoginLockedError{Reason: "too many attempts, slow down", RetryAfter: wait, failure: loginattempt.FailureReasonThrottled}
+		}
+	}
+	return nil
+}
+
+// registerFailure suma un fallo a la cuenta y la bloquea al llegar a cada múltiplo
+// de lockoutThreshold
+func (m *AuthModel) registerFailure(ctx context.Context, u *ent.User, now time.Time) error {
+	u, err := m.client.User.UpdateOne(u).
+		AddFailedLogins(1).
+		SetLastFailedLoginAt(now).
+		Save(ctx)
+	if err != nil {
+		return err
+	}
+	if u.FailedLogins%lockoutThreshold != 0 {
+		return nil
+	}
+	lockout := min(baseLockout<<min(u.FailedLogins/lockoutThreshold-1, maxShift), maxLockout)
+	return m.client.User.UpdateOne(u).SetLockedUntil(now.Add(lockout)).Exec(ctx)
+}
+
+// registerSuccess pone a cero los fallos de la cuenta
+func (m *AuthModel) registerSuccess(ctx context.Context, u *ent.User) error {
+	if u.FailedLogins == 0 && u.LockedUntil == nil {
+		return nil
+	}
+	return m.client.User.UpdateOne(u).
+		SetFailedLogins(0).
+		ClearLastFailedLoginAt().
+		ClearLockedUntil().
+		Exec(ctx)
+}
+
+// recordAttempt guarda el intento en el historial. Un fallo al guardarlo no
+// debe impedir el login, así que solo se registra en el log.
+func (m *AuthModel) recordAttempt(ctx context.Context, u *ent.User, email string, meta LoginMeta, reason *loginattempt.FailureReason) *ent.LoginAttempt {
+	create := m.client.LoginAttempt.Create().
+		SetEmail(strings.ToLower(strings.TrimSpace(email))).
+		SetIP(meta.IP).
+		SetUserAgent(truncateRunes(meta.UserAgent, 512)).
+		SetSuccess(reason == nil).
+		SetNillableFailureReason(reason)
+	if u != nil {
+		create.SetUserID(u.ID)
+	}
+	a, err := create.Save(ctx)
+	if err != nil {
+		log.Printf("error guardando el intento de login de %s: %v", email, err)
+	}
+	return a
+}
+
+func failure(r loginattempt.FailureReason) *loginattempt.FailureReason { return &r }
+
+// newLoginReasons compara un login correcto con los anteriores de la cuenta.
+// El primer login de una cuenta no avisa de nada.
+func (m *AuthModel) newLoginReasons(ctx context.Context, userID string, meta LoginMeta) ([]string, error) {
+	previous, err := m.client.LoginAttempt.Query().
+		Where(loginattempt.UserID(userID), loginattempt.Success(true)).
+		Order(ent.Desc(loginattempt.FieldCreatedAt)).
+		Limit(knownLoginsScanned).
+		Select(loginattempt.FieldIP, loginattempt.FieldUserAgent).
+		All(ctx)
+	if err != nil || len(previous) == 0 {
+		return nil, err
+	}
+
+	knownDevice, knownNetwork := false, false
+	network := networkOf(meta.IP)
+	for _, a := range previous {
+		knownDevice = knownDevice || a.UserAgent == truncateRunes(meta.UserAgent, 512)
+		knownNetwork = knownNetwork || networkOf(a.IP) == network
+	}
+	var reasons []string
+	if !knownDevice {
+		reasons = append(reasons, LoginNewDevice)
+	}
+	if !knownNetwork {
+		reasons = append(reasons, LoginNewLocation)
+	}
+	return reasons, nil
+}
+
+// networkOf reduce una IP a su red (/24 en IPv4, /48 en IPv6): sin geolocalizar,
+// es la mejor aproximación a "desde dónde"
+func networkOf(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ip
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		return v4.Mask(net.CIDRMask(24, 32)).String()
+	}
+	return parsed.Mask(net.CIDRMask(48, 128)).String()
+}
+
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
+
+// notifyNewLogin avisa en segundo plano para no retrasar la respuesta del login
+func (m *AuthModel) notifyNewLogin(u *ent.User, a *ent.LoginAttempt, reasons []string) {
+	if m.notifier == nil || a == nil || len(reasons) == 0 {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := m.notifier.NotifyNewLogin(ctx, u, a, reasons); err != nil {
+			log.Printf("error avisando del login de %s: %v", u.Email, err)
+		}
+	}()
+}
+
+// LoginHistory devuelve los últimos intentos de login de la cuenta
+func (m *UserModel) LoginHistory(ctx context.Context, userID string, limit int) ([]LoginAttemptResponse, error) {
+	attempts, err := m.client.LoginAttempt.Query().
+		Where(loginattempt.UserID(userID)).
+		Order(ent.Desc(loginattempt.FieldCreatedAt), ent.Desc(loginattempt.FieldID)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]LoginAttemptResponse, len(attempts))
+	for i, a := range attempts {
+		out[i] = LoginAttemptResponse{
+			ID:        a.ID,
+			CreatedAt: a.CreatedAt,
+			IP:        a.IP,
+			UserAgent: a.UserAgent,
+			Success:   a.Success,
+		}
+		if a.FailureReason != nil {
+			out[i].FailureReason = a.FailureReason.String()
+		}
+	}
+	return out, nil
+}
+
+// Unlock quita el bloqueo por intentos fallidos de una cuenta
+func (m *UserModel) Unlock(ctx context.Context, userID string) error {
+	err := m.client.User.UpdateOneID(userID).
+		SetFailedLogins(0).
+		ClearLastFailedLoginAt().
+		ClearLockedUntil().
+		Exec(ctx)
+	if ent.IsNotFound(err) {
+		return errors.New("USER_NOT_FOUND")
+	}
+	return err
+}
+
+// isLocked es para UserResponse: solo cuenta un bloqueo aún vigente
+func isLocked(u *ent.User) *time.Time {
+	if u.LockedUntil != nil && time.Now().Before(*u.LockedUntil) {
+		return u.LockedUntil
+	}
+	return nil
+}
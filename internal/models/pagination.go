@@ -0,0 +1,57 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// Límites de tamaño de página compartidos por los listados
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// PageInfo acompaña a cada página de resultados
+type PageInfo struct {
+	NextCursor string `json:"next_cursor,omitempty" doc:"Cursor opaco para pedir la siguiente página"`
+	HasMore    bool   `json:"has_more"`
+	Limit      int    `json:"limit"`
+}
+
+// cursor es la posición de la última fila devuelta. Es opaco para el cliente:
+// se serializa a JSON y se codifica en base64.
+type cursor struct {
+	Sort string `json:"s"`
+	Key  string `json:"k"`
+	ID   string `json:"id"`
+}
+
+func encodeCursor(c cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor rechaza cursores malformados o generados con otro orden
+func decodeCursor(raw, sort string) (*cursor, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, errors.New("INVALID_CURSOR")
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Sort != sort || c.ID == "" {
+		return nil, errors.New("INVALID_CURSOR")
+	}
+	return &c, nil
+}
+
+// pageSize normaliza el límite pedido
+func pageSize(limit int) int {
+	if limit <= 0 {
+		return DefaultPageSize
+	}
+	return min(limit, MaxPageSize)
+}
@@ -0,0 +1,184 @@
+package models
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/ent/passwordreset"
+	"api_voty/ent/user"
+	"api_voty/internal/mailer"
+)
+
+const (
+	// resetTokenBytes es la entropía del enlace de recuperación (256 bits)
+	resetTokenBytes = 32
+	resetTTL        = time.Hour
+	// maxResetsPerHour limita los correos que se pueden mandar a una cuenta
+	maxResetsPerHour = 3
+)
+
+// ForgotPassword envía un enlace de recuperación a email si es de una cuenta
+// activa. Devuelve lo mismo exista o no la cuenta, y todo el trabajo (buscar
+// la cuenta, crear el enlace y mandarlo) va en segundo plano, para que ni la
+// respuesta ni su duración revelen qué emails están registrados.
+func (m *AuthModel) ForgotPassword(ctx context.Context, email, ip string) error {
+	if m.mailer == nil {
+		return errors.New("MAILER_NOT_CONFIGURED")
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, mailTimeout)
+		defer cancel()
+		if err := m.sendResetLink(ctx, email, ip); err != nil {
+			log.Printf("error enviando el enlace de recuperación: %v", err)
+		}
+	}()
+	return nil
+}
+
+// sendResetLink crea el enlace de recuperación y lo manda, si email es de una
+// cuenta activa que no ha pedido demasiados en la última hora
+func (m *AuthModel) sendResetLink(ctx context.Context, email, ip string) error {
+	u, err := m.client.User.Query().
+		Where(user.Email(email), user.Active(true)).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	recent, err := m.client.PasswordReset.Query().
+		Where(passwordreset.UserID(u.ID), passwordreset.CreatedAtGT(now.Add(-time.Hour))).
+		Count(ctx)
+	if err != nil {
+		return err
+	}
+	if recent >= maxResetsPerHour {
+		log.Printf("demasiadas peticiones de recuperación para %s, no se envía otra", u.ID)
+		return nil
+	}
+
+	raw := make([]byte, resetTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	err = m.client.PasswordReset.Create().
+		SetUserID(u.ID).
+		SetTokenHash(hashShareToken(token)).
+		SetIP(ip).
+		SetExpiresAt(now.Add(resetTTL)).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	link := m.baseURL + "/reset-password?token=" + url.QueryEscape(token)
+	return m.mailer.Send(ctx, mailer.Message{
+		To:      u.Email,
+		Subject: "Recupera tu contraseña de Voty",
+		Text: fmt.Sprintf("Hola %s,\n\nPara elegir una contraseña nueva abre este enlace (caduca en una hora y solo sirve una vez):\n\n%s\n\n"+
+			"Si no lo has pedido tú, ignora este mensaje: tu contraseña no cambia.\n", u.Name, link),
+	})
+}
+
+// ResetPassword cambia la contraseña con un token de ForgotPassword. El token
+// se gasta, se anulan los demás enlaces pendientes y se cierran todas las
+// sesiones abiertas.
+func (m *AuthModel) ResetPassword(ctx context.Context, token, newPassword string) error {
+	now := time.Now()
+	reset, err := m.client.PasswordReset.Query().
+		Where(
+			passwordreset.TokenHash(hashShareToken(token)),
+			passwordreset.UsedAtIsNil(),
+			passwordreset.ExpiresAtGT(now),
+		).
+		WithUser().
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return errors.New("INVALID_RESET_TOKEN")
+	}
+	if err != nil {
+		return err
+	}
+	u := reset.Edges.User
+	hashed, err := m.hashNew(newPassword, u.Email, u.Name)
+	if err != nil {
+		return err
+	}
+
+	tx, err := m.client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	// Gastar el token es lo primero: de dos peticiones simultáneas solo una
+	// llega a cambiar la contraseña
+	n, err := tx.PasswordReset.Update().
+		Where(passwordreset.ID(reset.ID), passwordreset.UsedAtIsNil()).
+		SetUsedAt(now).
+		Save(ctx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if n == 0 {
+		tx.Rollback()
+		return errors.New("INVALID_RESET_TOKEN")
+	}
+	err = tx.PasswordReset.Update().
+		Where(passwordreset.UserID(reset.UserID), passwordreset.UsedAtIsNil()).
+		SetUsedAt(now).
+		Exec(ctx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	// Quien puede leer el correo recupera también una cuenta bloqueada
+	err = tx.User.UpdateOneID(reset.UserID).
+		SetPassword(hashed).
+		AddTokenVersion(1).
+		SetFailedLogins(0).
+		ClearLastFailedLoginAt().
+		ClearLockedUntil().
+		Exec(ctx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	m.sendLater(ctx, mailer.Message{
+		To:      u.Email,
+		Subject: "Tu contraseña de Voty ha cambiado",
+		Text: fmt.Sprintf("Hola %s,\n\nLa contraseña de tu cuenta se cambió el %s y se han cerrado todas las sesiones.\n\n"+
+			"Si no has sido tú, pide otro enlace de recuperación cuanto antes.\n", u.Name, now.UTC().Format("2006-01-02 15:04 MST")),
+	})
+	return nil
+}
+
+// CheckSession comprueba que un token de sesión emitido con version sigue
+// valiendo: tras un cambio de contraseña por recuperación ya no vale
+func (m *UserModel) CheckSession(ctx context.Context, userID string, version int) error {
+	ok, err := m.client.User.Query().
+		Where(user.ID(userID), user.TokenVersion(version)).
+		Exist(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("SESSION_REVOKED")
+	}
+	return nil
+}
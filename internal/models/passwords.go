@@ -0,0 +1,43 @@
+package models
+
+import (
+	"api_voty/internal/password"
+)
+
+// passwords es la configuración de credenciales que comparten UserModel y
+// AuthModel: las reglas de las contraseñas nuevas, el hasher y si los
+// administradores deben usar segundo factor
+type passwords struct {
+	policy         password.Policy
+	hasher         password.Hasher
+	adminTwoFactor bool
+}
+
+func defaultPasswords() passwords {
+	return passwords{policy: password.DefaultPolicy, hasher: password.DefaultHasher}
+}
+
+// UsePasswordPolicy cambia las reglas de las contraseñas nuevas
+func (p *passwords) UsePasswordPolicy(policy password.Policy) {
+	p.policy = policy
+}
+
+// UseHasher cambia el algoritmo de los hashes nuevos; los antiguos siguen
+// valiendo y se renuevan en el siguiente login
+func (p *passwords) UseHasher(h password.Hasher) {
+	p.hasher = h
+}
+
+// PasswordPolicy son las reglas vigentes, para contárselas a los clientes
+func (p *passwords) PasswordPolicy() password.Policy {
+	return p.policy
+}
+
+// hashNew comprueba una contraseña nueva contra la política y devuelve su
+// hash. personal son el email y el nombre del usuario.
+func (p *passwords) hashNew(plain string, personal ...string) (string, error) {
+	if err := p.policy.Check(plain, personal...); err != nil {
+		return "", err
+	}
+	return p.hasher.Hash(plain)
+}
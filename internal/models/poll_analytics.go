@@ -0,0 +1,206 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/ent/user"
+)
+
+// Tamaños de intervalo de las analíticas
+const (
+	BucketMinute = "minute"
+	BucketHour   = "hour"
+	BucketDay    = "day"
+)
+
+// maxBuckets limita la longitud de la serie; con más hace falta un intervalo mayor
+const maxBuckets = 2000
+
+// peakCount es cuántos intervalos de máxima actividad se devuelven
+const peakCount = 3
+
+func bucketDuration(bucket string) (time.Duration, error) {
+	switch bucket {
+	case BucketMinute:
+		return time.Minute, nil
+	case BucketHour:
+		return time.Hour, nil
+	case BucketDay:
+		return 24 * time.Hour, nil
+	}
+	return 0, errors.New("INVALID_BUCKET")
+}
+
+// AnalyticsOption identifica cada columna de Counts y Cumulative
+type AnalyticsOption struct {
+	ID   int    `json:"id"`
+	Text string `json:"text"`
+}
+
+// AnalyticsBucket son los votos de un intervalo (en UTC)
+type AnalyticsBucket struct {
+	Start           time.Time `json:"start"`
+	Counts          []int     `json:"counts" doc:"Votos del intervalo por opción, en el orden de options"`
+	Total           int       `json:"total"`
+	Cumulative      []int     `json:"cumulative" doc:"Votos acumulados por opción al final del intervalo"`
+	CumulativeTotal int       `json:"cumulative_total"`
+}
+
+// PollAnalytics es la evolución de los votos de una encuesta
+type PollAnalytics struct {
+	Bucket            string            `json:"bucket" enum:"minute,hour,day"`
+	Options           []AnalyticsOption `json:"options"`
+	Series            []AnalyticsBucket `json:"series" doc:"Intervalos consecutivos desde el primer voto hasta el último, incluidos los vacíos"`
+	Peaks             []AnalyticsBucket `json:"peaks" doc:"Intervalos con más votos"`
+	TotalVotes        int               `json:"total_votes"`
+	GuestVotes        int               `json:"guest_votes" doc:"Votos de invitados, incluidos en total_votes"`
+	EligibleVoters    int               `json:"eligible_voters" doc:"Usuarios activos"`
+	ParticipationRate float64           `json:"participation_rate" doc:"(total_votes - guest_votes) / eligible_voters"`
+	// Mediana del tiempo entre la publicación y cada voto
+	MedianTimeToVoteSeconds *float64 `json:"median_time_to_vote_seconds,omitempty"`
+}
+
+type AnalyticsModel struct {
+	client *ent.Client
+	db     *sql.DB
+}
+
+func NewAnalyticsModel(client *ent.Client, db *sql.DB) *AnalyticsModel {
+	return &AnalyticsModel{client: client, db: db}
+}
+
+// PollAnalytics recorre los votos de p en orden cronológico sin cargarlos en
+// memoria: solo se guardan los intervalos, y la mediana sale de la posición
+// central, porque ordenar por fecha de voto es ordenar por tiempo hasta votar.
+func (m *AnalyticsModel) PollAnalytics(ctx context.Context, p *ent.Poll, bucket string) (*PollAnalytics, error) {
+	size, err := bucketDuration(bucket)
+	if err != nil {
+		return nil, err
+	}
+	options, err := p.QueryOptions().All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(options, func(i, j int) bool {
+		if options[i].Position != options[j].Position {
+			return options[i].Position < options[j].Position
+		}
+		return options[i].ID < options[j].ID
+	})
+
+	out := &PollAnalytics{Bucket: bucket, Options: make([]AnalyticsOption, len(options)), Series: []AnalyticsBucket{}}
+	column := make(map[int]int, len(options))
+	for i, o := range options {
+		out.Options[i] = AnalyticsOption{ID: o.ID, Text: o.Text}
+		column[o.ID] = i
+	}
+
+	// Límites y número de votos, para validar la serie y localizar la mediana
+	var total int
+	var first, last sql.NullTime
+	err = m.db.QueryRowContext(ctx,
+		"SELECT COUNT(*), COUNT(guest_id), MIN(created_at), MAX(created_at) FROM votes WHERE poll_votes = ?", p.ID,
+	).Scan(&total, &out.GuestVotes, &first, &last)
+	if err != nil {
+		return nil, err
+	}
+	out.TotalVotes = total
+
+	out.EligibleVoters, err = m.client.User.Query().Where(user.Active(true)).Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if out.EligibleVoters > 0 {
+		// Los invitados no son usuarios: no cuentan para la participación
+		out.ParticipationRate = float64(total-out.GuestVotes) / float64(out.EligibleVoters)
+	}
+	if total == 0 {
+		out.Peaks = []AnalyticsBucket{}
+		return out, nil
+	}
+
+	start := first.Time.UTC().Truncate(size)
+	end := last.Time.UTC().Truncate(size)
+	n := int(end.Sub(start)/size) + 1
+	if n > maxBuckets {
+		return nil, errors.New("TOO_MANY_BUCKETS")
+	}
+	counts := make([][]int, n)
+	for i := range counts {
+		counts[i] = make([]int, len(options))
+	}
+
+	rows, err := m.db.QueryContext(ctx,
+		"SELECT poll_option_votes, created_at FROM votes WHERE poll_votes = ? ORDER BY created_at, id", p.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	published := p.CreatedAt
+	if p.PublishedAt != nil {
+		published = *p.PublishedAt
+	}
+	// Posiciones centrales (coinciden si total es impar)
+	lowMid, highMid := (total-1)/2, total/2
+	var median float64
+
+	for i := 0; rows.Next(); i++ {
+		var optionID int
+		var at time.Time
+		if err := rows.Scan(&optionID, &at); err != nil {
+			return nil, err
+		}
+		if i == lowMid || i == highMid {
+			wait := max(at.Sub(published).Seconds(), 0)
+			if lowMid == highMid {
+				median = wait
+			} else {
+				median += wait / 2
+			}
+		}
+		b := int(at.UTC().Truncate(size).Sub(start) / size)
+		if col, ok := column[optionID]; ok && b >= 0 && b < n {
+			counts[b][col]++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	out.MedianTimeToVoteSeconds = &median
+
+	// Serie densa con acumulados
+	running := make([]int, len(options))
+	runningTotal := 0
+	out.Series = make([]AnalyticsBucket, n)
+	for i := range counts {
+		b := AnalyticsBucket{Start: start.Add(time.Duration(i) * size), Counts: counts[i]}
+		for col, c := range counts[i] {
+			b.Total += c
+			running[col] += c
+		}
+		runningTotal += b.Total
+		b.Cumulative = append([]int(nil), running...)
+		b.CumulativeTotal = runningTotal
+		out.Series[i] = b
+	}
+
+	// Picos: más votos primero y, a igualdad, el más antiguo
+	peaks := make([]AnalyticsBucket, 0, n)
+	for _, b := range out.Series {
+		if b.Total > 0 {
+			peaks = append(peaks, b)
+		}
+	}
+	sort.SliceStable(peaks, func(i, j int) bool { return peaks[i].Total > peaks[j].Total })
+	if len(peaks) > peakCount {
+		peaks = peaks[:peakCount]
+	}
+	out.Peaks = peaks
+	return out, nil
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// Ballot es un voto individual con su votante
+type Ballot struct {
+	VoteID     int
+	CastAt     time.Time
+	UserID     string // vacío en votos de invitado
+	UserName   string
+	GuestID    string
+	OptionID   int
+	OptionText string
+}
+
+// StreamBallots recorre los votos de una encuesta en orden cronológico y llama
+// a fn con cada uno, sin cargarlos todos en memoria. Comprobar que la encuesta
+// no es anónima es cosa de quien llama.
+func (m *AnalyticsModel) StreamBallots(ctx context.Context, pollID int, fn func(Ballot) error) error {
+	rows, err := m.db.QueryContext(ctx, `SELECT v.id, v.created_at,
+			COALESCE(u.id, ''), COALESCE(u.name, ''), COALESCE(v.guest_id, ''), o.id, o.text
+		FROM votes v
+		LEFT JOIN users u ON u.id = v.user_votes
+		JOIN poll_options o ON o.id = v.poll_option_votes
+		WHERE v.poll_votes = ?
+		ORDER BY v.created_at, v.id`, pollID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var b Ballot
+		if err := rows.Scan(&b.VoteID, &b.CastAt, &b.UserID, &b.UserName, &b.GuestID, &b.OptionID, &b.OptionText); err != nil {
+			return err
+		}
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
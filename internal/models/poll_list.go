@@ -0,0 +1,246 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/ent/poll"
+	"api_voty/ent/polloption"
+	"api_voty/ent/predicate"
+	"api_voty/ent/tag"
+	"api_voty/ent/user"
+	"api_voty/ent/vote"
+)
+
+// Órdenes disponibles para el listado de encuestas
+const (
+	PollSortNewest       = "newest"
+	PollSortMostVotes    = "most_votes"
+	PollSortClosingSoon  = "closing_soon"
+	pollCursorTimeLayout = time.RFC3339Nano
+)
+
+// PollListParams son los filtros, el orden y la página del listado de encuestas
+type PollListParams struct {
+	UserID        string // quien consulta
+	Cursor        string
+	Limit         int
+	Sort          string
+	State         string // "open", "closed" o vacío
+	Voted         *bool  // votadas (o no) por UserID
+	OwnerID       string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Tag           string // slug o nombre de etiqueta
+	// FollowedBy limita a encuestas con alguna etiqueta que sigue ese usuario (feed)
+	FollowedBy string
+}
+
+// ListPage devuelve una página de encuestas visibles para params.UserID,
+// con sus opciones y el voto del usuario cargados.
+func (m *PollModel) ListPage(ctx context.Context, params PollListParams) ([]*ent.Poll, PageInfo, error) {
+	if params.Sort == "" {
+		params.Sort = PollSortNewest
+	}
+	limit := pageSize(params.Limit)
+	page := PageInfo{Limit: limit}
+
+	after, err := decodeCursor(params.Cursor, params.Sort)
+	if err != nil {
+		return nil, page, err
+	}
+
+	query := m.client.Poll.Query().Where(visibleTo(params.UserID))
+
+	// Filtros
+	switch params.State {
+	case "open":
+		query.Where(poll.IsOpen(true), poll.StatusEQ(poll.StatusPublished))
+	case "closed":
+		query.Where(poll.IsOpen(false), poll.StatusEQ(poll.StatusPublished))
+	}
+	if params.Voted != nil {
+		votedByMe := poll.HasVotesWith(vote.HasUserWith(user.ID(params.UserID)))
+		if *params.Voted {
+			query.Where(votedByMe)
+		} else {
+			query.Where(poll.Not(votedByMe))
+		}
+	}
+	if params.OwnerID != "" {
+		query.Where(poll.OwnerID(params.OwnerID))
+	}
+	if !params.CreatedAfter.IsZero() {
+		query.Where(poll.CreatedAtGTE(params.CreatedAfter))
+	}
+	if !params.CreatedBefore.IsZero() {
+		query.Where(poll.CreatedAtLT(params.CreatedBefore))
+	}
+	if params.Tag != "" {
+		query.Where(poll.HasTagsWith(tag.Slug(TagSlug(params.Tag))))
+	}
+	if params.FollowedBy != "" {
+		query.Where(poll.HasTagsWith(tag.HasFollowersWith(user.ID(params.FollowedBy))))
+	}
+
+	// Orden y posición (keyset: nunca OFFSET)
+	switch params.Sort {
+	case PollSortNewest:
+		query.Order(ent.Desc(poll.FieldCreatedAt), ent.Desc(poll.FieldID))
+	case PollSortMostVotes:
+		// El orden delataría los recuentos ocultos: solo entran las encuestas
+		// con resultados públicos y las del propio usuario
+		query.Where(poll.Or(resultsPublicAt(time.Now()), poll.OwnerID(params.UserID))).
+			Order(ent.Desc(poll.FieldTotalVotes), ent.Desc(poll.FieldID))
+	case PollSortClosingSoon:
+		// Solo tiene sentido para encuestas con cierre pendiente
+		query.Where(poll.ClosesAtGT(time.Now())).
+			Order(ent.Asc(poll.FieldClosesAt), ent.Asc(poll.FieldID))
+	default:
+		return nil, page, errors.New("INVALID_SORT")
+	}
+	if after != nil {
+		if params.Sort == PollSortMostVotes {
+			// El cursor no lleva el recuento: se compara con el total actual
+			// de la encuesta en la que acabó la página anterior
+			id, err := strconv.Atoi(after.ID)
+			if err != nil {
+				return nil, page, errors.New("INVALID_CURSOR")
+			}
+			total, err := m.client.Poll.Query().
+				Where(poll.ID(id)).
+				Select(poll.FieldTotalVotes).
+				Int(ctx)
+			if err != nil {
+				return nil, page, errors.New("INVALID_CURSOR")
+			}
+			after.Key = strconv.Itoa(total)
+		}
+		pred, err := pollAfter(params.Sort, after)
+		if err != nil {
+			return nil, page, err
+		}
+		query.Where(pred)
+	}
+
+	polls, err := query.
+		Limit(limit + 1).
+		WithOptions(orderedOptions).
+		WithTags(orderedTags).
+		WithVotes(func(q *ent.VoteQuery) {
+			q.Where(vote.HasUserWith(user.ID(params.UserID))).
+				WithPollOption()
+		}).
+		All(ctx)
+	if err != nil {
+		return nil, page, err
+	}
+
+	if len(polls) > limit {
+		polls = polls[:limit]
+		page.HasMore = true
+		page.NextCursor = encodeCursor(pollCursor(params.Sort, polls[limit-1]))
+	}
+	return polls, page, nil
+}
+
+func pollCursor(sort string, p *ent.Poll) cursor {
+	c := cursor{Sort: sort, ID: strconv.Itoa(p.ID)}
+	switch sort {
+	case PollSortNewest:
+		c.Key = p.CreatedAt.Format(pollCursorTimeLayout)
+	case PollSortClosingSoon:
+		c.Key = p.ClosesAt.Format(pollCursorTimeLayout)
+	}
+	return c
+}
+
+// pollAfter construye el predicado "viene después del cursor" para cada orden
+func pollAfter(sort string, c *cursor) (predicate.Poll, error) {
+	id, err := strconv.Atoi(c.ID)
+	if err != nil {
+		return nil, errors.New("INVALID_CURSOR")
+	}
+	switch sort {
+	case PollSortMostVotes:
+		votes, err := strconv.Atoi(c.Key)
+		if err != nil {
+			return nil, errors.New("INVALID_CURSOR")
+		}
+		return poll.Or(
+			poll.TotalVotesLT(votes),
+			poll.And(poll.TotalVotes(votes), poll.IDLT(id)),
+		), nil
+	case PollSortClosingSoon:
+		t, err := time.Parse(pollCursorTimeLayout, c.Key)
+		if err != nil {
+			return nil, errors.New("INVALID_CURSOR")
+		}
+		return poll.Or(
+			poll.ClosesAtGT(t),
+			poll.And(poll.ClosesAt(t), poll.IDGT(id)),
+		), nil
+	default:
+		t, err := time.Parse(pollCursorTimeLayout, c.Key)
+		if err != nil {
+			return nil, errors.New("INVALID_CURSOR")
+		}
+		return poll.Or(
+			poll.CreatedAtLT(t),
+			poll.And(poll.CreatedAt(t), poll.IDLT(id)),
+		), nil
+	}
+}
+
+// SyncTotalVotes rellena total_votes en las encuestas anteriores a la columna
+func (m *PollModel) SyncTotalVotes(ctx context.Context) error {
+	polls, err := m.client.Poll.Query().
+		Where(poll.TotalVotes(0), poll.HasOptionsWith(polloption.VotesCountGT(0))).
+		WithOptions().
+		All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, p := range polls {
+		total := 0
+		for _, o := range p.Edges.Options {
+			total += o.VotesCount
+		}
+		if err := m.client.Poll.UpdateOneID(p.ID).SetTotalVotes(total).Exec(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetManyWithUserStatus carga varias encuestas visibles para userID respetando
+// el orden de ids (por ejemplo, el de relevancia de una búsqueda)
+func (m *PollModel) GetManyWithUserStatus(ctx context.Context, ids []int, userID string) ([]*ent.Poll, error) {
+	polls, err := m.client.Poll.Query().
+		Where(poll.IDIn(ids...), visibleTo(userID)).
+		WithOptions(orderedOptions).
+		WithTags(orderedTags).
+		WithVotes(func(q *ent.VoteQuery) {
+			q.Where(vote.HasUserWith(user.ID(userID))).
+				WithPollOption()
+		}).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]*ent.Poll, len(polls))
+	for _, p := range polls {
+		byID[p.ID] = p
+	}
+	ordered := make([]*ent.Poll, 0, len(polls))
+	for _, id := range ids {
+		if p, ok := byID[id]; ok {
+			ordered = append(ordered, p)
+		}
+	}
+	return ordered, nil
+}
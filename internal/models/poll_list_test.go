@@ -0,0 +1,67 @@
+package models
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+func TestListMostVotesHidesPrivateCounts(t *testing.T) {
+	client := openTestClient(t)
+	m := NewPollModel(client)
+	ctx := context.Background()
+
+	owner := client.User.Create().SetEmail("ana@example.com").SetName("Ana").SetPassword("x").SaveX(ctx)
+	var public []int
+	for i := range 3 {
+		p, err := m.Create(ctx, PollInput{Title: "Pública " + strconv.Itoa(i), Options: []string{"Sí", "No"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		client.Poll.UpdateOneID(p.ID).SetTotalVotes(10 * (i + 1)).ExecX(ctx)
+		public = append(public, p.ID)
+	}
+	hidden, err := m.Create(ctx, PollInput{
+		Title:             "Oculta",
+		Options:           []string{"Sí", "No"},
+		ResultsVisibility: "after_close",
+		OwnerID:           owner.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Poll.UpdateOneID(hidden.ID).SetTotalVotes(25).ExecX(ctx)
+
+	// Para los demás la encuesta oculta no aparece: su posición revelaría el recuento
+	var got []int
+	cursor := ""
+	for {
+		polls, page, err := m.ListPage(ctx, PollListParams{Sort: PollSortMostVotes, Limit: 2, Cursor: cursor})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range polls {
+			got = append(got, p.ID)
+		}
+		if !page.HasMore {
+			break
+		}
+		if c, err := decodeCursor(page.NextCursor, PollSortMostVotes); err != nil || c.Key != "" {
+			t.Errorf("cursor %+v (err %v) carries a vote count", c, err)
+		}
+		cursor = page.NextCursor
+	}
+	want := []int{public[2], public[1], public[0]}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("most_votes = %v, want %v", got, want)
+	}
+
+	// Su autor sí la ve, en su sitio
+	polls, _, err := m.ListPage(ctx, PollListParams{UserID: owner.ID, Sort: PollSortMostVotes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(polls) != 4 || polls[1].ID != hidden.ID {
+		t.Errorf("owner's most_votes: got %d polls, hidden poll not second", len(polls))
+	}
+}
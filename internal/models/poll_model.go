@@ -8,180 +8,438 @@ import (
 	"api_voty/ent/vote"
 	"context"
 	"errors"
+	"log"
 	"strconv"
 	"time"
 )
 
 type PollModel struct {
-	client *ent.Client
+	client    *ent.Client
+	observers []VoteObserver
+}
+
+// VoteObserver recibe cada voto ya confirmado (por ejemplo, el ranking de tendencias)
+type VoteObserver interface {
+	RecordVote(pollID int, at time.Time)
+}
+
+// ObserveVotes registra o para que CastVote le avise de cada voto
+func (m *PollModel) ObserveVotes(o VoteObserver) {
+	m.observers = append(m.observers, o)
+}
+
+// PollInput agrupa los datos editables de una encuesta
+type PollInput struct {
+	Title       string
+	Description string
+	IsOpen      bool
+	Options     []string
+	OpensAt     *time.Time
+	ClosesAt    *time.Time
+	Rules       CloseRules
+
+	ResultsVisibility string
+	ResultsVisibleAt  *time.Time
+	OwnerID           string // solo al crear
+
+	// ForceOptions permite reemplazar Options aunque la encuesta tenga votos
+	ForceOptions bool
+	// EditorID es quien hace el cambio; queda en el historial de revisiones
+	EditorID string
+	// Draft crea la encuesta como borrador (solo al crear)
+	Draft bool
+	// Anonymous impide exportar quién votó qué (solo al crear)
+	Anonymous bool
+	// Embeddable permite incrustar la encuesta; nil conserva el valor actual
+	Embeddable *bool
+	// AllowGuests admite votos de invitados sin cuenta; nil conserva el valor actual
+	AllowGuests *bool
+	// Tags son nombres de etiqueta; las que no existen se crean. En Update,
+	// nil conserva las actuales y una lista vacía las quita todas
+	Tags []string
 }
 
 func NewPollModel(client *ent.Client) *PollModel {
 	return &PollModel{client: client}
 }
 
-func (m *PollModel) CastVote(ctx context.Context, pollIDStr, optionIDStr, userID string) (int, error) { // Iniciamos Transacción (Atomicidad)
+func (m *PollModel) CastVote(ctx context.Context, pollIDStr, optionIDStr string, voter Voter) (*VoteResult, error) { // Iniciamos Transacción (Atomicidad)
 	pollID, _ := strconv.Atoi(pollIDStr)
 	optionID, _ := strconv.Atoi(optionIDStr)
 
 	tx, err := m.client.Tx(ctx)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	// 1. Verificar si la encuesta está abierta
+	// 1. Verificar si la encuesta está abierta (según la hora del servidor)
 	p, err := tx.Poll.Query().Where(poll.ID(pollID)).Only(ctx)
-	if err != nil || !p.IsOpen {
+	if err != nil {
+		tx.Rollback()
+		return nil, errors.New("POLL_CLOSED")
+	}
+	if p.Status != poll.StatusPublished {
+		tx.Rollback()
+		return nil, errors.New("POLL_NOT_PUBLISHED")
+	}
+	if err := votingWindowError(p, time.Now()); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if voter.IsGuest() && !p.AllowGuests {
 		tx.Rollback()
-		return 0, errors.New("POLL_CLOSED")
+		return nil, errors.New("GUESTS_NOT_ALLOWED")
 	}
 
-	// 2. Verificar si el usuario ya votó (SSOT)
-	// El índice único que pusimos en el esquema también protegerá esto
+	// 2. La opción debe pertenecer a la encuesta y no estar archivada
+	o, err := tx.PollOption.Query().
+		Where(polloption.ID(optionID), polloption.HasPollWith(poll.ID(pollID))).
+		Only(ctx)
+	if err != nil {
+		tx.Rollback()
+		return nil, errors.New("INVALID_OPTION")
+	}
+	if o.Archived {
+		tx.Rollback()
+		return nil, errors.New("OPTION_ARCHIVED")
+	}
+
+	// 3. Verificar si el usuario (o el dispositivo invitado) ya votó (SSOT)
+	// Los índices únicos que pusimos en el esquema también protegerán esto
 	exists, _ := tx.Vote.Query().
-		Where(vote.HasUserWith(user.ID(userID)), vote.HasPollWith(poll.ID(pollID))).
+		Where(voter.ballots(), vote.HasPollWith(poll.ID(pollID))).
 		Exist(ctx)
 
 	if exists {
 		tx.Rollback()
-		return 0, errors.New("ALREADY_VOTED") // El móvil dispara el Rollback con esto
+		return nil, errors.New("ALREADY_VOTED") // El móvil dispara el Rollback con esto
 	}
 
-	// 3. Crear el registro del voto
-	_, err = tx.Vote.Create().
-		SetUserID(userID).
+	// 4. Crear el registro del voto
+	create := tx.Vote.Create()
+	if voter.IsGuest() {
+		create.SetGuestID(voter.GuestID)
+	} else {
+		create.SetUserID(voter.UserID)
+	}
+	v, err := create.
 		SetPollID(pollID).
 		SetPollOptionID(optionID).
 		Save(ctx)
 	if err != nil {
 		tx.Rollback()
-		return 0, err
+		return nil, err
 	}
 
-	// 4. Incrementar contador en la opción
+	// 5. Incrementar contador en la opción y el total de la encuesta
 	opt, err := tx.PollOption.UpdateOneID(optionID).
 		AddVotesCount(1).
 		Save(ctx)
 	if err != nil {
 		tx.Rollback()
-		return 0, err
+		return nil, err
+	}
+	// El tope de votos se aplica aquí y no en applyCloseRules: el UPDATE
+	// condicionado bloquea la fila y relee el total, así que de dos votos
+	// simultáneos al último hueco solo uno pasa
+	inc := tx.Poll.Update().Where(poll.ID(pollID))
+	if p.MaxVotes != nil {
+		inc.Where(poll.TotalVotesLT(*p.MaxVotes))
+	}
+	n, err := inc.AddTotalVotes(1).Save(ctx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if n == 0 {
+		tx.Rollback()
+		return nil, errors.New("POLL_CLOSED")
 	}
 
 	// Confirmar todo
 	if err := tx.Commit(); err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	return opt.VotesCount, nil
+	for _, o := range m.observers {
+		o.RecordVote(pollID, v.CreatedAt)
+	}
+
+	// 6. Reglas de cierre automático, ya con el voto consolidado
+	result := &VoteResult{NewCount: opt.VotesCount}
+	result.ClosedReason, err = m.applyCloseRules(ctx, pollID)
+	if err != nil {
+		// El voto ya cuenta; el siguiente voto volverá a evaluar las reglas
+		log.Printf("error evaluando reglas de cierre de la encuesta %d: %v", pollID, err)
+	}
+	return result, nil
+}
+
+// votingWindowError comprueba el estado y la programación de la encuesta.
+// No depende del scheduler: si este va con retraso, el voto se rechaza igual.
+func votingWindowError(p *ent.Poll, now time.Time) error {
+	if p.OpensAt != nil && now.Before(*p.OpensAt) {
+		return errors.New("POLL_NOT_OPEN_YET")
+	}
+	if p.ClosesAt != nil && !now.Before(*p.ClosesAt) {
+		return errors.New("POLL_CLOSED")
+	}
+	if !p.IsOpen {
+		return errors.New("POLL_CLOSED")
+	}
+	return nil
+}
+
+// validateSchedule rechaza programaciones incoherentes
+func validateSchedule(opensAt, closesAt *time.Time) error {
+	if opensAt != nil && closesAt != nil && !closesAt.After(*opensAt) {
+		return errors.New("INVALID_SCHEDULE")
+	}
+	return nil
 }
 
 
 func (m *PollModel) GetByIDWithUserStatus(ctx context.Context, pollID int, userID string) (*ent.Poll, error) {
 	return m.client.Poll.
 		Query().
-		Where(poll.ID(pollID)).
-		WithOptions().
+		Where(poll.ID(pollID), visibleTo(userID)).
+		WithOptions(orderedOptions).
+		WithTags(orderedTags).
 		WithVotes(func(q *ent.VoteQuery) {
 			q.Where(vote.HasUserWith(user.IDEQ(userID))).WithPollOption()
 		}).
 		Only(ctx)
 }
 
-// Create crea la cabecera de la encuesta
-func (m *PollModel) Create(ctx context.Context, title string) (*ent.Poll, error) {
-	return m.client.Poll.
-		Create().
-		SetTitle(title).
-		SetIsOpen(true). // La creamos abierta por defecto
-		SetCreatedAt(time.Now()).
-		Save(ctx)
-}
+// Create crea la encuesta y sus opciones en una sola transacción, así un fallo
+// no deja encuestas a medio construir. Si input.Draft es false se valida y se
+// publica en el acto; un borrador se guarda sin validar y se publica con Publish.
+func (m *PollModel) Create(ctx context.Context, input PollInput) (*ent.Poll, error) {
+	now := time.Now()
+	if input.Draft {
+		if err := validateSchedule(input.OpensAt, input.ClosesAt); err != nil {
+			return nil, err
+		}
+	} else if err := validatePublishable(input.Title, input.Options, input.OpensAt, input.ClosesAt, now); err != nil {
+		return nil, err
+	}
+	if err := validateVisibility(input.ResultsVisibility, input.ResultsVisibleAt); err != nil {
+		return nil, err
+	}
 
-// AddOption añade una opción individual a una encuesta existente
-func (m *PollModel) AddOption(ctx context.Context, pollID string, text string) error {
-	id, err := strconv.Atoi(pollID)
+	tx, err := m.client.Tx(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return m.client.PollOption.
+
+	// 1. Crear la cabecera
+	create := tx.Poll.
 		Create().
-		SetText(text).
-		SetPollID(id).
-		SetVotesCount(0).
-		Exec(ctx)
+		SetTitle(input.Title).
+		SetDescription(input.Description).
+		SetNillableOpensAt(input.OpensAt).
+		SetNillableClosesAt(input.ClosesAt).
+		SetNillableMaxVotes(input.Rules.MaxVotes).
+		SetNillableQuorumPercent(input.Rules.QuorumPercent).
+		SetCloseOnDecisiveLead(input.Rules.DecisiveLead).
+		SetNillableResultsVisibleAt(input.ResultsVisibleAt).
+		SetAnonymous(input.Anonymous).
+		SetNillableEmbeddable(input.Embeddable).
+		SetNillableAllowGuests(input.AllowGuests).
+		SetCreatedAt(now)
+	if input.Draft {
+		create.SetStatus(poll.StatusDraft).SetIsOpen(false)
+	} else {
+		// La creamos abierta por defecto, salvo que tenga una apertura programada
+		create.SetStatus(poll.StatusPublished).
+			SetPublishedAt(now).
+			SetIsOpen(input.OpensAt == nil || !input.OpensAt.After(now))
+	}
+	if input.ResultsVisibility != "" {
+		create.SetResultsVisibility(poll.ResultsVisibility(input.ResultsVisibility))
+	}
+	if input.OwnerID != "" {
+		create.SetOwnerID(input.OwnerID)
+	}
+	if len(input.Tags) > 0 {
+		tagIDs, err := ensureTags(ctx, tx, input.Tags)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		create.AddTagIDs(tagIDs...)
+	}
+	p, err := create.Save(ctx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// 2. Crear las opciones en bloque
+	if len(input.Options) > 0 {
+		bulk := make([]*ent.PollOptionCreate, len(input.Options))
+		for i, txt := range input.Options {
+			bulk[i] = tx.PollOption.Create().SetText(txt).SetPollID(p.ID).SetPosition(i)
+		}
+		if err := tx.PollOption.CreateBulk(bulk...).Exec(ctx); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	// 3. Primera revisión del historial
+	if _, err := recordRevision(ctx, tx, p.ID, input.OwnerID, nil); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return p, nil
 }
 
 func (m *PollModel) ListAll(ctx context.Context) ([]*ent.Poll, error) {
 	return m.client.Poll.
 		Query().
-		WithOptions(). // Carga las opciones de cada encuesta (Eager Loading)
+		Where(poll.StatusEQ(poll.StatusPublished)).
+		WithOptions(orderedOptions). // Carga las opciones de cada encuesta (Eager Loading)
 		Order(ent.Desc(poll.FieldCreatedAt)).
 		All(ctx)
 }
 
-func (m *PollModel) ListAllWithUserStatus(ctx context.Context, userID string) ([]*ent.Poll, error) {
-	return m.client.Poll.
-		Query().
-		WithOptions().
-		WithVotes(func(q *ent.VoteQuery) {
-			q.Where(vote.HasUserWith(user.ID(userID))).
-				WithPollOption()
-		}).
-		Order(ent.Desc(poll.FieldCreatedAt)).
-		All(ctx)
-}
+// Update actualiza el título, el estado o la programación de una encuesta.
+// Devuelve además los cambios de opciones aplicados, para avisar a los votantes.
+func (m *PollModel) Update(ctx context.Context, id int, input PollInput) (*ent.Poll, []OptionChange, error) {
+	if err := validateSchedule(input.OpensAt, input.ClosesAt); err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	if input.IsOpen && input.ClosesAt != nil && !input.ClosesAt.After(now) {
+		// Reabrir con un cierre ya vencido no tiene sentido: el scheduler la cerraría al instante
+		return nil, nil, errors.New("INVALID_SCHEDULE")
+	}
 
-// Update actualiza el título o el estado de una encuesta
-func (m *PollModel) Update(ctx context.Context, id int, title string, isOpen bool, options []string) (*ent.Poll, error) {
-    // Usamos una transacción porque vamos a tocar varias tablas
-    tx, err := m.client.Tx(ctx)
-    if err != nil {
-        return nil, err
-    }
+	// Usamos una transacción porque vamos a tocar varias tablas
+	tx, err := m.client.Tx(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
 
-    // 1. Actualizar datos básicos de la encuesta
-    p, err := tx.Poll.UpdateOneID(id).
-        SetTitle(title).
-        SetIsOpen(isOpen).
-        Save(ctx)
-    if err != nil {
-        tx.Rollback()
-        return nil, err
-    }
-	println(p)
-
-    // 2. Si vienen opciones, sincronizamos (Borrar antiguas y crear nuevas)
-    // Nota: Solo haz esto si no hay votos o si decides resetear la encuesta
-    if options != nil {
-        // Borrar opciones actuales
-        _, err = tx.PollOption.Delete().
-            Where(polloption.HasPollWith(poll.ID(id))).
-            Exec(ctx)
-        if err != nil {
-            tx.Rollback()
-            return nil, err
-        }
-
-        // Crear las nuevas opciones
-        bulk := make([]*ent.PollOptionCreate, len(options))
-        for i, txt := range options {
-            bulk[i] = tx.PollOption.Create().SetText(txt).SetPollID(id)
-        }
-        err = tx.PollOption.CreateBulk(bulk...).Exec(ctx)
-        if err != nil {
-            tx.Rollback()
-            return nil, err
-        }
-    }
+	current, err := tx.Poll.Get(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, nil, err
+	}
+	// Las encuestas anteriores al historial necesitan una revisión base para poder comparar
+	if err := ensureBaseRevision(ctx, tx, id); err != nil {
+		tx.Rollback()
+		return nil, nil, err
+	}
 
-    err = tx.Commit()
-    if err != nil {
-        return nil, err
-    }
+	// 1. Actualizar datos básicos de la encuesta
+	if err := updatePollFields(ctx, tx, current, input, now); err != nil {
+		tx.Rollback()
+		return nil, nil, err
+	}
+
+	// 2. Si vienen opciones, se reemplazan (borrar antiguas y crear nuevas).
+	// Esto se lleva por delante los votos, así que con votos exige ForceOptions;
+	// para editar sin perder votos está ApplyOptionOps
+	var changes []OptionChange
+	if input.Options != nil {
+		changes, err = replaceOptions(ctx, tx, id, input.Options, input.ForceOptions)
+		if err != nil {
+			tx.Rollback()
+			return nil, nil, err
+		}
+	}
 
-    // Devolvemos la encuesta con los cambios cargados (Eager load)
-    return m.client.Poll.Query().Where(poll.ID(id)).WithOptions().Only(ctx)
+	// 3. Reemplazar las etiquetas si vienen
+	if input.Tags != nil {
+		tagIDs, err := ensureTags(ctx, tx, input.Tags)
+		if err != nil {
+			tx.Rollback()
+			return nil, nil, err
+		}
+		if err := tx.Poll.UpdateOneID(id).ClearTags().AddTagIDs(tagIDs...).Exec(ctx); err != nil {
+			tx.Rollback()
+			return nil, nil, err
+		}
+	}
+
+	// 4. Guardar la revisión resultante
+	if _, err := recordRevision(ctx, tx, id, input.EditorID, nil); err != nil {
+		tx.Rollback()
+		return nil, nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Devolvemos la encuesta con los cambios cargados (Eager load)
+	p, err := m.client.Poll.Query().Where(poll.ID(id)).WithOptions(orderedOptions).Only(ctx)
+	return p, changes, err
+}
+
+// updatePollFields aplica título y ajustes de input sobre la encuesta current
+func updatePollFields(ctx context.Context, tx *ent.Tx, current *ent.Poll, input PollInput, now time.Time) error {
+	// Si no se envía política o fecha de resultados se conserva la actual, pero
+	// debe seguir siendo válida
+	visibility := input.ResultsVisibility
+	if visibility == "" {
+		visibility = current.ResultsVisibility.String()
+	}
+	visibleAt := input.ResultsVisibleAt
+	if visibleAt == nil {
+		visibleAt = current.ResultsVisibleAt
+	}
+	if err := validateVisibility(visibility, visibleAt); err != nil {
+		return err
+	}
+
+	update := tx.Poll.UpdateOneID(current.ID).
+		SetTitle(input.Title).
+		SetDescription(input.Description).
+		SetIsOpen(input.IsOpen).
+		SetNillableClosesAt(input.ClosesAt).
+		SetNillableMaxVotes(input.Rules.MaxVotes).
+		SetNillableQuorumPercent(input.Rules.QuorumPercent).
+		SetCloseOnDecisiveLead(input.Rules.DecisiveLead).
+		SetResultsVisibility(poll.ResultsVisibility(visibility)).
+		SetNillableResultsVisibleAt(visibleAt).
+		SetNillableEmbeddable(input.Embeddable).
+		SetNillableAllowGuests(input.AllowGuests)
+	if input.ClosesAt == nil {
+		update.ClearClosesAt()
+	}
+	if input.Rules.MaxVotes == nil {
+		update.ClearMaxVotes()
+	}
+	if input.Rules.QuorumPercent == nil {
+		update.ClearQuorumPercent()
+	}
+	// Una apertura futura deja la encuesta cerrada hasta entonces;
+	// una ya vencida no se guarda: el estado manual manda
+	scheduled := input.OpensAt != nil && input.OpensAt.After(now)
+	if scheduled {
+		update.SetOpensAt(*input.OpensAt).SetIsOpen(false)
+	} else {
+		update.ClearOpensAt()
+	}
+	// Motivo de cierre: se limpia al reabrir y se marca "manual" al cerrar a mano
+	switch {
+	case input.IsOpen || scheduled:
+		update.ClearClosedReason()
+	case current.IsOpen:
+		update.SetClosedReason(poll.ClosedReasonManual)
+	}
+	_, err := update.Save(ctx)
+	return err
 }
+
 // Delete elimina una encuesta y, dependiendo de tu esquema,
 // Ent puede manejar el "Cascade Delete" de opciones y votos.
 func (m *PollModel) Delete(ctx context.Context, id string) error {
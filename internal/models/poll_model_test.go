@@ -0,0 +1,53 @@
+package models
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"api_voty/ent"
+	"api_voty/ent/enttest"
+	"api_voty/ent/pollrevision"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openTestClient abre una base SQLite en memoria con las claves ajenas activas,
+// para que un ON DELETE mal declarado falle igual que en MySQL
+func openTestClient(t *testing.T) *ent.Client {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestDeleteFreshPoll(t *testing.T) {
+	client := openTestClient(t)
+	m := NewPollModel(client)
+	ctx := context.Background()
+
+	// Recién creada ya tiene opciones y su primera revisión
+	p, err := m.Create(ctx, PollInput{Title: "¿Café o té?", Options: []string{"Café", "Té"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := client.PollRevision.Query().Where(pollrevision.PollID(p.ID)).CountX(ctx); n != 1 {
+		t.Fatalf("revisions = %d, want 1", n)
+	}
+	if _, _, err := m.CreateShareLink(ctx, p.ID, "", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Delete(ctx, strconv.Itoa(p.ID)); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if n := client.Poll.Query().CountX(ctx); n != 0 {
+		t.Errorf("polls = %d, want 0", n)
+	}
+	if n := client.PollRevision.Query().CountX(ctx); n != 0 {
+		t.Errorf("revisions = %d, want 0", n)
+	}
+	if n := client.ShareLink.Query().CountX(ctx); n != 0 {
+		t.Errorf("share links = %d, want 0", n)
+	}
+}
@@ -0,0 +1,253 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"api_voty/ent"
+	"api_voty/ent/poll"
+	"api_voty/ent/polloption"
+)
+
+// Operaciones sobre opciones
+const (
+	OptionOpAdd       = "add"
+	OptionOpRename    = "rename"
+	OptionOpReorder   = "reorder"
+	OptionOpArchive   = "archive"
+	OptionOpUnarchive = "unarchive"
+	OptionOpRemove    = "remove" // solo la produce el reemplazo forzado de opciones
+)
+
+// minPollOptions es el mínimo de opciones activas de una encuesta
+const minPollOptions = 2
+
+// OptionOp es una operación sobre una opción concreta, identificada por su ID
+type OptionOp struct {
+	Op        string   `json:"op" enum:"add,rename,reorder,archive,unarchive"`
+	OptionID  string   `json:"option_id,omitempty" doc:"Requerido en rename, archive y unarchive"`
+	Text      string   `json:"text,omitempty" doc:"Texto nuevo en add y rename"`
+	OptionIDs []string `json:"option_ids,omitempty" doc:"Orden completo de las opciones en reorder"`
+}
+
+// OptionChange describe un cambio aplicado, para avisar a los votantes
+type OptionChange struct {
+	Op       string `json:"op"`
+	OptionID string `json:"option_id"`
+	Before   string `json:"before,omitempty"`
+	After    string `json:"after,omitempty"`
+}
+
+// optionKey es lo que se compara para detectar opciones repetidas
+func optionKey(text string) string {
+	return strings.ToLower(strings.TrimSpace(text))
+}
+
+// orderedOptions ordena las opciones por posición (y por ID en caso de empate)
+func orderedOptions(q *ent.PollOptionQuery) {
+	q.Order(ent.Asc(polloption.FieldPosition), ent.Asc(polloption.FieldID))
+}
+
+// ApplyOptionOps aplica las operaciones en una sola transacción.
+// Renombrar una opción que ya tiene votos cambia lo que sus votantes eligieron,
+// así que se rechaza salvo que force sea true. Archivar no pierde votos, pero
+// deben quedar al menos dos opciones activas. Los textos no pueden quedar
+// vacíos ni repetirse.
+func (m *PollModel) ApplyOptionOps(ctx context.Context, pollID int, ops []OptionOp, force bool, editorID string) ([]OptionChange, error) {
+	tx, err := m.client.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ensureBaseRevision(ctx, tx, pollID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	changes, err := applyOptionOps(ctx, tx, pollID, ops, force)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if len(changes) > 0 {
+		if _, err := recordRevision(ctx, tx, pollID, editorID, nil); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	return changes, tx.Commit()
+}
+
+func applyOptionOps(ctx context.Context, tx *ent.Tx, pollID int, ops []OptionOp, force bool) ([]OptionChange, error) {
+	current, err := tx.PollOption.Query().
+		Where(polloption.HasPollWith(poll.ID(pollID))).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*ent.PollOption, len(current))
+	for _, o := range current {
+		byID[strconv.Itoa(o.ID)] = o
+	}
+	lookup := func(id string) (*ent.PollOption, error) {
+		o, ok := byID[id]
+		if !ok {
+			return nil, errors.New("INVALID_OPTION")
+		}
+		return o, nil
+	}
+	// taken indica si otra opción (archivada o no) ya tiene ese texto
+	taken := func(text string, self *ent.PollOption) bool {
+		for _, o := range byID {
+			if o != self && optionKey(o.Text) == optionKey(text) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var changes []OptionChange
+	archived := false
+	for _, op := range ops {
+		switch op.Op {
+		case OptionOpAdd:
+			text := strings.TrimSpace(op.Text)
+			if text == "" {
+				return nil, errors.New("INVALID_OPTION")
+			}
+			if taken(text, nil) {
+				return nil, errors.New("DUPLICATE_OPTIONS")
+			}
+			o, err := tx.PollOption.Create().
+				SetText(text).
+				SetPollID(pollID).
+				SetPosition(len(byID)).
+				Save(ctx)
+			if err != nil {
+				return nil, err
+			}
+			byID[strconv.Itoa(o.ID)] = o
+			changes = append(changes, OptionChange{Op: op.Op, OptionID: strconv.Itoa(o.ID), After: o.Text})
+
+		case OptionOpRename:
+			o, err := lookup(op.OptionID)
+			text := strings.TrimSpace(op.Text)
+			if err != nil || text == "" {
+				return nil, errors.New("INVALID_OPTION")
+			}
+			if o.Text == text {
+				continue
+			}
+			if taken(text, o) {
+				return nil, errors.New("DUPLICATE_OPTIONS")
+			}
+			if o.VotesCount > 0 && !force {
+				return nil, errors.New("OPTION_HAS_VOTES")
+			}
+			if _, err := tx.PollOption.UpdateOne(o).SetText(text).Save(ctx); err != nil {
+				return nil, err
+			}
+			changes = append(changes, OptionChange{Op: op.Op, OptionID: op.OptionID, Before: o.Text, After: text})
+			o.Text = text
+
+		case OptionOpArchive, OptionOpUnarchive:
+			o, err := lookup(op.OptionID)
+			if err != nil {
+				return nil, err
+			}
+			archive := op.Op == OptionOpArchive
+			if o.Archived == archive {
+				continue
+			}
+			if _, err := tx.PollOption.UpdateOne(o).SetArchived(archive).Save(ctx); err != nil {
+				return nil, err
+			}
+			o.Archived = archive
+			archived = archived || archive
+			changes = append(changes, OptionChange{Op: op.Op, OptionID: op.OptionID, Before: o.Text})
+
+		case OptionOpReorder:
+			// Debe venir el orden completo: así no hay posiciones ambiguas
+			if len(op.OptionIDs) != len(byID) {
+				return nil, errors.New("INVALID_OPTION")
+			}
+			seen := make(map[string]bool, len(op.OptionIDs))
+			for pos, id := range op.OptionIDs {
+				o, err := lookup(id)
+				if err != nil || seen[id] {
+					return nil, errors.New("INVALID_OPTION")
+				}
+				seen[id] = true
+				if o.Position == pos {
+					continue
+				}
+				if _, err := tx.PollOption.UpdateOne(o).SetPosition(pos).Save(ctx); err != nil {
+					return nil, err
+				}
+				changes = append(changes, OptionChange{Op: op.Op, OptionID: id, Before: strconv.Itoa(o.Position), After: strconv.Itoa(pos)})
+				o.Position = pos
+			}
+
+		default:
+			return nil, errors.New("INVALID_OPTION")
+		}
+	}
+
+	if archived {
+		active := 0
+		for _, o := range byID {
+			if !o.Archived {
+				active++
+			}
+		}
+		if active < minPollOptions {
+			return nil, errors.New("TOO_FEW_OPTIONS")
+		}
+	}
+	return changes, nil
+}
+
+// replaceOptions borra las opciones actuales (y con ellas sus votos) y crea las nuevas.
+// Si la encuesta ya tiene votos solo se permite con force.
+func replaceOptions(ctx context.Context, tx *ent.Tx, pollID int, texts []string, force bool) ([]OptionChange, error) {
+	current, err := tx.PollOption.Query().
+		Where(polloption.HasPollWith(poll.ID(pollID))).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []OptionChange
+	for _, o := range current {
+		if o.VotesCount > 0 && !force {
+			return nil, errors.New("POLL_HAS_VOTES")
+		}
+		changes = append(changes, OptionChange{Op: OptionOpRemove, OptionID: strconv.Itoa(o.ID), Before: o.Text})
+	}
+
+	// Borrar opciones actuales (los votos caen en cascada)
+	_, err = tx.PollOption.Delete().
+		Where(polloption.HasPollWith(poll.ID(pollID))).
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Poll.UpdateOneID(pollID).SetTotalVotes(0).Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	// Crear las nuevas opciones
+	bulk := make([]*ent.PollOptionCreate, len(texts))
+	for i, txt := range texts {
+		bulk[i] = tx.PollOption.Create().SetText(txt).SetPollID(pollID).SetPosition(i)
+	}
+	created, err := tx.PollOption.CreateBulk(bulk...).Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range created {
+		changes = append(changes, OptionChange{Op: OptionOpAdd, OptionID: strconv.Itoa(o.ID), After: o.Text})
+	}
+	return changes, nil
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"api_voty/ent/poll"
+	"api_voty/ent/polloption"
+)
+
+func TestApplyOptionOpsValidation(t *testing.T) {
+	client := openTestClient(t)
+	m := NewPollModel(client)
+	ctx := context.Background()
+
+	p, err := m.Create(ctx, PollInput{Title: "¿Qué día?", Options: []string{"Lunes", "Martes", "Miércoles"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := client.PollOption.Query().Where(polloption.HasPollWith(poll.ID(p.ID))).Order(polloption.ByPosition()).AllX(ctx)
+	id := func(i int) string { return strconv.Itoa(options[i].ID) }
+
+	tests := []struct {
+		name string
+		ops  []OptionOp
+		want string // código de error, vacío si se acepta
+	}{
+		{"add blank", []OptionOp{{Op: OptionOpAdd, Text: "   "}}, "INVALID_OPTION"},
+		{"add duplicate", []OptionOp{{Op: OptionOpAdd, Text: " lunes "}}, "DUPLICATE_OPTIONS"},
+		{"add twice in one request", []OptionOp{{Op: OptionOpAdd, Text: "Jueves"}, {Op: OptionOpAdd, Text: "JUEVES"}}, "DUPLICATE_OPTIONS"},
+		{"rename blank", []OptionOp{{Op: OptionOpRename, OptionID: id(0), Text: " "}}, "INVALID_OPTION"},
+		{"rename onto another", []OptionOp{{Op: OptionOpRename, OptionID: id(0), Text: "Martes"}}, "DUPLICATE_OPTIONS"},
+		{"archive down to one", []OptionOp{{Op: OptionOpArchive, OptionID: id(0)}, {Op: OptionOpArchive, OptionID: id(1)}}, "TOO_FEW_OPTIONS"},
+		{"rename case only", []OptionOp{{Op: OptionOpRename, OptionID: id(0), Text: "LUNES"}}, ""},
+		{"archive one", []OptionOp{{Op: OptionOpArchive, OptionID: id(2)}}, ""},
+		{"archived text is still taken", []OptionOp{{Op: OptionOpAdd, Text: "miércoles"}}, "DUPLICATE_OPTIONS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.ApplyOptionOps(ctx, p.ID, tt.ops, false, "")
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.want {
+				t.Errorf("error %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Los rechazos no dejan nada a medias
+	if n := client.PollOption.Query().Where(polloption.HasPollWith(poll.ID(p.ID))).CountX(ctx); n != 3 {
+		t.Errorf("options = %d, want 3", n)
+	}
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/ent/poll"
+	"api_voty/ent/polloption"
+	"api_voty/ent/predicate"
+)
+
+// visibleTo filtra las encuestas que el usuario puede ver: las publicadas y sus borradores
+func visibleTo(userID string) predicate.Poll {
+	return poll.Or(poll.StatusEQ(poll.StatusPublished), poll.OwnerID(userID))
+}
+
+// validatePublishable comprueba que una encuesta está lista para publicarse
+func validatePublishable(title string, options []string, opensAt, closesAt *time.Time, now time.Time) error {
+	if strings.TrimSpace(title) == "" {
+		return errors.New("EMPTY_TITLE")
+	}
+	if len(options) < minPollOptions {
+		return errors.New("TOO_FEW_OPTIONS")
+	}
+	seen := make(map[string]bool, len(options))
+	for _, o := range options {
+		key := optionKey(o)
+		if key == "" {
+			return errors.New("INVALID_OPTION")
+		}
+		if seen[key] {
+			return errors.New("DUPLICATE_OPTIONS")
+		}
+		seen[key] = true
+	}
+	if err := validateSchedule(opensAt, closesAt); err != nil {
+		return err
+	}
+	// Publicar algo que ya debería estar cerrado no tiene sentido
+	if closesAt != nil && !closesAt.After(now) {
+		return errors.New("INVALID_SCHEDULE")
+	}
+	return nil
+}
+
+// Publish valida un borrador y lo hace visible para todos.
+// Solo su autor puede publicarlo.
+func (m *PollModel) Publish(ctx context.Context, pollID int, userID string) (*ent.Poll, error) {
+	tx, err := m.client.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := tx.Poll.Query().
+		Where(poll.ID(pollID)).
+		WithOptions(func(q *ent.PollOptionQuery) {
+			q.Where(polloption.Archived(false))
+		}).
+		Only(ctx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if p.OwnerID == nil || *p.OwnerID != userID {
+		tx.Rollback()
+		return nil, errors.New("NOT_POLL_OWNER")
+	}
+	if p.Status == poll.StatusPublished {
+		tx.Rollback()
+		return nil, errors.New("ALREADY_PUBLISHED")
+	}
+
+	texts := make([]string, len(p.Edges.Options))
+	for i, o := range p.Edges.Options {
+		texts[i] = o.Text
+	}
+	now := time.Now()
+	if err := validatePublishable(p.Title, texts, p.OpensAt, p.ClosesAt, now); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Igual que al crear: abierta salvo que tenga una apertura futura
+	update := tx.Poll.UpdateOneID(pollID).
+		SetStatus(poll.StatusPublished).
+		SetPublishedAt(now).
+		SetIsOpen(p.OpensAt == nil || !p.OpensAt.After(now)).
+		ClearClosedReason()
+	if p.OpensAt != nil && !p.OpensAt.After(now) {
+		update.ClearOpensAt()
+	}
+	p, err = update.Save(ctx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return p, tx.Commit()
+}
@@ -0,0 +1,357 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/ent/poll"
+	"api_voty/ent/polloption"
+	"api_voty/ent/pollrevision"
+	"api_voty/ent/schema"
+)
+
+// RevisionChange es una diferencia entre dos revisiones consecutivas
+type RevisionChange struct {
+	Field    string `json:"field" doc:"title, un ajuste (is_open, closes_at...) u option.added/removed/text/position/archived"`
+	OptionID string `json:"option_id,omitempty"`
+	Before   string `json:"before,omitempty"`
+	After    string `json:"after,omitempty"`
+}
+
+// snapshotPoll lee el estado actual de la encuesta dentro de la transacción
+func snapshotPoll(ctx context.Context, tx *ent.Tx, pollID int) (*ent.Poll, []schema.OptionSnapshot, schema.PollSettings, error) {
+	p, err := tx.Poll.Query().
+		Where(poll.ID(pollID)).
+		WithOptions(orderedOptions).
+		WithTags(orderedTags).
+		Only(ctx)
+	if err != nil {
+		return nil, nil, schema.PollSettings{}, err
+	}
+
+	options := make([]schema.OptionSnapshot, len(p.Edges.Options))
+	for i, o := range p.Edges.Options {
+		options[i] = schema.OptionSnapshot{ID: o.ID, Text: o.Text, Position: o.Position, Archived: o.Archived}
+	}
+	settings := schema.PollSettings{
+		IsOpen:              p.IsOpen,
+		OpensAt:             p.OpensAt,
+		ClosesAt:            p.ClosesAt,
+		MaxVotes:            p.MaxVotes,
+		QuorumPercent:       p.QuorumPercent,
+		CloseOnDecisiveLead: p.CloseOnDecisiveLead,
+		ResultsVisibility:   p.ResultsVisibility.String(),
+		ResultsVisibleAt:    p.ResultsVisibleAt,
+		Anonymous:           p.Anonymous,
+		Embeddable:          p.Embeddable,
+		AllowGuests:         p.AllowGuests,
+	}
+	for _, t := range p.Edges.Tags {
+		settings.Tags = append(settings.Tags, t.Name)
+	}
+	return p, options, settings, nil
+}
+
+// recordRevision guarda el estado actual como nueva revisión.
+// Si nada cambió respecto a la última revisión no escribe nada y devuelve nil.
+func recordRevision(ctx context.Context, tx *ent.Tx, pollID int, authorID string, restoredFrom *int) (*ent.PollRevision, error) {
+	p, options, settings, err := snapshotPoll(ctx, tx, pollID)
+	if err != nil {
+		return nil, err
+	}
+
+	last, err := tx.PollRevision.Query().
+		Where(pollrevision.PollID(pollID)).
+		Order(ent.Desc(pollrevision.FieldRevision)).
+		First(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return nil, err
+	}
+	number := 1
+	if last != nil {
+		if restoredFrom == nil && sameRevision(last, p, options, settings) {
+			return nil, nil
+		}
+		number = last.Revision + 1
+	}
+
+	create := tx.PollRevision.Create().
+		SetPollID(pollID).
+		SetRevision(number).
+		SetTitle(p.Title).
+		SetDescription(p.Description).
+		SetOptions(options).
+		SetSettings(settings).
+		SetNillableRestoredFrom(restoredFrom).
+		SetCreatedAt(time.Now())
+	if authorID != "" {
+		create.SetAuthorID(authorID)
+	}
+	return create.Save(ctx)
+}
+
+// ensureBaseRevision guarda el estado previo a la primera edición
+// de las encuestas creadas antes de que existiera el historial
+func ensureBaseRevision(ctx context.Context, tx *ent.Tx, pollID int) error {
+	exists, err := tx.PollRevision.Query().
+		Where(pollrevision.PollID(pollID)).
+		Exist(ctx)
+	if err != nil || exists {
+		return err
+	}
+	_, err = recordRevision(ctx, tx, pollID, "", nil)
+	return err
+}
+
+func sameRevision(r *ent.PollRevision, p *ent.Poll, options []schema.OptionSnapshot, settings schema.PollSettings) bool {
+	a, _ := json.Marshal([]any{r.Title, r.Description, r.Options, r.Settings})
+	b, _ := json.Marshal([]any{p.Title, p.Description, options, settings})
+	return string(a) == string(b)
+}
+
+// Revisions devuelve el historial de la encuesta, de la más antigua a la más reciente
+func (m *PollModel) Revisions(ctx context.Context, pollID int) ([]*ent.PollRevision, error) {
+	return m.client.PollRevision.Query().
+		Where(pollrevision.PollID(pollID)).
+		Order(ent.Asc(pollrevision.FieldRevision)).
+		All(ctx)
+}
+
+// DiffRevisions compara dos revisiones; prev puede ser nil para la primera
+func DiffRevisions(prev, cur *ent.PollRevision) []RevisionChange {
+	changes := []RevisionChange{}
+	if prev == nil {
+		return changes
+	}
+	add := func(field, before, after string) {
+		if before != after {
+			changes = append(changes, RevisionChange{Field: field, Before: before, After: after})
+		}
+	}
+
+	add("title", prev.Title, cur.Title)
+	add("description", prev.Description, cur.Description)
+	ps, cs := prev.Settings, cur.Settings
+	add("is_open", strconv.FormatBool(ps.IsOpen), strconv.FormatBool(cs.IsOpen))
+	add("opens_at", fmtTime(ps.OpensAt), fmtTime(cs.OpensAt))
+	add("closes_at", fmtTime(ps.ClosesAt), fmtTime(cs.ClosesAt))
+	add("max_votes", fmtInt(ps.MaxVotes), fmtInt(cs.MaxVotes))
+	add("quorum_percent", fmtInt(ps.QuorumPercent), fmtInt(cs.QuorumPercent))
+	add("close_on_decisive_lead", strconv.FormatBool(ps.CloseOnDecisiveLead), strconv.FormatBool(cs.CloseOnDecisiveLead))
+	add("results_visibility", ps.ResultsVisibility, cs.ResultsVisibility)
+	add("results_visible_at", fmtTime(ps.ResultsVisibleAt), fmtTime(cs.ResultsVisibleAt))
+	add("tags", strings.Join(ps.Tags, ", "), strings.Join(cs.Tags, ", "))
+	add("anonymous", strconv.FormatBool(ps.Anonymous), strconv.FormatBool(cs.Anonymous))
+	add("embeddable", strconv.FormatBool(ps.Embeddable), strconv.FormatBool(cs.Embeddable))
+	add("allow_guests", strconv.FormatBool(ps.AllowGuests), strconv.FormatBool(cs.AllowGuests))
+
+	before := make(map[int]schema.OptionSnapshot, len(prev.Options))
+	for _, o := range prev.Options {
+		before[o.ID] = o
+	}
+	for _, o := range cur.Options {
+		id := strconv.Itoa(o.ID)
+		old, ok := before[o.ID]
+		if !ok {
+			changes = append(changes, RevisionChange{Field: "option.added", OptionID: id, After: o.Text})
+			continue
+		}
+		delete(before, o.ID)
+		if old.Text != o.Text {
+			changes = append(changes, RevisionChange{Field: "option.text", OptionID: id, Before: old.Text, After: o.Text})
+		}
+		if old.Position != o.Position {
+			changes = append(changes, RevisionChange{Field: "option.position", OptionID: id, Before: strconv.Itoa(old.Position), After: strconv.Itoa(o.Position)})
+		}
+		if old.Archived != o.Archived {
+			changes = append(changes, RevisionChange{Field: "option.archived", OptionID: id, Before: strconv.FormatBool(old.Archived), After: strconv.FormatBool(o.Archived)})
+		}
+	}
+	// Lo que queda en before ya no existe
+	for _, o := range prev.Options {
+		if _, gone := before[o.ID]; gone {
+			changes = append(changes, RevisionChange{Field: "option.removed", OptionID: strconv.Itoa(o.ID), Before: o.Text})
+		}
+	}
+	return changes
+}
+
+func fmtTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func fmtInt(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
+
+// RestoreRevision devuelve la encuesta al título, opciones y ajustes de una revisión.
+// El estado abierto/cerrado actual se conserva, y las opciones que no existían en la
+// revisión se archivan en lugar de borrarse para no perder votos. Como al editarlas,
+// cambiar el texto de una opción con votos requiere force. El anonimato no se
+// restaura: solo se fija al crear la encuesta.
+func (m *PollModel) RestoreRevision(ctx context.Context, pollID, number int, force bool, editorID string) (*ent.PollRevision, []OptionChange, error) {
+	tx, err := m.client.Tx(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	rev, changes, err := restoreRevision(ctx, tx, pollID, number, force, editorID)
+	if err != nil {
+		tx.Rollback()
+		return nil, nil, err
+	}
+	return rev, changes, tx.Commit()
+}
+
+func restoreRevision(ctx context.Context, tx *ent.Tx, pollID, number int, force bool, editorID string) (*ent.PollRevision, []OptionChange, error) {
+	target, err := tx.PollRevision.Query().
+		Where(pollrevision.PollID(pollID), pollrevision.Revision(number)).
+		Only(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	current, err := tx.Poll.Get(ctx, pollID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := ensureBaseRevision(ctx, tx, pollID); err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	s := target.Settings
+	closesAt := s.ClosesAt
+	if current.IsOpen && closesAt != nil && !closesAt.After(now) {
+		// Un cierre ya vencido cerraría la encuesta en cuanto se restaurase
+		closesAt = nil
+	}
+	if err := validateSchedule(s.OpensAt, closesAt); err != nil {
+		return nil, nil, err
+	}
+	err = updatePollFields(ctx, tx, current, PollInput{
+		Title:       target.Title,
+		Description: target.Description,
+		IsOpen:      current.IsOpen,
+		OpensAt:     s.OpensAt,
+		ClosesAt:    closesAt,
+		Rules: CloseRules{
+			MaxVotes:      s.MaxVotes,
+			QuorumPercent: s.QuorumPercent,
+			DecisiveLead:  s.CloseOnDecisiveLead,
+		},
+		ResultsVisibility: s.ResultsVisibility,
+		ResultsVisibleAt:  s.ResultsVisibleAt,
+		Embeddable:        &s.Embeddable,
+		AllowGuests:       &s.AllowGuests,
+	}, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	// updatePollFields conserva la fecha de resultados si no se indica, pero
+	// la revisión no tenía ninguna
+	if s.ResultsVisibleAt == nil && current.ResultsVisibleAt != nil {
+		if err := tx.Poll.UpdateOneID(pollID).ClearResultsVisibleAt().Exec(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	tagIDs, err := ensureTags(ctx, tx, s.Tags)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := tx.Poll.UpdateOneID(pollID).ClearTags().AddTagIDs(tagIDs...).Exec(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	changes, err := restoreOptions(ctx, tx, pollID, target.Options, force)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rev, err := recordRevision(ctx, tx, pollID, editorID, &target.Revision)
+	return rev, changes, err
+}
+
+func restoreOptions(ctx context.Context, tx *ent.Tx, pollID int, snapshot []schema.OptionSnapshot, force bool) ([]OptionChange, error) {
+	current, err := tx.PollOption.Query().
+		Where(polloption.HasPollWith(poll.ID(pollID))).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]*ent.PollOption, len(current))
+	for _, o := range current {
+		byID[o.ID] = o
+	}
+
+	var changes []OptionChange
+	for _, snap := range snapshot {
+		o, ok := byID[snap.ID]
+		if !ok {
+			// La opción se borró con un reemplazo forzado: se recrea sin votos
+			created, err := tx.PollOption.Create().
+				SetText(snap.Text).
+				SetPosition(snap.Position).
+				SetArchived(snap.Archived).
+				SetPollID(pollID).
+				Save(ctx)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, OptionChange{Op: OptionOpAdd, OptionID: strconv.Itoa(created.ID), After: snap.Text})
+			continue
+		}
+		delete(byID, snap.ID)
+		if o.Text == snap.Text && o.Position == snap.Position && o.Archived == snap.Archived {
+			continue
+		}
+		if o.Text != snap.Text && o.VotesCount > 0 && !force {
+			return nil, errors.New("OPTION_HAS_VOTES")
+		}
+		if _, err := tx.PollOption.UpdateOne(o).
+			SetText(snap.Text).
+			SetPosition(snap.Position).
+			SetArchived(snap.Archived).
+			Save(ctx); err != nil {
+			return nil, err
+		}
+		if o.Text != snap.Text {
+			changes = append(changes, OptionChange{Op: OptionOpRename, OptionID: strconv.Itoa(o.ID), Before: o.Text, After: snap.Text})
+		}
+		if o.Archived != snap.Archived {
+			op := OptionOpUnarchive
+			if snap.Archived {
+				op = OptionOpArchive
+			}
+			changes = append(changes, OptionChange{Op: op, OptionID: strconv.Itoa(o.ID), Before: snap.Text})
+		}
+		if o.Position != snap.Position {
+			changes = append(changes, OptionChange{Op: OptionOpReorder, OptionID: strconv.Itoa(o.ID), Before: strconv.Itoa(o.Position), After: strconv.Itoa(snap.Position)})
+		}
+	}
+
+	// Opciones posteriores a la revisión: se archivan al final
+	for _, o := range current {
+		if _, extra := byID[o.ID]; !extra || o.Archived {
+			continue
+		}
+		if _, err := tx.PollOption.UpdateOne(o).
+			SetArchived(true).
+			SetPosition(len(snapshot) + o.Position).
+			Save(ctx); err != nil {
+			return nil, err
+		}
+		changes = append(changes, OptionChange{Op: OptionOpArchive, OptionID: strconv.Itoa(o.ID), Before: o.Text})
+	}
+	return changes, nil
+}
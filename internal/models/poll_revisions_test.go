@@ -0,0 +1,56 @@
+package models
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"api_voty/ent/poll"
+	"api_voty/ent/polloption"
+)
+
+func TestRestoreRevisionRenamingVotedOption(t *testing.T) {
+	client := openTestClient(t)
+	m := NewPollModel(client)
+	ctx := context.Background()
+
+	voter := client.User.Create().SetEmail("ana@example.com").SetName("Ana").SetPassword("x").SaveX(ctx)
+	p, err := m.Create(ctx, PollInput{Title: "¿Café o té?", Options: []string{"Café", "Té"}, Tags: []string{"Bebidas"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	coffee := client.PollOption.Query().
+		Where(polloption.HasPollWith(poll.ID(p.ID)), polloption.Text("Café")).
+		OnlyX(ctx)
+	id := strconv.Itoa(coffee.ID)
+	if _, err := m.CastVote(ctx, strconv.Itoa(p.ID), id, UserVoter(voter.ID)); err != nil {
+		t.Fatal(err)
+	}
+	rename := []OptionOp{{Op: OptionOpRename, OptionID: id, Text: "Café solo"}}
+	if _, err := m.ApplyOptionOps(ctx, p.ID, rename, true, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := m.Update(ctx, p.ID, PollInput{Title: "¿Café o té?", IsOpen: true, Tags: []string{"Desayuno"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Volver a la revisión 1 cambiaría lo que eligió quien ya votó
+	if _, _, err := m.RestoreRevision(ctx, p.ID, 1, false, ""); err == nil || err.Error() != "OPTION_HAS_VOTES" {
+		t.Fatalf("restore without force: err %v, want OPTION_HAS_VOTES", err)
+	}
+	if text := client.PollOption.GetX(ctx, coffee.ID).Text; text != "Café solo" {
+		t.Errorf("rejected restore renamed the option to %q", text)
+	}
+
+	if _, _, err := m.RestoreRevision(ctx, p.ID, 1, true, ""); err != nil {
+		t.Fatalf("restore with force: %v", err)
+	}
+	if o := client.PollOption.GetX(ctx, coffee.ID); o.Text != "Café" || o.VotesCount != 1 {
+		t.Errorf("restored option = %q with %d votes, want \"Café\" with 1", o.Text, o.VotesCount)
+	}
+	// Las etiquetas también vuelven a las de la revisión
+	tags := client.Poll.QueryTags(client.Poll.GetX(ctx, p.ID)).AllX(ctx)
+	if len(tags) != 1 || tags[0].Name != "Bebidas" {
+		t.Errorf("restored tags = %v, want [Bebidas]", tags)
+	}
+}
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"context"
+	"sort"
+
+	"api_voty/ent"
+	"api_voty/ent/poll"
+	"api_voty/ent/polloption"
+	"api_voty/ent/user"
+	"api_voty/ent/vote"
+)
+
+// CloseRules son las condiciones con las que una encuesta se cierra sola
+type CloseRules struct {
+	MaxVotes      *int `json:"max_votes,omitempty" minimum:"1" doc:"Cierra al alcanzar este total de votos"`
+	QuorumPercent *int `json:"quorum_percent,omitempty" minimum:"1" maximum:"100" doc:"Cierra cuando vota este % de usuarios elegibles (los votos de invitados no cuentan)"`
+	DecisiveLead  bool `json:"decisive_lead,omitempty" doc:"Cierra cuando ninguna opción puede alcanzar a la primera, contando solo votos de usuarios"`
+}
+
+// VoteResult es el resultado de un voto aceptado
+type VoteResult struct {
+	NewCount     int
+	ClosedReason string // vacío si el voto no cerró la encuesta
+}
+
+func closeRulesOf(p *ent.Poll) CloseRules {
+	return CloseRules{
+		MaxVotes:      p.MaxVotes,
+		QuorumPercent: p.QuorumPercent,
+		DecisiveLead:  p.CloseOnDecisiveLead,
+	}
+}
+
+func (r CloseRules) empty() bool {
+	return r.MaxVotes == nil && r.QuorumPercent == nil && !r.DecisiveLead
+}
+
+// Evaluate devuelve el motivo de cierre que se cumple, o "" si ninguno.
+// counts son todos los votos por opción y userCounts solo los de usuarios
+// registrados; eligible es el número de usuarios que pueden votar.
+//
+// El tope de votos cuenta todas las papeletas, también las de invitados. El
+// quórum y la ventaja decisiva, en cambio, se miden solo con votos de
+// usuarios: eligible no incluye a los invitados (no hay forma de saber
+// cuántos podrían votar), y mezclarlos permitiría cerrar la encuesta por
+// quórum sin que votara ningún usuario.
+func (r CloseRules) Evaluate(counts, userCounts []int, eligible int) string {
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	users := 0
+	for _, c := range userCounts {
+		users += c
+	}
+
+	if r.MaxVotes != nil && total >= *r.MaxVotes {
+		return poll.ClosedReasonVoteCap.String()
+	}
+	if r.QuorumPercent != nil && eligible > 0 && users*100 >= *r.QuorumPercent*eligible {
+		return poll.ClosedReasonQuorum.String()
+	}
+	if r.DecisiveLead && eligible > 0 && len(userCounts) > 0 {
+		sorted := append([]int(nil), userCounts...)
+		sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+		runnerUp := 0
+		if len(sorted) > 1 {
+			runnerUp = sorted[1]
+		}
+		// Aunque todos los que faltan votaran a la segunda, no la alcanzaría
+		remaining := max(eligible-users, 0)
+		if sorted[0] > runnerUp+remaining {
+			return poll.ClosedReasonDecisiveLead.String()
+		}
+	}
+	return ""
+}
+
+// applyCloseRules evalúa las reglas de la encuesta y la cierra si alguna se cumple.
+// El cierre va condicionado a is_open, así que solo un voto concurrente lo reporta.
+func (m *PollModel) applyCloseRules(ctx context.Context, pollID int) (string, error) {
+	p, err := m.client.Poll.Query().
+		Where(poll.ID(pollID)).
+		WithOptions(func(q *ent.PollOptionQuery) {
+			q.Select(polloption.FieldVotesCount)
+		}).
+		Only(ctx)
+	if err != nil {
+		return "", err
+	}
+	rules := closeRulesOf(p)
+	if !p.IsOpen || rules.empty() {
+		return "", nil
+	}
+
+	counts := make([]int, len(p.Edges.Options))
+	for i, o := range p.Edges.Options {
+		counts[i] = o.VotesCount
+	}
+
+	eligible := 0
+	var userCounts []int
+	if rules.QuorumPercent != nil || rules.DecisiveLead {
+		// Por ahora son elegibles todos los usuarios activos
+		eligible, err = m.client.User.Query().Where(user.Active(true)).Count(ctx)
+		if err != nil {
+			return "", err
+		}
+		userCounts, err = m.userVoteCounts(ctx, p)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	reason := rules.Evaluate(counts, userCounts, eligible)
+	if reason == "" {
+		return "", nil
+	}
+
+	n, err := m.client.Poll.Update().
+		Where(poll.ID(pollID), poll.IsOpen(true)).
+		SetIsOpen(false).
+		SetClosedReason(poll.ClosedReason(reason)).
+		Save(ctx)
+	if err != nil || n == 0 {
+		return "", err
+	}
+	return reason, nil
+}
+
+// userVoteCounts cuenta los votos de usuarios registrados (sin invitados) de
+// cada opción, en el mismo orden que p.Edges.Options
+func (m *PollModel) userVoteCounts(ctx context.Context, p *ent.Poll) ([]int, error) {
+	var rows []struct {
+		OptionID int `json:"poll_option_votes"`
+		Count    int `json:"count"`
+	}
+	err := m.client.Vote.Query().
+		Where(vote.HasPollWith(poll.ID(p.ID)), vote.HasUser()).
+		GroupBy(vote.PollOptionColumn).
+		Aggregate(ent.Count()).
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+	byOption := make(map[int]int, len(rows))
+	for _, r := range rows {
+		byOption[r.OptionID] = r.Count
+	}
+
+	counts := make([]int, len(p.Edges.Options))
+	for i, o := range p.Edges.Options {
+		counts[i] = byOption[o.ID]
+	}
+	return counts, nil
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/ent/poll"
+)
+
+// NextScheduleChange devuelve el próximo instante en el que alguna encuesta
+// debe abrirse o cerrarse, o nil si no hay nada programado.
+func (m *PollModel) NextScheduleChange(ctx context.Context, now time.Time) (*time.Time, error) {
+	var next *time.Time
+
+	nextOpen, err := m.client.Poll.Query().
+		Where(poll.StatusEQ(poll.StatusPublished), poll.IsOpen(false), poll.OpensAtGT(now)).
+		Order(ent.Asc(poll.FieldOpensAt)).
+		First(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return nil, err
+	}
+	if nextOpen != nil {
+		next = nextOpen.OpensAt
+	}
+
+	nextClose, err := m.client.Poll.Query().
+		Where(poll.StatusEQ(poll.StatusPublished), poll.ClosesAtGT(now)).
+		Order(ent.Asc(poll.FieldClosesAt)).
+		First(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return nil, err
+	}
+	if nextClose != nil && (next == nil || nextClose.ClosesAt.Before(*next)) {
+		next = nextClose.ClosesAt
+	}
+
+	return next, nil
+}
+
+// ApplySchedule abre y cierra las encuestas cuyo momento ya llegó.
+// Cada cambio se hace con una actualización condicionada al estado previo,
+// así que si varias instancias corren a la vez solo una lo reporta.
+func (m *PollModel) ApplySchedule(ctx context.Context, now time.Time) (opened, closed []*ent.Poll, err error) {
+	toOpen, err := m.client.Poll.Query().
+		Where(
+			poll.StatusEQ(poll.StatusPublished),
+			poll.IsOpen(false),
+			poll.OpensAtLTE(now),
+			poll.Or(poll.ClosesAtIsNil(), poll.ClosesAtGT(now)),
+		).
+		All(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, p := range toOpen {
+		// Al abrirse se limpia opens_at: a partir de aquí manda el estado manual
+		n, err := m.client.Poll.Update().
+			Where(poll.ID(p.ID), poll.IsOpen(false), poll.OpensAtLTE(now)).
+			SetIsOpen(true).
+			ClearOpensAt().
+			ClearClosedReason().
+			Save(ctx)
+		if err != nil {
+			return opened, closed, err
+		}
+		if n == 1 {
+			p.IsOpen = true
+			p.OpensAt = nil
+			opened = append(opened, p)
+		}
+	}
+
+	toClose, err := m.client.Poll.Query().
+		Where(
+			poll.StatusEQ(poll.StatusPublished),
+			poll.ClosesAtLTE(now),
+			poll.Or(poll.IsOpen(true), poll.OpensAtNotNil()),
+		).
+		All(ctx)
+	if err != nil {
+		return opened, closed, err
+	}
+	for _, p := range toClose {
+		n, err := m.client.Poll.Update().
+			Where(poll.ID(p.ID), poll.ClosesAtLTE(now), poll.Or(poll.IsOpen(true), poll.OpensAtNotNil())).
+			SetIsOpen(false).
+			ClearOpensAt().
+			SetClosedReason(poll.ClosedReasonSchedule).
+			Save(ctx)
+		if err != nil {
+			return opened, closed, err
+		}
+		if n == 1 {
+			p.IsOpen = false
+			p.OpensAt = nil
+			reason := poll.ClosedReasonSchedule
+			p.ClosedReason = &reason
+			closed = append(closed, p)
+		}
+	}
+
+	return opened, closed, nil
+}
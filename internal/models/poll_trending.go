@@ -0,0 +1,39 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/ent/poll"
+	"api_voty/ent/vote"
+)
+
+// replayBatchSize es cuántos votos se leen por consulta en ReplayVotes
+const replayBatchSize = 1000
+
+// ReplayVotes pasa a o los votos emitidos desde since, en lotes para no cargar
+// todos en memoria. Sirve para reconstruir el ranking de tendencias al arrancar.
+func (m *PollModel) ReplayVotes(ctx context.Context, since time.Time, o VoteObserver) error {
+	lastID := 0
+	for {
+		votes, err := m.client.Vote.Query().
+			Where(vote.CreatedAtGTE(since), vote.IDGT(lastID)).
+			Order(ent.Asc(vote.FieldID)).
+			Limit(replayBatchSize).
+			WithPoll(func(q *ent.PollQuery) { q.Select(poll.FieldID) }).
+			All(ctx)
+		if err != nil {
+			return err
+		}
+		for _, v := range votes {
+			if v.Edges.Poll != nil {
+				o.RecordVote(v.Edges.Poll.ID, v.CreatedAt)
+			}
+		}
+		if len(votes) < replayBatchSize {
+			return nil
+		}
+		lastID = votes[len(votes)-1].ID
+	}
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/ent/poll"
+	"api_voty/ent/sharelink"
+)
+
+// shareTokenBytes es la entropía de un token de enlace público (192 bits)
+const shareTokenBytes = 24
+
+// shareTokenPrefixLen es lo que se guarda en claro para identificar el enlace
+const shareTokenPrefixLen = 6
+
+// hashShareToken es lo que se guarda y se busca: filtrar la tabla no da tokens válidos
+func hashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateShareLink genera un token público para leer la encuesta sin sesión.
+// El token en claro solo se devuelve aquí.
+func (m *PollModel) CreateShareLink(ctx context.Context, pollID int, authorID string, expiresAt *time.Time) (string, *ent.ShareLink, error) {
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return "", nil, errors.New("INVALID_EXPIRY")
+	}
+	p, err := m.client.Poll.Get(ctx, pollID)
+	if err != nil {
+		return "", nil, err
+	}
+	if p.Status != poll.StatusPublished {
+		return "", nil, errors.New("POLL_NOT_PUBLISHED")
+	}
+
+	raw := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	create := m.client.ShareLink.Create().
+		SetPollID(pollID).
+		SetTokenHash(hashShareToken(token)).
+		SetTokenPrefix(token[:shareTokenPrefixLen]).
+		SetNillableExpiresAt(expiresAt)
+	if authorID != "" {
+		create.SetCreatedBy(authorID)
+	}
+	link, err := create.Save(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	return token, link, nil
+}
+
+// ShareLinks lista los enlaces de una encuesta, los más recientes primero
+func (m *PollModel) ShareLinks(ctx context.Context, pollID int) ([]*ent.ShareLink, error) {
+	return m.client.ShareLink.Query().
+		Where(sharelink.PollID(pollID)).
+		Order(ent.Desc(sharelink.FieldCreatedAt), ent.Desc(sharelink.FieldID)).
+		All(ctx)
+}
+
+// RevokeShareLink invalida un enlace; revocarlo dos veces no es un error
+func (m *PollModel) RevokeShareLink(ctx context.Context, pollID, linkID int) error {
+	link, err := m.client.ShareLink.Query().
+		Where(sharelink.ID(linkID), sharelink.PollID(pollID)).
+		Only(ctx)
+	if err != nil {
+		return err
+	}
+	if link.RevokedAt != nil {
+		return nil
+	}
+	return link.Update().SetRevokedAt(time.Now()).Exec(ctx)
+}
+
+// ResolveShareLink devuelve la encuesta (con sus opciones) de un token vigente.
+// Cualquier fallo, incluido un borrador, se presenta igual: SHARE_LINK_INVALID.
+func (m *PollModel) ResolveShareLink(ctx context.Context, token string) (*ent.Poll, error) {
+	now := time.Now()
+	p, err := m.client.Poll.Query().
+		Where(
+			poll.StatusEQ(poll.StatusPublished),
+			poll.HasShareLinksWith(
+				sharelink.TokenHash(hashShareToken(token)),
+				sharelink.RevokedAtIsNil(),
+				sharelink.Or(sharelink.ExpiresAtIsNil(), sharelink.ExpiresAtGT(now)),
+			),
+		).
+		WithOptions(orderedOptions).
+		WithTags(orderedTags).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, errors.New("SHARE_LINK_INVALID")
+	}
+	return p, err
+}
+
+// ShareLinkActive indica si el enlace sigue sirviendo
+func ShareLinkActive(l *ent.ShareLink, now time.Time) bool {
+	return l.RevokedAt == nil && (l.ExpiresAt == nil || l.ExpiresAt.After(now))
+}
@@ -0,0 +1,242 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"api_voty/ent"
+	"api_voty/ent/tag"
+	"api_voty/ent/user"
+	"api_voty/internal/utils"
+)
+
+// Límites de las etiquetas
+const (
+	MaxTagsPerPoll = 5
+	maxTagLength   = 40
+)
+
+// Órdenes disponibles para el listado de etiquetas
+const (
+	TagSortPopular = "popular"
+	TagSortName    = "name"
+)
+
+// TagSlug normaliza el nombre de una etiqueta: sin tildes, en minúsculas y con
+// guiones entre palabras. "Política Exterior" y "politica  exterior" dan
+// "politica-exterior", así que son la misma etiqueta.
+func TagSlug(name string) string {
+	return strings.Join(utils.Tokenize(name), "-")
+}
+
+// TagResponse es una etiqueta con su popularidad
+type TagResponse struct {
+	Slug      string `json:"slug"`
+	Name      string `json:"name"`
+	PollCount int    `json:"poll_count" doc:"Encuestas publicadas con esta etiqueta"`
+	Following bool   `json:"following" doc:"Si quien consulta la sigue"`
+}
+
+// TagListParams filtra y ordena el listado de etiquetas
+type TagListParams struct {
+	UserID string // quien consulta, para marcar las que sigue
+	Prefix string // autocompletado por el principio del slug
+	Limit  int
+	Sort   string
+	// Followed limita el listado a las etiquetas que sigue UserID
+	Followed bool
+}
+
+type TagModel struct {
+	client *ent.Client
+	db     *sql.DB
+}
+
+func NewTagModel(client *ent.Client, db *sql.DB) *TagModel {
+	return &TagModel{client: client, db: db}
+}
+
+// List devuelve etiquetas con el número de encuestas publicadas que las usan
+func (m *TagModel) List(ctx context.Context, params TagListParams) ([]TagResponse, error) {
+	limit := pageSize(params.Limit)
+
+	// Los borradores no cuentan: su existencia solo la conoce el autor
+	query := `SELECT t.slug, t.name, COUNT(p.id),
+		EXISTS (SELECT 1 FROM user_followed_tags f WHERE f.tag_id = t.id AND f.user_id = ?)
+		FROM tags t
+		LEFT JOIN poll_tags pt ON pt.tag_id = t.id
+		LEFT JOIN polls p ON p.id = pt.poll_id AND p.status = 'published'
+		WHERE 1=1`
+	args := []any{params.UserID}
+
+	if prefix := TagSlug(params.Prefix); prefix != "" {
+		query += " AND t.slug LIKE ?"
+		args = append(args, escapeLike(prefix)+"%")
+	}
+	if params.Followed {
+		query += " AND t.id IN (SELECT tag_id FROM user_followed_tags WHERE user_id = ?)"
+		args = append(args, params.UserID)
+	}
+	query += " GROUP BY t.id, t.slug, t.name"
+
+	switch params.Sort {
+	case "", TagSortPopular:
+		query += " ORDER BY COUNT(p.id) DESC, t.slug ASC"
+	case TagSortName:
+		query += " ORDER BY t.slug ASC"
+	default:
+		return nil, errors.New("INVALID_SORT")
+	}
+	query += " LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []TagResponse{}
+	for rows.Next() {
+		var t TagResponse
+		if err := rows.Scan(&t.Slug, &t.Name, &t.PollCount, &t.Following); err != nil {
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+	return tags, rows.Err()
+}
+
+// orderedTags carga las etiquetas de una encuesta en orden alfabético
+func orderedTags(q *ent.TagQuery) {
+	q.Order(ent.Asc(tag.FieldSlug))
+}
+
+// escapeLike evita que % y _ del usuario actúen como comodines
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// Create da de alta una etiqueta; falla con TAG_EXISTS si ya hay una equivalente
+func (m *TagModel) Create(ctx context.Context, name string) (*ent.Tag, error) {
+	name, slug, err := cleanTagName(name)
+	if err != nil {
+		return nil, err
+	}
+	exists, err := m.client.Tag.Query().Where(tag.Slug(slug)).Exist(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.New("TAG_EXISTS")
+	}
+	t, err := m.client.Tag.Create().SetName(name).SetSlug(slug).Save(ctx)
+	if ent.IsConstraintError(err) {
+		return nil, errors.New("TAG_EXISTS")
+	}
+	return t, err
+}
+
+// Rename cambia el nombre visible (y con él el slug) de una etiqueta
+func (m *TagModel) Rename(ctx context.Context, slug, name string) (*ent.Tag, error) {
+	name, newSlug, err := cleanTagName(name)
+	if err != nil {
+		return nil, err
+	}
+	t, err := m.Get(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if newSlug != t.Slug {
+		exists, err := m.client.Tag.Query().Where(tag.Slug(newSlug)).Exist(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, errors.New("TAG_EXISTS")
+		}
+	}
+	t, err = t.Update().SetName(name).SetSlug(newSlug).Save(ctx)
+	if ent.IsConstraintError(err) {
+		return nil, errors.New("TAG_EXISTS")
+	}
+	return t, err
+}
+
+// Delete elimina la etiqueta; las encuestas y los seguidores solo pierden la relación
+func (m *TagModel) Delete(ctx context.Context, slug string) error {
+	t, err := m.Get(ctx, slug)
+	if err != nil {
+		return err
+	}
+	return m.client.Tag.DeleteOne(t).Exec(ctx)
+}
+
+// Get busca una etiqueta por su slug o por cualquier forma de su nombre
+func (m *TagModel) Get(ctx context.Context, slugOrName string) (*ent.Tag, error) {
+	return m.client.Tag.Query().Where(tag.Slug(TagSlug(slugOrName))).Only(ctx)
+}
+
+// Follow hace que las encuestas de la etiqueta aparezcan en el feed de userID
+func (m *TagModel) Follow(ctx context.Context, userID, slug string) error {
+	t, err := m.Get(ctx, slug)
+	if err != nil {
+		return err
+	}
+	following, err := t.QueryFollowers().Where(user.ID(userID)).Exist(ctx)
+	if err != nil || following {
+		return err
+	}
+	return m.client.User.UpdateOneID(userID).AddFollowedTags(t).Exec(ctx)
+}
+
+// Unfollow deja de seguir una etiqueta (no falla si no se seguía)
+func (m *TagModel) Unfollow(ctx context.Context, userID, slug string) error {
+	t, err := m.Get(ctx, slug)
+	if err != nil {
+		return err
+	}
+	return m.client.User.UpdateOneID(userID).RemoveFollowedTags(t).Exec(ctx)
+}
+
+// cleanTagName recorta el nombre y calcula su slug, rechazando los vacíos o largos
+func cleanTagName(name string) (string, string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	slug := TagSlug(name)
+	if slug == "" || len([]rune(name)) > maxTagLength {
+		return "", "", errors.New("INVALID_TAG")
+	}
+	return name, slug, nil
+}
+
+// ensureTags devuelve los IDs de las etiquetas indicadas, creando las que no
+// existan. Nombres equivalentes ("Política", "politica") cuentan una sola vez.
+func ensureTags(ctx context.Context, tx *ent.Tx, names []string) ([]int, error) {
+	ids := make([]int, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, raw := range names {
+		name, slug, err := cleanTagName(raw)
+		if err != nil {
+			return nil, err
+		}
+		if seen[slug] {
+			continue
+		}
+		seen[slug] = true
+
+		t, err := tx.Tag.Query().Where(tag.Slug(slug)).Only(ctx)
+		if ent.IsNotFound(err) {
+			t, err = tx.Tag.Create().SetName(name).SetSlug(slug).Save(ctx)
+		}
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, t.ID)
+	}
+	if len(ids) > MaxTagsPerPoll {
+		return nil, errors.New("TOO_MANY_TAGS")
+	}
+	return ids, nil
+}
@@ -0,0 +1,298 @@
+package models
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/ent/loginattempt"
+	"api_voty/ent/user"
+	"api_voty/internal/totp"
+	"api_voty/internal/utils"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqljson"
+)
+
+const (
+	totpIssuer = "Voty"
+	// recoveryCodeCount códigos de 40 bits: con el bloqueo por fallos no se
+	// pueden adivinar
+	recoveryCodeCount = 10
+	recoveryCodeBytes = 5
+)
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TwoFactorSetup es lo que necesita la app de autenticación para darse de alta
+type TwoFactorSetup struct {
+	Secret string `json:"secret" doc:"Secreto en base32, para introducirlo a mano"`
+	URI    string `json:"otpauth_uri"`
+	QRCode string `json:"qr_code" doc:"PNG con el URI como data URI"`
+}
+
+// TwoFactorStatus resume el segundo factor de un usuario
+type TwoFactorStatus struct {
+	Enabled           bool       `json:"enabled"`
+	EnabledAt         *time.Time `json:"enabled_at,omitempty"`
+	Required          bool       `json:"required" doc:"Los administradores pueden estar obligados a activarlo"`
+	RecoveryCodesLeft int        `json:"recovery_codes_left"`
+}
+
+// RequireAdminTwoFactor obliga a los administradores a activar el segundo
+// factor: hasta que lo hacen no tienen permisos de administrador
+func (p *passwords) RequireAdminTwoFactor(on bool) {
+	p.adminTwoFactor = on
+}
+
+// mustEnrollTwoFactor indica si el usuario está obligado y aún no lo activó
+func (p *passwords) mustEnrollTwoFactor(u *ent.User) bool {
+	return p.adminTwoFactor && u.Role == user.RoleAdmin && u.TotpEnabledAt == nil
+}
+
+// TwoFactorStatus devuelve el estado del segundo factor del usuario
+func (m *AuthModel) TwoFactorStatus(ctx context.Context, userID string) (*TwoFactorStatus, error) {
+	u, err := m.client.User.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &TwoFactorStatus{
+		Enabled:           u.TotpEnabledAt != nil,
+		EnabledAt:         u.TotpEnabledAt,
+		Required:          m.adminTwoFactor && u.Role == user.RoleAdmin,
+		RecoveryCodesLeft: len(u.RecoveryCodes),
+	}, nil
+}
+
+// SetupTwoFactor genera un secreto nuevo. No se exige en el login hasta que
+// ConfirmTwoFactor comprueba que la app lo tiene bien; repetir el alta antes
+// de confirmar descarta el secreto anterior.
+func (m *AuthModel) SetupTwoFactor(ctx context.Context, userID string) (*TwoFactorSetup, error) {
+	u, err := m.client.User.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u.TotpEnabledAt != nil {
+		return nil, errors.New("TWO_FACTOR_ALREADY_ENABLED")
+	}
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	uri := totp.URI(totpIssuer, u.Email, secret)
+	png, err := totp.QRCode(uri)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.client.User.UpdateOne(u).SetTotpSecret(secret).SetTotpLastStep(0).Exec(ctx); err != nil {
+		return nil, err
+	}
+	return &TwoFactorSetup{
+		Secret: secret,
+		URI:    uri,
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+	}, nil
+}
+
+// ConfirmTwoFactor activa el segundo factor con un primer código de la app y
+// devuelve los códigos de recuperación, que no se vuelven a mostrar
+func (m *AuthModel) ConfirmTwoFactor(ctx context.Context, userID, code string) ([]string, error) {
+	u, err := m.client.User.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u.TotpEnabledAt != nil {
+		return nil, errors.New("TWO_FACTOR_ALREADY_ENABLED")
+	}
+	if u.TotpSecret == nil {
+		return nil, errors.New("TWO_FACTOR_NOT_SET_UP")
+	}
+	now := time.Now()
+	step, ok := totp.Validate(*u.TotpSecret, code, now, u.TotpLastStep)
+	if !ok {
+		return nil, errors.New("INVALID_CODE")
+	}
+	codes, hashes, err := newRecoveryCodes(u.ID)
+	if err != nil {
+		return nil, err
+	}
+	err = m.client.User.UpdateOne(u).
+		SetTotpEnabledAt(now).
+		SetTotpLastStep(step).
+		SetRecoveryCodes(hashes).
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableTwoFactor desactiva el segundo factor; pide la contraseña y un
+// código (de la app o de recuperación)
+func (m *AuthModel) DisableTwoFactor(ctx context.Context, userID, plain, code string) error {
+	u, err := m.client.User.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if u.TotpEnabledAt == nil {
+		return errors.New("TWO_FACTOR_NOT_ENABLED")
+	}
+	if m.adminTwoFactor && u.Role == user.RoleAdmin {
+		return errors.New("TWO_FACTOR_REQUIRED")
+	}
+	// Sin contraseña (cuentas de proveedor OIDC) hay que crear una antes
+	if u.Password == "" {
+		return errors.New("INVALID_PASSWORD")
+	}
+	ok, err := m.hasher.Verify(plain, u.Password)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("INVALID_PASSWORD")
+	}
+	if ok, err := m.verifySecondFactor(ctx, u, code, true); err != nil || !ok {
+		if err != nil {
+			return err
+		}
+		return errors.New("INVALID_CODE")
+	}
+	return m.client.User.UpdateOne(u).
+		ClearTotpSecret().
+		ClearTotpEnabledAt().
+		SetTotpLastStep(0).
+		ClearRecoveryCodes().
+		Exec(ctx)
+}
+
+// RegenerateRecoveryCodes sustituye los códigos de recuperación; pide un
+// código de la app, no uno de recuperación
+func (m *AuthModel) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	u, err := m.client.User.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u.TotpEnabledAt == nil {
+		return nil, errors.New("TWO_FACTOR_NOT_ENABLED")
+	}
+	ok, err := m.verifySecondFactor(ctx, u, code, false)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("INVALID_CODE")
+	}
+	codes, hashes, err := newRecoveryCodes(u.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.client.User.UpdateOne(u).SetRecoveryCodes(hashes).Exec(ctx); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// LoginTwoFactor completa un login que devolvió TwoFactorRequired. Los
+// códigos erróneos cuentan como fallos de login, con sus bloqueos.
+func (m *AuthModel) LoginTwoFactor(ctx context.Context, challenge, code string, meta LoginMeta) (*AuthResponse, error) {
+	userID, err := utils.ValidateTwoFactorChallenge(challenge)
+	if err != nil {
+		return nil, errors.New("invalid or expired challenge")
+	}
+	now := time.Now()
+	wait, err := m.ipBlocked(ctx, meta.IP, now)
+	if err != nil {
+		return nil, err
+	}
+	if wait > 0 {
+		return nil, &LoginLockedError{Reason: "too many failed logins from this address", RetryAfter: wait}
+	}
+	u, err := m.client.User.Get(ctx, userID)
+	if err != nil || u.TotpEnabledAt == nil || !u.Active {
+		return nil, errors.New("invalid or expired challenge")
+	}
+	if locked := accountLocked(u, now); locked != nil {
+		m.recordAttempt(ctx, u, u.Email, meta, failure(locked.failure))
+		return nil, locked
+	}
+
+	ok, err := m.verifySecondFactor(ctx, u, code, true)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := m.registerFailure(ctx, u, now); err != nil {
+			return nil, err
+		}
+		m.recordAttempt(ctx, u, u.Email, meta, failure(loginattempt.FailureReasonBadSecondFactor))
+		return nil, errors.New("invalid code")
+	}
+	return m.completeLogin(ctx, u, u.Email, meta)
+}
+
+// verifySecondFactor acepta un código de la app o, con allowRecovery, uno de
+// recuperación, que se gasta. Un código de la app tampoco vale dos veces.
+func (m *AuthModel) verifySecondFactor(ctx context.Context, u *ent.User, code string, allowRecovery bool) (bool, error) {
+	if u.TotpSecret == nil {
+		return false, nil
+	}
+	if step, ok := totp.Validate(*u.TotpSecret, code, time.Now(), u.TotpLastStep); ok {
+		// Condicionado al paso anterior: de dos peticiones con el mismo
+		// código solo una lo gasta
+		n, err := m.client.User.Update().
+			Where(user.ID(u.ID), user.TotpLastStepLT(step)).
+			SetTotpLastStep(step).
+			Save(ctx)
+		return n == 1, err
+	}
+	if !allowRecovery {
+		return false, nil
+	}
+	hash := utils.RecoveryCodeHash(u.ID, normalizeRecoveryCode(code))
+	i := slices.Index(u.RecoveryCodes, hash)
+	if i < 0 {
+		return false, nil
+	}
+	// Como con la app, la escritura va condicionada a que la lista no haya
+	// cambiado desde que la leímos: los códigos solo se gastan o se regeneran
+	// todos, así que si sigue teniendo este código y el mismo tamaño es la
+	// misma. De dos peticiones con el mismo código (o con dos códigos a la
+	// vez) solo una escribe; la otra no lo da por bueno.
+	rest := slices.Delete(slices.Clone(u.RecoveryCodes), i, i+1)
+	n, err := m.client.User.Update().
+		Where(user.ID(u.ID), func(s *sql.Selector) {
+			s.Where(sql.And(
+				sqljson.ValueContains(user.FieldRecoveryCodes, hash),
+				sqljson.LenEQ(user.FieldRecoveryCodes, len(u.RecoveryCodes)),
+			))
+		}).
+		SetRecoveryCodes(rest).
+		Save(ctx)
+	return n == 1, err
+}
+
+// newRecoveryCodes genera los códigos (xxxx-xxxx) del usuario y los hashes
+// que se guardan
+func newRecoveryCodes(userID string) (codes, hashes []string, err error) {
+	for range recoveryCodeCount {
+		raw := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(recoveryEncoding.EncodeToString(raw))
+		codes = append(codes, code[:4]+"-"+code[4:])
+		hashes = append(hashes, utils.RecoveryCodeHash(userID, code))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode admite el código con o sin guion y en mayúsculas
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
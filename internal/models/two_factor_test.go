@@ -0,0 +1,83 @@
+package models
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"api_voty/internal/totp"
+)
+
+func TestSpendRecoveryCode(t *testing.T) {
+	client := openTestClient(t)
+	m := NewAuthModel(client, nil)
+	ctx := context.Background()
+
+	u := client.User.Create().SetEmail("ana@example.com").SetName("Ana").SetPassword("x").SaveX(ctx)
+	setup, err := m.SetupTwoFactor(ctx, u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, _ := totp.Code(setup.Secret, totp.Step(time.Now()))
+	codes, err := m.ConfirmTwoFactor(ctx, u.ID, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != recoveryCodeCount {
+		t.Fatalf("got %d recovery codes, want %d", len(codes), recoveryCodeCount)
+	}
+
+	// Solo se guardan los hashes, ligados al usuario
+	u = client.User.GetX(ctx, u.ID)
+	for i, hash := range u.RecoveryCodes {
+		if strings.Contains(hash, strings.ReplaceAll(codes[i], "-", "")) {
+			t.Fatalf("recovery code %d stored in clear", i)
+		}
+	}
+
+	spend := func(userID, code string, allowRecovery bool) bool {
+		t.Helper()
+		ok, err := m.verifySecondFactor(ctx, client.User.GetX(ctx, userID), code, allowRecovery)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ok
+	}
+	if spend(u.ID, first, true) {
+		t.Error("the confirmation code was accepted twice")
+	}
+	if spend(u.ID, codes[0], false) {
+		t.Error("recovery code accepted where only the app is allowed")
+	}
+	// Sin guion y en mayúsculas también vale, pero solo una vez
+	if !spend(u.ID, strings.ToUpper(strings.ReplaceAll(codes[0], "-", "")), true) {
+		t.Fatal("valid recovery code rejected")
+	}
+	if spend(u.ID, codes[0], true) {
+		t.Error("recovery code accepted twice")
+	}
+	if left := len(client.User.GetX(ctx, u.ID).RecoveryCodes); left != recoveryCodeCount-1 {
+		t.Errorf("recovery codes left = %d, want %d", left, recoveryCodeCount-1)
+	}
+
+	// Dos peticiones que leyeron la lista a la vez: solo una gasta su código
+	stale := client.User.GetX(ctx, u.ID)
+	if !spend(u.ID, codes[1], true) {
+		t.Fatal("valid recovery code rejected")
+	}
+	if ok, err := m.verifySecondFactor(ctx, stale, codes[2], true); ok || err != nil {
+		t.Errorf("spend with a stale list = %v, %v, want false", ok, err)
+	}
+	if !spend(u.ID, codes[2], true) {
+		t.Error("code rejected after the concurrent spend")
+	}
+
+	// Los códigos de otra cuenta no valen aunque coincidan
+	other := client.User.Create().SetEmail("leo@example.com").SetName("Leo").SetPassword("x").
+		SetTotpSecret(setup.Secret).SetTotpEnabledAt(time.Now()).
+		SetRecoveryCodes(u.RecoveryCodes).SaveX(ctx)
+	if spend(other.ID, codes[3], true) {
+		t.Error("another account's recovery code was accepted")
+	}
+}
@@ -2,6 +2,9 @@ package models
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"api_voty/ent"
@@ -9,7 +12,6 @@ import (
 	"database/sql"
 
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type UserInput struct {
@@ -28,34 +30,54 @@ type UserUpdateInput struct {
 }
 
 type UserResponse struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	Name      string    `json:"name"`
-	Active    bool      `json:"active"`
+	ID          string  `json:"id"`
+	Email       string  `json:"email"`
+	Name        string  `json:"name"`
+	Active      bool    `json:"active"`
+	Role        string  `json:"role" enum:"user,admin"`
 	AvatarImage *string `json:"avatar_image"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	// Si el usuario abrió el enlace de verificación del email
+	EmailVerified bool `json:"email_verified"`
+	// Si el login pide un código de la app de autenticación
+	TwoFactorEnabled bool `json:"two_factor_enabled"`
+	// Solo mientras dura un bloqueo por intentos fallidos
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func toUserResponse(u *ent.User) *UserResponse {
+	return &UserResponse{
+		ID:               u.ID,
+		Email:            u.Email,
+		Name:             u.Name,
+		Active:           u.Active,
+		Role:             u.Role.String(),
+		EmailVerified:    u.EmailVerifiedAt != nil,
+		TwoFactorEnabled: u.TotpEnabledAt != nil,
+		AvatarImage:      u.AvatarImage,
+		LockedUntil:      isLocked(u),
+		CreatedAt:        u.CreatedAt,
+		UpdatedAt:        u.UpdatedAt,
+	}
 }
 
 type UserModel struct {
 	client *ent.Client
 	db     *sql.DB
+	passwords
 }
 
 func NewUserModel(client *ent.Client, db *sql.DB) *UserModel {
 	return &UserModel{
-		client: client,
-		db:     db,
+		client:    client,
+		db:        db,
+		passwords: defaultPasswords(),
 	}
 }
 
-func hashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
-	return string(bytes), err
-}
-
 func (m *UserModel) Create(ctx context.Context, input UserInput) (*UserResponse, error) {
-	hashedPass, err := hashPassword(input.Password)
+	hashedPass, err := m.hashNew(input.Password, input.Email, input.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -80,44 +102,114 @@ func (m *UserModel) Create(ctx context.Context, input UserInput) (*UserResponse,
 		return nil, err
 	}
 
-	return &UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		Name:      user.Name,
-		Active:    user.Active,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}, nil
+	return toUserResponse(user), nil
 }
 
-func (m *UserModel) GetAll(ctx context.Context) ([]*UserResponse, error) {
-    // 1. Aquí pides 7 campos: id(1), email(2), name(3), active(4), avatar_image(5), created_at(6), updated_at(7)
-    query := "SELECT id, email, name, active, avatar_image, created_at, updated_at FROM users"
-
-    rows, err := m.db.QueryContext(ctx, query)
-    if err != nil {
-        return nil, err
-    }
-    defer rows.Close()
-
-    var responses []*UserResponse
-    for rows.Next() {
-        u := &UserResponse{}
-        err := rows.Scan(
-            &u.ID, 
-            &u.Email, 
-            &u.Name, 
-            &u.Active, 
-            &u.AvatarImage, // <--- ESTE FALTABA (Posición 5)
-            &u.CreatedAt, 
-            &u.UpdatedAt,
-        )
-        if err != nil {
-            return nil, err
-        }
-        responses = append(responses, u)
-    }
-    return responses, nil
+// Órdenes disponibles para el listado de usuarios
+const (
+	UserSortNewest = "newest"
+	UserSortOldest = "oldest"
+	UserSortName   = "name"
+)
+
+// UserListParams son los filtros, el orden y la página del listado de usuarios
+type UserListParams struct {
+	Cursor string
+	Limit  int
+	Sort   string
+	Active *bool
+}
+
+// GetPage devuelve una página de usuarios usando paginación por cursor (keyset)
+func (m *UserModel) GetPage(ctx context.Context, params UserListParams) ([]*UserResponse, PageInfo, error) {
+	if params.Sort == "" {
+		params.Sort = UserSortNewest
+	}
+	limit := pageSize(params.Limit)
+	page := PageInfo{Limit: limit}
+
+	after, err := decodeCursor(params.Cursor, params.Sort)
+	if err != nil {
+		return nil, page, err
+	}
+
+	// 1. Aquí pides 10 campos: id(1), email(2), name(3), active(4), role(5), verificado(6), 2fa(7), avatar_image(8), created_at(9), updated_at(10)
+	query := "SELECT id, email, name, active, role, email_verified_at IS NOT NULL, totp_enabled_at IS NOT NULL, avatar_image, created_at, updated_at FROM users WHERE 1=1"
+	var args []any
+
+	if params.Active != nil {
+		query += " AND active = ?"
+		args = append(args, *params.Active)
+	}
+
+	// La columna de orden y el ID como desempate
+	var column, dir, op string
+	switch params.Sort {
+	case UserSortNewest:
+		column, dir, op = "created_at", "DESC", "<"
+	case UserSortOldest:
+		column, dir, op = "created_at", "ASC", ">"
+	case UserSortName:
+		column, dir, op = "name", "ASC", ">"
+	default:
+		return nil, page, errors.New("INVALID_SORT")
+	}
+	if after != nil {
+		var key any = after.Key
+		if column == "created_at" {
+			t, err := time.Parse(time.RFC3339Nano, after.Key)
+			if err != nil {
+				return nil, page, errors.New("INVALID_CURSOR")
+			}
+			key = t
+		}
+		query += fmt.Sprintf(" AND (%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", column, op)
+		args = append(args, key, key, after.ID)
+	}
+	query += fmt.Sprintf(" ORDER BY %[1]s %[2]s, id %[2]s LIMIT ?", column, dir)
+	args = append(args, limit+1)
+
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, page, err
+	}
+	defer rows.Close()
+
+	responses := []*UserResponse{}
+	for rows.Next() {
+		u := &UserResponse{}
+		err := rows.Scan(
+			&u.ID,
+			&u.Email,
+			&u.Name,
+			&u.Active,
+			&u.Role,
+			&u.EmailVerified,
+			&u.TwoFactorEnabled,
+			&u.AvatarImage, // <--- ESTE FALTABA (Posición 8)
+			&u.CreatedAt,
+			&u.UpdatedAt,
+		)
+		if err != nil {
+			return nil, page, err
+		}
+		responses = append(responses, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, page, err
+	}
+
+	if len(responses) > limit {
+		responses = responses[:limit]
+		last := responses[limit-1]
+		key := last.Name
+		if column == "created_at" {
+			key = last.CreatedAt.Format(time.RFC3339Nano)
+		}
+		page.HasMore = true
+		page.NextCursor = encodeCursor(cursor{Sort: params.Sort, Key: key, ID: last.ID})
+	}
+	return responses, page, nil
 }
 
 func (m *UserModel) GetByID(ctx context.Context, id string) (*UserResponse, error) {
@@ -130,34 +222,46 @@ func (m *UserModel) GetByID(ctx context.Context, id string) (*UserResponse, erro
 		return nil, err
 	}
 
-	return &UserResponse{
-		ID:        u.ID,
-		Email:     u.Email,
-		Name:      u.Name,
-		Active:    u.Active,
-		CreatedAt: u.CreatedAt,
-		AvatarImage: u.AvatarImage,
-		UpdatedAt: u.UpdatedAt,
-	}, nil
+	return toUserResponse(u), nil
 }
 
 func (m *UserModel) Update(ctx context.Context, id string, input UserUpdateInput) (*UserResponse, error) {
 	update := m.client.User.UpdateOneID(id).
 		SetUpdatedAt(time.Now())
 
+	var current *ent.User
+	if input.Email != nil || input.Password != nil {
+		var err error
+		if current, err = m.client.User.Get(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
 	if input.Email != nil {
+		// Un email nuevo hay que volver a verificarlo
+		if !strings.EqualFold(current.Email, *input.Email) {
+			update.ClearEmailVerifiedAt().ClearVerificationSentAt()
+		}
 		update.SetEmail(*input.Email)
 	}
 	if input.Name != nil {
 		update.SetName(*input.Name)
 	}
-	
+
 	if input.Avatar != nil {
-        update.SetAvatarImage(*input.Avatar)
-    }
+		update.SetAvatarImage(*input.Avatar)
+	}
 
 	if input.Password != nil {
-		hashedPass, err := hashPassword(*input.Password)
+		// Ni los datos actuales ni los que llegan en la misma petición
+		personal := []string{current.Email, current.Name}
+		if input.Email != nil {
+			personal = append(personal, *input.Email)
+		}
+		if input.Name != nil {
+			personal = append(personal, *input.Name)
+		}
+		hashedPass, err := m.hashNew(*input.Password, personal...)
 		if err != nil {
 			return nil, err
 		}
@@ -172,19 +276,16 @@ func (m *UserModel) Update(ctx context.Context, id string, input UserUpdateInput
 		return nil, err
 	}
 
-	return &UserResponse{
-		ID:        u.ID,
-		Email:     u.Email,
-		Name:      u.Name,
-		Active:    u.Active,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
-		AvatarImage: u.AvatarImage,
-	}, nil
+	return toUserResponse(u), nil
 }
 
 func (m *UserModel) Delete(ctx context.Context, id string) error {
-	return m.client.User.
+	err := m.client.User.
 		DeleteOneID(id).
 		Exec(ctx)
+	if ent.IsConstraintError(err) {
+		// Sus votos siguen contando en los resultados (ON DELETE RESTRICT)
+		return errors.New("USER_HAS_VOTES")
+	}
+	return err
 }
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDeleteUser(t *testing.T) {
+	client := openTestClient(t)
+	users := NewUserModel(client, nil)
+	polls := NewPollModel(client)
+	ctx := context.Background()
+
+	voter := client.User.Create().SetEmail("ana@example.com").SetName("Ana").SetPassword("x").SaveX(ctx)
+	idle := client.User.Create().SetEmail("leo@example.com").SetName("Leo").SetPassword("x").SaveX(ctx)
+
+	p, err := polls.Create(ctx, PollInput{Title: "¿Café o té?", Options: []string{"Café", "Té"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	option := client.PollOption.Query().FirstX(ctx)
+	if _, err := polls.CastVote(ctx, strconv.Itoa(p.ID), strconv.Itoa(option.ID), UserVoter(voter.ID)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Quien ha votado no se borra: su papeleta quedaría huérfana en los recuentos
+	if err := users.Delete(ctx, voter.ID); err == nil || err.Error() != "USER_HAS_VOTES" {
+		t.Errorf("delete voter: err %v, want USER_HAS_VOTES", err)
+	}
+	if n := client.Vote.Query().CountX(ctx); n != 1 {
+		t.Errorf("votes = %d, want 1", n)
+	}
+
+	// Su historial de accesos, sus enlaces de recuperación y sus identidades
+	// vinculadas se van con la cuenta
+	client.LoginAttempt.Create().SetUserID(idle.ID).SetEmail(idle.Email).SetIP("192.0.2.1").SetSuccess(true).ExecX(ctx)
+	client.PasswordReset.Create().SetUserID(idle.ID).SetTokenHash("reset-hash").SetExpiresAt(time.Now().Add(time.Hour)).ExecX(ctx)
+	client.LinkedIdentity.Create().SetUserID(idle.ID).SetProvider("corp").SetSubject("sub-leo").ExecX(ctx)
+
+	if err := users.Delete(ctx, idle.ID); err != nil {
+		t.Errorf("delete user without votes: %v", err)
+	}
+	if n := client.User.Query().CountX(ctx); n != 1 {
+		t.Errorf("users = %d, want 1", n)
+	}
+	if n := client.LoginAttempt.Query().CountX(ctx); n != 0 {
+		t.Errorf("login attempts = %d, want 0", n)
+	}
+	if n := client.PasswordReset.Query().CountX(ctx); n != 0 {
+		t.Errorf("password resets = %d, want 0", n)
+	}
+	if n := client.LinkedIdentity.Query().CountX(ctx); n != 0 {
+		t.Errorf("linked identities = %d, want 0", n)
+	}
+}
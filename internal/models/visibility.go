@@ -0,0 +1,122 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"api_voty/ent"
+	"api_voty/ent/poll"
+	"api_voty/ent/predicate"
+	"api_voty/ent/user"
+	"api_voty/ent/vote"
+)
+
+// Viewer es quien consulta los resultados de una encuesta
+type Viewer struct {
+	UserID  string
+	IsAdmin bool
+}
+
+// IsOwner indica si el viewer creó la encuesta
+func (v Viewer) IsOwner(p *ent.Poll) bool {
+	return v.UserID != "" && p.OwnerID != nil && *p.OwnerID == v.UserID
+}
+
+// ResultsPublic indica si los recuentos son visibles para cualquiera
+func ResultsPublic(p *ent.Poll, now time.Time) bool {
+	switch p.ResultsVisibility {
+	case poll.ResultsVisibilityAfterClose:
+		// Cerrada y sin apertura pendiente
+		return !p.IsOpen && p.OpensAt == nil
+	case poll.ResultsVisibilityEmbargo:
+		return p.ResultsVisibleAt != nil && !now.Before(*p.ResultsVisibleAt)
+	case poll.ResultsVisibilityAfterVote:
+		return false
+	default:
+		return true
+	}
+}
+
+// resultsPublicAt es ResultsPublic como predicado, para filtrar en la consulta
+func resultsPublicAt(now time.Time) predicate.Poll {
+	return poll.Or(
+		poll.ResultsVisibilityEQ(poll.ResultsVisibilityAlways),
+		poll.And(
+			poll.ResultsVisibilityEQ(poll.ResultsVisibilityAfterClose),
+			poll.IsOpen(false),
+			poll.OpensAtIsNil(),
+		),
+		poll.And(
+			poll.ResultsVisibilityEQ(poll.ResultsVisibilityEmbargo),
+			poll.ResultsVisibleAtLTE(now),
+		),
+	)
+}
+
+// ResultsVisible indica si el viewer puede ver los recuentos.
+// El autor y los administradores siempre los ven.
+func ResultsVisible(p *ent.Poll, v Viewer, voted bool, now time.Time) bool {
+	if v.IsAdmin || v.IsOwner(p) {
+		return true
+	}
+	if ResultsPublic(p, now) {
+		return true
+	}
+	return p.ResultsVisibility == poll.ResultsVisibilityAfterVote && voted
+}
+
+func validateVisibility(visibility string, visibleAt *time.Time) error {
+	if visibility == "" {
+		return nil
+	}
+	if err := poll.ResultsVisibilityValidator(poll.ResultsVisibility(visibility)); err != nil {
+		return errors.New("INVALID_VISIBILITY")
+	}
+	if poll.ResultsVisibility(visibility) == poll.ResultsVisibilityEmbargo && visibleAt == nil {
+		return errors.New("INVALID_VISIBILITY")
+	}
+	return nil
+}
+
+// Viewer carga el rol del usuario para decidir qué puede ver
+func (m *UserModel) Viewer(ctx context.Context, id string) Viewer {
+	v := Viewer{UserID: id}
+	if id == "" {
+		return v
+	}
+	u, err := m.client.User.Query().
+		Where(user.ID(id)).
+		Select(user.FieldRole, user.FieldTotpEnabledAt).
+		Only(ctx)
+	if err == nil {
+		v.IsAdmin = u.Role == user.RoleAdmin && !m.mustEnrollTwoFactor(u)
+	}
+	return v
+}
+
+// Get devuelve la encuesta sin relaciones
+func (m *PollModel) Get(ctx context.Context, id int) (*ent.Poll, error) {
+	return m.client.Poll.Get(ctx, id)
+}
+
+// VotersAmong devuelve cuáles de los usuarios dados ya votaron en la encuesta
+func (m *PollModel) VotersAmong(ctx context.Context, pollID int, userIDs []string) (map[string]bool, error) {
+	voters := make(map[string]bool)
+	if len(userIDs) == 0 {
+		return voters, nil
+	}
+	ids, err := m.client.User.Query().
+		Where(
+			user.IDIn(userIDs...),
+			user.HasVotesWith(vote.HasPollWith(poll.ID(pollID))),
+		).
+		IDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		voters[id] = true
+	}
+	return voters, nil
+}
@@ -0,0 +1,44 @@
+package oidc
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// wellKnownIssuers evita configurar el emisor de los proveedores públicos
+var wellKnownIssuers = map[string]string{
+	"google": "https://accounts.google.com",
+}
+
+// ProvidersFromEnv lee OIDC_PROVIDERS (nombres separados por comas) y, por
+// cada nombre, OIDC_<NOMBRE>_ISSUER, OIDC_<NOMBRE>_CLIENT_ID y
+// OIDC_<NOMBRE>_CLIENT_SECRET. El callback es
+// {baseURL}/auth/oidc/{nombre}/callback. Sin OIDC_PROVIDERS devuelve un mapa
+// vacío.
+func ProvidersFromEnv(baseURL string, client *http.Client) (map[string]*Provider, error) {
+	providers := map[string]*Provider{}
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		cfg := Config{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  strings.TrimSuffix(baseURL, "/") + "/auth/oidc/" + name + "/callback",
+		}
+		if cfg.Issuer == "" {
+			cfg.Issuer = wellKnownIssuers[name]
+		}
+		if cfg.Issuer == "" || cfg.ClientID == "" {
+			return nil, fmt.Errorf("oidc provider %q needs %sISSUER and %sCLIENT_ID", name, prefix, prefix)
+		}
+		providers[name] = NewProvider(cfg, client)
+	}
+	return providers, nil
+}
@@ -0,0 +1,82 @@
+package oidc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// FlowTTL es lo que puede tardar el usuario en volver del proveedor
+const FlowTTL = 10 * time.Minute
+
+// MinKeyLen es la longitud mínima de la clave que firma los flujos: con una
+// clave corta o vacía cualquiera podría fabricar la cookie
+const MinKeyLen = 32
+
+var ErrFlow = errors.New("invalid or expired login flow")
+
+// Flow es el estado de un login en curso. Viaja firmado en una cookie
+// HttpOnly entre el inicio y el callback, así que no hay nada que guardar en
+// el servidor.
+type Flow struct {
+	Provider  string    `json:"p"`
+	State     string    `json:"s"`
+	Nonce     string    `json:"n"`
+	Verifier  string    `json:"v"`
+	ExpiresAt time.Time `json:"e"`
+}
+
+// NewFlow prepara un login con state, nonce y verifier nuevos
+func NewFlow(provider string, now time.Time) (*Flow, error) {
+	f := &Flow{Provider: provider, ExpiresAt: now.Add(FlowTTL).Truncate(time.Second)}
+	for _, v := range []*string{&f.State, &f.Nonce, &f.Verifier} {
+		s, err := RandomString()
+		if err != nil {
+			return nil, err
+		}
+		*v = s
+	}
+	return f, nil
+}
+
+// Seal firma el flujo con key
+func (f *Flow) Seal(key []byte) (string, error) {
+	payload, err := json.Marshal(f)
+	if err != nil {
+		return "", err
+	}
+	body := base64.RawURLEncoding.EncodeToString(payload)
+	return body + "." + sign(key, body), nil
+}
+
+// OpenFlow comprueba la firma y la caducidad de la cookie y que el state
+// devuelto por el proveedor sea el de este navegador
+func OpenFlow(key []byte, sealed, provider, state string, now time.Time) (*Flow, error) {
+	body, mac, ok := strings.Cut(sealed, ".")
+	if !ok || !hmac.Equal([]byte(mac), []byte(sign(key, body))) {
+		return nil, ErrFlow
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return nil, ErrFlow
+	}
+	var f Flow
+	if err := json.Unmarshal(payload, &f); err != nil {
+		return nil, ErrFlow
+	}
+	if f.Provider != provider || state == "" ||
+		!hmac.Equal([]byte(f.State), []byte(state)) || !now.Before(f.ExpiresAt) {
+		return nil, ErrFlow
+	}
+	return &f, nil
+}
+
+func sign(key []byte, body string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("oidc-flow:" + body))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
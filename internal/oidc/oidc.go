@@ -0,0 +1,259 @@
+// Package oidc implementa la parte de cliente (relying party) de OpenID
+// Connect: flujo authorization code con PKCE (S256), descubrimiento de
+// endpoints y verificación del ID token con las claves JWKS del proveedor.
+//
+// Solo se admiten ID tokens firmados con RS256, que es lo que emiten Google y
+// los proveedores corporativos habituales.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrDiscovery = errors.New("oidc discovery failed")
+	ErrExchange  = errors.New("oidc code exchange failed")
+	// ErrGrant es un código rechazado por el proveedor: caducado, ya usado o
+	// con un verificador PKCE que no corresponde
+	ErrGrant   = errors.New("oidc authorization code rejected")
+	ErrIDToken = errors.New("invalid id token")
+)
+
+// maxResponseBytes limita lo que se lee de las respuestas del proveedor
+const maxResponseBytes = 1 << 20
+
+// Config describe un proveedor registrado
+type Config struct {
+	// Name es el identificador en las rutas (/auth/oidc/{name}/...)
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL es la URL de callback registrada en el proveedor
+	RedirectURL string
+	// Scopes además de openid; por defecto email y profile
+	Scopes []string
+}
+
+// Identity es lo que el proveedor afirma del usuario en el ID token
+type Identity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// discovery es la parte de /.well-known/openid-configuration que se usa
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider es un proveedor configurado. El descubrimiento se hace en el
+// primer uso, así que el servidor arranca aunque el proveedor no responda.
+type Provider struct {
+	cfg    Config
+	client *http.Client
+
+	mu   sync.Mutex
+	meta *discovery
+	keys *keySet
+}
+
+// NewProvider crea un proveedor; client nil usa un cliente con timeout de 10 s
+func NewProvider(cfg Config, client *http.Client) *Provider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"email", "profile"}
+	}
+	cfg.Issuer = strings.TrimSuffix(cfg.Issuer, "/")
+	return &Provider{cfg: cfg, client: client}
+}
+
+// Name es el identificador del proveedor
+func (p *Provider) Name() string { return p.cfg.Name }
+
+// RedirectURL es el callback registrado en el proveedor
+func (p *Provider) RedirectURL() string { return p.cfg.RedirectURL }
+
+func (p *Provider) discover(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.meta != nil {
+		return p.meta, nil
+	}
+	var d discovery
+	if err := p.getJSON(ctx, p.cfg.Issuer+"/.well-known/openid-configuration", &d); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDiscovery, err)
+	}
+	if strings.TrimSuffix(d.Issuer, "/") != p.cfg.Issuer || d.AuthorizationEndpoint == "" ||
+		d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: incomplete or mismatched configuration for %s", ErrDiscovery, p.cfg.Issuer)
+	}
+	p.meta = &d
+	p.keys = newKeySet(d.JWKSURI, p.getJSON)
+	return p.meta, nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(v)
+}
+
+// AuthCodeURL es la URL del proveedor a la que se manda al usuario. state
+// protege el callback de CSRF, nonce liga el ID token a esta petición y el
+// reto PKCE se deriva de verifier.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.cfg.RedirectURL},
+		"scope":                 {strings.Join(append([]string{"openid"}, p.cfg.Scopes...), " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {S256Challenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return d.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+// tokenResponse es la respuesta del token endpoint
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// idClaims son los claims del ID token que se usan
+type idClaims struct {
+	Nonce         string `json:"nonce"`
+	Email         string `json:"email"`
+	EmailVerified any    `json:"email_verified"` // algunos proveedores lo mandan como "true"
+	Name          string `json:"name"`
+	jwt.RegisteredClaims
+}
+
+// Exchange canjea el código del callback por un ID token y devuelve la
+// identidad que contiene, ya verificada (firma, emisor, audiencia, caducidad
+// y nonce)
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (*Identity, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"client_id":     {p.cfg.ClientID},
+		"code_verifier": {verifier},
+	}
+	if p.cfg.ClientSecret != "" {
+		form.Set("client_secret", p.cfg.ClientSecret)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrExchange, err)
+	}
+	defer resp.Body.Close()
+	var tok tokenResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&tok); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrExchange, resp.Status)
+	}
+	if resp.StatusCode == http.StatusBadRequest && tok.Error == "invalid_grant" {
+		return nil, fmt.Errorf("%w: %s", ErrGrant, tok.ErrorDescription)
+	}
+	if resp.StatusCode != http.StatusOK || tok.IDToken == "" {
+		return nil, fmt.Errorf("%w: %s %s", ErrExchange, tok.Error, tok.ErrorDescription)
+	}
+	return p.verify(ctx, tok.IDToken, nonce)
+}
+
+func (p *Provider) verify(ctx context.Context, raw, nonce string) (*Identity, error) {
+	claims := &idClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.keys.key(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(p.meta.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIDToken, err)
+	}
+	if claims.Subject == "" || claims.Nonce == "" || claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: missing subject or nonce mismatch", ErrIDToken)
+	}
+	verified := claims.EmailVerified == true || claims.EmailVerified == "true"
+	return &Identity{
+		Provider:      p.cfg.Name,
+		Subject:       claims.Subject,
+		Email:         strings.TrimSpace(claims.Email),
+		EmailVerified: verified,
+		Name:          claims.Name,
+	}, nil
+}
+
+// RandomString genera un valor aleatorio de 256 bits en base64url, apto
+// para state, nonce y code verifier
+func RandomString() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// S256Challenge es el code_challenge PKCE de verifier
+func S256Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,199 @@
+package password
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+)
+
+// Checker comprueba si una contraseña aparece en filtraciones conocidas
+type Checker interface {
+	Breached(password string) bool
+}
+
+// prefixLen es el prefijo del SHA-1 por el que se agrupan los hashes, como en
+// la API de rangos de Have I Been Pwned: quien consulta solo necesita conocer
+// el prefijo para obtener los candidatos
+const prefixLen = 5
+
+// hashLen es la longitud de un SHA-1 en hexadecimal
+const hashLen = 2 * sha1.Size
+
+// maxLineLen acota lo que se lee por línea; las del formato de Have I Been
+// Pwned ("HASH:apariciones") no pasan de unas decenas de bytes
+const maxLineLen = 256
+
+// BreachedList es una lista de hashes SHA-1 de contraseñas filtradas que se
+// consulta directamente sobre el fichero, sin cargarla en memoria: las listas
+// reales ocupan decenas de GB. Cada consulta es una búsqueda binaria por
+// desplazamiento en bytes, así que lee unas pocas líneas. Es segura para uso
+// concurrente (solo usa ReadAt).
+type BreachedList struct {
+	file *os.File
+	size int64
+}
+
+// OpenBreachedList abre un fichero con un SHA-1 en hexadecimal por línea,
+// opcionalmente seguido de ":apariciones" (el formato de las descargas de
+// Have I Been Pwned ordenadas por hash). El fichero debe estar ordenado por
+// hash; solo se admiten comentarios (#) y líneas vacías al principio.
+func OpenBreachedList(path string) (*BreachedList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	list := &BreachedList{file: f, size: info.Size()}
+
+	// Comprobación rápida del formato con la primera línea de datos
+	first, _, err := list.lineAt(list.firstDataLine())
+	if err == nil && !validHash(first) {
+		err = fmt.Errorf("%s: not a list of SHA-1 hashes", path)
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return list, nil
+}
+
+// Close libera el fichero
+func (l *BreachedList) Close() error { return l.file.Close() }
+
+// Range devuelve los sufijos conocidos para un prefijo de cinco caracteres
+func (l *BreachedList) Range(prefix string) ([]string, error) {
+	prefix = strings.ToUpper(prefix)
+	off, err := l.search(prefix)
+	if err != nil {
+		return nil, err
+	}
+	var suffixes []string
+	for off < l.size {
+		key, next, err := l.lineAt(off)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(string(key), prefix) {
+			break
+		}
+		if suffix := string(key[prefixLen:]); len(suffixes) == 0 || suffixes[len(suffixes)-1] != suffix {
+			suffixes = append(suffixes, suffix)
+		}
+		off = next
+	}
+	return suffixes, nil
+}
+
+// Breached indica si la contraseña está en la lista. Si el fichero no se
+// puede leer la contraseña se acepta: no se bloquea el registro por un fallo
+// del disco, pero queda en el log.
+func (l *BreachedList) Breached(password string) bool {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	off, err := l.search(hash)
+	if err == nil && off < l.size {
+		var key []byte
+		key, _, err = l.lineAt(off)
+		if err == nil {
+			return string(key) == hash
+		}
+	}
+	if err != nil {
+		log.Printf("error consultando la lista de contraseñas filtradas: %v", err)
+	}
+	return false
+}
+
+// search devuelve el desplazamiento de la primera línea cuyo hash es >= target
+// (o el tamaño del fichero si no hay ninguna)
+func (l *BreachedList) search(target string) (int64, error) {
+	lo, hi := int64(0), l.size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		start := l.lineStart(mid)
+		if start >= l.size {
+			hi = mid
+			continue
+		}
+		key, _, err := l.lineAt(start)
+		if err != nil {
+			return 0, err
+		}
+		if string(key) >= target {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return l.lineStart(lo), nil
+}
+
+// lineStart devuelve el comienzo de la primera línea que empieza en off o
+// después. Un error de lectura se trata como fin de fichero y lo reporta
+// la lectura siguiente.
+func (l *BreachedList) lineStart(off int64) int64 {
+	if off == 0 {
+		return 0
+	}
+	buf := make([]byte, maxLineLen)
+	for off < l.size {
+		n, err := l.file.ReadAt(buf, off-1)
+		if i := bytes.IndexByte(buf[:n], '\n'); i >= 0 {
+			return off + int64(i)
+		}
+		if err != nil {
+			break
+		}
+		off += int64(n)
+	}
+	return l.size
+}
+
+// lineAt lee la línea que empieza en off y devuelve su hash en mayúsculas
+// (vacío en comentarios y líneas en blanco) y el comienzo de la siguiente
+func (l *BreachedList) lineAt(off int64) ([]byte, int64, error) {
+	r := bufio.NewReaderSize(io.NewSectionReader(l.file, off, l.size-off), maxLineLen)
+	line, err := r.ReadSlice('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, 0, err
+	}
+	next := off + int64(len(line))
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 || line[0] == '#' {
+		return nil, next, nil
+	}
+	hash, _, _ := bytes.Cut(line, []byte(":"))
+	return bytes.ToUpper(hash), next, nil
+}
+
+// firstDataLine salta los comentarios y líneas vacías del principio
+func (l *BreachedList) firstDataLine() int64 {
+	off := int64(0)
+	for off < l.size {
+		key, next, err := l.lineAt(off)
+		if err != nil || key != nil {
+			return off
+		}
+		off = next
+	}
+	return off
+}
+
+func validHash(h []byte) bool {
+	if len(h) != hashLen {
+		return false
+	}
+	_, err := hex.DecodeString(string(h))
+	return err == nil
+}
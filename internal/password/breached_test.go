@@ -0,0 +1,156 @@
+package password
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// testBreachedLines sigue el formato de Have I Been Pwned, con los casos
+// raros que admite: minúsculas, sin contador y prefijos compartidos
+var testBreachedLines = []string{
+	"0808F4F387948CA6E398F4030E8EE19D74C5883E:3", // pass11
+	"5BAA600000000000000000000000000000000000:1",
+	"5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:9659365", // password
+	"5BAA6FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:1",
+	"7c4a8d09ca3762af61e59520943dc26494f8941b:37359195", // 123456
+	"8D6E34F987851AA599257D3831A1AF040886842F",          // sunshine
+	"B1B3773A05C0ED0176787A4F1574FF0075F7521E:12",       // qwerty
+	"F78ECA20109796E5C2598DAC799A7B666E27E36E:1",        // pass25
+}
+
+func writeBreachedList(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func openTestList(t *testing.T, content string) *BreachedList {
+	t.Helper()
+	list, err := OpenBreachedList(writeBreachedList(t, content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { list.Close() })
+	return list
+}
+
+func TestBreachedList(t *testing.T) {
+	data := strings.Join(testBreachedLines, "\n")
+	files := []struct {
+		name, content string
+	}{
+		{"plain", data + "\n"},
+		{"no trailing newline", data},
+		{"comments", "# Contraseñas filtradas\n#\n\n" + data + "\n"},
+		{"crlf", strings.ReplaceAll("# Contraseñas filtradas\n"+data+"\n", "\n", "\r\n")},
+	}
+	passwords := []struct {
+		password string
+		want     bool
+	}{
+		{"pass11", true},   // primera línea
+		{"password", true}, // entre dos con el mismo prefijo
+		{"123456", true},   // en minúsculas
+		{"sunshine", true}, // sin contador
+		{"qwerty", true},
+		{"pass25", true},         // última línea
+		{"pass84", false},        // antes de la primera
+		{"correct horse", false}, // entre dos líneas
+		{"monkey", false},
+		{"pass56", false}, // después de la última
+		{"", false},
+	}
+	for _, f := range files {
+		t.Run(f.name, func(t *testing.T) {
+			list := openTestList(t, f.content)
+			for _, p := range passwords {
+				if got := list.Breached(p.password); got != p.want {
+					t.Errorf("Breached(%q) = %v, want %v", p.password, got, p.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBreachedListRange(t *testing.T) {
+	list := openTestList(t, "# Contraseñas filtradas\n"+strings.Join(testBreachedLines, "\n")+"\n")
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"5BAA6", []string{
+			"00000000000000000000000000000000000",
+			"1E4C9B93F3F0682250B6CF8331B7EE68FD8",
+			"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+		}},
+		{"5baa6", []string{
+			"00000000000000000000000000000000000",
+			"1E4C9B93F3F0682250B6CF8331B7EE68FD8",
+			"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+		}},
+		{"0808F", []string{"4F387948CA6E398F4030E8EE19D74C5883E"}},
+		{"7C4A8", []string{"D09CA3762AF61E59520943DC26494F8941B"}},
+		{"F78EC", []string{"A20109796E5C2598DAC799A7B666E27E36E"}},
+		{"00000", nil},
+		{"5BAA7", nil},
+		{"FFFFF", nil},
+	}
+	for _, tt := range tests {
+		got, err := list.Range(tt.prefix)
+		if err != nil {
+			t.Fatalf("Range(%q): %v", tt.prefix, err)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Range(%q) = %q, want %q", tt.prefix, got, tt.want)
+		}
+	}
+}
+
+// Con muchas líneas la búsqueda binaria cae a mitad de línea y cruza varios
+// bloques de lectura
+func TestBreachedListLarge(t *testing.T) {
+	var hashes []string
+	for n := 0; n < 2000; n += 2 {
+		sum := sha1.Sum([]byte(fmt.Sprint(n)))
+		hashes = append(hashes, strings.ToUpper(hex.EncodeToString(sum[:])))
+	}
+	sort.Strings(hashes)
+	var b strings.Builder
+	for i, h := range hashes {
+		fmt.Fprintf(&b, "%s:%d\n", h, i+1)
+	}
+	list := openTestList(t, b.String())
+
+	for n := 0; n < 2000; n++ {
+		if got, want := list.Breached(fmt.Sprint(n)), n%2 == 0; got != want {
+			t.Errorf("Breached(%d) = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestOpenBreachedListRejectsOtherFormats(t *testing.T) {
+	for name, content := range map[string]string{
+		"empty":         "",
+		"only comments": "# nada\n",
+		"plain words":   "password\n123456\n",
+		"short hash":    "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD\n",
+	} {
+		if list, err := OpenBreachedList(writeBreachedList(t, content)); err == nil {
+			list.Close()
+			t.Errorf("%s: accepted", name)
+		}
+	}
+	if _, err := OpenBreachedList(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("missing file: accepted")
+	}
+}
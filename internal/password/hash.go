@@ -0,0 +1,171 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Algoritmos de hash admitidos
+const (
+	Argon2id = "argon2id"
+	Bcrypt   = "bcrypt"
+)
+
+var ErrMalformedHash = errors.New("malformed password hash")
+
+// Argon2Params son los parámetros de argon2id
+type Argon2Params struct {
+	Memory  uint32 // KiB
+	Time    uint32
+	Threads uint8
+	SaltLen uint32
+	KeyLen  uint32
+}
+
+// Hasher calcula y comprueba hashes codificados con su algoritmo y
+// parámetros, así que los hashes antiguos siguen valiendo al cambiar la
+// configuración y NeedsRehash indica cuáles conviene renovar.
+type Hasher struct {
+	// Algorithm es el algoritmo de los hashes nuevos
+	Algorithm  string
+	Argon2     Argon2Params
+	BcryptCost int
+}
+
+// DefaultHasher sigue la recomendación de OWASP para argon2id (19 MiB, dos
+// pasadas): unos milisegundos por hash en vez del segundo de bcrypt a coste 14
+var DefaultHasher = Hasher{
+	Algorithm: Argon2id,
+	Argon2: Argon2Params{
+		Memory:  19 * 1024,
+		Time:    2,
+		Threads: 1,
+		SaltLen: 16,
+		KeyLen:  32,
+	},
+	BcryptCost: 10,
+}
+
+// HasherFromEnv parte de DefaultHasher y aplica PASSWORD_HASH (argon2id o
+// bcrypt), ARGON2_MEMORY (KiB), ARGON2_TIME, ARGON2_THREADS y BCRYPT_COST
+func HasherFromEnv() (Hasher, error) {
+	h := DefaultHasher
+	if v := os.Getenv("PASSWORD_HASH"); v != "" {
+		if v != Argon2id && v != Bcrypt {
+			return h, fmt.Errorf("invalid PASSWORD_HASH %q: use argon2id or bcrypt", v)
+		}
+		h.Algorithm = v
+	}
+	for _, env := range []struct {
+		name     string
+		min, max uint64
+		set      func(uint64)
+	}{
+		{"ARGON2_MEMORY", 8 * 1024, 4 * 1024 * 1024, func(n uint64) { h.Argon2.Memory = uint32(n) }},
+		{"ARGON2_TIME", 1, 100, func(n uint64) { h.Argon2.Time = uint32(n) }},
+		{"ARGON2_THREADS", 1, 255, func(n uint64) { h.Argon2.Threads = uint8(n) }},
+		{"BCRYPT_COST", uint64(bcrypt.MinCost), uint64(bcrypt.MaxCost), func(n uint64) { h.BcryptCost = int(n) }},
+	} {
+		v := os.Getenv(env.name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.ParseUint(v, 10, 32)
+		if err != nil || n < env.min || n > env.max {
+			return h, fmt.Errorf("invalid %s %q: must be between %d and %d", env.name, v, env.min, env.max)
+		}
+		env.set(n)
+	}
+	return h, nil
+}
+
+// Hash calcula el hash codificado de password con el algoritmo configurado
+func (h Hasher) Hash(password string) (string, error) {
+	if h.Algorithm == Bcrypt {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.BcryptCost)
+		return string(hash), err
+	}
+	p := h.Argon2
+	salt := make([]byte, p.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Threads, p.KeyLen)
+	return encodeArgon2(p, salt, key), nil
+}
+
+// Verify comprueba password contra un hash de cualquier algoritmo admitido.
+// Una contraseña que no coincide no es un error.
+func (h Hasher) Verify(password, encoded string) (bool, error) {
+	if strings.HasPrefix(encoded, "$argon2id$") {
+		p, salt, key, err := decodeArgon2(encoded)
+		if err != nil {
+			return false, err
+		}
+		got := argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Threads, p.KeyLen)
+		return subtle.ConstantTimeCompare(got, key) == 1, nil
+	}
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	if err != nil {
+		return false, ErrMalformedHash
+	}
+	return true, nil
+}
+
+// NeedsRehash indica si el hash se calculó con otro algoritmo u otros
+// parámetros que los actuales
+func (h Hasher) NeedsRehash(encoded string) bool {
+	if h.Algorithm == Bcrypt {
+		cost, err := bcrypt.Cost([]byte(encoded))
+		return err != nil || cost != h.BcryptCost
+	}
+	p, salt, key, err := decodeArgon2(encoded)
+	if err != nil {
+		return true
+	}
+	want := h.Argon2
+	return p.Memory != want.Memory || p.Time != want.Time || p.Threads != want.Threads ||
+		uint32(len(salt)) != want.SaltLen || uint32(len(key)) != want.KeyLen
+}
+
+// encodeArgon2 usa el formato PHC de la implementación de referencia:
+// $argon2id$v=19$m=19456,t=2,p=1$<sal>$<hash>
+func encodeArgon2(p Argon2Params, salt, key []byte) string {
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Time, p.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+}
+
+func decodeArgon2(encoded string) (Argon2Params, []byte, []byte, error) {
+	var p Argon2Params
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != Argon2id {
+		return p, nil, nil, ErrMalformedHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, ErrMalformedHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Time, &p.Threads); err != nil {
+		return p, nil, nil, ErrMalformedHash
+	}
+	salt, err1 := base64.RawStdEncoding.DecodeString(parts[4])
+	key, err2 := base64.RawStdEncoding.DecodeString(parts[5])
+	if err1 != nil || err2 != nil || len(salt) == 0 || len(key) == 0 {
+		return p, nil, nil, ErrMalformedHash
+	}
+	p.SaltLen, p.KeyLen = uint32(len(salt)), uint32(len(key))
+	return p, salt, key, nil
+}
@@ -0,0 +1,117 @@
+package password
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testHasher usa parámetros mínimos para que los tests vayan rápido
+var testHasher = Hasher{
+	Algorithm: Argon2id,
+	Argon2: Argon2Params{
+		Memory:  8 * 1024,
+		Time:    1,
+		Threads: 1,
+		SaltLen: 16,
+		KeyLen:  32,
+	},
+	BcryptCost: bcrypt.MinCost,
+}
+
+func withAlgorithm(h Hasher, algorithm string) Hasher {
+	h.Algorithm = algorithm
+	return h
+}
+
+func TestHashRoundTrip(t *testing.T) {
+	for _, algorithm := range []string{Argon2id, Bcrypt} {
+		t.Run(algorithm, func(t *testing.T) {
+			h := withAlgorithm(testHasher, algorithm)
+			encoded, err := h.Hash("contraseña segura")
+			if err != nil {
+				t.Fatal(err)
+			}
+			again, _ := h.Hash("contraseña segura")
+			if again == encoded {
+				t.Error("two hashes of the same password are equal: missing salt")
+			}
+
+			for _, tt := range []struct {
+				password string
+				want     bool
+			}{
+				{"contraseña segura", true},
+				{"contrasena segura", false},
+				{"", false},
+			} {
+				ok, err := h.Verify(tt.password, encoded)
+				if err != nil || ok != tt.want {
+					t.Errorf("Verify(%q) = %v, %v, want %v", tt.password, ok, err, tt.want)
+				}
+			}
+
+			// Cambiar de algoritmo no invalida los hashes existentes
+			other := withAlgorithm(testHasher, Bcrypt)
+			if algorithm == Bcrypt {
+				other = withAlgorithm(testHasher, Argon2id)
+			}
+			if ok, err := other.Verify("contraseña segura", encoded); !ok || err != nil {
+				t.Errorf("Verify with the other algorithm = %v, %v", ok, err)
+			}
+		})
+	}
+}
+
+func TestVerifyMalformed(t *testing.T) {
+	for _, encoded := range []string{
+		"",
+		"not a hash",
+		"$argon2id$v=19$m=8192,t=1,p=1$c2FsdA",
+		"$argon2id$v=16$m=8192,t=1,p=1$c2FsdHNhbHQ$a2V5a2V5",
+		"$argon2id$v=19$m=x,t=1,p=1$c2FsdHNhbHQ$a2V5a2V5",
+		"$argon2id$v=19$m=8192,t=1,p=1$$a2V5a2V5",
+		"$argon2id$v=19$m=8192,t=1,p=1$c2FsdHNhbHQ$!!!",
+		"$2a$04$short",
+	} {
+		if ok, err := testHasher.Verify("x", encoded); ok || !errors.Is(err, ErrMalformedHash) {
+			t.Errorf("Verify(%q) = %v, %v, want %v", encoded, ok, err, ErrMalformedHash)
+		}
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	argon, _ := testHasher.Hash("x")
+	bcryptHash, _ := withAlgorithm(testHasher, Bcrypt).Hash("x")
+
+	changed := func(change func(*Hasher)) Hasher {
+		h := testHasher
+		change(&h)
+		return h
+	}
+	tests := []struct {
+		name    string
+		h       Hasher
+		encoded string
+		want    bool
+	}{
+		{"argon2 same params", testHasher, argon, false},
+		{"argon2 more memory", changed(func(h *Hasher) { h.Argon2.Memory *= 2 }), argon, true},
+		{"argon2 more passes", changed(func(h *Hasher) { h.Argon2.Time++ }), argon, true},
+		{"argon2 more threads", changed(func(h *Hasher) { h.Argon2.Threads++ }), argon, true},
+		{"argon2 longer salt", changed(func(h *Hasher) { h.Argon2.SaltLen = 32 }), argon, true},
+		{"argon2 longer key", changed(func(h *Hasher) { h.Argon2.KeyLen = 64 }), argon, true},
+		{"argon2 ignores bcrypt cost", changed(func(h *Hasher) { h.BcryptCost++ }), argon, false},
+		{"bcrypt to argon2", testHasher, bcryptHash, true},
+		{"bcrypt same cost", withAlgorithm(testHasher, Bcrypt), bcryptHash, false},
+		{"bcrypt higher cost", changed(func(h *Hasher) { h.Algorithm = Bcrypt; h.BcryptCost++ }), bcryptHash, true},
+		{"argon2 to bcrypt", withAlgorithm(testHasher, Bcrypt), argon, true},
+		{"garbage", testHasher, "not a hash", true},
+	}
+	for _, tt := range tests {
+		if got := tt.h.NeedsRehash(tt.encoded); got != tt.want {
+			t.Errorf("%s: NeedsRehash = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
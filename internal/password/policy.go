@@ -0,0 +1,191 @@
+// Package password reúne las reglas que debe cumplir una contraseña nueva y
+// el cálculo y la comprobación de sus hashes.
+package password
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Reglas de la política, tal como se devuelven a los clientes
+const (
+	RuleMinLength = "min_length"
+	RuleMaxLength = "max_length"
+	RuleEntropy   = "entropy"
+	RulePersonal  = "personal_info"
+	RuleBreached  = "breached"
+)
+
+// Violation es una regla incumplida
+type Violation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// PolicyError lista todas las reglas que incumple una contraseña, para que el
+// cliente pueda mostrarlas a la vez
+type PolicyError struct {
+	Violations []Violation
+}
+
+func (e *PolicyError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.Message
+	}
+	return "weak password: " + strings.Join(msgs, "; ")
+}
+
+// Policy configura las reglas
+type Policy struct {
+	// MinLength en caracteres
+	MinLength int
+	// MaxLength en bytes: bcrypt solo admite 72
+	MaxLength int
+	// MinEntropy es la entropía estimada mínima en bits (0 no la comprueba)
+	MinEntropy float64
+	// Breached, si no es nil, rechaza las contraseñas filtradas
+	Breached Checker
+}
+
+// DefaultPolicy: ocho caracteres y algo de variedad
+var DefaultPolicy = Policy{
+	MinLength:  8,
+	MaxLength:  72,
+	MinEntropy: 36,
+}
+
+// minFragment es el tamaño mínimo de un trozo del email o del nombre para
+// considerarlo dato personal; los más cortos aparecen por casualidad
+const minFragment = 4
+
+// PolicyFromEnv parte de DefaultPolicy y aplica PASSWORD_MIN_LENGTH,
+// PASSWORD_MIN_ENTROPY y BREACHED_PASSWORDS_FILE
+func PolicyFromEnv() (Policy, error) {
+	p := DefaultPolicy
+	if v := os.Getenv("PASSWORD_MIN_LENGTH"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > p.MaxLength {
+			return p, fmt.Errorf("invalid PASSWORD_MIN_LENGTH %q", v)
+		}
+		p.MinLength = n
+	}
+	if v := os.Getenv("PASSWORD_MIN_ENTROPY"); v != "" {
+		bits, err := strconv.ParseFloat(v, 64)
+		if err != nil || bits < 0 {
+			return p, fmt.Errorf("invalid PASSWORD_MIN_ENTROPY %q", v)
+		}
+		p.MinEntropy = bits
+	}
+	if path := os.Getenv("BREACHED_PASSWORDS_FILE"); path != "" {
+		list, err := OpenBreachedList(path)
+		if err != nil {
+			return p, err
+		}
+		p.Breached = list
+	}
+	return p, nil
+}
+
+// Check devuelve nil si password cumple la política o un *PolicyError con
+// todas las reglas que incumple. personal son datos del usuario (email,
+// nombre) que la contraseña no debe contener.
+func (p Policy) Check(password string, personal ...string) error {
+	var violations []Violation
+	if n := utf8.RuneCountInString(password); n < p.MinLength {
+		violations = append(violations, Violation{RuleMinLength,
+			fmt.Sprintf("must be at least %d characters long", p.MinLength)})
+	}
+	if p.MaxLength > 0 && len(password) > p.MaxLength {
+		violations = append(violations, Violation{RuleMaxLength,
+			fmt.Sprintf("must be at most %d bytes long", p.MaxLength)})
+	}
+	if password != "" && p.MinEntropy > 0 && Entropy(password) < p.MinEntropy {
+		violations = append(violations, Violation{RuleEntropy,
+			"is too easy to guess: make it longer or mix letters, digits and symbols"})
+	}
+	if frag := personalFragment(password, personal); frag != "" {
+		violations = append(violations, Violation{RulePersonal,
+			"must not contain your email or name"})
+	}
+	if p.Breached != nil && password != "" && p.Breached.Breached(password) {
+		violations = append(violations, Violation{RuleBreached,
+			"has appeared in a data breach, choose a different one"})
+	}
+	if len(violations) > 0 {
+		return &PolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// Entropy estima los bits de una contraseña por el tamaño del alfabeto que
+// usa. Las repeticiones y secuencias (aaaa, 1234, abcd) cuentan la mitad.
+func Entropy(password string) float64 {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range password {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+	pool := 0
+	for _, c := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if c.used {
+			pool += c.size
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+
+	perChar := math.Log2(float64(pool))
+	bits := 0.0
+	prev := rune(-1)
+	for _, r := range password {
+		if d := r - prev; d >= -1 && d <= 1 {
+			bits += perChar / 2
+		} else {
+			bits += perChar
+		}
+		prev = r
+	}
+	return bits
+}
+
+// personalFragment devuelve el primer trozo de personal que aparece en la
+// contraseña, sin distinguir mayúsculas
+func personalFragment(password string, personal []string) string {
+	lower := strings.ToLower(password)
+	for _, value := range personal {
+		value = strings.ToLower(value)
+		// Del email solo cuenta la parte local
+		if at := strings.LastIndexByte(value, '@'); at >= 0 {
+			value = value[:at]
+		}
+		fields := strings.FieldsFunc(value, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, f := range fields {
+			if utf8.RuneCountInString(f) >= minFragment && strings.Contains(lower, f) {
+				return f
+			}
+		}
+	}
+	return ""
+}
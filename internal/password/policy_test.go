@@ -0,0 +1,96 @@
+package password
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestEntropy(t *testing.T) {
+	lower, alnum, printable := math.Log2(26), math.Log2(62), math.Log2(95)
+	tests := []struct {
+		password string
+		want     float64
+	}{
+		{"", 0},
+		{"a", lower},
+		{"qwzx", 4 * lower},
+		// Repeticiones y secuencias cuentan la mitad
+		{"aaaa", lower + 3*lower/2},
+		{"abcd", lower + 3*lower/2},
+		{"dcba", lower + 3*lower/2},
+		{"Ab9x", 4 * alnum},
+		{"Ab9!", 4 * printable},
+		// Fuera de ASCII se supone un alfabeto de 100
+		{"ñ", math.Log2(100)},
+		{"añ", math.Log2(126) * 2},
+	}
+	for _, tt := range tests {
+		if got := Entropy(tt.password); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Entropy(%q) = %v, want %v", tt.password, got, tt.want)
+		}
+	}
+}
+
+func TestPersonalFragment(t *testing.T) {
+	tests := []struct {
+		password string
+		personal []string
+		want     string
+	}{
+		{"soyMaria2024!", []string{"maria.lopez@example.com", "María López"}, "maria"},
+		{"LOPEZ-rules", []string{"maria.lopez@example.com"}, "lopez"},
+		// Del email no cuenta el dominio
+		{"example-rules", []string{"maria.lopez@example.com"}, ""},
+		// Los trozos de menos de cuatro letras se ignoran
+		{"ana-y-leo", []string{"ana@example.com", "Ana Leo"}, ""},
+		{"míralópez", []string{"Maria", "López"}, "lópez"},
+		{"nothing personal", []string{"", "jo@x.io"}, ""},
+		{"nothing personal", nil, ""},
+	}
+	for _, tt := range tests {
+		if got := personalFragment(tt.password, tt.personal); got != tt.want {
+			t.Errorf("personalFragment(%q, %q) = %q, want %q", tt.password, tt.personal, got, tt.want)
+		}
+	}
+}
+
+// breachedSet es un Checker en memoria
+type breachedSet []string
+
+func (s breachedSet) Breached(password string) bool { return slices.Contains(s, password) }
+
+func TestPolicyCheck(t *testing.T) {
+	p := DefaultPolicy
+	p.Breached = breachedSet{"correct horse battery"}
+	tests := []struct {
+		password string
+		want     []string
+	}{
+		{"Tr0ub4dor&3x", nil},
+		{"", []string{RuleMinLength}},
+		{"aB3$xZ9", []string{RuleMinLength}},
+		{"aaaaaaaa", []string{RuleEntropy}},
+		{"maria.lopez1984", []string{RulePersonal}},
+		{"correct horse battery", []string{RuleBreached}},
+		{"maria", []string{RuleMinLength, RuleEntropy, RulePersonal}},
+		{strings.Repeat("x", 73), []string{RuleMaxLength}},
+	}
+	for _, tt := range tests {
+		err := p.Check(tt.password, "maria.lopez@example.com", "María López")
+		var got []string
+		var pe *PolicyError
+		if errors.As(err, &pe) {
+			for _, v := range pe.Violations {
+				got = append(got, v.Rule)
+			}
+		} else if err != nil {
+			t.Fatalf("Check(%q) = %v", tt.password, err)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Check(%q) = %v, want %v", tt.password, got, tt.want)
+		}
+	}
+}
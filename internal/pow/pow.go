@@ -0,0 +1,354 @@
+// Package pow implementa un reto de prueba de trabajo al estilo hashcash para
+// frenar scripts sin depender de un CAPTCHA externo.
+//
+// El servidor emite un reto firmado con HMAC (ámbito, dificultad, caducidad y
+// un nonce aleatorio), así que no necesita guardarlo. El cliente busca una
+// solución tal que sha256(reto + solución) empiece por tantos bits a cero como
+// indique la dificultad, y la envía junto al reto. Cada reto se acepta una
+// sola vez: los usados se apuntan en un SpentStore hasta que caducan.
+//
+// Con varias instancias todas deben compartir la clave (Options.Key) y el
+// SpentStore: si no, un reto emitido por una no vale en otra, o un reto
+// resuelto se acepta una vez en cada una.
+//
+// La dificultad sube sola para cada cliente (IP) cuando su volumen de intentos
+// del último minuto supera un umbral: cada vez que se duplica, un bit más
+// (cada bit duplica el trabajo del cliente). Así quien abusa no encarece los
+// retos de los demás.
+package pow
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/bits"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Ámbitos en los que se piden retos
+const (
+	ScopeRegister = "register"
+	ScopeVote     = "vote"
+	ScopeGuest    = "guest"
+)
+
+// Scopes son los ámbitos válidos
+var Scopes = []string{ScopeRegister, ScopeVote, ScopeGuest}
+
+// Cabeceras con las que el cliente envía el reto resuelto
+const (
+	ChallengeHeader = "X-PoW-Challenge"
+	SolutionHeader  = "X-PoW-Solution"
+)
+
+const (
+	challengeVersion = "v1"
+	maxSolutionLen   = 64
+	window           = time.Minute
+)
+
+var (
+	ErrMissing  = errors.New("proof of work required")
+	ErrInvalid  = errors.New("invalid challenge")
+	ErrExpired  = errors.New("challenge expired")
+	ErrReused   = errors.New("challenge already used")
+	ErrTooEasy  = errors.New("challenge difficulty too low")
+	ErrSolution = errors.New("wrong solution")
+)
+
+// Options configura un Issuer
+type Options struct {
+	// Difficulty es la dificultad base en bits a cero (0 desactiva el ámbito)
+	Difficulty int
+	// MaxDifficulty limita la subida automática
+	MaxDifficulty int
+	// Threshold es el número de intentos por minuto a partir del cual se
+	// considera abuso y sube la dificultad
+	Threshold int
+	// TTL es la vida de un reto
+	TTL time.Duration
+	// Key firma los retos. Vacía, se genera una aleatoria: vale para una sola
+	// instancia y los retos no sobreviven a un reinicio, lo que con su vida
+	// corta no importa
+	Key []byte
+	// Spent recuerda los retos usados; nil los guarda en memoria (una sola
+	// instancia)
+	Spent SpentStore
+}
+
+// DefaultOptions: ~65.000 hashes de media, menos de un segundo en un móvil
+var DefaultOptions = Options{
+	Difficulty:    16,
+	MaxDifficulty: 24,
+	Threshold:     60,
+	TTL:           5 * time.Minute,
+}
+
+// Challenge es un reto emitido
+type Challenge struct {
+	Challenge  string    `json:"challenge"`
+	Difficulty int       `json:"difficulty" doc:"Bits iniciales a cero que debe tener sha256(challenge + solution)"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Algorithm  string    `json:"algorithm" example:"sha256"`
+}
+
+// scopeState es la dificultad base de un ámbito y los intentos de cada cliente
+type scopeState struct {
+	base    int
+	clients map[string]*attempts // IP -> intentos
+}
+
+// attempts cuenta los intentos de un cliente en la ventana actual y la anterior
+type attempts struct {
+	windowStart time.Time
+	current     int
+	previous    int
+}
+
+// Issuer emite y verifica retos. Es seguro para uso concurrente.
+type Issuer struct {
+	opts  Options
+	key   []byte
+	spent SpentStore
+	now   func() time.Time
+
+	mu        sync.Mutex
+	scopes    map[string]*scopeState
+	lastSweep time.Time
+}
+
+// NewIssuer crea un Issuer; sin opts.Key, con una clave aleatoria
+func NewIssuer(opts Options) *Issuer {
+	key := opts.Key
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			panic(err)
+		}
+	}
+	i := &Issuer{
+		opts:   opts,
+		key:    key,
+		spent:  opts.Spent,
+		now:    time.Now,
+		scopes: map[string]*scopeState{},
+	}
+	if i.spent == nil {
+		i.spent = newMemorySpent(func() time.Time { return i.now() })
+	}
+	for _, s := range Scopes {
+		i.scopes[s] = &scopeState{base: opts.Difficulty, clients: map[string]*attempts{}}
+	}
+	return i
+}
+
+// SetDifficulty cambia la dificultad base de un ámbito (0 lo desactiva)
+func (i *Issuer) SetDifficulty(scope string, difficulty int) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	st, ok := i.scopes[scope]
+	if !ok {
+		return fmt.Errorf("unknown scope %q", scope)
+	}
+	st.base = min(max(difficulty, 0), i.opts.MaxDifficulty)
+	return nil
+}
+
+// Enabled indica si el ámbito pide retos
+func (i *Issuer) Enabled(scope string) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	st, ok := i.scopes[scope]
+	return ok && st.base > 0
+}
+
+// Difficulty es la dificultad actual del ámbito para el cliente ip, con la
+// subida por abuso
+func (i *Issuer) Difficulty(scope, ip string) int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.difficulty(scope, ip)
+}
+
+func (i *Issuer) difficulty(scope, ip string) int {
+	st, ok := i.scopes[scope]
+	if !ok || st.base == 0 {
+		return 0
+	}
+	boost := 0
+	if a := st.clients[ip]; a != nil && i.opts.Threshold > 0 {
+		a.roll(i.now())
+		// Se suma la ventana anterior para que la dificultad no caiga de golpe
+		// al empezar un minuto nuevo
+		if n := a.current + a.previous; n > i.opts.Threshold {
+			boost = bits.Len(uint(n / i.opts.Threshold))
+		}
+	}
+	return min(st.base+boost, max(i.opts.MaxDifficulty, st.base))
+}
+
+// count apunta un intento del cliente ip en el ámbito
+func (i *Issuer) count(st *scopeState, ip string, now time.Time) {
+	a := st.clients[ip]
+	if a == nil {
+		a = &attempts{windowStart: now}
+		st.clients[ip] = a
+	}
+	a.roll(now)
+	a.current++
+	i.sweep(now)
+}
+
+// sweep olvida los clientes sin intentos en las dos últimas ventanas, como
+// mucho una vez por minuto
+func (i *Issuer) sweep(now time.Time) {
+	if now.Sub(i.lastSweep) < window {
+		return
+	}
+	i.lastSweep = now
+	for _, st := range i.scopes {
+		for ip, a := range st.clients {
+			if now.Sub(a.windowStart) >= 2*window {
+				delete(st.clients, ip)
+			}
+		}
+	}
+}
+
+// roll avanza las ventanas de conteo hasta now
+func (a *attempts) roll(now time.Time) {
+	switch elapsed := now.Sub(a.windowStart); {
+	case elapsed >= 2*window:
+		a.previous, a.current = 0, 0
+		a.windowStart = now
+	case elapsed >= window:
+		a.previous, a.current = a.current, 0
+		a.windowStart = a.windowStart.Add(window)
+	}
+}
+
+// Issue emite un reto del ámbito para el cliente ip
+func (i *Issuer) Issue(scope, ip string) (*Challenge, error) {
+	i.mu.Lock()
+	_, known := i.scopes[scope]
+	difficulty := i.difficulty(scope, ip)
+	i.mu.Unlock()
+	if !known {
+		return nil, fmt.Errorf("unknown scope %q", scope)
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	expiresAt := i.now().Add(i.opts.TTL).Truncate(time.Second)
+	payload := strings.Join([]string{
+		challengeVersion, scope, strconv.Itoa(difficulty),
+		strconv.FormatInt(expiresAt.Unix(), 10), hex.EncodeToString(nonce),
+	}, ".")
+	return &Challenge{
+		Challenge:  payload + "." + i.sign(payload),
+		Difficulty: difficulty,
+		ExpiresAt:  expiresAt,
+		Algorithm:  "sha256",
+	}, nil
+}
+
+func (i *Issuer) sign(payload string) string {
+	mac := hmac.New(sha256.New, i.key)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify comprueba un reto resuelto para el ámbito y lo marca como usado.
+// Cada llamada cuenta como un intento del cliente ip para la detección de
+// abuso. Si el ámbito está desactivado no se pide nada.
+func (i *Issuer) Verify(ctx context.Context, scope, ip, challenge, solution string) error {
+	i.mu.Lock()
+	st, ok := i.scopes[scope]
+	if !ok || st.base == 0 {
+		i.mu.Unlock()
+		return nil
+	}
+	now := i.now()
+	i.count(st, ip, now)
+	current := i.difficulty(scope, ip)
+	i.mu.Unlock()
+
+	if challenge == "" || solution == "" {
+		return ErrMissing
+	}
+	if len(solution) > maxSolutionLen {
+		return ErrSolution
+	}
+
+	// La firma es el último campo
+	k := strings.LastIndexByte(challenge, '.')
+	if k < 0 || !hmac.Equal([]byte(challenge[k+1:]), []byte(i.sign(challenge[:k]))) {
+		return ErrInvalid
+	}
+	payload := challenge[:k]
+	parts := strings.Split(payload, ".")
+	if len(parts) != 5 || parts[0] != challengeVersion || parts[1] != scope {
+		return ErrInvalid
+	}
+	difficulty, err1 := strconv.Atoi(parts[2])
+	expires, err2 := strconv.ParseInt(parts[3], 10, 64)
+	if err1 != nil || err2 != nil {
+		return ErrInvalid
+	}
+	if !now.Before(time.Unix(expires, 0)) {
+		return ErrExpired
+	}
+	// Un bit de margen: el reto pudo emitirse justo antes de una subida
+	if difficulty < current-1 {
+		return ErrTooEasy
+	}
+	if LeadingZeroBits(challenge, solution) < difficulty {
+		return ErrSolution
+	}
+
+	fresh, err := i.spent.Spend(ctx, challenge, time.Unix(expires, 0))
+	if err != nil {
+		// Como con los límites de peticiones, un almacén caído no debe tumbar
+		// la API: el trabajo ya está hecho
+		log.Printf("error apuntando el reto usado: %v", err)
+		return nil
+	}
+	if !fresh {
+		return ErrReused
+	}
+	return nil
+}
+
+// LeadingZeroBits cuenta los bits iniciales a cero de sha256(challenge + solution)
+func LeadingZeroBits(challenge, solution string) int {
+	sum := sha256.Sum256([]byte(challenge + solution))
+	n := 0
+	for _, b := range sum {
+		if b != 0 {
+			return n + bits.LeadingZeros8(b)
+		}
+		n += 8
+	}
+	return n
+}
+
+// Solve busca una solución por fuerza bruta. Lo usan los clientes en Go y las
+// herramientas de prueba; el servidor nunca lo necesita.
+func Solve(challenge string, difficulty int) string {
+	for n := 0; ; n++ {
+		s := strconv.Itoa(n)
+		if LeadingZeroBits(challenge, s) >= difficulty {
+			return s
+		}
+	}
+}
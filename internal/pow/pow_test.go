@@ -0,0 +1,143 @@
+package pow
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestIssuer crea un Issuer con retos baratos y un reloj que solo avanza
+// cuando el test lo pide
+func newTestIssuer(opts Options) (*Issuer, *time.Time) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	i := NewIssuer(opts)
+	i.now = func() time.Time { return now }
+	return i, &now
+}
+
+var testOptions = Options{
+	Difficulty:    4,
+	MaxDifficulty: 8,
+	Threshold:     2,
+	TTL:           time.Minute,
+	Key:           []byte("test key"),
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	i, _ := newTestIssuer(testOptions)
+	c, err := i.Issue(ScopeVote, "10.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	solution := Solve(c.Challenge, c.Difficulty)
+	wrong := solution
+	for n := 0; LeadingZeroBits(c.Challenge, wrong) >= c.Difficulty; n++ {
+		wrong = solution + strings.Repeat("x", n+1)
+	}
+	k := strings.LastIndexByte(c.Challenge, '.')
+	other, _ := NewIssuer(Options{Difficulty: 4, MaxDifficulty: 8, TTL: time.Minute}).Issue(ScopeVote, "10.0.0.1")
+
+	tests := []struct {
+		name                string
+		scope               string
+		challenge, solution string
+		want                error
+	}{
+		{"missing challenge", ScopeVote, "", solution, ErrMissing},
+		{"missing solution", ScopeVote, c.Challenge, "", ErrMissing},
+		{"solution too long", ScopeVote, c.Challenge, strings.Repeat("1", maxSolutionLen+1), ErrSolution},
+		{"tampered payload", ScopeVote, "v1.vote.0" + c.Challenge[len("v1.vote.4"):], solution, ErrInvalid},
+		{"tampered signature", ScopeVote, c.Challenge[:k+1] + strings.Repeat("0", len(c.Challenge)-k-1), solution, ErrInvalid},
+		{"signed with another key", ScopeVote, other.Challenge, Solve(other.Challenge, other.Difficulty), ErrInvalid},
+		{"another scope", ScopeGuest, c.Challenge, solution, ErrInvalid},
+		{"wrong solution", ScopeVote, c.Challenge, wrong, ErrSolution},
+		{"solved", ScopeVote, c.Challenge, solution, nil},
+		{"replayed", ScopeVote, c.Challenge, solution, ErrReused},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Cada caso desde una IP distinta, para que no suba la dificultad
+			if err := i.Verify(ctx, tt.scope, tt.name, tt.challenge, tt.solution); err != tt.want {
+				t.Errorf("Verify = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyExpired(t *testing.T) {
+	ctx := context.Background()
+	i, now := newTestIssuer(testOptions)
+	c, _ := i.Issue(ScopeRegister, "10.0.0.1")
+	solution := Solve(c.Challenge, c.Difficulty)
+
+	*now = c.ExpiresAt
+	if err := i.Verify(ctx, ScopeRegister, "10.0.0.1", c.Challenge, solution); err != ErrExpired {
+		t.Errorf("Verify at expiry = %v, want %v", err, ErrExpired)
+	}
+}
+
+func TestDisabledScope(t *testing.T) {
+	i, _ := newTestIssuer(testOptions)
+	if err := i.SetDifficulty(ScopeGuest, 0); err != nil {
+		t.Fatal(err)
+	}
+	if i.Enabled(ScopeGuest) {
+		t.Error("scope still enabled")
+	}
+	if err := i.Verify(context.Background(), ScopeGuest, "10.0.0.1", "", ""); err != nil {
+		t.Errorf("Verify on a disabled scope = %v", err)
+	}
+	if err := i.SetDifficulty("login", 4); err == nil {
+		t.Error("SetDifficulty accepted an unknown scope")
+	}
+}
+
+func TestDifficultyBoostPerIP(t *testing.T) {
+	ctx := context.Background()
+	i, now := newTestIssuer(testOptions)
+	const abuser, other = "10.0.0.1", "10.0.0.2"
+
+	// Umbral 2: un bit más al pasarlo y otro cada vez que se duplica
+	wants := []int{4, 4, 4, 5, 6, 6, 6, 6, 7}
+	for n, want := range wants {
+		if got := i.Difficulty(ScopeVote, abuser); got != want {
+			t.Errorf("after %d attempts difficulty = %d, want %d", n, got, want)
+		}
+		i.Verify(ctx, ScopeVote, abuser, "", "")
+	}
+	for n := 0; n < 100; n++ {
+		i.Verify(ctx, ScopeVote, abuser, "", "")
+	}
+	if got := i.Difficulty(ScopeVote, abuser); got != testOptions.MaxDifficulty {
+		t.Errorf("difficulty = %d, want the maximum %d", got, testOptions.MaxDifficulty)
+	}
+	if got := i.Difficulty(ScopeVote, other); got != testOptions.Difficulty {
+		t.Errorf("another client got difficulty %d, want %d", got, testOptions.Difficulty)
+	}
+	if got := i.Difficulty(ScopeRegister, abuser); got != testOptions.Difficulty {
+		t.Errorf("another scope got difficulty %d, want %d", got, testOptions.Difficulty)
+	}
+	c, _ := i.Issue(ScopeVote, abuser)
+	if c.Difficulty != testOptions.MaxDifficulty {
+		t.Errorf("issued difficulty = %d, want %d", c.Difficulty, testOptions.MaxDifficulty)
+	}
+
+	// Un reto fácil emitido antes de la subida ya no vale
+	easy, _ := newTestIssuer(testOptions)
+	e, _ := easy.Issue(ScopeVote, abuser)
+	if err := i.Verify(ctx, ScopeVote, abuser, e.Challenge, Solve(e.Challenge, e.Difficulty)); err != ErrTooEasy {
+		t.Errorf("Verify of an easy challenge = %v, want %v", err, ErrTooEasy)
+	}
+
+	// La ventana anterior aún cuenta durante un minuto; después se olvida
+	*now = now.Add(window)
+	if got := i.Difficulty(ScopeVote, abuser); got != testOptions.MaxDifficulty {
+		t.Errorf("next minute difficulty = %d, want %d", got, testOptions.MaxDifficulty)
+	}
+	*now = now.Add(window)
+	if got := i.Difficulty(ScopeVote, abuser); got != testOptions.Difficulty {
+		t.Errorf("two minutes later difficulty = %d, want %d", got, testOptions.Difficulty)
+	}
+}
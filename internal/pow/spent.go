@@ -0,0 +1,50 @@
+package pow
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SpentStore recuerda los retos usados hasta que caducan
+type SpentStore interface {
+	// Spend marca el reto como usado; devuelve false si ya lo estaba
+	Spend(ctx context.Context, challenge string, expiresAt time.Time) (bool, error)
+}
+
+// memorySpent guarda los retos usados en memoria: vale para una sola instancia
+type memorySpent struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	spent     map[string]time.Time // reto -> caducidad
+	lastSweep time.Time
+}
+
+func newMemorySpent(now func() time.Time) *memorySpent {
+	return &memorySpent{now: now, spent: map[string]time.Time{}}
+}
+
+func (s *memorySpent) Spend(ctx context.Context, challenge string, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, used := s.spent[challenge]; used {
+		return false, nil
+	}
+	s.sweep(s.now())
+	s.spent[challenge] = expiresAt
+	return true, nil
+}
+
+// sweep olvida los retos usados que ya caducaron, como mucho una vez por minuto
+func (s *memorySpent) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < window {
+		return
+	}
+	s.lastSweep = now
+	for c, exp := range s.spent {
+		if !now.Before(exp) {
+			delete(s.spent, c)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval es cada cuánto MemoryStore olvida los cubos inactivos
+const sweepInterval = time.Minute
+
+// MemoryStore guarda los cubos en memoria: vale para una sola instancia
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]bucket
+	idle      map[string]time.Duration // cuándo un cubo inactivo está lleno
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]bucket{}, idle: map[string]time.Duration{}}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, l Limit, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, r := take(s.buckets[key], l, now)
+	s.buckets[key] = b
+	s.idle[key] = r.Reset
+	s.sweep(now)
+	return r, nil
+}
+
+// sweep borra los cubos que ya se han rellenado: equivalen a uno nuevo
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if now.Sub(b.updated) >= s.idle[key] {
+			delete(s.buckets, key)
+			delete(s.idle, key)
+		}
+	}
+}
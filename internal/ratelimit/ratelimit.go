@@ -0,0 +1,161 @@
+// Package ratelimit limita peticiones con cubos de fichas (token bucket).
+//
+// Cada clave (ruta + IP o usuario) tiene un cubo de Burst fichas que se
+// rellena a razón de Rate cada Per; cada petición gasta una. El estado vive en
+// un Store intercambiable: MemoryStore para una sola instancia y SQLStore
+// para compartirlo entre varias.
+package ratelimit
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+)
+
+// Limit es el caudal permitido: Rate peticiones cada Per, con ráfagas de
+// hasta Burst (si es 0, Rate)
+type Limit struct {
+	Rate  int
+	Per   time.Duration
+	Burst int
+}
+
+func PerMinute(n int) Limit { return Limit{Rate: n, Per: time.Minute} }
+func PerHour(n int) Limit   { return Limit{Rate: n, Per: time.Hour} }
+
+func (l Limit) capacity() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return float64(l.Rate)
+}
+
+// perSecond es la velocidad de rellenado
+func (l Limit) perSecond() float64 {
+	return float64(l.Rate) / l.Per.Seconds()
+}
+
+// Result es la decisión para una petición
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration // hasta que el cubo vuelva a estar lleno
+	RetryAfter time.Duration // solo si !Allowed
+}
+
+// Store guarda los cubos. Take gasta una ficha del cubo de key si la hay.
+type Store interface {
+	Take(ctx context.Context, key string, l Limit, now time.Time) (Result, error)
+}
+
+// bucket es el estado de un cubo: fichas que quedaban en updated
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// take rellena el cubo hasta now e intenta gastar una ficha. Un cubo sin
+// estado (updated cero) empieza lleno.
+func take(b bucket, l Limit, now time.Time) (bucket, Result) {
+	capacity, rate := l.capacity(), l.perSecond()
+	if b.updated.IsZero() {
+		b.tokens = capacity
+	} else if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+elapsed*rate)
+	}
+	b.updated = now
+
+	r := Result{Limit: int(capacity)}
+	if b.tokens >= 1 {
+		b.tokens--
+		r.Allowed = true
+	} else {
+		r.RetryAfter = seconds((1 - b.tokens) / rate)
+	}
+	r.Remaining = int(b.tokens)
+	r.Reset = seconds((capacity - b.tokens) / rate)
+	return b, r
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
+
+// KeyKind dice de qué depende una regla
+type KeyKind string
+
+const (
+	ByIP   KeyKind = "ip"
+	ByUser KeyKind = "user"
+)
+
+// Rule es un límite por IP o por usuario en una operación
+type Rule struct {
+	By    KeyKind
+	Limit Limit
+}
+
+func PerIP(l Limit) Rule   { return Rule{By: ByIP, Limit: l} }
+func PerUser(l Limit) Rule { return Rule{By: ByUser, Limit: l} }
+
+// Subject identifica una petición
+type Subject struct {
+	Route  string // normalmente el OperationID
+	IP     string
+	UserID string // vacío en peticiones anónimas
+}
+
+func (s Subject) value(k KeyKind) string {
+	if k == ByUser {
+		return s.UserID
+	}
+	return s.IP
+}
+
+// Limiter aplica reglas sobre un Store
+type Limiter struct {
+	store Store
+	now   func() time.Time
+}
+
+func NewLimiter(store Store) *Limiter {
+	return &Limiter{store: store, now: time.Now}
+}
+
+// Check gasta una ficha de cada regla aplicable y devuelve la decisión más
+// restrictiva. Las reglas por usuario no se aplican a peticiones anónimas.
+// Si el Store falla se deja pasar la petición: un límite caído no debe tumbar
+// la API.
+func (l *Limiter) Check(ctx context.Context, s Subject, rules []Rule) (Result, bool) {
+	var worst Result
+	applied := false
+	now := l.now()
+	for _, rule := range rules {
+		v := s.value(rule.By)
+		if v == "" {
+			continue
+		}
+		r, err := l.store.Take(ctx, s.Route+"|"+string(rule.By)+"|"+v, rule.Limit, now)
+		if err != nil {
+			log.Printf("error de rate limit en %s: %v", s.Route, err)
+			continue
+		}
+		if !applied || moreRestrictive(r, worst) {
+			worst = r
+		}
+		applied = true
+	}
+	return worst, applied
+}
+
+func moreRestrictive(a, b Result) bool {
+	if a.Allowed != b.Allowed {
+		return !a.Allowed
+	}
+	if !a.Allowed {
+		return a.RetryAfter > b.RetryAfter
+	}
+	return a.Remaining < b.Remaining
+}
@@ -0,0 +1,103 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTake(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	l := PerMinute(6) // una ficha cada 10 s
+
+	tests := []struct {
+		name      string
+		b         bucket
+		l         Limit
+		now       time.Time
+		allowed   bool
+		remaining int
+		retry     time.Duration
+		reset     time.Duration
+	}{
+		{"new bucket starts full", bucket{}, l, t0, true, 5, 0, 10 * time.Second},
+		{"burst overrides rate", bucket{}, Limit{Rate: 1, Per: time.Second, Burst: 3}, t0, true, 2, 0, time.Second},
+		{"last token", bucket{tokens: 1, updated: t0}, l, t0, true, 0, 0, 60 * time.Second},
+		{"empty", bucket{tokens: 0, updated: t0}, l, t0, false, 0, 10 * time.Second, 60 * time.Second},
+		{"half refilled", bucket{tokens: 0, updated: t0}, l, t0.Add(5 * time.Second), false, 0, 5 * time.Second, 55 * time.Second},
+		{"refilled one", bucket{tokens: 0, updated: t0}, l, t0.Add(10 * time.Second), true, 0, 0, 60 * time.Second},
+		{"refill caps at capacity", bucket{tokens: 2, updated: t0}, l, t0.Add(time.Hour), true, 5, 0, 10 * time.Second},
+		// Un reloj que retrocede no rellena ni vacía el cubo
+		{"clock going back", bucket{tokens: 3, updated: t0}, l, t0.Add(-time.Minute), true, 2, 0, 40 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, r := take(tt.b, tt.l, tt.now)
+			if r.Allowed != tt.allowed || r.Remaining != tt.remaining || r.RetryAfter != tt.retry || r.Reset != tt.reset {
+				t.Errorf("take = %+v, want allowed=%v remaining=%d retry=%v reset=%v",
+					r, tt.allowed, tt.remaining, tt.retry, tt.reset)
+			}
+			if r.Limit != int(tt.l.capacity()) {
+				t.Errorf("Limit = %d, want %v", r.Limit, tt.l.capacity())
+			}
+			if !b.updated.Equal(tt.now) {
+				t.Errorf("updated = %v, want %v", b.updated, tt.now)
+			}
+		})
+	}
+}
+
+func TestMoreRestrictive(t *testing.T) {
+	allowed := func(remaining int) Result { return Result{Allowed: true, Remaining: remaining} }
+	denied := func(retry time.Duration) Result { return Result{RetryAfter: retry} }
+
+	tests := []struct {
+		name string
+		a, b Result
+		want bool
+	}{
+		{"denied beats allowed", denied(time.Second), allowed(0), true},
+		{"allowed never beats denied", allowed(0), denied(time.Second), false},
+		{"longer wait", denied(2 * time.Second), denied(time.Second), true},
+		{"shorter wait", denied(time.Second), denied(2 * time.Second), false},
+		{"same wait", denied(time.Second), denied(time.Second), false},
+		{"fewer remaining", allowed(1), allowed(2), true},
+		{"more remaining", allowed(2), allowed(1), false},
+		{"same remaining", allowed(1), allowed(1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := moreRestrictive(tt.a, tt.b); got != tt.want {
+				t.Errorf("moreRestrictive(%+v, %+v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckReturnsMostRestrictive(t *testing.T) {
+	l := NewLimiter(NewMemoryStore())
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	ctx := context.Background()
+	rules := []Rule{PerIP(PerMinute(10)), PerUser(PerMinute(2))}
+
+	// Anónima: solo cuenta la regla por IP
+	if r, ok := l.Check(ctx, Subject{Route: "vote", IP: "10.0.0.1"}, rules); !ok || r.Limit != 10 || r.Remaining != 9 {
+		t.Fatalf("anonymous = %+v, %v", r, ok)
+	}
+
+	s := Subject{Route: "vote", IP: "10.0.0.2", UserID: "u1"}
+	for i := 0; i < 2; i++ {
+		if r, _ := l.Check(ctx, s, rules); !r.Allowed || r.Limit != 2 {
+			t.Fatalf("request %d = %+v", i, r)
+		}
+	}
+	r, _ := l.Check(ctx, s, rules)
+	if r.Allowed || r.RetryAfter != 30*time.Second {
+		t.Errorf("third request = %+v, want denied for 30s", r)
+	}
+
+	if _, ok := l.Check(ctx, Subject{Route: "vote"}, rules); ok {
+		t.Error("a subject without IP or user applied a rule")
+	}
+}
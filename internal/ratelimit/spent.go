@@ -0,0 +1,27 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// SpentChallenges apunta en un Store los retos de prueba de trabajo ya usados
+// (es un pow.SpentStore): cada reto es un cubo de una sola ficha que no se
+// rellena hasta que el reto caduca. Sobre SQLStore lo comparten todas las
+// instancias.
+type SpentChallenges struct {
+	Store Store
+}
+
+func (s SpentChallenges) Spend(ctx context.Context, challenge string, expiresAt time.Time) (bool, error) {
+	now := time.Now()
+	ttl := expiresAt.Sub(now)
+	if ttl <= 0 {
+		return false, nil
+	}
+	sum := sha256.Sum256([]byte(challenge))
+	r, err := s.Store.Take(ctx, "pow|"+hex.EncodeToString(sum[:]), Limit{Rate: 1, Per: ttl}, now)
+	return r.Allowed, err
+}
@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+)
+
+// SQLStore guarda los cubos en la tabla rate_limit_buckets (MySQL), para que
+// varias instancias compartan los límites. Cada Take bloquea la fila del cubo
+// durante su transacción.
+type SQLStore struct {
+	db *sql.DB
+}
+
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{db: db}
+}
+
+func (s *SQLStore) Take(ctx context.Context, key string, l Limit, now time.Time) (Result, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Result{}, err
+	}
+	defer tx.Rollback()
+
+	var b bucket
+	err = tx.QueryRowContext(ctx,
+		"SELECT tokens, updated_at FROM rate_limit_buckets WHERE id = ? FOR UPDATE", key,
+	).Scan(&b.tokens, &b.updated)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return Result{}, err
+	}
+
+	b, r := take(b, l, now)
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO rate_limit_buckets (id, tokens, updated_at) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE tokens = VALUES(tokens), updated_at = VALUES(updated_at)`,
+		key, b.tokens, b.updated,
+	)
+	if err != nil {
+		return Result{}, err
+	}
+	return r, tx.Commit()
+}
+
+// Purge borra los cubos sin uso desde olderThan. Un cubo ausente empieza
+// lleno, así que olderThan debe quedar más atrás que el Per más largo en uso.
+func (s *SQLStore) Purge(ctx context.Context, olderThan time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM rate_limit_buckets WHERE updated_at < ?", olderThan)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// RunPurge llama a Purge cada every con los cubos sin uso desde hace idle,
+// hasta que se cancela ctx
+func (s *SQLStore) RunPurge(ctx context.Context, every, idle time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := s.Purge(ctx, now.Add(-idle)); err != nil {
+				log.Printf("error purgando rate_limit_buckets: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package search
+
+import (
+	"context"
+	"log"
+
+	"api_voty/ent"
+	"api_voty/ent/poll"
+	"api_voty/ent/polloption"
+)
+
+// Indexer mantiene el índice al día a partir de las mutaciones de ent
+type Indexer struct {
+	Index  *Index
+	client *ent.Client
+}
+
+func NewIndexer(client *ent.Client, index *Index) *Indexer {
+	return &Indexer{Index: index, client: client}
+}
+
+// Register engancha el indexador a las mutaciones de encuestas y opciones
+func (ixr *Indexer) Register() {
+	ixr.client.Poll.Use(ixr.hook)
+	ixr.client.PollOption.Use(ixr.hook)
+}
+
+// Rebuild indexa todas las encuestas existentes (al arrancar)
+func (ixr *Indexer) Rebuild(ctx context.Context) error {
+	polls, err := ixr.client.Poll.Query().
+		WithOptions(func(q *ent.PollOptionQuery) { q.Where(polloption.Archived(false)) }).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, p := range polls {
+		ixr.Index.Put(docFor(p))
+	}
+	return nil
+}
+
+func (ixr *Indexer) hook(next ent.Mutator) ent.Mutator {
+	return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+		// Los votos actualizan contadores a menudo: eso no cambia el índice
+		if !touchesIndexedFields(m) {
+			return next.Mutate(ctx, m)
+		}
+		// En borrados y actualizaciones masivas hay que saber qué encuestas
+		// se ven afectadas antes de que las filas cambien
+		before, err := affectedPolls(ctx, m, nil)
+		if err != nil {
+			return nil, err
+		}
+		v, err := next.Mutate(ctx, m)
+		if err != nil {
+			return v, err
+		}
+		after, err := affectedPolls(ctx, m, v)
+		if err != nil {
+			log.Printf("search: no se pudo resolver la encuesta de la mutación: %v", err)
+		}
+		ixr.refreshAfterCommit(m, append(before, after...))
+		return v, nil
+	})
+}
+
+// refreshAfterCommit reindexa al confirmar la transacción (o en el acto si no hay una)
+func (ixr *Indexer) refreshAfterCommit(m ent.Mutation, ids []int) {
+	if len(ids) == 0 {
+		return
+	}
+	txm, ok := m.(interface{ Tx() (*ent.Tx, error) })
+	if !ok {
+		return
+	}
+	tx, err := txm.Tx()
+	if err != nil {
+		// Fuera de transacción
+		ixr.refresh(ids)
+		return
+	}
+	tx.OnCommit(func(next ent.Committer) ent.Committer {
+		return ent.CommitFunc(func(ctx context.Context, tx *ent.Tx) error {
+			if err := next.Commit(ctx, tx); err != nil {
+				return err
+			}
+			ixr.refresh(ids)
+			return nil
+		})
+	})
+}
+
+func (ixr *Indexer) refresh(ids []int) {
+	ctx := context.Background()
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		p, err := ixr.client.Poll.Query().
+			Where(poll.ID(id)).
+			WithOptions(func(q *ent.PollOptionQuery) { q.Where(polloption.Archived(false)) }).
+			Only(ctx)
+		switch {
+		case ent.IsNotFound(err):
+			ixr.Index.Remove(id)
+		case err != nil:
+			log.Printf("search: error reindexando la encuesta %d: %v", id, err)
+		default:
+			ixr.Index.Put(docFor(p))
+		}
+	}
+}
+
+// affectedPolls resuelve las encuestas que toca una mutación. Con v == nil se
+// llama antes de aplicarla (borrados y updates con predicados); con el valor
+// devuelto, después (altas y updates de una sola fila).
+func affectedPolls(ctx context.Context, m ent.Mutation, v ent.Value) ([]int, error) {
+	switch m := m.(type) {
+	case *ent.PollMutation:
+		if v == nil {
+			if m.Op().Is(ent.OpDelete | ent.OpDeleteOne | ent.OpUpdate) {
+				return m.IDs(ctx)
+			}
+			return nil, nil
+		}
+		if p, ok := v.(*ent.Poll); ok {
+			return []int{p.ID}, nil
+		}
+		if id, ok := m.ID(); ok {
+			return []int{id}, nil
+		}
+
+	case *ent.PollOptionMutation:
+		if v == nil {
+			if !m.Op().Is(ent.OpDelete | ent.OpDeleteOne | ent.OpUpdate | ent.OpUpdateOne) {
+				return nil, nil
+			}
+			ids, err := m.IDs(ctx)
+			if err != nil || len(ids) == 0 {
+				return nil, err
+			}
+			return m.Client().PollOption.Query().
+				Where(polloption.IDIn(ids...)).
+				QueryPoll().
+				IDs(ctx)
+		}
+		if id, ok := m.PollID(); ok {
+			return []int{id}, nil
+		}
+	}
+	return nil, nil
+}
+
+// indexedFields son los campos que afectan al contenido del índice
+var indexedFields = map[string]bool{
+	poll.FieldTitle:          true,
+	poll.FieldDescription:    true,
+	poll.FieldStatus:         true,
+	poll.FieldOwnerID:        true,
+	polloption.FieldText:     true,
+	polloption.FieldArchived: true,
+}
+
+func touchesIndexedFields(m ent.Mutation) bool {
+	if !m.Op().Is(ent.OpUpdate | ent.OpUpdateOne) {
+		return true // altas y bajas siempre
+	}
+	for _, f := range append(m.Fields(), m.ClearedFields()...) {
+		if indexedFields[f] {
+			return true
+		}
+	}
+	// Mover una opción a otra encuesta
+	return len(m.AddedEdges()) > 0 || len(m.ClearedEdges()) > 0
+}
+
+func docFor(p *ent.Poll) Doc {
+	doc := Doc{
+		PollID:      p.ID,
+		Title:       p.Title,
+		Description: p.Description,
+		Published:   p.Status == poll.StatusPublished,
+	}
+	if p.OwnerID != nil {
+		doc.OwnerID = *p.OwnerID
+	}
+	for _, o := range p.Edges.Options {
+		doc.Options = append(doc.Options, o.Text)
+	}
+	return doc
+}
@@ -0,0 +1,265 @@
+// Package search mantiene un índice invertido en memoria sobre los títulos,
+// descripciones y opciones de las encuestas.
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"api_voty/internal/utils"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Pesos por campo: un acierto en el título pesa más que en una opción
+const (
+	weightTitle       = 3.0
+	weightOption      = 2.0
+	weightDescription = 1.0
+
+	// Un término que solo coincide por prefijo puntúa menos que uno exacto
+	prefixPenalty = 0.6
+)
+
+// Doc es lo que se indexa de cada encuesta
+type Doc struct {
+	PollID      int
+	Title       string
+	Description string
+	Options     []string
+	Published   bool
+	OwnerID     string
+}
+
+// Hit es un resultado de búsqueda
+type Hit struct {
+	PollID int
+	Score  float64
+}
+
+type entry struct {
+	published bool
+	ownerID   string
+	weights   map[string]float64 // término -> frecuencia ponderada por campo
+}
+
+// Index es seguro para uso concurrente
+type Index struct {
+	mu       sync.RWMutex
+	docs     map[int]*entry
+	postings map[string]map[int]float64
+	display  map[string]string // término normalizado -> forma original más reciente
+	terms    []string          // términos ordenados, para autocompletar por prefijo
+	dirty    bool
+}
+
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[int]*entry),
+		postings: make(map[string]map[int]float64),
+		display:  make(map[string]string),
+	}
+}
+
+// Put indexa (o reindexa) una encuesta
+func (ix *Index) Put(doc Doc) {
+	e := &entry{published: doc.Published, ownerID: doc.OwnerID, weights: make(map[string]float64)}
+	display := make(map[string]string)
+	add := func(text string, weight float64) {
+		// Se parte el texto original para recordar la forma con tildes de cada término
+		for _, word := range strings.FieldsFunc(strings.ToLower(norm.NFC.String(text)), isSeparator) {
+			term := utils.NormalizeText(word)
+			e.weights[term] += weight
+			display[term] = word
+		}
+	}
+	add(doc.Title, weightTitle)
+	add(doc.Description, weightDescription)
+	for _, o := range doc.Options {
+		add(o, weightOption)
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.removeLocked(doc.PollID)
+	ix.docs[doc.PollID] = e
+	for term, w := range e.weights {
+		if ix.postings[term] == nil {
+			ix.postings[term] = make(map[int]float64)
+			ix.dirty = true
+		}
+		ix.postings[term][doc.PollID] = w
+		ix.display[term] = display[term]
+	}
+}
+
+// Remove saca una encuesta del índice
+func (ix *Index) Remove(pollID int) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.removeLocked(pollID)
+}
+
+func (ix *Index) removeLocked(pollID int) {
+	e, ok := ix.docs[pollID]
+	if !ok {
+		return
+	}
+	for term := range e.weights {
+		delete(ix.postings[term], pollID)
+		if len(ix.postings[term]) == 0 {
+			delete(ix.postings, term)
+			delete(ix.display, term)
+			ix.dirty = true
+		}
+	}
+	delete(ix.docs, pollID)
+}
+
+// Search busca las encuestas que contienen los términos de la consulta,
+// ordenadas por relevancia. La última palabra se trata como prefijo para
+// que la búsqueda funcione mientras el usuario escribe. Solo se devuelven
+// encuestas publicadas o borradores de viewerID.
+func (ix *Index) Search(query, viewerID string, limit int) []Hit {
+	words := utils.Tokenize(query)
+	if len(words) == 0 {
+		return nil
+	}
+
+	ix.mu.Lock()
+	ix.sortTermsLocked()
+	ix.mu.Unlock()
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	total := float64(len(ix.docs))
+	scores := make(map[int]float64)
+	matched := make(map[int]int)
+	for i, word := range words {
+		// Cada palabra de la consulta debe aparecer (AND); cuenta su mejor término
+		best := make(map[int]float64)
+		consider := func(term string, factor float64) {
+			posting := ix.postings[term]
+			idf := math.Log(1 + total/float64(len(posting)))
+			for id, w := range posting {
+				if s := w * idf * factor; s > best[id] {
+					best[id] = s
+				}
+			}
+		}
+		if i == len(words)-1 {
+			for _, term := range ix.prefixLocked(word) {
+				factor := prefixPenalty
+				if term == word {
+					factor = 1
+				}
+				consider(term, factor)
+			}
+		} else {
+			consider(word, 1)
+		}
+		for id, s := range best {
+			scores[id] += s
+			matched[id]++
+		}
+	}
+
+	var hits []Hit
+	for id, score := range scores {
+		e := ix.docs[id]
+		if matched[id] < len(words) || !e.visibleTo(viewerID) {
+			continue
+		}
+		hits = append(hits, Hit{PollID: id, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].PollID > hits[j].PollID // a igual relevancia, la más reciente
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// Suggest autocompleta la última palabra de prefix con los términos más
+// frecuentes. Igual que Search, solo cuenta encuestas publicadas o borradores
+// de viewerID, para no filtrar palabras de borradores ajenos.
+func (ix *Index) Suggest(prefix, viewerID string, limit int) []string {
+	words := utils.Tokenize(prefix)
+	if len(words) == 0 {
+		return nil
+	}
+	last := words[len(words)-1]
+
+	ix.mu.Lock()
+	ix.sortTermsLocked()
+	ix.mu.Unlock()
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	var candidates []string
+	freq := make(map[string]int)
+	for _, term := range ix.prefixLocked(last) {
+		for id := range ix.postings[term] {
+			if ix.docs[id].visibleTo(viewerID) {
+				freq[term]++
+			}
+		}
+		if freq[term] > 0 {
+			candidates = append(candidates, term)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return freq[candidates[i]] > freq[candidates[j]]
+	})
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	suggestions := make([]string, len(candidates))
+	for i, term := range candidates {
+		suggestions[i] = ix.display[term]
+	}
+	return suggestions
+}
+
+// prefixLocked devuelve los términos que empiezan por prefix (requiere terms ordenado)
+func (ix *Index) prefixLocked(prefix string) []string {
+	start := sort.SearchStrings(ix.terms, prefix)
+	var out []string
+	for i := start; i < len(ix.terms) && strings.HasPrefix(ix.terms[i], prefix); i++ {
+		if _, ok := ix.postings[ix.terms[i]]; ok {
+			out = append(out, ix.terms[i])
+		}
+	}
+	return out
+}
+
+func (ix *Index) sortTermsLocked() {
+	if !ix.dirty {
+		return
+	}
+	ix.terms = ix.terms[:0]
+	for term := range ix.postings {
+		ix.terms = append(ix.terms, term)
+	}
+	sort.Strings(ix.terms)
+	ix.dirty = false
+}
+
+// visibleTo indica si la encuesta es publicada o un borrador de viewerID
+func (e *entry) visibleTo(viewerID string) bool {
+	return e.published || (viewerID != "" && e.ownerID == viewerID)
+}
+
+// isSeparator usa el mismo criterio que utils.Tokenize
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
@@ -0,0 +1,91 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret es la semilla SHA-1 del apéndice B del RFC 6238
+// ("12345678901234567890") en base32
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCodeRFC6238(t *testing.T) {
+	// El RFC da ocho dígitos; con seis son los seis últimos
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		got, err := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("Code at %d = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+	// Las apps también aceptan el secreto en minúsculas
+	if got, _ := Code(strings.ToLower(rfcSecret), Step(time.Unix(59, 0))); got != "287082" {
+		t.Errorf("lowercase secret: Code = %s", got)
+	}
+	if _, err := Code("not base32!", 1); err == nil {
+		t.Error("Code accepted a malformed secret")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := Step(now)
+	code := func(s int64) string {
+		c, _ := Code(rfcSecret, s)
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		lastStep int64
+		wantStep int64
+		ok       bool
+	}{
+		{"current", "050471", 0, step, true},
+		{"with spaces", " 050 471 ", 0, step, true},
+		{"previous step", code(step - 1), 0, step - 1, true},
+		{"next step", code(step + 1), 0, step + 1, true},
+		{"too old", code(step - 2), 0, 0, false},
+		{"too new", code(step + 2), 0, 0, false},
+		{"already used", "050471", step, 0, false},
+		{"newer than last used", code(step + 1), step, step + 1, true},
+		{"wrong", "123456", 0, 0, false},
+		{"too short", "05047", 0, 0, false},
+		{"too long", "0504711", 0, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := Validate(rfcSecret, tt.code, now, tt.lastStep)
+		if ok != tt.ok || got != tt.wantStep {
+			t.Errorf("%s: Validate(%q) = %d, %v, want %d, %v", tt.name, tt.code, got, ok, tt.wantStep, tt.ok)
+		}
+	}
+}
+
+func TestURI(t *testing.T) {
+	u, err := url.Parse(URI("Voty", "ana@example.com", "ABC"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if u.Scheme != "otpauth" || u.Host != "totp" || u.Path != "/Voty:ana@example.com" ||
+		q.Get("secret") != "ABC" || q.Get("issuer") != "Voty" || q.Get("digits") != "6" || q.Get("period") != "30" {
+		t.Errorf("URI = %s", u)
+	}
+}
@@ -0,0 +1,233 @@
+// Package trending calcula qué encuestas están "calientes": cada voto (y en
+// menor medida cada visita) suma a una puntuación que se desintegra
+// exponencialmente con el tiempo.
+//
+// La puntuación se mantiene de forma incremental: para cada ventana se guarda
+// el valor y el instante en que se calculó, y al sumar un evento o consultar
+// el ranking basta con desintegrar ese valor hasta el instante actual. Todo el
+// tiempo sale de un Clock, así que con un reloj falso el resultado es
+// determinista.
+package trending
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock da la hora actual; en pruebas se sustituye por un reloj falso
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock es el reloj real
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time { return time.Now() }
+
+// Window es el periodo que se considera al ordenar
+type Window string
+
+const (
+	WindowHour Window = "hour"
+	WindowDay  Window = "day"
+	WindowWeek Window = "week"
+)
+
+// Windows son las ventanas disponibles, de la más corta a la más larga
+var Windows = []Window{WindowHour, WindowDay, WindowWeek}
+
+// Duration es la longitud de la ventana
+func (w Window) Duration() time.Duration {
+	switch w {
+	case WindowHour:
+		return time.Hour
+	case WindowDay:
+		return 24 * time.Hour
+	case WindowWeek:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// halfLife es la vida media de un evento en la ventana: tras una ventana
+// completa un evento conserva 1/64 de su peso
+func (w Window) halfLife() time.Duration {
+	return w.Duration() / 6
+}
+
+// Valid indica si w es una de las ventanas conocidas
+func (w Window) Valid() bool {
+	return w.Duration() > 0
+}
+
+// Peso de cada tipo de evento
+const (
+	VoteWeight = 1.0
+	ViewWeight = 0.1
+)
+
+// minScore es la puntuación por debajo de la cual una encuesta se olvida
+const minScore = 1e-3
+
+// Las visitas repetidas de un mismo usuario dentro de viewCooldown cuentan una
+// vez; al superar maxTrackedViews se purgan las ya vencidas
+const (
+	viewCooldown    = 30 * time.Minute
+	maxTrackedViews = 10000
+)
+
+type viewKey struct {
+	pollID int
+	userID string
+}
+
+// Entry es una encuesta del ranking
+type Entry struct {
+	PollID int
+	Score  float64
+}
+
+// decayed es una puntuación junto con el instante al que corresponde
+type decayed struct {
+	score float64
+	at    time.Time
+}
+
+// valueAt desintegra la puntuación hasta t. Un t anterior no la aumenta.
+func (d decayed) valueAt(t time.Time, halfLife time.Duration) float64 {
+	if !t.After(d.at) {
+		return d.score
+	}
+	return d.score * math.Exp2(-float64(t.Sub(d.at))/float64(halfLife))
+}
+
+// Tracker acumula las puntuaciones de todas las encuestas. Es seguro para uso concurrente.
+type Tracker struct {
+	clock  Clock
+	mu     sync.Mutex
+	scores map[Window]map[int]decayed
+	views  map[viewKey]time.Time
+}
+
+func NewTracker(clock Clock) *Tracker {
+	t := &Tracker{
+		clock:  clock,
+		scores: make(map[Window]map[int]decayed, len(Windows)),
+		views:  map[viewKey]time.Time{},
+	}
+	for _, w := range Windows {
+		t.scores[w] = map[int]decayed{}
+	}
+	return t
+}
+
+// RecordVote suma un voto emitido en at
+func (t *Tracker) RecordVote(pollID int, at time.Time) {
+	t.add(pollID, VoteWeight, at)
+}
+
+// RecordView suma una visita de userID ahora, salvo que ya la contara hace poco
+func (t *Tracker) RecordView(pollID int, userID string) {
+	now := t.clock.Now()
+	key := viewKey{pollID: pollID, userID: userID}
+
+	t.mu.Lock()
+	if last, ok := t.views[key]; ok && now.Sub(last) < viewCooldown {
+		t.mu.Unlock()
+		return
+	}
+	if len(t.views) >= maxTrackedViews {
+		for k, last := range t.views {
+			if now.Sub(last) >= viewCooldown {
+				delete(t.views, k)
+			}
+		}
+	}
+	t.views[key] = now
+	t.mu.Unlock()
+
+	t.add(pollID, ViewWeight, now)
+}
+
+// Forget quita una encuesta del ranking (por ejemplo, al borrarla)
+func (t *Tracker) Forget(pollID int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, w := range Windows {
+		delete(t.scores[w], pollID)
+	}
+}
+
+// add suma weight a la encuesta en cada ventana. Los eventos de at anterior al
+// último cálculo (p. ej. al cargar votos antiguos) entran ya desintegrados.
+func (t *Tracker) add(pollID int, weight float64, at time.Time) {
+	now := t.clock.Now()
+	if at.After(now) {
+		at = now
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, w := range Windows {
+		if now.Sub(at) > w.Duration() {
+			continue
+		}
+		hl := w.halfLife()
+		cur := t.scores[w][pollID]
+		if cur.at.IsZero() {
+			cur.at = at
+		}
+		if at.After(cur.at) {
+			cur = decayed{score: cur.valueAt(at, hl), at: at}
+			cur.score += weight
+		} else {
+			cur.score += decayed{score: weight, at: at}.valueAt(cur.at, hl)
+		}
+		t.scores[w][pollID] = cur
+	}
+}
+
+// Score es la puntuación actual de una encuesta en la ventana w
+func (t *Tracker) Score(pollID int, w Window) float64 {
+	now := t.clock.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	d, ok := t.scores[w][pollID]
+	if !ok {
+		return 0
+	}
+	return d.valueAt(now, w.halfLife())
+}
+
+// Top devuelve las encuestas con mayor puntuación en la ventana w. Los empates
+// se resuelven por ID descendente (la más reciente primero). De paso olvida
+// las encuestas cuya puntuación ya es despreciable.
+func (t *Tracker) Top(w Window, limit int) []Entry {
+	now := t.clock.Now()
+	hl := w.halfLife()
+
+	t.mu.Lock()
+	entries := make([]Entry, 0, len(t.scores[w]))
+	for id, d := range t.scores[w] {
+		score := d.valueAt(now, hl)
+		if score < minScore {
+			delete(t.scores[w], id)
+			continue
+		}
+		entries = append(entries, Entry{PollID: id, Score: score})
+	}
+	t.mu.Unlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		return entries[i].PollID > entries[j].PollID
+	})
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries
+}
@@ -0,0 +1,125 @@
+package trending
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// fakeClock es un reloj que solo avanza cuando el test lo pide
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestTracker() (*Tracker, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	return NewTracker(clock), clock
+}
+
+func assertScore(t *testing.T, tr *Tracker, pollID int, w Window, want float64) {
+	t.Helper()
+	if got := tr.Score(pollID, w); math.Abs(got-want) > 1e-9 {
+		t.Errorf("Score(%d, %s) = %v, want %v", pollID, w, got, want)
+	}
+}
+
+func TestDecay(t *testing.T) {
+	tr, clock := newTestTracker()
+	tr.RecordVote(1, clock.Now())
+	assertScore(t, tr, 1, WindowDay, 1)
+
+	// Una vida media de la ventana diaria son 4 horas
+	clock.Advance(4 * time.Hour)
+	assertScore(t, tr, 1, WindowDay, 0.5)
+	assertScore(t, tr, 1, WindowHour, math.Exp2(-24))
+
+	// El segundo voto se suma al valor ya desintegrado
+	tr.RecordVote(1, clock.Now())
+	assertScore(t, tr, 1, WindowDay, 1.5)
+
+	// Tras una ventana completa queda 1/64 del peso
+	clock.Advance(24 * time.Hour)
+	assertScore(t, tr, 1, WindowDay, 1.5/64)
+}
+
+func TestLateEventsArriveDecayed(t *testing.T) {
+	tr, clock := newTestTracker()
+	tr.RecordVote(1, clock.Now())
+	// Un voto de hace una vida media vale la mitad
+	tr.RecordVote(1, clock.Now().Add(-4*time.Hour))
+	assertScore(t, tr, 1, WindowDay, 1.5)
+
+	// Un evento futuro se trata como si fuera de ahora
+	tr.RecordVote(2, clock.Now().Add(time.Hour))
+	assertScore(t, tr, 2, WindowDay, 1)
+}
+
+func TestWindowRollover(t *testing.T) {
+	tr, clock := newTestTracker()
+
+	// Un voto de hace dos horas queda fuera de la ventana de una hora
+	tr.RecordVote(1, clock.Now().Add(-2*time.Hour))
+	assertScore(t, tr, 1, WindowHour, 0)
+	if got := tr.Score(1, WindowDay); got <= 0 {
+		t.Errorf("Score(1, day) = %v, want > 0", got)
+	}
+
+	tr.RecordVote(2, clock.Now())
+	if top := tr.Top(WindowHour, 0); len(top) != 1 || top[0].PollID != 2 {
+		t.Fatalf("Top(hour) = %v, want only poll 2", top)
+	}
+
+	// Dos horas después el voto ha caído por debajo de minScore en la ventana
+	// de una hora y Top la olvida, pero sigue en la diaria y la semanal
+	clock.Advance(2 * time.Hour)
+	if top := tr.Top(WindowHour, 0); len(top) != 0 {
+		t.Errorf("Top(hour) = %v, want empty", top)
+	}
+	if _, ok := tr.scores[WindowHour][2]; ok {
+		t.Error("poll 2 should have been forgotten in the hour window")
+	}
+	if top := tr.Top(WindowDay, 0); len(top) != 2 {
+		t.Errorf("Top(day) = %v, want 2 entries", top)
+	}
+	if top := tr.Top(WindowWeek, 0); len(top) != 2 {
+		t.Errorf("Top(week) = %v, want 2 entries", top)
+	}
+}
+
+func TestTopTieOrdering(t *testing.T) {
+	tr, clock := newTestTracker()
+	for _, id := range []int{3, 7, 5} {
+		tr.RecordVote(id, clock.Now())
+	}
+	tr.RecordVote(4, clock.Now())
+	tr.RecordVote(4, clock.Now())
+
+	top := tr.Top(WindowDay, 0)
+	want := []int{4, 7, 5, 3}
+	if len(top) != len(want) {
+		t.Fatalf("Top(day) = %v, want IDs %v", top, want)
+	}
+	for i, id := range want {
+		if top[i].PollID != id {
+			t.Fatalf("Top(day) = %v, want IDs %v", top, want)
+		}
+	}
+
+	if top := tr.Top(WindowDay, 2); len(top) != 2 || top[1].PollID != 7 {
+		t.Errorf("Top(day, 2) = %v, want IDs [4 7]", top)
+	}
+}
+
+func TestViewCooldown(t *testing.T) {
+	tr, clock := newTestTracker()
+	tr.RecordView(1, "u1")
+	tr.RecordView(1, "u1")
+	assertScore(t, tr, 1, WindowWeek, ViewWeight)
+
+	clock.Advance(viewCooldown)
+	tr.RecordView(1, "u1")
+	want := ViewWeight*math.Exp2(-float64(viewCooldown)/float64(WindowWeek.halfLife())) + ViewWeight
+	assertScore(t, tr, 1, WindowWeek, want)
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Los tokens de invitado identifican un dispositivo, no una persona: solo
+// sirven para votar en encuestas que admiten invitados.
+const (
+	guestAudience = "guest"
+	guestPrefix   = "guest:"
+	guestTokenTTL = 90 * 24 * time.Hour
+)
+
+var (
+	ErrGuestToken    = errors.New("guest token is not a user session")
+	ErrNotGuestToken = errors.New("not a guest token")
+)
+
+func isGuest(c *Claims) bool {
+	return slices.Contains(c.Audience, guestAudience)
+}
+
+// GenerateGuestToken emite un token para un dispositivo nuevo y devuelve su guest ID
+func GenerateGuestToken() (token, guestID string, expiresAt time.Time, err error) {
+	guestID = uuid.New().String()
+	token, expiresAt, err = issuePurposeToken(guestAudience, guestPrefix+guestID, "", guestTokenTTL)
+	return token, guestID, expiresAt, err
+}
+
+// ValidateGuestToken comprueba un token de invitado y devuelve su guest ID
+func ValidateGuestToken(tokenString string) (string, error) {
+	claims, err := parsePurposeToken(tokenString, guestAudience)
+	if err != nil {
+		return "", err
+	}
+	guestID, ok := strings.CutPrefix(claims.Subject, guestPrefix)
+	if !ok || guestID == "" {
+		return "", ErrNotGuestToken
+	}
+	return guestID, nil
+}
+
+const GuestIDKey contextKey = "guest_id"
+
+func GetGuestIDFromContext(ctx context.Context) string {
+	if val := ctx.Value(GuestIDKey); val != nil {
+		if s, ok := val.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+func SetGuestInContext(ctx context.Context, guestID string) context.Context {
+	return context.WithValue(ctx, GuestIDKey, guestID)
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"net"
+	"os"
+	"strings"
+)
+
+// trustProxy indica si el servidor está detrás de un proxy que fija
+// X-Forwarded-For; si no, la cabecera la puede inventar el cliente
+var trustProxy = os.Getenv("TRUST_PROXY") == "true"
+
+// ClientIP devuelve la IP del cliente a partir de RemoteAddr y, detrás de un
+// proxy de confianza, del último salto de X-Forwarded-For (el que añadió el proxy)
+func ClientIP(remoteAddr, forwardedFor string) string {
+	if trustProxy && forwardedFor != "" {
+		hops := strings.Split(forwardedFor, ",")
+		if ip := strings.TrimSpace(hops[len(hops)-1]); net.ParseIP(ip) != nil {
+			return ip
+		}
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
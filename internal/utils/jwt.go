@@ -13,13 +13,16 @@ var jwtSecret = []byte(os.Getenv("SECRET"))
 type Claims struct {
 	UserID string `json:"user_id"`
 	Email  string `json:"email"`
+	// Version es la versión de sesiones del usuario al emitir el token
+	Version int `json:"ver,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(userID, email string) (string, error) {
+func GenerateToken(userID, email string, version int) (string, error) {
 	claims := Claims{
-		UserID: userID,
-		Email:  email,
+		UserID:  userID,
+		Email:   email,
+		Version: version,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -43,6 +46,13 @@ func ValidateToken(tokenString string) (*Claims, error) {
 	}
 
 	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
+		// Un token de invitado (o de cualquier otro propósito) no es una sesión
+		if isGuest(claims) {
+			return nil, ErrGuestToken
+		}
+		if len(claims.Audience) > 0 {
+			return nil, ErrNotSession
+		}
 		return claims, nil
 	}
 
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Los tokens de propósito (invitado, verificación de email...) llevan una
+// audiencia; las sesiones de usuario no llevan ninguna, así que ValidateToken
+// los rechaza y cada uno solo sirve para lo suyo.
+
+var ErrNotSession = errors.New("token is not a user session")
+
+func issuePurposeToken(audience, subject, email string, ttl time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	claims := Claims{
+		UserID: subject,
+		Email:  email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "api_voty",
+			Subject:   subject,
+			Audience:  jwt.ClaimStrings{audience},
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(jwtSecret)
+	return token, expiresAt, err
+}
+
+func parsePurposeToken(tokenString, audience string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return jwtSecret, nil
+	}, jwt.WithAudience(audience))
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid || claims.Subject == "" {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	return claims, nil
+}
+
+const (
+	emailVerificationAudience = "email-verification"
+	emailVerificationTTL      = 48 * time.Hour
+)
+
+// GenerateEmailVerificationToken firma el enlace de verificación. Incluye el
+// email: si el usuario lo cambia, los enlaces anteriores dejan de valer.
+func GenerateEmailVerificationToken(userID, email string) (string, error) {
+	token, _, err := issuePurposeToken(emailVerificationAudience, userID, email, emailVerificationTTL)
+	return token, err
+}
+
+// ValidateEmailVerificationToken devuelve el usuario y el email que verifica el token
+func ValidateEmailVerificationToken(tokenString string) (userID, email string, err error) {
+	claims, err := parsePurposeToken(tokenString, emailVerificationAudience)
+	if err != nil {
+		return "", "", err
+	}
+	return claims.Subject, claims.Email, nil
+}
+
+const (
+	twoFactorAudience = "2fa-login"
+	twoFactorTTL      = 5 * time.Minute
+)
+
+// GenerateTwoFactorChallenge firma el paso intermedio del login: la contraseña
+// ya es correcta y falta el segundo factor
+func GenerateTwoFactorChallenge(userID string) (string, time.Time, error) {
+	return issuePurposeToken(twoFactorAudience, userID, "", twoFactorTTL)
+}
+
+// ValidateTwoFactorChallenge devuelve el usuario del paso intermedio
+func ValidateTwoFactorChallenge(tokenString string) (string, error) {
+	claims, err := parsePurposeToken(tokenString, twoFactorAudience)
+	if err != nil {
+		return "", err
+	}
+	return claims.Subject, nil
+}
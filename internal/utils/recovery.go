@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// RecoveryCodeHash es lo que se guarda de un código de recuperación: un HMAC
+// con la clave del servidor y el usuario. Con una copia de la tabla no se
+// pueden probar códigos sin la clave, y el mismo código en dos cuentas no da
+// el mismo hash.
+func RecoveryCodeHash(userID, code string) string {
+	mac := hmac.New(sha256.New, jwtSecret)
+	mac.Write([]byte("recovery-code:" + userID + ":" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeText pasa a minúsculas y quita tildes y diéresis, para que
+// "Política", "politica" y "POLÍTICA" se traten como lo mismo. La ñ se
+// conserva: es otra letra, y "año" no es "ano".
+func NormalizeText(s string) string {
+	return strings.ToLower(strings.TrimSpace(foldAccents(s)))
+}
+
+// foldAccents quita las marcas diacríticas salvo la virgulilla de la ñ
+func foldAccents(s string) string {
+	var b strings.Builder
+	prev := rune(0)
+	for _, r := range norm.NFD.String(s) {
+		if unicode.Is(unicode.Mn, r) && (r != '\u0303' || (prev != 'n' && prev != 'N')) {
+			continue
+		}
+		b.WriteRune(r)
+		prev = r
+	}
+	return norm.NFC.String(b.String())
+}
+
+// StripAccents quita todas las marcas diacríticas, también la de la ñ, y
+// conserva mayúsculas ("Política" -> "Politica", "Año" -> "Ano")
+func StripAccents(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, s)
+	if err != nil {
+		return s
+	}
+	return folded
+}
+
+// Tokenize normaliza el texto y lo parte en palabras (letras y dígitos)
+func Tokenize(s string) []string {
+	return strings.FieldsFunc(NormalizeText(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Política", "politica"},
+		{"  POLÍTICA ", "politica"},
+		{"pingüino", "pinguino"},
+		{"Año", "año"},
+		{"AÑO", "año"},
+		{"ano", "ano"},
+		{"Ñandú", "ñandu"},
+		// Ya compuesta o con la virgulilla aparte, es la misma ñ
+		{"an\u0303o", "año"},
+		// Solo la ñ conserva la virgulilla
+		{"São Tomé", "sao tome"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeText(tt.in); got != tt.want {
+			t.Errorf("NormalizeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTokenizeKeepsEñe(t *testing.T) {
+	if got, want := Tokenize("¿Cuál es el mejor año?"), []string{"cual", "es", "el", "mejor", "año"}; !slices.Equal(got, want) {
+		t.Errorf("Tokenize = %q, want %q", got, want)
+	}
+}